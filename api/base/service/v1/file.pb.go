@@ -1017,10 +1017,14 @@ func (x *GetFileInfoByIdReq) GetBizName() string {
 }
 
 type GetFileInfoByIdResp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Meta          *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
-	ObjectName    string                 `protobuf:"bytes,2,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
-	Hash          string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Meta       *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	ObjectName string                 `protobuf:"bytes,2,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	Hash       string                 `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	// 图片感知哈希（pHash，16 位十六进制），非图片或未计算时为空
+	Phash string `protobuf:"bytes,4,opt,name=phash,proto3" json:"phash,omitempty"`
+	// 图片差值哈希（dHash，16 位十六进制）
+	Dhash         string `protobuf:"bytes,5,opt,name=dhash,proto3" json:"dhash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1076,6 +1080,20 @@ func (x *GetFileInfoByIdResp) GetHash() string {
 	return ""
 }
 
+func (x *GetFileInfoByIdResp) GetPhash() string {
+	if x != nil {
+		return x.Phash
+	}
+	return ""
+}
+
+func (x *GetFileInfoByIdResp) GetDhash() string {
+	if x != nil {
+		return x.Dhash
+	}
+	return ""
+}
+
 var File_api_base_service_v1_file_proto protoreflect.FileDescriptor
 
 const file_api_base_service_v1_file_proto_rawDesc = "" +
@@ -1146,12 +1164,14 @@ const file_api_base_service_v1_file_proto_rawDesc = "" +
 	"\afile_id\x18\x01 \x01(\tR\x06fileId\x12\x1f\n" +
 	"\vdomain_name\x18\x02 \x01(\tR\n" +
 	"domainName\x12\x19\n" +
-	"\bbiz_name\x18\x03 \x01(\tR\abizName\"}\n" +
+	"\bbiz_name\x18\x03 \x01(\tR\abizName\"\xa9\x01\n" +
 	"\x13GetFileInfoByIdResp\x121\n" +
 	"\x04meta\x18\x01 \x01(\v2\x1d.api.base.service.v1.MetadataR\x04meta\x12\x1f\n" +
 	"\vobject_name\x18\x02 \x01(\tR\n" +
 	"objectName\x12\x12\n" +
-	"\x04hash\x18\x03 \x01(\tR\x04hash2\xb2\x06\n" +
+	"\x04hash\x18\x03 \x01(\tR\x04hash\x12\x14\n" +
+	"\x05phash\x18\x04 \x01(\tR\x05phash\x12\x14\n" +
+	"\x05dhash\x18\x05 \x01(\tR\x05dhash2\xb2\x06\n" +
 	"\vFileService\x12U\n" +
 	"\n" +
 	"PreSignGet\x12\".api.base.service.v1.PreSignGetReq\x1a#.api.base.service.v1.PreSignGetResp\x12U\n" +
@@ -988,6 +988,462 @@ func (x *UpdateUserLastOnlineTimeResp) GetMeta() *Metadata {
 	return nil
 }
 
+type FollowUserReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowUserReq) Reset() {
+	*x = FollowUserReq{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowUserReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowUserReq) ProtoMessage() {}
+
+func (x *FollowUserReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowUserReq.ProtoReflect.Descriptor instead.
+func (*FollowUserReq) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *FollowUserReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FollowUserReq) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type FollowUserResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // 之前未关注时为 true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowUserResp) Reset() {
+	*x = FollowUserResp{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowUserResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowUserResp) ProtoMessage() {}
+
+func (x *FollowUserResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowUserResp.ProtoReflect.Descriptor instead.
+func (*FollowUserResp) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FollowUserResp) GetMeta() *Metadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *FollowUserResp) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type UnfollowUserReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowUserReq) Reset() {
+	*x = UnfollowUserReq{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowUserReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowUserReq) ProtoMessage() {}
+
+func (x *UnfollowUserReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowUserReq.ProtoReflect.Descriptor instead.
+func (*UnfollowUserReq) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UnfollowUserReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UnfollowUserReq) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type UnfollowUserResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Changed       bool                   `protobuf:"varint,2,opt,name=changed,proto3" json:"changed,omitempty"` // 之前已关注时为 true
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfollowUserResp) Reset() {
+	*x = UnfollowUserResp{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfollowUserResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfollowUserResp) ProtoMessage() {}
+
+func (x *UnfollowUserResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfollowUserResp.ProtoReflect.Descriptor instead.
+func (*UnfollowUserResp) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UnfollowUserResp) GetMeta() *Metadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *UnfollowUserResp) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type ListFollowReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	AfterUserId   string                 `protobuf:"bytes,4,opt,name=after_user_id,json=afterUserId,proto3" json:"after_user_id,omitempty"` // 非空时按用户 ID 升序游标翻页，用于批量扇出
+	IdsOnly       bool                   `protobuf:"varint,5,opt,name=ids_only,json=idsOnly,proto3" json:"ids_only,omitempty"`              // 只返回 user_ids，不查用户资料
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowReq) Reset() {
+	*x = ListFollowReq{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowReq) ProtoMessage() {}
+
+func (x *ListFollowReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowReq.ProtoReflect.Descriptor instead.
+func (*ListFollowReq) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListFollowReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListFollowReq) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListFollowReq) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListFollowReq) GetAfterUserId() string {
+	if x != nil {
+		return x.AfterUserId
+	}
+	return ""
+}
+
+func (x *ListFollowReq) GetIdsOnly() bool {
+	if x != nil {
+		return x.IdsOnly
+	}
+	return false
+}
+
+type ListFollowResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Users         []*UserBaseInfo        `protobuf:"bytes,2,rep,name=users,proto3" json:"users,omitempty"`
+	UserIds       []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Total         int64                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFollowResp) Reset() {
+	*x = ListFollowResp{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFollowResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFollowResp) ProtoMessage() {}
+
+func (x *ListFollowResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFollowResp.ProtoReflect.Descriptor instead.
+func (*ListFollowResp) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListFollowResp) GetMeta() *Metadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *ListFollowResp) GetUsers() []*UserBaseInfo {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListFollowResp) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *ListFollowResp) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type CheckFollowingReq struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserIds []string               `protobuf:"bytes,2,rep,name=target_user_ids,json=targetUserIds,proto3" json:"target_user_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckFollowingReq) Reset() {
+	*x = CheckFollowingReq{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckFollowingReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFollowingReq) ProtoMessage() {}
+
+func (x *CheckFollowingReq) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFollowingReq.ProtoReflect.Descriptor instead.
+func (*CheckFollowingReq) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CheckFollowingReq) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CheckFollowingReq) GetTargetUserIds() []string {
+	if x != nil {
+		return x.TargetUserIds
+	}
+	return nil
+}
+
+type CheckFollowingResp struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Meta             *Metadata              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	FollowingUserIds []string               `protobuf:"bytes,2,rep,name=following_user_ids,json=followingUserIds,proto3" json:"following_user_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CheckFollowingResp) Reset() {
+	*x = CheckFollowingResp{}
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckFollowingResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckFollowingResp) ProtoMessage() {}
+
+func (x *CheckFollowingResp) ProtoReflect() protoreflect.Message {
+	mi := &file_api_campusUser_service_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckFollowingResp.ProtoReflect.Descriptor instead.
+func (*CheckFollowingResp) Descriptor() ([]byte, []int) {
+	return file_api_campusUser_service_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CheckFollowingResp) GetMeta() *Metadata {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *CheckFollowingResp) GetFollowingUserIds() []string {
+	if x != nil {
+		return x.FollowingUserIds
+	}
+	return nil
+}
+
 var File_api_campusUser_service_v1_user_proto protoreflect.FileDescriptor
 
 const file_api_campusUser_service_v1_user_proto_rawDesc = "" +
@@ -1072,7 +1528,37 @@ const file_api_campusUser_service_v1_user_proto_rawDesc = "" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
 	"\x10last_online_time\x18\x02 \x01(\tR\x0elastOnlineTime\"W\n" +
 	"\x1cUpdateUserLastOnlineTimeResp\x127\n" +
-	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta2\xba\x06\n" +
+	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta\"N\n" +
+	"\rFollowUserReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"c\n" +
+	"\x0eFollowUserResp\x127\n" +
+	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\"P\n" +
+	"\x0fUnfollowUserReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"e\n" +
+	"\x10UnfollowUserResp\x127\n" +
+	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta\x12\x18\n" +
+	"\achanged\x18\x02 \x01(\bR\achanged\"\x98\x01\n" +
+	"\rListFollowReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\"\n" +
+	"\rafter_user_id\x18\x04 \x01(\tR\vafterUserId\x12\x19\n" +
+	"\bids_only\x18\x05 \x01(\bR\aidsOnly\"\xb9\x01\n" +
+	"\x0eListFollowResp\x127\n" +
+	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta\x12=\n" +
+	"\x05users\x18\x02 \x03(\v2'.api.campusUser.service.v1.UserBaseInfoR\x05users\x12\x19\n" +
+	"\buser_ids\x18\x03 \x03(\tR\auserIds\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\"T\n" +
+	"\x11CheckFollowingReq\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0ftarget_user_ids\x18\x02 \x03(\tR\rtargetUserIds\"{\n" +
+	"\x12CheckFollowingResp\x127\n" +
+	"\x04meta\x18\x01 \x01(\v2#.api.campusUser.service.v1.MetadataR\x04meta\x12,\n" +
+	"\x12following_user_ids\x18\x02 \x03(\tR\x10followingUserIds2\xc1\n" +
+	"\n" +
 	"\vUserService\x12c\n" +
 	"\n" +
 	"CreateUser\x12(.api.campusUser.service.v1.CreateUserReq\x1a).api.campusUser.service.v1.CreateUserResp\"\x00\x12p\n" +
@@ -1081,7 +1567,13 @@ const file_api_campusUser_service_v1_user_proto_rawDesc = "" +
 	"\x14BatchGetUserBaseInfo\x122.api.campusUser.service.v1.BatchGetUserBaseInfoReq\x1a3.api.campusUser.service.v1.BatchGetUserBaseInfoResp\x12d\n" +
 	"\vSearchUsers\x12).api.campusUser.service.v1.SearchUsersReq\x1a*.api.campusUser.service.v1.SearchUsersResp\x12p\n" +
 	"\x0fUpdateUserStats\x12-.api.campusUser.service.v1.UpdateUserStatsReq\x1a..api.campusUser.service.v1.UpdateUserStatsResp\x12\x8b\x01\n" +
-	"\x18UpdateUserLastOnlineTime\x126.api.campusUser.service.v1.UpdateUserLastOnlineTimeReq\x1a7.api.campusUser.service.v1.UpdateUserLastOnlineTimeRespBF\n" +
+	"\x18UpdateUserLastOnlineTime\x126.api.campusUser.service.v1.UpdateUserLastOnlineTimeReq\x1a7.api.campusUser.service.v1.UpdateUserLastOnlineTimeResp\x12a\n" +
+	"\n" +
+	"FollowUser\x12(.api.campusUser.service.v1.FollowUserReq\x1a).api.campusUser.service.v1.FollowUserResp\x12g\n" +
+	"\fUnfollowUser\x12*.api.campusUser.service.v1.UnfollowUserReq\x1a+.api.campusUser.service.v1.UnfollowUserResp\x12d\n" +
+	"\rListFollowers\x12(.api.campusUser.service.v1.ListFollowReq\x1a).api.campusUser.service.v1.ListFollowResp\x12d\n" +
+	"\rListFollowing\x12(.api.campusUser.service.v1.ListFollowReq\x1a).api.campusUser.service.v1.ListFollowResp\x12m\n" +
+	"\x0eCheckFollowing\x12,.api.campusUser.service.v1.CheckFollowingReq\x1a-.api.campusUser.service.v1.CheckFollowingRespBF\n" +
 	"\x19api.campusUser.service.v1P\x01Z'lehu-video/api/campusUser/service/v1;v1b\x06proto3"
 
 var (
@@ -1096,7 +1588,7 @@ func file_api_campusUser_service_v1_user_proto_rawDescGZIP() []byte {
 	return file_api_campusUser_service_v1_user_proto_rawDescData
 }
 
-var file_api_campusUser_service_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_api_campusUser_service_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_api_campusUser_service_v1_user_proto_goTypes = []any{
 	(*UserBaseInfo)(nil),                 // 0: api.campusUser.service.v1.UserBaseInfo
 	(*CreateUserReq)(nil),                // 1: api.campusUser.service.v1.CreateUserReq
@@ -1113,38 +1605,61 @@ var file_api_campusUser_service_v1_user_proto_goTypes = []any{
 	(*UpdateUserStatsResp)(nil),          // 12: api.campusUser.service.v1.UpdateUserStatsResp
 	(*UpdateUserLastOnlineTimeReq)(nil),  // 13: api.campusUser.service.v1.UpdateUserLastOnlineTimeReq
 	(*UpdateUserLastOnlineTimeResp)(nil), // 14: api.campusUser.service.v1.UpdateUserLastOnlineTimeResp
-	(*Metadata)(nil),                     // 15: api.campusUser.service.v1.Metadata
+	(*FollowUserReq)(nil),                // 15: api.campusUser.service.v1.FollowUserReq
+	(*FollowUserResp)(nil),               // 16: api.campusUser.service.v1.FollowUserResp
+	(*UnfollowUserReq)(nil),              // 17: api.campusUser.service.v1.UnfollowUserReq
+	(*UnfollowUserResp)(nil),             // 18: api.campusUser.service.v1.UnfollowUserResp
+	(*ListFollowReq)(nil),                // 19: api.campusUser.service.v1.ListFollowReq
+	(*ListFollowResp)(nil),               // 20: api.campusUser.service.v1.ListFollowResp
+	(*CheckFollowingReq)(nil),            // 21: api.campusUser.service.v1.CheckFollowingReq
+	(*CheckFollowingResp)(nil),           // 22: api.campusUser.service.v1.CheckFollowingResp
+	(*Metadata)(nil),                     // 23: api.campusUser.service.v1.Metadata
 }
 var file_api_campusUser_service_v1_user_proto_depIdxs = []int32{
-	15, // 0: api.campusUser.service.v1.CreateUserResp.meta:type_name -> api.campusUser.service.v1.Metadata
-	15, // 1: api.campusUser.service.v1.GetUserBaseInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 0: api.campusUser.service.v1.CreateUserResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 1: api.campusUser.service.v1.GetUserBaseInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
 	0,  // 2: api.campusUser.service.v1.GetUserBaseInfoResp.user:type_name -> api.campusUser.service.v1.UserBaseInfo
-	15, // 3: api.campusUser.service.v1.UpdateUserInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 3: api.campusUser.service.v1.UpdateUserInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
 	0,  // 4: api.campusUser.service.v1.BatchGetUserBaseInfoResp.users:type_name -> api.campusUser.service.v1.UserBaseInfo
-	15, // 5: api.campusUser.service.v1.BatchGetUserBaseInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 5: api.campusUser.service.v1.BatchGetUserBaseInfoResp.meta:type_name -> api.campusUser.service.v1.Metadata
 	0,  // 6: api.campusUser.service.v1.SearchUsersResp.users:type_name -> api.campusUser.service.v1.UserBaseInfo
-	15, // 7: api.campusUser.service.v1.SearchUsersResp.meta:type_name -> api.campusUser.service.v1.Metadata
-	15, // 8: api.campusUser.service.v1.UpdateUserStatsResp.meta:type_name -> api.campusUser.service.v1.Metadata
-	15, // 9: api.campusUser.service.v1.UpdateUserLastOnlineTimeResp.meta:type_name -> api.campusUser.service.v1.Metadata
-	1,  // 10: api.campusUser.service.v1.UserService.CreateUser:input_type -> api.campusUser.service.v1.CreateUserReq
-	3,  // 11: api.campusUser.service.v1.UserService.GetUserBaseInfo:input_type -> api.campusUser.service.v1.GetUserBaseInfoReq
-	5,  // 12: api.campusUser.service.v1.UserService.UpdateUserInfo:input_type -> api.campusUser.service.v1.UpdateUserInfoReq
-	7,  // 13: api.campusUser.service.v1.UserService.BatchGetUserBaseInfo:input_type -> api.campusUser.service.v1.BatchGetUserBaseInfoReq
-	9,  // 14: api.campusUser.service.v1.UserService.SearchUsers:input_type -> api.campusUser.service.v1.SearchUsersReq
-	11, // 15: api.campusUser.service.v1.UserService.UpdateUserStats:input_type -> api.campusUser.service.v1.UpdateUserStatsReq
-	13, // 16: api.campusUser.service.v1.UserService.UpdateUserLastOnlineTime:input_type -> api.campusUser.service.v1.UpdateUserLastOnlineTimeReq
-	2,  // 17: api.campusUser.service.v1.UserService.CreateUser:output_type -> api.campusUser.service.v1.CreateUserResp
-	4,  // 18: api.campusUser.service.v1.UserService.GetUserBaseInfo:output_type -> api.campusUser.service.v1.GetUserBaseInfoResp
-	6,  // 19: api.campusUser.service.v1.UserService.UpdateUserInfo:output_type -> api.campusUser.service.v1.UpdateUserInfoResp
-	8,  // 20: api.campusUser.service.v1.UserService.BatchGetUserBaseInfo:output_type -> api.campusUser.service.v1.BatchGetUserBaseInfoResp
-	10, // 21: api.campusUser.service.v1.UserService.SearchUsers:output_type -> api.campusUser.service.v1.SearchUsersResp
-	12, // 22: api.campusUser.service.v1.UserService.UpdateUserStats:output_type -> api.campusUser.service.v1.UpdateUserStatsResp
-	14, // 23: api.campusUser.service.v1.UserService.UpdateUserLastOnlineTime:output_type -> api.campusUser.service.v1.UpdateUserLastOnlineTimeResp
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	23, // 7: api.campusUser.service.v1.SearchUsersResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 8: api.campusUser.service.v1.UpdateUserStatsResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 9: api.campusUser.service.v1.UpdateUserLastOnlineTimeResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 10: api.campusUser.service.v1.FollowUserResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 11: api.campusUser.service.v1.UnfollowUserResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	23, // 12: api.campusUser.service.v1.ListFollowResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	0,  // 13: api.campusUser.service.v1.ListFollowResp.users:type_name -> api.campusUser.service.v1.UserBaseInfo
+	23, // 14: api.campusUser.service.v1.CheckFollowingResp.meta:type_name -> api.campusUser.service.v1.Metadata
+	1,  // 15: api.campusUser.service.v1.UserService.CreateUser:input_type -> api.campusUser.service.v1.CreateUserReq
+	3,  // 16: api.campusUser.service.v1.UserService.GetUserBaseInfo:input_type -> api.campusUser.service.v1.GetUserBaseInfoReq
+	5,  // 17: api.campusUser.service.v1.UserService.UpdateUserInfo:input_type -> api.campusUser.service.v1.UpdateUserInfoReq
+	7,  // 18: api.campusUser.service.v1.UserService.BatchGetUserBaseInfo:input_type -> api.campusUser.service.v1.BatchGetUserBaseInfoReq
+	9,  // 19: api.campusUser.service.v1.UserService.SearchUsers:input_type -> api.campusUser.service.v1.SearchUsersReq
+	11, // 20: api.campusUser.service.v1.UserService.UpdateUserStats:input_type -> api.campusUser.service.v1.UpdateUserStatsReq
+	13, // 21: api.campusUser.service.v1.UserService.UpdateUserLastOnlineTime:input_type -> api.campusUser.service.v1.UpdateUserLastOnlineTimeReq
+	15, // 22: api.campusUser.service.v1.UserService.FollowUser:input_type -> api.campusUser.service.v1.FollowUserReq
+	17, // 23: api.campusUser.service.v1.UserService.UnfollowUser:input_type -> api.campusUser.service.v1.UnfollowUserReq
+	19, // 24: api.campusUser.service.v1.UserService.ListFollowers:input_type -> api.campusUser.service.v1.ListFollowReq
+	19, // 25: api.campusUser.service.v1.UserService.ListFollowing:input_type -> api.campusUser.service.v1.ListFollowReq
+	21, // 26: api.campusUser.service.v1.UserService.CheckFollowing:input_type -> api.campusUser.service.v1.CheckFollowingReq
+	2,  // 27: api.campusUser.service.v1.UserService.CreateUser:output_type -> api.campusUser.service.v1.CreateUserResp
+	4,  // 28: api.campusUser.service.v1.UserService.GetUserBaseInfo:output_type -> api.campusUser.service.v1.GetUserBaseInfoResp
+	6,  // 29: api.campusUser.service.v1.UserService.UpdateUserInfo:output_type -> api.campusUser.service.v1.UpdateUserInfoResp
+	8,  // 30: api.campusUser.service.v1.UserService.BatchGetUserBaseInfo:output_type -> api.campusUser.service.v1.BatchGetUserBaseInfoResp
+	10, // 31: api.campusUser.service.v1.UserService.SearchUsers:output_type -> api.campusUser.service.v1.SearchUsersResp
+	12, // 32: api.campusUser.service.v1.UserService.UpdateUserStats:output_type -> api.campusUser.service.v1.UpdateUserStatsResp
+	14, // 33: api.campusUser.service.v1.UserService.UpdateUserLastOnlineTime:output_type -> api.campusUser.service.v1.UpdateUserLastOnlineTimeResp
+	16, // 34: api.campusUser.service.v1.UserService.FollowUser:output_type -> api.campusUser.service.v1.FollowUserResp
+	18, // 35: api.campusUser.service.v1.UserService.UnfollowUser:output_type -> api.campusUser.service.v1.UnfollowUserResp
+	20, // 36: api.campusUser.service.v1.UserService.ListFollowers:output_type -> api.campusUser.service.v1.ListFollowResp
+	20, // 37: api.campusUser.service.v1.UserService.ListFollowing:output_type -> api.campusUser.service.v1.ListFollowResp
+	22, // 38: api.campusUser.service.v1.UserService.CheckFollowing:output_type -> api.campusUser.service.v1.CheckFollowingResp
+	27, // [27:39] is the sub-list for method output_type
+	15, // [15:27] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_api_campusUser_service_v1_user_proto_init() }
@@ -1160,7 +1675,7 @@ func file_api_campusUser_service_v1_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_campusUser_service_v1_user_proto_rawDesc), len(file_api_campusUser_service_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
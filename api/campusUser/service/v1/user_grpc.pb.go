@@ -26,6 +26,11 @@ const (
 	UserService_SearchUsers_FullMethodName              = "/api.campusUser.service.v1.UserService/SearchUsers"
 	UserService_UpdateUserStats_FullMethodName          = "/api.campusUser.service.v1.UserService/UpdateUserStats"
 	UserService_UpdateUserLastOnlineTime_FullMethodName = "/api.campusUser.service.v1.UserService/UpdateUserLastOnlineTime"
+	UserService_FollowUser_FullMethodName               = "/api.campusUser.service.v1.UserService/FollowUser"
+	UserService_UnfollowUser_FullMethodName             = "/api.campusUser.service.v1.UserService/UnfollowUser"
+	UserService_ListFollowers_FullMethodName            = "/api.campusUser.service.v1.UserService/ListFollowers"
+	UserService_ListFollowing_FullMethodName            = "/api.campusUser.service.v1.UserService/ListFollowing"
+	UserService_CheckFollowing_FullMethodName           = "/api.campusUser.service.v1.UserService/CheckFollowing"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -46,6 +51,16 @@ type UserServiceClient interface {
 	UpdateUserStats(ctx context.Context, in *UpdateUserStatsReq, opts ...grpc.CallOption) (*UpdateUserStatsResp, error)
 	// 更新用户最后上线时间
 	UpdateUserLastOnlineTime(ctx context.Context, in *UpdateUserLastOnlineTimeReq, opts ...grpc.CallOption) (*UpdateUserLastOnlineTimeResp, error)
+	// 关注用户
+	FollowUser(ctx context.Context, in *FollowUserReq, opts ...grpc.CallOption) (*FollowUserResp, error)
+	// 取消关注
+	UnfollowUser(ctx context.Context, in *UnfollowUserReq, opts ...grpc.CallOption) (*UnfollowUserResp, error)
+	// 粉丝列表
+	ListFollowers(ctx context.Context, in *ListFollowReq, opts ...grpc.CallOption) (*ListFollowResp, error)
+	// 关注列表
+	ListFollowing(ctx context.Context, in *ListFollowReq, opts ...grpc.CallOption) (*ListFollowResp, error)
+	// 批量查询是否已关注
+	CheckFollowing(ctx context.Context, in *CheckFollowingReq, opts ...grpc.CallOption) (*CheckFollowingResp, error)
 }
 
 type userServiceClient struct {
@@ -126,6 +141,56 @@ func (c *userServiceClient) UpdateUserLastOnlineTime(ctx context.Context, in *Up
 	return out, nil
 }
 
+func (c *userServiceClient) FollowUser(ctx context.Context, in *FollowUserReq, opts ...grpc.CallOption) (*FollowUserResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowUserResp)
+	err := c.cc.Invoke(ctx, UserService_FollowUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) UnfollowUser(ctx context.Context, in *UnfollowUserReq, opts ...grpc.CallOption) (*UnfollowUserResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnfollowUserResp)
+	err := c.cc.Invoke(ctx, UserService_UnfollowUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowers(ctx context.Context, in *ListFollowReq, opts ...grpc.CallOption) (*ListFollowResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowResp)
+	err := c.cc.Invoke(ctx, UserService_ListFollowers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListFollowing(ctx context.Context, in *ListFollowReq, opts ...grpc.CallOption) (*ListFollowResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFollowResp)
+	err := c.cc.Invoke(ctx, UserService_ListFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) CheckFollowing(ctx context.Context, in *CheckFollowingReq, opts ...grpc.CallOption) (*CheckFollowingResp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckFollowingResp)
+	err := c.cc.Invoke(ctx, UserService_CheckFollowing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -144,6 +209,16 @@ type UserServiceServer interface {
 	UpdateUserStats(context.Context, *UpdateUserStatsReq) (*UpdateUserStatsResp, error)
 	// 更新用户最后上线时间
 	UpdateUserLastOnlineTime(context.Context, *UpdateUserLastOnlineTimeReq) (*UpdateUserLastOnlineTimeResp, error)
+	// 关注用户
+	FollowUser(context.Context, *FollowUserReq) (*FollowUserResp, error)
+	// 取消关注
+	UnfollowUser(context.Context, *UnfollowUserReq) (*UnfollowUserResp, error)
+	// 粉丝列表
+	ListFollowers(context.Context, *ListFollowReq) (*ListFollowResp, error)
+	// 关注列表
+	ListFollowing(context.Context, *ListFollowReq) (*ListFollowResp, error)
+	// 批量查询是否已关注
+	CheckFollowing(context.Context, *CheckFollowingReq) (*CheckFollowingResp, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -175,6 +250,21 @@ func (UnimplementedUserServiceServer) UpdateUserStats(context.Context, *UpdateUs
 func (UnimplementedUserServiceServer) UpdateUserLastOnlineTime(context.Context, *UpdateUserLastOnlineTimeReq) (*UpdateUserLastOnlineTimeResp, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserLastOnlineTime not implemented")
 }
+func (UnimplementedUserServiceServer) FollowUser(context.Context, *FollowUserReq) (*FollowUserResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowUser not implemented")
+}
+func (UnimplementedUserServiceServer) UnfollowUser(context.Context, *UnfollowUserReq) (*UnfollowUserResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowUser not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowers(context.Context, *ListFollowReq) (*ListFollowResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowers not implemented")
+}
+func (UnimplementedUserServiceServer) ListFollowing(context.Context, *ListFollowReq) (*ListFollowResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFollowing not implemented")
+}
+func (UnimplementedUserServiceServer) CheckFollowing(context.Context, *CheckFollowingReq) (*CheckFollowingResp, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckFollowing not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -322,6 +412,96 @@ func _UserService_UpdateUserLastOnlineTime_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_FollowUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FollowUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_FollowUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FollowUser(ctx, req.(*FollowUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_UnfollowUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfollowUserReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnfollowUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UnfollowUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnfollowUser(ctx, req.(*UnfollowUserReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowers(ctx, req.(*ListFollowReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFollowReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListFollowing(ctx, req.(*ListFollowReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_CheckFollowing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckFollowingReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CheckFollowing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_CheckFollowing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CheckFollowing(ctx, req.(*CheckFollowingReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -357,6 +537,26 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUserLastOnlineTime",
 			Handler:    _UserService_UpdateUserLastOnlineTime_Handler,
 		},
+		{
+			MethodName: "FollowUser",
+			Handler:    _UserService_FollowUser_Handler,
+		},
+		{
+			MethodName: "UnfollowUser",
+			Handler:    _UserService_UnfollowUser_Handler,
+		},
+		{
+			MethodName: "ListFollowers",
+			Handler:    _UserService_ListFollowers_Handler,
+		},
+		{
+			MethodName: "ListFollowing",
+			Handler:    _UserService_ListFollowing_Handler,
+		},
+		{
+			MethodName: "CheckFollowing",
+			Handler:    _UserService_CheckFollowing_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/campusUser/service/v1/user.proto",
@@ -24,6 +24,8 @@ type File struct {
 	Uploaded      bool
 	FileName      string
 	ExpireSeconds int64
+	PHash         string
+	DHash         string
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 }
@@ -177,15 +179,16 @@ type FileRepo interface {
 }
 
 type FileUsecase struct {
-	repo  FileRepo
-	minio MinioRepo
-	idGen idgen.Generator
-	frh   FileRepoHelper
-	log   *log.Helper
+	repo      FileRepo
+	minio     MinioRepo
+	idGen     idgen.Generator
+	frh       FileRepoHelper
+	log       *log.Helper
+	hashSlots chan struct{}
 }
 
 func NewFileUsecase(repo FileRepo, logger log.Logger, frh FileRepoHelper, minio MinioRepo, idGen idgen.Generator) *FileUsecase {
-	return &FileUsecase{repo: repo, frh: frh, minio: minio, log: log.NewHelper(logger), idGen: idGen}
+	return &FileUsecase{repo: repo, frh: frh, minio: minio, log: log.NewHelper(logger), idGen: idGen, hashSlots: make(chan struct{}, imageHashConcurrency)}
 }
 
 func (uc *FileUsecase) CheckFileExistedAndGetFile(ctx context.Context, query *CheckFileQuery) (*CheckFileResult, error) {
@@ -265,6 +268,7 @@ func (uc *FileUsecase) ReportUploaded(ctx context.Context, cmd *ReportUploadedCo
 	if err != nil {
 		return nil, err
 	}
+	uc.hashImageAsync(retFile)
 
 	// 改用公共 URL
 	publicUrl, err := uc.minio.GetPublicUrl(ctx, retFile.DomainName, retFile.GetObjectName())
@@ -0,0 +1,92 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+	"time"
+
+	_ "golang.org/x/image/webp"
+	"lehu-video/pkg/imagehash"
+)
+
+const (
+	// 超过该大小的图片不计算指纹，避免上传确认时占用过多内存
+	maxImageHashBytes = 20 << 20
+	// 解码前先按头部声明的宽高限制像素数，防止小文件解压出超大位图
+	maxImageHashPixels = 40_000_000
+	// 同时解码的图片数，超出的排队等待
+	imageHashConcurrency = 2
+	imageHashTimeout     = 30 * time.Second
+)
+
+func isImageFileType(fileType string) bool {
+	switch strings.Trim(strings.ToLower(fileType), ".") {
+	case "jpg", "jpeg", "png", "webp", "gif":
+		return true
+	default:
+		return false
+	}
+}
+
+// hashImageAsync 在上传确认返回后异步计算指纹，失败只记日志；没有指纹时校园侧会在审核时自行计算
+func (uc *FileUsecase) hashImageAsync(file *File) {
+	if file == nil || !isImageFileType(file.FileType) || file.PHash != "" || file.FileSize > maxImageHashBytes {
+		return
+	}
+	copied := *file
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), imageHashTimeout)
+		defer cancel()
+		select {
+		case uc.hashSlots <- struct{}{}:
+			defer func() { <-uc.hashSlots }()
+		case <-ctx.Done():
+			uc.log.Warnf("image hash queue timeout: id=%d", copied.Id)
+			return
+		}
+		if !uc.fillImageHash(ctx, &copied) {
+			return
+		}
+		if err := uc.frh.UpdateFile(ctx, &copied); err != nil {
+			uc.log.WithContext(ctx).Warnf("save image hash failed: id=%d err=%v", copied.Id, err)
+		}
+	}()
+}
+
+// fillImageHash 为图片文件计算 pHash/dHash，成功时返回 true
+func (uc *FileUsecase) fillImageHash(ctx context.Context, file *File) bool {
+	reader, err := uc.minio.GetObject(ctx, file.DomainName, file.GetObjectName())
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("read uploaded image failed: id=%d err=%v", file.Id, err)
+		return false
+	}
+	defer reader.Close()
+	img, err := decodeImageForHash(io.LimitReader(reader, maxImageHashBytes))
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("decode uploaded image failed: id=%d err=%v", file.Id, err)
+		return false
+	}
+	file.PHash = imagehash.Format(imagehash.PHash(img))
+	file.DHash = imagehash.Format(imagehash.DHash(img))
+	return true
+}
+
+func decodeImageForHash(reader io.Reader) (image.Image, error) {
+	var header bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(reader, &header))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > maxImageHashPixels {
+		return nil, fmt.Errorf("image too large to hash: %dx%d", cfg.Width, cfg.Height)
+	}
+	img, _, err := image.Decode(io.MultiReader(&header, reader))
+	return img, err
+}
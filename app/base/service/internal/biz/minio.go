@@ -2,6 +2,8 @@ package biz
 
 import (
 	"context"
+	"io"
+
 	"github.com/minio/minio-go/v7"
 )
 
@@ -20,6 +22,8 @@ type MinioRepo interface {
 	MergeSlices(ctx context.Context, bucketName, objectName, uploadId string, parts []minio.CompletePart) error
 	// GetObjectHash returns the hash of an object
 	GetObjectHash(ctx context.Context, bucketName, objectName string) (string, error)
+	// GetObject returns a reader of the object content
+	GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error)
 
 	// 新增：获取公共 URL
 	GetPublicUrl(ctx context.Context, bucketName, objectName string) (string, error)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -132,6 +133,14 @@ func (r *cosRepo) GetObjectHash(ctx context.Context, bucketName, objectName stri
 	return strings.ToUpper(etag), nil
 }
 
+func (r *cosRepo) GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+	resp, err := r.client.Object.Get(ctx, objectName, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
 func (r *cosRepo) GetPublicUrl(ctx context.Context, bucketName, objectName string) (string, error) {
 	return joinPublicURL(r.publicBaseURL, objectName), nil
 }
@@ -46,6 +46,8 @@ func (r *fileRepo) GetUploadedFileById(ctx context.Context, tableName string, id
 		FileType:   file.FileType,
 		FileSize:   file.FileSize,
 		Uploaded:   file.Uploaded,
+		PHash:      file.PHash,
+		DHash:      file.DHash,
 		CreatedAt:  file.CreatedAt,
 		UpdatedAt:  file.UpdatedAt,
 	}
@@ -69,6 +71,8 @@ func (r *fileRepo) GetUploadedFileByHash(ctx context.Context, tableName string,
 		FileType:   file.FileType,
 		FileSize:   file.FileSize,
 		Uploaded:   file.Uploaded,
+		PHash:      file.PHash,
+		DHash:      file.DHash,
 		CreatedAt:  file.CreatedAt,
 		UpdatedAt:  file.UpdatedAt,
 	}
@@ -92,6 +96,8 @@ func (r *fileRepo) GetFileById(ctx context.Context, tableName string, id int64)
 		FileType:   file.FileType,
 		FileSize:   file.FileSize,
 		Uploaded:   file.Uploaded,
+		PHash:      file.PHash,
+		DHash:      file.DHash,
 		CreatedAt:  file.CreatedAt,
 		UpdatedAt:  file.UpdatedAt,
 	}
@@ -107,6 +113,8 @@ func (r *fileRepo) CreateFile(ctx context.Context, tx *gorm.DB, tableName string
 		FileSize:   retFile.FileSize,
 		FileType:   retFile.FileType,
 		Uploaded:   retFile.Uploaded,
+		PHash:      retFile.PHash,
+		DHash:      retFile.DHash,
 		IsDeleted:  false,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
@@ -126,6 +134,8 @@ func (r *fileRepo) UpdateFile(ctx context.Context, tx *gorm.DB, tableName string
 		FileSize:   retFile.FileSize,
 		FileType:   retFile.FileType,
 		Uploaded:   retFile.Uploaded,
+		PHash:      retFile.PHash,
+		DHash:      retFile.DHash,
 		CreatedAt:  retFile.CreatedAt,
 		UpdatedAt:  time.Now(),
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"io"
 	"lehu-video/app/base/service/internal/biz"
 	"lehu-video/app/base/service/internal/conf"
 	"net"
@@ -403,6 +404,15 @@ func (r *minioRepo) GetObjectHash(
 	return strings.ToUpper(stat.ETag), nil
 }
 
+// GetObject 读取对象内容，用于上传后计算图片指纹
+func (r *minioRepo) GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+	reader, _, _, err := r.core.GetObject(ctx, bucketName, objectName, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
 // GetPublicUrl 返回对象的公共访问 URL（需要桶已设置为 public）
 func (r *minioRepo) GetPublicUrl(ctx context.Context, bucketName, objectName string) (string, error) {
 	// 拼接公共地址，确保末尾没有多余的斜杠
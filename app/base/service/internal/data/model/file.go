@@ -12,6 +12,8 @@ type File struct {
 	FileSize   int64     `gorm:"column:file_size;default:0;NOT NULL"`
 	FileType   string    `gorm:"column:file_type;NOT NULL"`
 	Uploaded   bool      `gorm:"column:uploaded;default:0;NOT NULL"`
+	PHash      string    `gorm:"column:phash;NOT NULL"`
+	DHash      string    `gorm:"column:dhash;NOT NULL"`
 	IsDeleted  bool      `gorm:"column:is_deleted;default:0;NOT NULL"`
 	CreatedAt  time.Time `gorm:"column:created_at;default:CURRENT_TIMESTAMP;NOT NULL"`
 	UpdatedAt  time.Time `gorm:"column:updated_at;default:CURRENT_TIMESTAMP;NOT NULL"`
@@ -2,6 +2,7 @@ package data
 
 import (
 	"context"
+	"io"
 	"path/filepath"
 	"strings"
 
@@ -63,6 +64,10 @@ func (r *publicMediaStorageRepo) GetObjectHash(ctx context.Context, bucketName,
 	return r.target(objectName).GetObjectHash(ctx, bucketName, objectName)
 }
 
+func (r *publicMediaStorageRepo) GetObject(ctx context.Context, bucketName, objectName string) (io.ReadCloser, error) {
+	return r.target(objectName).GetObject(ctx, bucketName, objectName)
+}
+
 func (r *publicMediaStorageRepo) GetPublicUrl(ctx context.Context, bucketName, objectName string) (string, error) {
 	return r.target(objectName).GetPublicUrl(ctx, bucketName, objectName)
 }
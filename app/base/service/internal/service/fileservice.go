@@ -230,5 +230,7 @@ func (s *FileServiceService) GetFileInfoById(ctx context.Context, req *pb.GetFil
 		Meta:       utils.GetSuccessMeta(),
 		ObjectName: result.File.GetObjectName(),
 		Hash:       result.File.Hash,
+		Phash:      result.File.PHash,
+		Dhash:      result.File.DHash,
 	}, nil
 }
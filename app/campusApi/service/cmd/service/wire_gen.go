@@ -67,20 +67,33 @@ func wireApp(confServer *conf.Server, registry *conf.Registry, confData *conf.Da
 	if err != nil {
 		return nil, nil, err
 	}
-	campusRepo := data.NewCampusRepo(dataData, logger)
-	campusTimetableProvider := biz.NewMockCampusTimetableProvider()
+	campusSearchIndex, cleanup2, err := data.NewCampusSearchIndex(dataData, logger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	campusRepo := data.NewCampusRepo(dataData, campusSearchIndex, logger)
+	campusTimetableSessionStore := data.NewCampusTimetableSessionStore(dataData)
+	campusTimetableProvider, err := biz.NewCampusTimetableProvider(campusTimetableSessionStore, logger)
+	if err != nil {
+		cleanup2()
+		cleanup()
+		return nil, nil, err
+	}
 	campusIDGenerator, err := biz.NewCampusIDGenerator()
 	if err != nil {
+		cleanup2()
 		cleanup()
 		return nil, nil, err
 	}
 	campusRAGClient := biz.NewCampusRAGClient(logger)
-	campusUsecase := biz.NewCampusUsecase(campusRepo, baseAdapter, coreAdapter, campusTimetableProvider, campusIDGenerator, campusRAGClient, string2, logger)
+	campusUsecase := biz.NewCampusUsecase(campusRepo, baseAdapter, coreAdapter, campusTimetableProvider, campusIDGenerator, campusRAGClient, campusSearchIndex, string2, logger)
 	campusService := service.NewCampusService(campusUsecase, string2, logger)
 	httpServer := server.NewHTTPServer(confServer, auth, userServiceService, fileServiceService, campusService, dataData, logger)
 	campusTaskServer := server.NewCampusTaskServer(campusUsecase, logger)
 	app := newApp(logger, registrar, httpServer, campusTaskServer)
 	return app, func() {
+		cleanup2()
 		cleanup()
 	}, nil
 }
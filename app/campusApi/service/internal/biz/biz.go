@@ -26,7 +26,7 @@ var ProviderSet = wire.NewSet(
 	NewAuthSecret,
 	NewUserUsecase,
 	NewFileUsecase,
-	NewMockCampusTimetableProvider,
+	NewCampusTimetableProvider,
 	NewCampusIDGenerator,
 	NewCampusRAGClient,
 	NewCampusUsecase,
@@ -24,10 +24,17 @@ import (
 )
 
 const (
-	CampusAuditStatusPending  int32 = 0
-	CampusAuditStatusVisible  int32 = 1
-	CampusAuditStatusRejected int32 = 2
-	CampusAuditStatusDeleted  int32 = 3
+	CampusAuditStatusPending   int32 = 0
+	CampusAuditStatusVisible   int32 = 1
+	CampusAuditStatusRejected  int32 = 2
+	CampusAuditStatusDeleted   int32 = 3
+	CampusAuditStatusDraft     int32 = 4
+	CampusAuditStatusScheduled int32 = 5
+
+	// 作者自己删除（含随帖子、父评论一起删除的评论）时写入的 audit_reason，这些内容不能申诉，也不计入违规
+	CampusAuditReasonUserDeleted          = "用户删除"
+	CampusAuditReasonPostDeleted          = "帖子已删除"
+	CampusAuditReasonParentCommentDeleted = "父评论已撤回"
 
 	CampusFeedbackStatusPending    int32 = 0
 	CampusFeedbackStatusProcessing int32 = 1
@@ -62,6 +69,9 @@ const (
 	CampusOpsAlertTypeReportOverdue       = "report_overdue"
 	CampusOpsAlertTypeAuditOverdue        = "audit_overdue"
 	CampusOpsAlertTypeFeishuDegraded      = "feishu_delivery_degraded"
+	CampusOpsAlertTypeAppealCreated       = "appeal_created"
+	CampusOpsAlertTypeAppealOverdue       = "appeal_overdue"
+	CampusOpsAlertTypeReportAutoHidden    = "report_auto_hidden"
 
 	CampusOpsAlertPriorityNormal   = "normal"
 	CampusOpsAlertPriorityHigh     = "high"
@@ -81,6 +91,7 @@ const (
 	CampusPostTypeQuestion = "question"
 	CampusPostTypeGuide    = "guide"
 	CampusPostTypeClub     = "club"
+	CampusPostTypePoll     = "poll"
 
 	CampusPostSortRecommend = "recommend"
 	CampusPostSortHot       = "hot"
@@ -170,11 +181,6 @@ const (
 	campusOpsSettingEzaiPersonaPromptVer = "ezai_persona_prompt_version"
 )
 
-var (
-	defaultAuditHighRiskWords = []string{"赌博", "裸聊", "诈骗", "代考", "代课", "身份证", "银行卡", "毒品", "买卖账号", "刷单", "套现"}
-	defaultAuditReviewWords   = []string{"加微信", "兼职", "引战", "辱骂", "曝光", "挂人", "联系方式", "私聊", "群号", "二维码"}
-)
-
 type CampusIDGenerator interface {
 	NextID() int64
 }
@@ -197,10 +203,13 @@ func (g *campusIDGenerator) NextID() int64 {
 
 type CampusForumCategory struct {
 	ID          int64
+	CampusCode  string
 	Code        string
 	Name        string
 	Description string
 	SortOrder   int32
+
+	AllowAnonymous bool
 }
 
 type CampusProfile struct {
@@ -209,6 +218,7 @@ type CampusProfile struct {
 	AccountID    string
 	OpenID       string
 	UnionID      string
+	CampusCode   string
 	SchoolName   string
 	StudentNo    string
 	RealName     string
@@ -251,74 +261,6 @@ type CampusTimetableCourse struct {
 	UpdatedAt      time.Time
 }
 
-type CampusTimetableProvider interface {
-	Fetch(ctx context.Context, studentNo, password, term string) ([]*CampusTimetableCourse, error)
-}
-
-type MockCampusTimetableProvider struct{}
-
-func NewMockCampusTimetableProvider() CampusTimetableProvider {
-	return &MockCampusTimetableProvider{}
-}
-
-func (p *MockCampusTimetableProvider) Fetch(ctx context.Context, studentNo, password, term string) ([]*CampusTimetableCourse, error) {
-	_ = ctx
-	if strings.TrimSpace(password) == "" {
-		return nil, apperror.InvalidArgument("教务系统密码不能为空")
-	}
-	seed := shortHash(studentNo+term, 8)
-	return []*CampusTimetableCourse{
-		{
-			CourseName:     "高等数学 A",
-			Teacher:        "李老师",
-			Classroom:      "教学楼 A203",
-			Weekday:        1,
-			StartSection:   1,
-			EndSection:     2,
-			StartWeek:      1,
-			EndWeek:        16,
-			WeekParity:     0,
-			SourceCourseID: "mock-math-" + seed,
-		},
-		{
-			CourseName:     "大学英语",
-			Teacher:        "陈老师",
-			Classroom:      "教学楼 B105",
-			Weekday:        2,
-			StartSection:   3,
-			EndSection:     4,
-			StartWeek:      1,
-			EndWeek:        16,
-			WeekParity:     0,
-			SourceCourseID: "mock-english-" + seed,
-		},
-		{
-			CourseName:     "程序设计基础",
-			Teacher:        "王老师",
-			Classroom:      "实训楼 C301",
-			Weekday:        3,
-			StartSection:   5,
-			EndSection:     6,
-			StartWeek:      2,
-			EndWeek:        15,
-			WeekParity:     0,
-			SourceCourseID: "mock-code-" + seed,
-		},
-		{
-			CourseName:     "体育",
-			Teacher:        "周老师",
-			Classroom:      "运动场",
-			Weekday:        5,
-			StartSection:   7,
-			EndSection:     8,
-			StartWeek:      1,
-			EndWeek:        12,
-			WeekParity:     0,
-			SourceCourseID: "mock-pe-" + seed,
-		},
-	}, nil
-}
-
 type CampusForumAuthor struct {
 	UserID     string
 	Name       string
@@ -326,6 +268,8 @@ type CampusForumAuthor struct {
 	Avatar     string
 	SchoolName string
 	AuthStatus int32
+
+	IsAnonymous bool
 }
 
 type CampusPublicUserStats struct {
@@ -345,10 +289,16 @@ type CampusPublicUserProfile struct {
 	IsOfficial bool
 	Bio        string
 	Stats      *CampusPublicUserStats
+
+	FollowCount   int64
+	FollowerCount int64
+	IsFollowing   bool
 }
 
 type CampusForumPost struct {
 	ID              int64
+	CampusCode      string
+	IsCrossCampus   bool
 	CategoryCode    string
 	CategoryName    string
 	AuthorID        string
@@ -363,6 +313,7 @@ type CampusForumPost struct {
 	IsOfficial      bool
 	IsFeatured      bool
 	IsPinned        bool
+	IsAnonymous     bool
 	SortWeight      int32
 	Status          int32
 	AuditReason     string
@@ -376,8 +327,18 @@ type CampusForumPost struct {
 	CollectedCount  int64
 	IsLiked         bool
 	IsCollected     bool
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	SearchHighlight *CampusSearchHighlight
+	Poll            *CampusPoll
+	LostItem        *CampusLostItem
+	Question        *CampusQuestion
+	ClubEvent       *CampusClubEvent
+	Tags            []*CampusTag
+	EditCount       int32
+	EditedAt        *time.Time
+	// 只在审核队列里填充
+	Claim     *CampusModerationClaim
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 type CampusForumComment struct {
@@ -397,6 +358,7 @@ type CampusForumComment struct {
 	LikeCount        int64
 	ReplyCount       int64
 	IsLiked          bool
+	IsAccepted       bool
 	PreviewReplies   []*CampusForumComment
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
@@ -413,8 +375,11 @@ type CampusForumReport struct {
 	Reason     string
 	Detail     string
 	Status     int32
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	// 按举报人历史成立率算出的权重，只在后台列表里填充
+	ReporterWeight float64
+	Claim          *CampusModerationClaim
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type CampusFeedback struct {
@@ -479,6 +444,7 @@ type CampusAIReplyTask struct {
 	TriggerCommentID int64
 	AskerID          string
 	BotUserID        string
+	Source           string
 	Prompt           string
 	Status           string
 	RetryCount       int32
@@ -547,6 +513,8 @@ type CampusOpsSLASnapshot struct {
 	OverdueCommentCount  int64
 	FeishuDegradedAlerts []*CampusOpsAlert
 	FeishuDegradedCount  int64
+	OverdueAppeals       []*CampusModerationAppeal
+	OverdueAppealCount   int64
 }
 
 type CampusMetricSeries struct {
@@ -870,6 +838,7 @@ type WechatLoginOutput struct {
 
 type UpdateCampusProfileInput struct {
 	UserID       string
+	CampusCode   string
 	SchoolName   string
 	StudentNo    string
 	RealName     string
@@ -880,16 +849,19 @@ type UpdateCampusProfileInput struct {
 }
 
 type ImportCampusTimetableInput struct {
-	UserID    string
-	StudentNo string
-	Password  string
-	Term      string
+	UserID       string
+	StudentNo    string
+	Password     string
+	Term         string
+	CaptchaToken string
+	Captcha      string
 }
 
 type ImportCampusTimetableOutput struct {
 	Term    string
 	Courses []*CampusTimetableCourse
 	Count   int32
+	Captcha *CampusTimetableCaptcha
 }
 
 type ListCampusTimetableInput struct {
@@ -904,6 +876,8 @@ type ListCampusTimetableOutput struct {
 
 type CreateCampusPostInput struct {
 	UserID       string
+	CampusCode   string
+	CrossCampus  bool
 	CategoryCode string
 	Title        string
 	Content      string
@@ -916,30 +890,47 @@ type CreateCampusPostInput struct {
 	IsFeatured   bool
 	IsPinned     bool
 	SortWeight   int32
+	PublishMode  string
+	PublishAt    *time.Time
+	Poll         *CampusPollInput
+	LostItem     *CampusLostItemInput
+	ClubEvent    *CampusClubEventInput
+	Tags         []string
+	Anonymous    bool
 }
 
 type ListCampusPostsInput struct {
 	CurrentUserID string
+	CampusCode    string
 	AuthorID      string
 	CategoryCode  string
 	PostType      string
+	Tag           string
 	Sort          string
 	Keyword       string
+	Cursor        string
 	Page          int32
 	Size          int32
 }
 
 type ListCampusPostsOutput struct {
-	Posts []*CampusForumPost
-	Total int64
+	Posts      []*CampusForumPost
+	Total      int64
+	NextCursor string
 }
 
 type ListCampusPostQuery struct {
+	CampusCode        string
+	OnlyOwnCampus     bool
 	CategoryCode      string
 	PostType          string
 	Sort              string
 	Keyword           string
 	AuthorID          string
+	AuthorIDs         []string
+	ExcludeAuthorIDs  []string
+	ExcludeAnonymous  bool
+	TagID             int64
 	CollectedByUserID string
 	Statuses          []int32
 	IncludeDeleted    bool
@@ -947,6 +938,9 @@ type ListCampusPostQuery struct {
 	OnlyFeatured      *bool
 	OnlyPinned        *bool
 	OnlyReported      bool
+	ClaimFilter       string
+	ClaimOperatorID   string
+	After             *CampusListCursor
 	Offset            int
 	Limit             int
 }
@@ -970,23 +964,29 @@ type ListCampusCommentsInput struct {
 	UserID        string
 	CommentID     int64
 	CurrentUserID string
+	Cursor        string
 	Page          int32
 	Size          int32
 }
 
 type ListCampusCommentsOutput struct {
-	Comments []*CampusForumComment
-	Total    int64
+	Comments   []*CampusForumComment
+	Total      int64
+	NextCursor string
 }
 
 type ListCampusCommentQuery struct {
-	PostID         int64
-	ParentID       *int64
-	AuthorID       string
-	Statuses       []int32
-	IncludeDeleted bool
-	Offset         int
-	Limit          int
+	CampusCode       string
+	PostID           int64
+	ParentID         *int64
+	AuthorID         string
+	Statuses         []int32
+	IncludeDeleted   bool
+	ExcludeIDs       []int64
+	ExcludeAuthorIDs []string
+	After            *CampusListCursor
+	Offset           int
+	Limit            int
 }
 
 type ReportCampusContentInput struct {
@@ -998,10 +998,13 @@ type ReportCampusContentInput struct {
 }
 
 type ListCampusModerationInput struct {
-	UserID string
-	Status int32
-	Page   int32
-	Size   int32
+	UserID     string
+	CampusCode string
+	Status     int32
+	Claim      string
+	Cursor     string
+	Page       int32
+	Size       int32
 }
 
 type CampusAdminSummary struct {
@@ -1052,12 +1055,14 @@ type CampusAdminTrend struct {
 
 type ListCampusAdminPostsInput struct {
 	UserID       string
+	CampusCode   string
 	CategoryCode string
 	PostType     string
 	OpsFilter    string
 	Keyword      string
 	Status       int32
 	Sort         string
+	Cursor       string
 	Page         int32
 	Size         int32
 }
@@ -1078,6 +1083,7 @@ type UpdateCampusAdminPostInput struct {
 	IsOfficial   bool
 	IsFeatured   bool
 	IsPinned     bool
+	CrossCampus  *bool
 	SortWeight   int32
 }
 
@@ -1093,11 +1099,13 @@ type BatchCampusAdminPostsOutput struct {
 }
 
 type ListCampusAdminCommentsInput struct {
-	UserID string
-	Status int32
-	PostID int64
-	Page   int32
-	Size   int32
+	UserID     string
+	CampusCode string
+	Status     int32
+	PostID     int64
+	Cursor     string
+	Page       int32
+	Size       int32
 }
 
 type ListCampusAIReplyTasksInput struct {
@@ -1377,10 +1385,21 @@ type ListCampusAgentRunsOutput struct {
 }
 
 type ListCampusReportsInput struct {
-	UserID string
-	Status int32
-	Page   int32
-	Size   int32
+	UserID     string
+	CampusCode string
+	Status     int32
+	Claim      string
+	Page       int32
+	Size       int32
+}
+
+type CampusReportQuery struct {
+	Status          int32
+	CampusCode      string
+	ClaimFilter     string
+	ClaimOperatorID string
+	Offset          int
+	Limit           int
 }
 
 type ListCampusReportsOutput struct {
@@ -1393,6 +1412,7 @@ type ReviewCampusReportInput struct {
 	ReportID int64
 	Action   string
 	Reason   string
+	Force    bool
 }
 
 type CampusAdminUser struct {
@@ -1412,6 +1432,8 @@ type CampusAdminUser struct {
 	LastActiveIP     string
 	LastActivePath   string
 	LastActiveStatus int32
+	Sanctions        []*CampusUserSanction
+	Trust            *CampusUserTrustScore
 }
 
 type ListCampusAdminUsersInput struct {
@@ -1464,6 +1486,7 @@ type ReviewCampusFeedbackInput struct {
 type ListCampusNotificationsInput struct {
 	UserID string
 	Type   string
+	Cursor string
 	Page   int32
 	Size   int32
 }
@@ -1471,6 +1494,7 @@ type ListCampusNotificationsInput struct {
 type ListCampusNotificationsOutput struct {
 	Notifications []*CampusNotification
 	Total         int64
+	NextCursor    string
 }
 
 type CreateCampusAdminNotificationInput struct {
@@ -1596,6 +1620,8 @@ type ReviewCampusContentInput struct {
 	TargetID   int64
 	Action     string
 	Reason     string
+	// 管理员越过别人的认领直接处理
+	Force bool
 }
 
 type CampusRepo interface {
@@ -1607,18 +1633,118 @@ type CampusRepo interface {
 	UpdateProfile(ctx context.Context, profile *CampusProfile) error
 	ReplaceTimetableCourses(ctx context.Context, userID, term, source string, courses []*CampusTimetableCourse) error
 	ListTimetableCourses(ctx context.Context, userID, term string) ([]*CampusTimetableCourse, error)
-	ListCategories(ctx context.Context) ([]*CampusForumCategory, error)
-	GetCategoryByCode(ctx context.Context, code string) (bool, *CampusForumCategory, error)
+	GetTimetableSubscription(ctx context.Context, userID string) (bool, *CampusTimetableSubscription, error)
+	SaveTimetableSubscription(ctx context.Context, sub *CampusTimetableSubscription) error
+	RevokeTimetableSubscription(ctx context.Context, userID string) error
+	CreateCalendarEvent(ctx context.Context, event *CampusCalendarEvent) error
+	UpdateCalendarEvent(ctx context.Context, event *CampusCalendarEvent) error
+	DeleteCalendarEvent(ctx context.Context, eventID int64, operatorID string) error
+	GetCalendarEvent(ctx context.Context, eventID int64) (bool, *CampusCalendarEvent, error)
+	ListCalendarEvents(ctx context.Context, query ListCampusCalendarEventQuery) ([]*CampusCalendarEvent, int64, error)
+	ListCategories(ctx context.Context, campusCode string) ([]*CampusForumCategory, error)
+	GetCategoryByCode(ctx context.Context, campusCode, code string) (bool, *CampusForumCategory, error)
 	CreatePost(ctx context.Context, post *CampusForumPost) error
 	ListPosts(ctx context.Context, query ListCampusPostQuery) ([]*CampusForumPost, int64, error)
+	ListPostCampusCodes(ctx context.Context) ([]string, error)
 	ListTopImagePostsByDate(ctx context.Context, start, end time.Time, limit int) ([]*CampusForumPost, error)
 	GetPublicUserPostStats(ctx context.Context, userID string) (*CampusPublicUserStats, error)
 	ListPostsByIDs(ctx context.Context, postIDs []int64, statuses []int32) ([]*CampusForumPost, error)
+	ListUserInteractionSignals(ctx context.Context, userID string, since time.Time, limit int) ([]*CampusInteractionSignal, error)
+	ListSearchDocuments(ctx context.Context, docType string, afterID int64, limit int) ([]*CampusSearchDocument, error)
 	GetPostByID(ctx context.Context, postID int64) (bool, *CampusForumPost, error)
 	GetAnyPostByID(ctx context.Context, postID int64) (bool, *CampusForumPost, error)
 	DeletePost(ctx context.Context, postID int64) error
 	UpdatePostStatus(ctx context.Context, postID int64, status int32, reason string) error
 	UpdatePostByAdmin(ctx context.Context, post *CampusForumPost) error
+	UpdatePostContent(ctx context.Context, post *CampusForumPost, revision *CampusPostRevision) error
+	CreatePostRevision(ctx context.Context, revision *CampusPostRevision) error
+	ListPostRevisions(ctx context.Context, postID int64) ([]*CampusPostRevision, error)
+	CreateScheduledPost(ctx context.Context, post *CampusForumPost, schedule *CampusPostSchedule) error
+	GetPostSchedule(ctx context.Context, postID int64) (bool, *CampusPostSchedule, error)
+	ListPostSchedules(ctx context.Context, campusCode string, states []string, offset, limit int) ([]*CampusPostSchedule, int64, error)
+	UpdatePostSchedule(ctx context.Context, schedule *CampusPostSchedule, postStatus int32) error
+	ListDuePostSchedules(ctx context.Context, now time.Time, limit int) ([]*CampusPostSchedule, error)
+	PublishScheduledPost(ctx context.Context, postID int64, now time.Time) (bool, error)
+	MarkPostScheduleError(ctx context.Context, postID int64, message string) error
+	GetPostPolls(ctx context.Context, postIDs []int64) (map[int64]*CampusPoll, error)
+	ListPollChoices(ctx context.Context, userID string, postIDs []int64) (map[int64][]int32, error)
+	ListPollVoters(ctx context.Context, postID int64, perOption int) (map[int32][]string, error)
+	VotePoll(ctx context.Context, postID int64, userID string, optionIDs []int32) (bool, error)
+	ListDuePollPostIDs(ctx context.Context, now time.Time, limit int) ([]int64, error)
+	ClosePoll(ctx context.Context, postID int64, now time.Time, outbox *CampusNotificationOutbox) (bool, error)
+	ListLostItemsByPostIDs(ctx context.Context, postIDs []int64) (map[int64]*CampusLostItem, error)
+	ListUnscannedLostItems(ctx context.Context, since time.Time, limit int) ([]*CampusLostItem, error)
+	ListOpenLostItems(ctx context.Context, campusCode, kind string, since time.Time, limit int) ([]*CampusLostItem, error)
+	CreateLostMatch(ctx context.Context, match *CampusLostMatch, outboxes []*CampusNotificationOutbox) (bool, error)
+	ListLostMatches(ctx context.Context, postID int64) ([]*CampusLostMatch, error)
+	MarkLostItemScanned(ctx context.Context, postID int64, now time.Time) error
+	ResolveLostItems(ctx context.Context, postIDs []int64, userID string, now time.Time) error
+	ListQuestionsByPostIDs(ctx context.Context, postIDs []int64) (map[int64]*CampusQuestion, error)
+	AcceptAnswer(ctx context.Context, postID, commentID int64, now time.Time, outbox *CampusNotificationOutbox) error
+	MarkQuestionAnswered(ctx context.Context, postID int64, now time.Time) error
+	ListQuestions(ctx context.Context, campusCode, state string, offset, limit int) ([]*CampusQuestion, int64, error)
+	ListAIDueQuestions(ctx context.Context, before time.Time, limit int) ([]*CampusQuestion, error)
+	QueueQuestionAIReply(ctx context.Context, postID int64, task *CampusAIReplyTask) (bool, error)
+	GetClubEvents(ctx context.Context, postIDs []int64) (map[int64]*CampusClubEvent, error)
+	GetClubRegistrations(ctx context.Context, userID string, postIDs []int64) (map[int64]*CampusClubRegistration, error)
+	RegisterClubEvent(ctx context.Context, postID int64, userID string, now time.Time) (*CampusClubRegistration, error)
+	CancelClubRegistration(ctx context.Context, postID int64, userID string, now time.Time) (string, error)
+	CheckInClubEvent(ctx context.Context, postID int64, userID string, now time.Time) error
+	ListClubRegistrations(ctx context.Context, postID int64, status string, offset, limit int) ([]*CampusClubRegistration, int64, error)
+	ListDueClubReminders(ctx context.Context, now, before time.Time, limit int) ([]*CampusClubEvent, error)
+	MarkClubReminderSent(ctx context.Context, postID int64, now time.Time, outboxes []*CampusNotificationOutbox) (bool, error)
+	ListDueFollowFanouts(ctx context.Context, since time.Time, limit int) ([]*CampusPostFanout, error)
+	SetUserBlock(ctx context.Context, block *CampusUserBlock) error
+	DeleteUserBlock(ctx context.Context, userID, targetUserID, kind string) error
+	ListUserBlocks(ctx context.Context, userID, kind string, offset, limit int) ([]*CampusUserBlock, int64, error)
+	ListBlockedUserIDs(ctx context.Context, userID string, limit int) ([]string, error)
+	GetUserBlockKind(ctx context.Context, userID, targetUserID string) (string, error)
+	EnsureTags(ctx context.Context, tags []*CampusTag) (map[string]*CampusTag, error)
+	GetTagByName(ctx context.Context, name string) (bool, *CampusTag, error)
+	GetTagsByIDs(ctx context.Context, ids []int64) (map[int64]*CampusTag, error)
+	GetPostTags(ctx context.Context, postIDs []int64) (map[int64][]*CampusTag, error)
+	ReplacePostTags(ctx context.Context, postID int64, tags []*CampusTag) error
+	GetAnonymousPostAuthors(ctx context.Context, postIDs []int64) (map[int64]string, error)
+	CreateImageAuditTasks(ctx context.Context, tasks []*CampusImageAuditTask) error
+	ClaimImageAuditTasks(ctx context.Context, limit int, lockFor time.Duration) ([]*CampusImageAuditTask, error)
+	MarkImageAuditTaskDone(ctx context.Context, id int64, provider, decision, label, reason, rawResult string) error
+	MarkImageAuditTaskRetry(ctx context.Context, id int64, retryCount int32, nextRetryAt *time.Time, lastError string, final bool) error
+	GetRecentImageAuditByURL(ctx context.Context, imageURL string, since time.Time) (bool, *CampusImageAuditTask, error)
+	ListImageAuditTasks(ctx context.Context, targetType string, targetID int64) ([]*CampusImageAuditTask, error)
+	SaveImageFingerprint(ctx context.Context, fingerprint *CampusImageFingerprint) error
+	GetImageFingerprint(ctx context.Context, imageURL string) (bool, *CampusImageFingerprint, error)
+	FindRejectedImageDuplicate(ctx context.Context, query CampusImageDuplicateQuery) (bool, *CampusImageDuplicate, error)
+	ListImageHashBlocks(ctx context.Context) ([]*CampusImageHashBlock, error)
+	CreateImageHashBlock(ctx context.Context, block *CampusImageHashBlock) error
+	DeleteImageHashBlock(ctx context.Context, id int64) (bool, error)
+	ListAuditRules(ctx context.Context) ([]*CampusAuditRule, error)
+	GetAuditRule(ctx context.Context, id int64) (bool, *CampusAuditRule, error)
+	CreateAuditRule(ctx context.Context, rule *CampusAuditRule) error
+	UpdateAuditRule(ctx context.Context, rule *CampusAuditRule) error
+	DeleteAuditRule(ctx context.Context, id int64) (bool, error)
+	IncrAuditRuleHits(ctx context.Context, ids []int64, at time.Time) error
+	CreateUserSanction(ctx context.Context, sanction *CampusUserSanction) error
+	GetUserSanction(ctx context.Context, id int64) (bool, *CampusUserSanction, error)
+	ListUserSanctions(ctx context.Context, userID string, limit int) ([]*CampusUserSanction, error)
+	ListActiveUserSanctions(ctx context.Context, userIDs []string) (map[string][]*CampusUserSanction, error)
+	GetActiveUserSanctions(ctx context.Context, userID string) ([]*CampusUserSanction, error)
+	RevokeUserSanction(ctx context.Context, id int64, revokedBy, reason string) (bool, error)
+	ListDueUserSanctions(ctx context.Context, now time.Time, limit int) ([]*CampusUserSanction, error)
+	ExpireUserSanction(ctx context.Context, id int64) (bool, error)
+	ListUserTrustSignals(ctx context.Context, userIDs []string) (map[string]*CampusUserTrustSignals, error)
+	CreateModerationAppeal(ctx context.Context, appeal *CampusModerationAppeal) (bool, error)
+	GetModerationAppeal(ctx context.Context, id int64) (bool, *CampusModerationAppeal, error)
+	ListModerationAppeals(ctx context.Context, query *CampusAppealQuery) ([]*CampusModerationAppeal, int64, error)
+	UpdateModerationAppealStatus(ctx context.Context, id int64, fromStatus, toStatus, reviewerID, note string) (bool, error)
+	ListTags(ctx context.Context, query ListCampusTagQuery) ([]*CampusTag, int64, error)
+	ListTrendingTags(ctx context.Context, campusCode string, limit int) ([]*CampusTag, error)
+	GetTagCampusStats(ctx context.Context, campusCode string, tagIDs []int64) (map[int64]*CampusTagStat, error)
+	ListTagActivity(ctx context.Context, since time.Time) ([]*CampusTagActivity, error)
+	SaveTagTrendScores(ctx context.Context, scores []*CampusTagStat) error
+	ReconcileTagPostCounts(ctx context.Context) error
+	MergeTag(ctx context.Context, sourceID, targetID int64) error
+	UpdateTagFlags(ctx context.Context, tag *CampusTag) error
+	SaveFollowFanoutBatch(ctx context.Context, fanout *CampusPostFanout, outboxes []*CampusNotificationOutbox) error
 	CreateComment(ctx context.Context, comment *CampusForumComment) error
 	CreateCommentWithOutbox(ctx context.Context, comment *CampusForumComment, outbox *CampusNotificationOutbox) error
 	ListComments(ctx context.Context, query ListCampusCommentQuery) ([]*CampusForumComment, int64, error)
@@ -1642,8 +1768,16 @@ type CampusRepo interface {
 	CreateReport(ctx context.Context, report *CampusForumReport) error
 	GetReportByID(ctx context.Context, reportID int64) (bool, *CampusForumReport, error)
 	GetReportByTargetAndReporter(ctx context.Context, targetType string, targetID int64, reporterID string) (bool, *CampusForumReport, error)
-	ListReports(ctx context.Context, status int32, offset, limit int) ([]*CampusForumReport, int64, error)
+	ListReports(ctx context.Context, query *CampusReportQuery) ([]*CampusForumReport, int64, error)
 	ListReportsByTarget(ctx context.Context, targetType string, targetID int64, status int32) ([]*CampusForumReport, error)
+	ListReportGroups(ctx context.Context, query *CampusReportQuery) ([]*CampusReportGroup, int64, error)
+	ListReporterStats(ctx context.Context, reporterIDs []string) (map[string]*CampusReporterStats, error)
+	ClaimModerationItem(ctx context.Context, claim *CampusModerationClaim, force bool) (bool, *CampusModerationClaim, error)
+	GetActiveModerationClaim(ctx context.Context, targetType string, targetID int64, now time.Time) (bool, *CampusModerationClaim, error)
+	ListActiveModerationClaims(ctx context.Context, targetType string, targetIDs []int64, now time.Time) (map[int64]*CampusModerationClaim, error)
+	ReleaseModerationClaim(ctx context.Context, targetType string, targetID int64, operatorID string) (bool, error)
+	DeleteExpiredModerationClaims(ctx context.Context, now time.Time) (int64, error)
+	ListModerationOperatorStats(ctx context.Context, since, now time.Time) ([]*CampusModerationOperatorStats, error)
 	UpdateReportStatus(ctx context.Context, reportID int64, status int32) error
 	UpdateReportsStatusByTarget(ctx context.Context, targetType string, targetID int64, status int32) error
 	CreateFeedback(ctx context.Context, feedback *CampusFeedback) error
@@ -1706,11 +1840,11 @@ type CampusRepo interface {
 	MarkOpsAlertSent(ctx context.Context, id int64, feishuStatus, feishuError string, sentAt *time.Time) error
 	MarkOpsAlertRetry(ctx context.Context, id int64, retryCount int32, nextRetryAt *time.Time, lastError string, final bool) error
 	GetOpsAlertSummary(ctx context.Context, todayStart time.Time, recentLimit int) (*CampusOpsAlertSummary, error)
-	GetOpsSLASnapshot(ctx context.Context, reportBefore, auditBefore, feishuBefore time.Time, sampleLimit int) (*CampusOpsSLASnapshot, error)
+	GetOpsSLASnapshot(ctx context.Context, reportBefore, auditBefore, feishuBefore, appealBefore time.Time, sampleLimit int) (*CampusOpsSLASnapshot, error)
 	GetOpsMetricSeries(ctx context.Context, now time.Time, sla *CampusOpsSLASnapshot) ([]CampusMetricSeries, error)
 	CreateOpsActionToken(ctx context.Context, item *CampusOpsActionToken) error
 	UseOpsActionToken(ctx context.Context, tokenHash string, now time.Time) (bool, *CampusOpsActionToken, error)
-	ListNotifications(ctx context.Context, userID, group string, offset, limit int) ([]*CampusNotification, int64, error)
+	ListNotifications(ctx context.Context, userID, group string, after *CampusListCursor, offset, limit int) ([]*CampusNotification, int64, error)
 	CountUnreadNotifications(ctx context.Context, userID string) (*CampusUnreadNotificationCount, error)
 	MarkNotificationRead(ctx context.Context, userID string, notificationID int64) error
 	MarkAllNotificationsRead(ctx context.Context, userID string) error
@@ -1743,12 +1877,17 @@ type CampusUsecase struct {
 	authSecret        string
 	assembler         *CampusPostAssembler
 	recommendPool     *CampusRecommendPool
+	personalFeed      *CampusPersonalFeed
 	eventBatcher      *CampusBatchProcessor[*TrackCampusEventInput]
 	accessLogBatcher  *CampusBatchProcessor[*CampusAccessLog]
 	knowledgeIndexer  *CampusBatchProcessor[*CampusKnowledgeDocument]
 	aiReplyConfig     CampusAIReplyConfig
 	aiAuditConfig     CampusAIContentAuditConfig
+	imageAuditor      CampusImageAuditor
 	rag               CampusRAGClient
+	search            CampusSearchIndex
+	searchReindex     campusSearchReindexState
+	auditRules        campusAuditRuleCache
 	log               *log.Helper
 }
 
@@ -1770,7 +1909,7 @@ type CampusAIContentAuditConfig struct {
 	Timeout time.Duration
 }
 
-func NewCampusUsecase(repo CampusRepo, base BaseAdapter, core CoreAdapter, timetableProvider CampusTimetableProvider, idGen CampusIDGenerator, rag CampusRAGClient, authSecret string, logger log.Logger) *CampusUsecase {
+func NewCampusUsecase(repo CampusRepo, base BaseAdapter, core CoreAdapter, timetableProvider CampusTimetableProvider, idGen CampusIDGenerator, rag CampusRAGClient, search CampusSearchIndex, authSecret string, logger log.Logger) *CampusUsecase {
 	if rag == nil {
 		rag = &noopCampusRAGClient{}
 	}
@@ -1785,15 +1924,18 @@ func NewCampusUsecase(repo CampusRepo, base BaseAdapter, core CoreAdapter, timet
 		authSecret:        authSecret,
 		assembler:         assembler,
 		recommendPool:     recommendPool,
+		personalFeed:      NewCampusPersonalFeed(),
 		aiReplyConfig:     loadCampusAIReplyConfig(),
 		aiAuditConfig:     loadCampusAIContentAuditConfig(),
 		rag:               rag,
+		search:            search,
 		log:               log.NewHelper(logger),
 	}
 	uc.eventBatcher = NewCampusBatchProcessor("campus_event", 100, 2*time.Second, uc.persistCampusEvents, logger)
 	uc.accessLogBatcher = NewCampusBatchProcessor("campus_access_log", 100, 2*time.Second, uc.persistCampusAccessLogs, logger)
 	uc.knowledgeIndexer = NewCampusBatchProcessor("campus_knowledge_index", 100, time.Second, uc.processKnowledgeIndexBatch, logger)
 	uc.knowledgeIndexer.timeout = 90 * time.Second
+	uc.imageAuditor = uc.loadCampusImageAuditor()
 	return uc
 }
 
@@ -2009,6 +2151,7 @@ func (uc *CampusUsecase) ensureProfile(ctx context.Context, identity *CampusWech
 		AccountID:  identity.AccountID,
 		OpenID:     identity.OpenID,
 		UnionID:    identity.UnionID,
+		CampusCode: defaultCampusCode(),
 		SchoolName: "深圳职业技术大学深汕校区",
 		Mobile:     user.Mobile,
 		AuthStatus: CampusAuthStatusUnverified,
@@ -2030,6 +2173,12 @@ func (uc *CampusUsecase) UpdateProfile(ctx context.Context, input *UpdateCampusP
 	if !exists {
 		return nil, apperror.NotFound("校园资料不存在")
 	}
+	if campusCode := normalizeCampusCode(input.CampusCode); campusCode != "" {
+		if !campusCodeAllowed(campusCode) {
+			return nil, apperror.InvalidArgument("校区暂未开放")
+		}
+		profile.CampusCode = campusCode
+	}
 	profile.SchoolName = firstNonEmpty(input.SchoolName, profile.SchoolName)
 	profile.StudentNo = strings.TrimSpace(input.StudentNo)
 	profile.RealName = strings.TrimSpace(input.RealName)
@@ -2092,17 +2241,29 @@ func (uc *CampusUsecase) ImportTimetable(ctx context.Context, input *ImportCampu
 		return nil, apperror.InvalidArgument("请输入教务系统密码")
 	}
 	term := normalizeCampusTerm(input.Term)
-	courses, err := uc.timetableProvider.Fetch(ctx, studentNo, password, term)
+	result, err := uc.timetableProvider.Fetch(ctx, &CampusTimetableFetchInput{
+		UserID:       input.UserID,
+		StudentNo:    studentNo,
+		Password:     password,
+		Term:         term,
+		CaptchaToken: strings.TrimSpace(input.CaptchaToken),
+		Captcha:      strings.TrimSpace(input.Captcha),
+	})
 	if err != nil {
 		return nil, err
 	}
+	if result.Captcha != nil {
+		return &ImportCampusTimetableOutput{Term: term, Courses: []*CampusTimetableCourse{}, Captcha: result.Captcha}, nil
+	}
+	source := uc.timetableProvider.Name()
+	courses := result.Courses
 	for _, course := range courses {
 		course.ID = uc.idGen.NextID()
 		course.UserID = input.UserID
 		course.Term = term
-		course.Source = "demo"
+		course.Source = source
 	}
-	if err := uc.repo.ReplaceTimetableCourses(ctx, input.UserID, term, "demo", courses); err != nil {
+	if err := uc.repo.ReplaceTimetableCourses(ctx, input.UserID, term, source, courses); err != nil {
 		return nil, apperror.Internal(err, "保存课表失败")
 	}
 	return &ImportCampusTimetableOutput{Term: term, Courses: courses, Count: int32(len(courses))}, nil
@@ -2120,11 +2281,14 @@ func (uc *CampusUsecase) ListTimetable(ctx context.Context, input *ListCampusTim
 	return &ListCampusTimetableOutput{Term: term, Courses: courses}, nil
 }
 
-func (uc *CampusUsecase) ListCategories(ctx context.Context) ([]*CampusForumCategory, error) {
-	categories, err := uc.repo.ListCategories(ctx)
+func (uc *CampusUsecase) ListCategories(ctx context.Context, userID, campusCode string) ([]*CampusForumCategory, error) {
+	categories, err := uc.repo.ListCategories(ctx, uc.resolveCampusCode(ctx, userID, campusCode))
 	if err != nil {
 		return nil, apperror.Internal(err, "获取论坛版块失败")
 	}
+	for _, category := range categories {
+		category.AllowAnonymous = uc.categoryAllowsAnonymous(ctx, category.Code)
+	}
 	return categories, nil
 }
 
@@ -2219,10 +2383,12 @@ func (uc *CampusUsecase) CompleteCampusUpload(ctx context.Context, input *Campus
 	if uc.base != nil {
 		if info, infoErr := uc.base.GetFileInfoById(ctx, fileID); infoErr == nil && info != nil {
 			objectName = info.ObjectName
+			uc.saveUploadImageFingerprint(ctx, fileID, finalURL, info)
 		} else if infoErr != nil {
 			uc.log.WithContext(ctx).Warnf("get uploaded file info failed: file_id=%s err=%v", fileID, infoErr)
 		}
 	}
+	uc.enqueueUploadImageAudit(ctx, fileID, finalURL)
 	return &CampusUploadCompleteOutput{FileID: fileID, URL: finalURL, ObjectName: objectName}, nil
 }
 
@@ -2270,17 +2436,30 @@ func (uc *CampusUsecase) CreatePost(ctx context.Context, input *CreateCampusPost
 	if strings.TrimSpace(input.UserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
+	if err := uc.ensureNotSanctioned(ctx, input.UserID, CampusSanctionTypePostBan, CampusSanctionTypeSuspend); err != nil {
+		return nil, err
+	}
 	input.CategoryCode = strings.TrimSpace(input.CategoryCode)
 	if input.CategoryCode == "" {
 		return nil, apperror.InvalidArgument("请选择版块")
 	}
-	ok, category, err := uc.repo.GetCategoryByCode(ctx, input.CategoryCode)
+	campusCode := firstNonEmpty(uc.userCampusCode(ctx, input.UserID), defaultCampusCode())
+	if requested := normalizeCampusCode(input.CampusCode); requested != "" && requested != campusCode && uc.isCampusAdmin(ctx, input.UserID) {
+		if !campusCodeAllowed(requested) {
+			return nil, apperror.InvalidArgument("校区暂未开放")
+		}
+		campusCode = requested
+	}
+	ok, category, err := uc.repo.GetCategoryByCode(ctx, campusCode, input.CategoryCode)
 	if err != nil {
 		return nil, apperror.Internal(err, "查询版块失败")
 	}
 	if !ok {
 		return nil, apperror.InvalidArgument("版块不存在")
 	}
+	if category.CampusCode != "" && category.CampusCode != campusCode {
+		return nil, apperror.InvalidArgument("版块不属于当前校区")
+	}
 	title := strings.TrimSpace(input.Title)
 	content := strings.TrimSpace(input.Content)
 	if len([]rune(title)) < 2 || len([]rune(title)) > 60 {
@@ -2298,113 +2477,122 @@ func (uc *CampusUsecase) CreatePost(ctx context.Context, input *CreateCampusPost
 		images = []string{}
 	}
 	postType := normalizeCampusPostType(input.PostType)
+	var poll *CampusPoll
+	if postType == CampusPostTypePoll {
+		if poll, err = newCampusPoll(input.Poll, campusLocalNow()); err != nil {
+			return nil, err
+		}
+	}
 	extra := sanitizeCampusPostExtra(input.Extra)
+	var lostItem *CampusLostItem
+	if postType == CampusPostTypeLost {
+		if lostItem, err = newCampusLostItem(input.LostItem, extra, campusLocalNow()); err != nil {
+			return nil, err
+		}
+	}
+	var clubEvent *CampusClubEvent
+	if postType == CampusPostTypeClub {
+		if clubEvent, err = newCampusClubEvent(input.ClubEvent, extra, campusLocalNow()); err != nil {
+			return nil, err
+		}
+		if clubEvent != nil {
+			if extra["activity_time"] == "" {
+				extra["activity_time"] = clubEvent.StartsAt.Format("2006-01-02 15:04")
+			}
+			if extra["activity_place"] == "" && clubEvent.Place != "" {
+				extra["activity_place"] = clubEvent.Place
+			}
+		}
+	}
 	isOperator := uc.isCampusOperator(ctx, input.UserID)
 	isOfficial := input.IsOfficial && isOperator
 	isFeatured := input.IsFeatured && isOperator
 	isPinned := input.IsPinned && isOperator
+	crossCampus := input.CrossCampus && isOperator
 	sortWeight := int32(0)
 	if isOperator {
 		sortWeight = clampSortWeight(input.SortWeight)
 	}
+	if input.Anonymous {
+		if isOfficial {
+			return nil, apperror.InvalidArgument("官方帖子不能匿名")
+		}
+		if !uc.categoryAllowsAnonymous(ctx, category.Code) {
+			return nil, apperror.InvalidArgument("该版块不支持匿名发帖")
+		}
+	}
+	schedule, err := newCampusPostSchedule(input, isOperator)
+	if err != nil {
+		return nil, err
+	}
+	if poll != nil && schedule != nil && schedule.PublishAt != nil && !poll.ClosesAt.After(*schedule.PublishAt) {
+		return nil, apperror.InvalidArgument("投票截止时间需要晚于定时发布时间")
+	}
+	if clubEvent != nil && schedule != nil && schedule.PublishAt != nil && !clubEvent.SignupDeadline.After(*schedule.PublishAt) {
+		return nil, apperror.InvalidArgument("报名截止时间需要晚于定时发布时间")
+	}
 	status := CampusAuditStatusVisible
 	auditReason := ""
-	var auditSettings *CampusOpsAuditSettings
-	var ruleResult campusContentRuleResult
-	var enqueueAIAudit bool
-	var directAuditAlertReason string
-	var directAuditAlertRisk string
-	var directAuditAlertEvidence []string
+	var auditPlan *campusPostAuditPlan
 	if !isOperator {
-		settings, err := uc.getCampusAuditSettings(ctx)
+		plan, err := uc.planCampusPostAudit(ctx, input.UserID, title, content)
 		if err != nil {
 			return nil, err
 		}
-		auditSettings = settings
-		switch settings.PostAuditMode {
-		case CampusPostAuditModeManual:
-			status = CampusAuditStatusPending
-			auditReason = "等待人工审核"
-		case CampusPostAuditModeAI:
-			ruleResult = uc.classifyCampusPostByRules(ctx, title, content)
-			directAuditAlertRisk = ruleResult.RiskLevel
-			directAuditAlertEvidence = ruleResult.Evidence
-			if !uc.agentAuditEnabled(ctx) || !campusAgentModelConfigured() {
-				if ruleResult.RiskLevel == "low" {
-					status = CampusAuditStatusVisible
-					auditReason = ""
-				} else {
-					status = CampusAuditStatusPending
-					auditReason = "同步中"
-					if !uc.agentAuditEnabled(ctx) {
-						directAuditAlertReason = "Agent 初审未启用，等待人工复核"
-					} else {
-						directAuditAlertReason = "Agent 模型未配置，等待人工复核"
-					}
-				}
-			} else if allowed, skippedReason := uc.aiBudgetAllowsModel(ctx, "content_audit", "post", "pending"); !allowed {
-				if ruleResult.RiskLevel == "low" {
-					status = CampusAuditStatusVisible
-					auditReason = ""
-				} else {
-					status = CampusAuditStatusPending
-					auditReason = "同步中"
-					directAuditAlertReason = skippedReason
-				}
-			} else {
-				status = CampusAuditStatusPending
-				auditReason = "同步中"
-				enqueueAIAudit = true
-			}
-		}
+		auditPlan = plan
+		status, auditReason = plan.Status, plan.AuditReason
+	}
+	tags, err := uc.resolveCampusPostTags(ctx, content, input.Tags)
+	if err != nil {
+		return nil, err
 	}
 	post := &CampusForumPost{
-		ID:           uc.idGen.NextID(),
-		CategoryCode: category.Code,
-		CategoryName: category.Name,
-		AuthorID:     input.UserID,
-		Title:        title,
-		Content:      content,
-		Images:       images,
-		MediaType:    mediaType,
-		PostType:     postType,
-		Extra:        extra,
-		CoverURL:     coverURL,
-		IsOfficial:   isOfficial,
-		IsFeatured:   isFeatured,
-		IsPinned:     isPinned,
-		SortWeight:   sortWeight,
-		Status:       status,
-		AuditReason:  auditReason,
+		ID:            uc.idGen.NextID(),
+		CampusCode:    campusCode,
+		IsCrossCampus: crossCampus,
+		CategoryCode:  category.Code,
+		CategoryName:  category.Name,
+		AuthorID:      input.UserID,
+		Title:         title,
+		Content:       content,
+		Images:        images,
+		MediaType:     mediaType,
+		PostType:      postType,
+		Extra:         extra,
+		CoverURL:      coverURL,
+		IsOfficial:    isOfficial,
+		IsFeatured:    isFeatured,
+		IsPinned:      isPinned,
+		IsAnonymous:   input.Anonymous,
+		SortWeight:    sortWeight,
+		Status:        status,
+		AuditReason:   auditReason,
+		Poll:          poll,
+		LostItem:      lostItem,
+		Tags:          tags,
+	}
+	if postType == CampusPostTypeQuestion {
+		post.Question = &CampusQuestion{PostID: post.ID, CampusCode: campusCode, AuthorID: input.UserID}
+	}
+	if poll != nil {
+		poll.PostID = post.ID
+	}
+	if lostItem != nil {
+		lostItem.PostID, lostItem.CampusCode, lostItem.AuthorID = post.ID, campusCode, input.UserID
+	}
+	if clubEvent != nil {
+		clubEvent.PostID, clubEvent.CampusCode, clubEvent.OrganizerID = post.ID, campusCode, input.UserID
+		post.ClubEvent = clubEvent
+	}
+	if schedule != nil {
+		return uc.createScheduledPost(ctx, post, schedule)
 	}
 	if err := uc.repo.CreatePost(ctx, post); err != nil {
 		return nil, apperror.Internal(err, "发布帖子失败")
 	}
-	if !isOperator && status == CampusAuditStatusPending {
-		if auditSettings != nil && auditSettings.PostAuditMode == CampusPostAuditModeAI && enqueueAIAudit {
-			if err := uc.enqueuePostAIContentAudit(ctx, post); err != nil {
-				uc.log.WithContext(ctx).Warnf("queue campus post ai audit failed: post_id=%d err=%v", post.ID, err)
-				reason := firstNonEmpty(ruleResult.Reason, "AI 审核任务入队失败，等待人工复核")
-				riskLevel := firstNonEmpty(directAuditAlertRisk, ruleResult.RiskLevel, "medium")
-				evidence := directAuditAlertEvidence
-				if len(evidence) == 0 {
-					evidence = []string{"ai_audit_queue_failed"}
-				}
-				if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, riskLevel, reason, evidence); err != nil {
-					uc.log.WithContext(ctx).Warnf("queue campus ai audit fallback alert failed: post_id=%d err=%v", post.ID, err)
-				}
-			}
-		} else {
-			reason := firstNonEmpty(directAuditAlertReason, ruleResult.Reason, auditReason, "新帖需要人工确认")
-			riskLevel := firstNonEmpty(directAuditAlertRisk, "medium")
-			evidence := directAuditAlertEvidence
-			if len(evidence) == 0 {
-				evidence = []string{"manual_review"}
-			}
-			if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, riskLevel, reason, evidence); err != nil {
-				uc.log.WithContext(ctx).Warnf("queue campus manual audit alert failed: post_id=%d err=%v", post.ID, err)
-			}
-		}
+	uc.dispatchCampusPostAudit(ctx, post, auditPlan)
+	if !isOperator {
+		uc.enqueuePostImageAudit(ctx, post)
 	}
 	uc.trackEvent(ctx, &TrackCampusEventInput{
 		UserID:     input.UserID,
@@ -2418,22 +2606,67 @@ func (uc *CampusUsecase) CreatePost(ctx context.Context, input *CreateCampusPost
 }
 
 func (uc *CampusUsecase) ListPosts(ctx context.Context, input *ListCampusPostsInput) (*ListCampusPostsOutput, error) {
-	page, size := normalizePage(input.Page, input.Size)
 	query := ListCampusPostQuery{
+		CampusCode:   uc.resolveCampusCode(ctx, input.CurrentUserID, input.CampusCode),
 		CategoryCode: strings.TrimSpace(input.CategoryCode),
 		PostType:     strings.TrimSpace(input.PostType),
 		Sort:         normalizeCampusPostSort(input.Sort, CampusPostSortRecommend),
 		Keyword:      strings.TrimSpace(input.Keyword),
 		Statuses:     []int32{CampusAuditStatusVisible},
-		Offset:       int((page - 1) * size),
-		Limit:        int(size),
 	}
-	posts, total, usedPool, err := uc.listPostsFromPool(ctx, query)
+	if strings.EqualFold(strings.TrimSpace(input.Sort), CampusPostSortFollowing) {
+		if strings.TrimSpace(input.CurrentUserID) == "" {
+			return nil, apperror.Unauthorized("请先登录")
+		}
+		authorIDs, err := uc.followingAuthorIDs(ctx, input.CurrentUserID)
+		if err != nil {
+			return nil, err
+		}
+		if len(authorIDs) == 0 {
+			return &ListCampusPostsOutput{Posts: []*CampusForumPost{}}, nil
+		}
+		query.Sort, query.AuthorIDs, query.ExcludeAnonymous = CampusPostSortFollowing, authorIDs, true
+	}
+	if tagName := strings.TrimSpace(input.Tag); tagName != "" {
+		tag, err := uc.GetTag(ctx, tagName)
+		if err != nil {
+			return nil, err
+		}
+		query.TagID = tag.ID
+	}
+	hidden := uc.hiddenAuthorIDs(ctx, input.CurrentUserID)
+	query.ExcludeAuthorIDs = hidden
+	scopeParts := []string{"posts", query.CampusCode, query.CategoryCode, query.PostType, query.Sort, query.Keyword, input.CurrentUserID}
+	if query.TagID > 0 {
+		scopeParts = append(scopeParts, "tag", strconv.FormatInt(query.TagID, 10))
+	}
+	scope := campusCursorScope(scopeParts...)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	if query.Keyword != "" && len(query.AuthorIDs) == 0 && query.TagID == 0 {
+		if posts, total, ok := uc.searchPostsByKeyword(ctx, query, input.CurrentUserID); ok {
+			nextCursor := uc.postsNextCursor(scope, query, posts, total)
+			return &ListCampusPostsOutput{Posts: filterHiddenAuthorPosts(posts, hidden), Total: total, NextCursor: nextCursor}, nil
+		}
+	}
+	posts, total, next, usedPool, err := uc.listPostsFromPool(ctx, query, input.CurrentUserID, cursor)
+	posts = filterHiddenAuthorPosts(posts, hidden)
 	if err != nil {
 		uc.log.WithContext(ctx).Warnf("list campus posts from pool failed: %v", err)
 	}
-	if !usedPool || err != nil {
+	nextCursor := ""
+	if usedPool && err == nil {
+		if next != nil {
+			next.Scope = scope
+			nextCursor = uc.encodeListCursor(next)
+		}
+	} else {
 		posts, total, err = uc.repo.ListPosts(ctx, query)
+		nextCursor = uc.postsNextCursor(scope, query, posts, total)
 	}
 	if err != nil {
 		return nil, apperror.Internal(err, "获取帖子列表失败")
@@ -2441,39 +2674,43 @@ func (uc *CampusUsecase) ListPosts(ctx context.Context, input *ListCampusPostsIn
 	if err := uc.assembler.HydratePosts(ctx, posts, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate campus posts failed: %v", err)
 	}
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: nextCursor}, nil
 }
 
 func (uc *CampusUsecase) ListMyPosts(ctx context.Context, input *ListCampusPostsInput) (*ListCampusPostsOutput, error) {
 	if strings.TrimSpace(input.CurrentUserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
-	page, size := normalizePage(input.Page, input.Size)
-	posts, total, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
+	query := ListCampusPostQuery{
 		CategoryCode:   strings.TrimSpace(input.CategoryCode),
 		PostType:       strings.TrimSpace(input.PostType),
 		Sort:           normalizeCampusPostSort(input.Sort, CampusPostSortNew),
 		Keyword:        strings.TrimSpace(input.Keyword),
 		AuthorID:       input.CurrentUserID,
 		IncludeDeleted: false,
-		Offset:         int((page - 1) * size),
-		Limit:          int(size),
-	})
+	}
+	scope := campusCursorScope("my_posts", input.CurrentUserID, query.CategoryCode, query.PostType, query.Sort, query.Keyword)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	posts, total, err := uc.repo.ListPosts(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取我的帖子失败")
 	}
 	if err := uc.assembler.HydratePosts(ctx, posts, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate my campus posts failed: %v", err)
 	}
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: uc.postsNextCursor(scope, query, posts, total)}, nil
 }
 
 func (uc *CampusUsecase) ListMyCollections(ctx context.Context, input *ListCampusPostsInput) (*ListCampusPostsOutput, error) {
 	if strings.TrimSpace(input.CurrentUserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
-	page, size := normalizePage(input.Page, input.Size)
-	posts, total, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
+	query := ListCampusPostQuery{
 		CategoryCode:      strings.TrimSpace(input.CategoryCode),
 		PostType:          strings.TrimSpace(input.PostType),
 		Sort:              normalizeCampusPostSort(input.Sort, CampusPostSortNew),
@@ -2481,19 +2718,25 @@ func (uc *CampusUsecase) ListMyCollections(ctx context.Context, input *ListCampu
 		CollectedByUserID: input.CurrentUserID,
 		Statuses:          []int32{CampusAuditStatusVisible},
 		IncludeDeleted:    false,
-		Offset:            int((page - 1) * size),
-		Limit:             int(size),
-	})
+	}
+	scope := campusCursorScope("my_collections", input.CurrentUserID, query.CategoryCode, query.PostType, query.Sort, query.Keyword)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	posts, total, err := uc.repo.ListPosts(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取我的收藏失败")
 	}
 	if err := uc.assembler.HydratePosts(ctx, posts, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate collected campus posts failed: %v", err)
 	}
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: uc.postsNextCursor(scope, query, posts, total)}, nil
 }
 
-func (uc *CampusUsecase) GetPublicCampusUserProfile(ctx context.Context, userID string) (*CampusPublicUserProfile, error) {
+func (uc *CampusUsecase) GetPublicCampusUserProfile(ctx context.Context, userID, currentUserID string) (*CampusPublicUserProfile, error) {
 	userID = strings.TrimSpace(userID)
 	if userID == "" || userID == "0" {
 		return nil, apperror.InvalidArgument("用户 ID 无效")
@@ -2519,6 +2762,16 @@ func (uc *CampusUsecase) GetPublicCampusUserProfile(ctx context.Context, userID
 		Avatar:     user.Avatar,
 		IsOfficial: stats.HasOfficialPost || uc.isCampusOperator(ctx, userID),
 		Stats:      stats,
+
+		FollowCount:   user.FollowCount,
+		FollowerCount: user.FollowerCount,
+	}
+	if currentUserID = strings.TrimSpace(currentUserID); currentUserID != "" && currentUserID != userID {
+		if following, err := uc.core.CheckFollowing(ctx, currentUserID, []string{userID}); err == nil {
+			profile.IsFollowing = following[userID]
+		} else {
+			uc.log.WithContext(ctx).Warnf("check campus following failed: user_id=%s err=%v", currentUserID, err)
+		}
 	}
 	if ok, campusProfile, err := uc.repo.GetProfileByUserID(ctx, userID); err == nil && ok {
 		profile.SchoolName = campusProfile.SchoolName
@@ -2541,27 +2794,33 @@ func (uc *CampusUsecase) ListPublicUserPosts(ctx context.Context, input *ListCam
 	if authorID == "" || authorID == "0" {
 		return nil, apperror.InvalidArgument("用户 ID 无效")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	sort := normalizeCampusPostSort(input.Sort, CampusPostSortNew)
 	if sort == CampusPostSortRecommend {
 		sort = CampusPostSortNew
 	}
-	posts, total, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
-		PostType:       strings.TrimSpace(input.PostType),
-		Sort:           sort,
-		AuthorID:       authorID,
-		Statuses:       []int32{CampusAuditStatusVisible},
-		IncludeDeleted: false,
-		Offset:         int((page - 1) * size),
-		Limit:          int(size),
-	})
+	query := ListCampusPostQuery{
+		PostType:         strings.TrimSpace(input.PostType),
+		Sort:             sort,
+		AuthorID:         authorID,
+		Statuses:         []int32{CampusAuditStatusVisible},
+		IncludeDeleted:   false,
+		ExcludeAnonymous: true,
+	}
+	scope := campusCursorScope("user_posts", authorID, query.PostType, query.Sort)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	posts, total, err := uc.repo.ListPosts(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取用户帖子失败")
 	}
 	if err := uc.assembler.HydratePosts(ctx, posts, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate public user posts failed: %v", err)
 	}
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: uc.postsNextCursor(scope, query, posts, total)}, nil
 }
 
 func (uc *CampusUsecase) GetPost(ctx context.Context, input *GetCampusPostInput) (*CampusForumPost, error) {
@@ -2621,6 +2880,9 @@ func (uc *CampusUsecase) CreateComment(ctx context.Context, input *CreateCampusC
 	if strings.TrimSpace(input.UserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
+	if err := uc.ensureNotSanctioned(ctx, input.UserID, CampusSanctionTypeMute, CampusSanctionTypeSuspend); err != nil {
+		return nil, err
+	}
 	if input.PostID <= 0 {
 		return nil, apperror.InvalidArgument("帖子 ID 无效")
 	}
@@ -2631,6 +2893,17 @@ func (uc *CampusUsecase) CreateComment(ctx context.Context, input *CreateCampusC
 	if !ok {
 		return nil, apperror.NotFound("帖子不存在")
 	}
+	if post.PostType == CampusPostTypeLost {
+		if items, err := uc.repo.ListLostItemsByPostIDs(ctx, []int64{post.ID}); err == nil {
+			post.LostItem = items[post.ID]
+		}
+		if campusLostItemClosed(post) {
+			return nil, apperror.InvalidArgument("物品已归还，评论已关闭")
+		}
+	}
+	if err := uc.ensureNotBlockedBy(ctx, post.AuthorID, input.UserID); err != nil {
+		return nil, err
+	}
 	parentID := int64(0)
 	replyToCommentID := int64(0)
 	replyToUserID := ""
@@ -2646,6 +2919,9 @@ func (uc *CampusUsecase) CreateComment(ctx context.Context, input *CreateCampusC
 		if !ok || target.PostID != input.PostID || target.Status != CampusAuditStatusVisible {
 			return nil, apperror.NotFound("被回复评论不存在")
 		}
+		if err := uc.ensureNotBlockedBy(ctx, target.AuthorID, input.UserID); err != nil {
+			return nil, err
+		}
 		if target.ParentID > 0 {
 			parentID = target.ParentID
 			replyToCommentID = target.ID
@@ -2660,6 +2936,9 @@ func (uc *CampusUsecase) CreateComment(ctx context.Context, input *CreateCampusC
 	if len([]rune(content)) < 1 || len([]rune(content)) > 500 {
 		return nil, apperror.InvalidArgument("评论需要 1-500 个字")
 	}
+	if err := uc.evaluateCampusCommentRules(ctx, input.UserID, content); err != nil {
+		return nil, err
+	}
 	comment := &CampusForumComment{
 		ID:               uc.idGen.NextID(),
 		PostID:           input.PostID,
@@ -2699,10 +2978,15 @@ func (uc *CampusUsecase) CreateComment(ctx context.Context, input *CreateCampusC
 			LinkParams:  map[string]string{"id": fmt.Sprintf("%d", input.PostID)},
 		}
 	}
-	if err := uc.repo.CreateCommentWithOutbox(ctx, comment, uc.buildNotificationOutbox(notification, false)); err != nil {
+	outbox := uc.buildNotificationOutbox(notification, false)
+	anonymizeOutboxActor(outbox, post)
+	if err := uc.repo.CreateCommentWithOutbox(ctx, comment, outbox); err != nil {
 		return nil, apperror.Internal(err, "发表评论失败")
 	}
 	uc.handleEzaiMention(ctx, comment)
+	if comment.Status == CampusAuditStatusVisible {
+		uc.markQuestionAnswered(ctx, post, comment.AuthorID)
+	}
 	uc.trackEvent(ctx, &TrackCampusEventInput{
 		UserID:     input.UserID,
 		EventType:  "comment_create",
@@ -2745,6 +3029,7 @@ func (uc *CampusUsecase) handleEzaiMention(ctx context.Context, comment *CampusF
 		TriggerCommentID: comment.ID,
 		AskerID:          comment.AuthorID,
 		BotUserID:        uc.aiReplyConfig.BotUserID,
+		Source:           CampusAIReplySourceMention,
 		Prompt:           trimLimit(prompt, 500),
 		Status:           CampusAIReplyTaskStatusPending,
 	}
@@ -2810,7 +3095,7 @@ func stripEzaiMention(content string, aliases ...string) string {
 
 func (uc *CampusUsecase) notifyEzaiHandoff(ctx context.Context, postID, triggerCommentID int64, actorID, content, reason string) {
 	botUserID := strings.TrimSpace(uc.aiReplyConfig.BotUserID)
-	if botUserID == "" || strings.TrimSpace(actorID) == "" || actorID == botUserID || postID <= 0 || triggerCommentID <= 0 {
+	if botUserID == "" || strings.TrimSpace(actorID) == "" || actorID == botUserID || postID <= 0 {
 		return
 	}
 	title := "有人 @e仔 需要回复"
@@ -2818,21 +3103,29 @@ func (uc *CampusUsecase) notifyEzaiHandoff(ctx context.Context, postID, triggerC
 	if strings.TrimSpace(content) != "" {
 		detail = trimLimit(content, 100)
 	}
+	dedupeKey := fmt.Sprintf("campus:ezai-handoff:%d", triggerCommentID)
+	linkParams := map[string]string{
+		"id":      fmt.Sprintf("%d", postID),
+		"comment": fmt.Sprintf("%d", triggerCommentID),
+		"reason":  trimLimit(reason, 60),
+	}
+	// 问题自动回答没有触发评论，按帖子去重，链接直接打开帖子
+	if triggerCommentID <= 0 {
+		title = "有问题长时间无人回答"
+		dedupeKey = fmt.Sprintf("campus:ezai-handoff:question:%d", postID)
+		delete(linkParams, "comment")
+	}
 	notification := uc.buildNotificationOutbox(&CampusNotification{
 		RecipientID: botUserID,
 		ActorID:     actorID,
 		EventType:   CampusNotificationTypeMention,
 		TargetType:  "post",
 		TargetID:    postID,
-		DedupeKey:   fmt.Sprintf("campus:ezai-handoff:%d", triggerCommentID),
+		DedupeKey:   dedupeKey,
 		Title:       title,
 		Content:     detail,
 		LinkPage:    "post-detail",
-		LinkParams: map[string]string{
-			"id":      fmt.Sprintf("%d", postID),
-			"comment": fmt.Sprintf("%d", triggerCommentID),
-			"reason":  trimLimit(reason, 60),
-		},
+		LinkParams:  linkParams,
 	}, true)
 	if err := uc.repo.CreateNotificationOutbox(ctx, notification); err != nil {
 		uc.log.WithContext(ctx).Warnf("queue ezai handoff notification failed: post_id=%d comment_id=%d reason=%s err=%v", postID, triggerCommentID, reason, err)
@@ -2843,25 +3136,41 @@ func (uc *CampusUsecase) ListComments(ctx context.Context, input *ListCampusComm
 	if input.PostID <= 0 {
 		return nil, apperror.InvalidArgument("帖子 ID 无效")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	rootParentID := int64(0)
-	comments, total, err := uc.repo.ListComments(ctx, ListCampusCommentQuery{
-		PostID:   input.PostID,
-		ParentID: &rootParentID,
-		Statuses: []int32{CampusAuditStatusVisible},
-		Offset:   int((page - 1) * size),
-		Limit:    int(size),
-	})
+	query := ListCampusCommentQuery{
+		PostID:           input.PostID,
+		ParentID:         &rootParentID,
+		Statuses:         []int32{CampusAuditStatusVisible},
+		ExcludeAuthorIDs: uc.hiddenAuthorIDs(ctx, input.CurrentUserID),
+	}
+	scope := campusCursorScope("comments", strconv.FormatInt(input.PostID, 10))
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.After, query.Offset, query.Limit = cursor, offset, limit
+	var accepted *CampusForumComment
+	if ok, post, err := uc.repo.GetPostByID(ctx, input.PostID); err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus post for comments failed: post_id=%d err=%v", input.PostID, err)
+	} else if ok {
+		accepted = uc.surfaceAcceptedAnswer(ctx, post, &query)
+	}
+	comments, total, err := uc.repo.ListComments(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取评论失败")
 	}
+	nextCursor := uc.commentsNextCursor(scope, limit, comments)
+	if accepted != nil {
+		comments = append([]*CampusForumComment{accepted}, comments...)
+		total++
+	}
 	if err := uc.assembler.HydrateComments(ctx, comments, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate campus comments failed: %v", err)
 	}
-	if err := uc.assembler.FillPreviewReplies(ctx, comments, input.CurrentUserID); err != nil {
+	if err := uc.assembler.FillPreviewReplies(ctx, comments, input.CurrentUserID, query.ExcludeAuthorIDs); err != nil {
 		uc.log.WithContext(ctx).Warnf("fill campus comment replies failed: %v", err)
 	}
-	return &ListCampusCommentsOutput{Comments: comments, Total: total}, nil
+	return &ListCampusCommentsOutput{Comments: comments, Total: total, NextCursor: nextCursor}, nil
 }
 
 func (uc *CampusUsecase) ListCommentReplies(ctx context.Context, input *ListCampusCommentsInput) (*ListCampusCommentsOutput, error) {
@@ -2879,34 +3188,43 @@ func (uc *CampusUsecase) ListCommentReplies(ctx context.Context, input *ListCamp
 	if comment.ParentID > 0 {
 		rootID = comment.ParentID
 	}
-	page, size := normalizePage(input.Page, input.Size)
-	replies, total, err := uc.repo.ListComments(ctx, ListCampusCommentQuery{
-		PostID:   comment.PostID,
-		ParentID: &rootID,
-		Statuses: []int32{CampusAuditStatusVisible},
-		Offset:   int((page - 1) * size),
-		Limit:    int(size),
-	})
+	query := ListCampusCommentQuery{
+		PostID:           comment.PostID,
+		ParentID:         &rootID,
+		Statuses:         []int32{CampusAuditStatusVisible},
+		ExcludeAuthorIDs: uc.hiddenAuthorIDs(ctx, input.CurrentUserID),
+	}
+	scope := campusCursorScope("replies", strconv.FormatInt(rootID, 10))
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.After, query.Offset, query.Limit = cursor, offset, limit
+	replies, total, err := uc.repo.ListComments(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取回复失败")
 	}
 	if err := uc.assembler.HydrateComments(ctx, replies, input.CurrentUserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate campus replies failed: %v", err)
 	}
-	return &ListCampusCommentsOutput{Comments: replies, Total: total}, nil
+	return &ListCampusCommentsOutput{Comments: replies, Total: total, NextCursor: uc.commentsNextCursor(scope, limit, replies)}, nil
 }
 
 func (uc *CampusUsecase) ListMyComments(ctx context.Context, input *ListCampusCommentsInput) (*ListCampusCommentsOutput, error) {
 	if strings.TrimSpace(input.UserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
-	page, size := normalizePage(input.Page, input.Size)
-	comments, total, err := uc.repo.ListComments(ctx, ListCampusCommentQuery{
+	query := ListCampusCommentQuery{
 		AuthorID: input.UserID,
 		Statuses: []int32{CampusAuditStatusVisible},
-		Offset:   int((page - 1) * size),
-		Limit:    int(size),
-	})
+	}
+	scope := campusCursorScope("my_comments", input.UserID)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.After, query.Offset, query.Limit = cursor, offset, limit
+	comments, total, err := uc.repo.ListComments(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取我的评论失败")
 	}
@@ -2916,7 +3234,7 @@ func (uc *CampusUsecase) ListMyComments(ctx context.Context, input *ListCampusCo
 	if err := uc.repo.FillCommentPosts(ctx, comments); err != nil {
 		uc.log.WithContext(ctx).Warnf("fill my comment posts failed: %v", err)
 	}
-	return &ListCampusCommentsOutput{Comments: comments, Total: total}, nil
+	return &ListCampusCommentsOutput{Comments: comments, Total: total, NextCursor: uc.commentsNextCursor(scope, limit, comments)}, nil
 }
 
 func (uc *CampusUsecase) DeleteComment(ctx context.Context, userID string, commentID int64) error {
@@ -2956,6 +3274,9 @@ func (uc *CampusUsecase) LikeComment(ctx context.Context, userID string, comment
 	if !ok || comment.Status != CampusAuditStatusVisible {
 		return apperror.NotFound("评论不存在")
 	}
+	if err := uc.ensureNotBlockedBy(ctx, comment.AuthorID, userID); err != nil {
+		return err
+	}
 	notification := uc.buildNotificationOutbox(&CampusNotification{
 		RecipientID: comment.AuthorID,
 		ActorID:     userID,
@@ -2967,6 +3288,7 @@ func (uc *CampusUsecase) LikeComment(ctx context.Context, userID string, comment
 		LinkPage:    "post-detail",
 		LinkParams:  map[string]string{"id": fmt.Sprintf("%d", comment.PostID)},
 	}, true)
+	uc.anonymizeCommentOutboxActor(ctx, notification, comment.PostID)
 	if err := uc.repo.AddCommentLikeWithOutbox(ctx, uc.idGen.NextID(), userID, commentID, notification); err != nil {
 		return apperror.Internal(err, "评论点赞失败")
 	}
@@ -3007,6 +3329,9 @@ func (uc *CampusUsecase) LikePost(ctx context.Context, userID string, postID int
 	if !ok {
 		return apperror.NotFound("帖子不存在")
 	}
+	if err := uc.ensureNotBlockedBy(ctx, post.AuthorID, userID); err != nil {
+		return err
+	}
 	var notification *CampusNotificationOutbox
 	if post != nil {
 		notification = uc.buildNotificationOutbox(&CampusNotification{
@@ -3105,13 +3430,22 @@ func (uc *CampusUsecase) ListNotifications(ctx context.Context, input *ListCampu
 	if strings.TrimSpace(input.UserID) == "" {
 		return nil, apperror.Unauthorized("请先登录")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	group := normalizeCampusNotificationGroup(input.Type)
-	notifications, total, err := uc.repo.ListNotifications(ctx, input.UserID, group, int((page-1)*size), int(size))
+	scope := campusCursorScope("notifications", input.UserID, group)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	notifications, total, err := uc.repo.ListNotifications(ctx, input.UserID, group, cursor, offset, limit)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取消息失败")
 	}
-	return &ListCampusNotificationsOutput{Notifications: notifications, Total: total}, nil
+	nextCursor := ""
+	if len(notifications) > 0 {
+		last := notifications[len(notifications)-1]
+		nextCursor = uc.keysetNextCursor(scope, len(notifications), limit, last.CreatedAt, last.ID)
+	}
+	return &ListCampusNotificationsOutput{Notifications: notifications, Total: total, NextCursor: nextCursor}, nil
 }
 
 func (uc *CampusUsecase) CountUnreadNotifications(ctx context.Context, userID string) (*CampusUnreadNotificationCount, error) {
@@ -3304,6 +3638,15 @@ func (uc *CampusUsecase) deliverInteractionNotificationOutbox(ctx context.Contex
 	if strings.TrimSpace(item.RecipientID) == "" || item.RecipientID == "0" || item.RecipientID == item.ActorID {
 		return nil
 	}
+	if actorID := strings.TrimSpace(item.ActorID); actorID != "" && actorID != "0" {
+		kind, err := uc.repo.GetUserBlockKind(ctx, item.RecipientID, actorID)
+		if err != nil {
+			return err
+		}
+		if kind != "" {
+			return nil
+		}
+	}
 	dedupeKey := strings.TrimSpace(item.DedupeKey)
 	if dedupeKey == "" {
 		dedupeKey = fmt.Sprintf("campus:notification-outbox:%d", item.ID)
@@ -3421,30 +3764,6 @@ type campusContentRuleResult struct {
 	Evidence  []string
 }
 
-func (uc *CampusUsecase) classifyCampusPostByRules(ctx context.Context, title, content string) campusContentRuleResult {
-	return classifyCampusPostByWords(title, content, uc.auditHighRiskWords(ctx), uc.auditReviewWords(ctx))
-}
-
-func classifyCampusPostByWords(title, content string, highWords, mediumWords []string) campusContentRuleResult {
-	text := strings.ToLower(title + "\n" + content)
-	for _, word := range highWords {
-		word = strings.TrimSpace(word)
-		if word != "" && strings.Contains(text, strings.ToLower(word)) {
-			return campusContentRuleResult{RiskLevel: "high", Decision: CampusAIContentAuditDecisionReview, Reason: "疑似包含高风险词：" + word, Evidence: []string{"keyword:" + word}}
-		}
-	}
-	for _, word := range mediumWords {
-		word = strings.TrimSpace(word)
-		if word != "" && strings.Contains(text, strings.ToLower(word)) {
-			return campusContentRuleResult{RiskLevel: "medium", Decision: CampusAIContentAuditDecisionReview, Reason: "疑似需要人工确认：" + word, Evidence: []string{"keyword:" + word}}
-		}
-	}
-	if len([]rune(strings.TrimSpace(title+content))) < 8 {
-		return campusContentRuleResult{RiskLevel: "medium", Decision: CampusAIContentAuditDecisionReview, Reason: "内容过短，语义不够明确", Evidence: []string{"too_short"}}
-	}
-	return campusContentRuleResult{RiskLevel: "low", Decision: CampusAIContentAuditDecisionPass, Reason: "规则未发现明显风险", Evidence: []string{"rule_low_risk"}}
-}
-
 func (uc *CampusUsecase) ProcessPendingAIContentAuditTasks(ctx context.Context, limit int) error {
 	if limit <= 0 {
 		limit = 10
@@ -3470,6 +3789,12 @@ func (uc *CampusUsecase) ProcessPendingAIContentAuditTasks(ctx context.Context,
 }
 
 func (uc *CampusUsecase) passPostByRuleFallback(ctx context.Context, task *CampusAIContentAuditTask, post *CampusForumPost, ruleResult campusContentRuleResult, skippedReason string) error {
+	if holdReason := uc.postImageAuditHoldReason(ctx, post); holdReason != "" {
+		if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusPending, holdReason); err != nil {
+			return err
+		}
+		return uc.repo.MarkAIContentAuditTaskDone(ctx, task.ID, CampusAIContentAuditDecisionReview, "medium", holdReason, "")
+	}
 	if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusVisible, ""); err != nil {
 		return err
 	}
@@ -3514,7 +3839,7 @@ func (uc *CampusUsecase) processAIContentAuditTask(ctx context.Context, task *Ca
 	if post.Status != CampusAuditStatusPending {
 		return uc.repo.MarkAIContentAuditTaskDone(ctx, task.ID, CampusAIContentAuditDecisionReview, "none", "内容已不处于待审核状态", "")
 	}
-	ruleResult := uc.classifyCampusPostByRules(ctx, post.Title, post.Content)
+	ruleResult, _ := classifyCampusPostByRuleSet(uc.auditRuleSet(ctx), post.Title, post.Content)
 	if !uc.agentAuditEnabled(ctx) || !campusAgentModelConfigured() {
 		reason := "Agent 初审不可用，等待人工处理"
 		if !campusAgentModelConfigured() {
@@ -3558,7 +3883,7 @@ func (uc *CampusUsecase) processAIContentAuditTask(ctx context.Context, task *Ca
 		_ = uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, ruleResult.RiskLevel, reason, append(ruleResult.Evidence, reason))
 		return uc.repo.MarkAIContentAuditTaskDone(ctx, task.ID, CampusAIContentAuditDecisionReview, ruleResult.RiskLevel, reason, string(rawResult))
 	}
-	result, raw, err := uc.auditPostWithAI(ctx, post)
+	result, raw, err := uc.auditPostWithAI(ctx, post, ruleResult)
 	if err != nil {
 		if ruleResult.RiskLevel == "low" {
 			return uc.passPostByRuleFallback(ctx, task, post, ruleResult, "agent_error:"+trimLimit(err.Error(), 120))
@@ -3603,7 +3928,17 @@ func (uc *CampusUsecase) processAIContentAuditTask(ctx context.Context, task *Ca
 		uc.recordAIUsage(ctx, "content_audit", "post", fmt.Sprintf("%d", post.ID), usageStatus, usageError, result.ModelUsage)
 	}
 	autoPass := ruleRiskLevel != "high" && decision == CampusAIContentAuditDecisionPass && riskLevel == "low" && confidence >= uc.agentAuditAutoPassConfidence(ctx)
+	imageHoldReason := ""
+	if autoPass {
+		imageHoldReason = uc.postImageAuditHoldReason(ctx, post)
+	}
 	switch {
+	case autoPass && imageHoldReason != "":
+		decision = CampusAIContentAuditDecisionReview
+		reason = imageHoldReason
+		if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusPending, reason); err != nil {
+			return err
+		}
 	case autoPass:
 		if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusVisible, ""); err != nil {
 			return err
@@ -3653,7 +3988,7 @@ type aiContentAuditResult struct {
 	ModelSkippedReason string              `json:"model_skipped_reason"`
 }
 
-func (uc *CampusUsecase) auditPostWithAI(ctx context.Context, post *CampusForumPost) (*aiContentAuditResult, string, error) {
+func (uc *CampusUsecase) auditPostWithAI(ctx context.Context, post *CampusForumPost, rule campusContentRuleResult) (*aiContentAuditResult, string, error) {
 	cfg := uc.aiAuditConfig
 	taskCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
 	defer cancel()
@@ -3670,8 +4005,9 @@ func (uc *CampusUsecase) auditPostWithAI(ctx context.Context, post *CampusForumP
 		"media_type":      post.MediaType,
 		"image_count":     len(post.Images),
 		"model_allowed":   true,
-		"high_risk_words": uc.auditHighRiskWords(ctx),
-		"review_words":    uc.auditReviewWords(ctx),
+		"rule_risk_level": rule.RiskLevel,
+		"rule_reason":     rule.Reason,
+		"rule_evidence":   rule.Evidence,
 	})
 	req, err := http.NewRequestWithContext(taskCtx, http.MethodPost, baseURL+"/internal/moderation/audit", bytes.NewReader(body))
 	if err != nil {
@@ -4088,7 +4424,7 @@ func (uc *CampusUsecase) ProcessOpsSLAAlerts(ctx context.Context) error {
 	}
 	bucket := now.Format("2006010215")
 	targetID := now.Truncate(time.Hour).Unix()
-	reportThreshold, auditThreshold, feishuThreshold := opsSLAThresholds()
+	reportThreshold, auditThreshold, feishuThreshold, appealThreshold := opsSLAThresholds()
 	if snapshot.OverdueReportCount > 0 {
 		if err := uc.enqueueOpsAlert(ctx, CampusOpsAlertTypeReportOverdue, CampusOpsAlertPriorityHigh, "sla", targetID,
 			"sla:report_overdue:"+bucket,
@@ -4132,18 +4468,33 @@ func (uc *CampusUsecase) ProcessOpsSLAAlerts(ctx context.Context) error {
 			return err
 		}
 	}
+	if snapshot.OverdueAppealCount > 0 {
+		if err := uc.enqueueOpsAlert(ctx, CampusOpsAlertTypeAppealOverdue, CampusOpsAlertPriorityHigh, "sla", targetID,
+			"sla:appeal_overdue:"+bucket,
+			"校园 e站申诉处理超时",
+			fmt.Sprintf("有 %d 条申诉超过 %s 未处理", snapshot.OverdueAppealCount, formatOpsDuration(appealThreshold)),
+			map[string]interface{}{
+				"count":      snapshot.OverdueAppealCount,
+				"threshold":  appealThreshold.String(),
+				"samples":    appealSLASamples(snapshot.OverdueAppeals),
+				"admin_path": "/admin/moderation?tab=appeals&status=pending",
+			}); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func (uc *CampusUsecase) currentOpsSLASnapshot(ctx context.Context, now time.Time) (*CampusOpsSLASnapshot, error) {
-	reportThreshold, auditThreshold, feishuThreshold := opsSLAThresholds()
-	return uc.repo.GetOpsSLASnapshot(ctx, now.Add(-reportThreshold), now.Add(-auditThreshold), now.Add(-feishuThreshold), 3)
+	reportThreshold, auditThreshold, feishuThreshold, appealThreshold := opsSLAThresholds()
+	return uc.repo.GetOpsSLASnapshot(ctx, now.Add(-reportThreshold), now.Add(-auditThreshold), now.Add(-feishuThreshold), now.Add(-appealThreshold), 3)
 }
 
-func opsSLAThresholds() (time.Duration, time.Duration, time.Duration) {
+func opsSLAThresholds() (time.Duration, time.Duration, time.Duration, time.Duration) {
 	return envDurationBiz("CAMPUS_OPS_SLA_REPORT_OVERDUE", 30*time.Minute),
 		envDurationBiz("CAMPUS_OPS_SLA_AUDIT_OVERDUE", 2*time.Hour),
-		envDurationBiz("CAMPUS_OPS_SLA_FEISHU_FAILED", 10*time.Minute)
+		envDurationBiz("CAMPUS_OPS_SLA_FEISHU_FAILED", 10*time.Minute),
+		envDurationBiz("CAMPUS_OPS_SLA_APPEAL_OVERDUE", 24*time.Hour)
 }
 
 func reportSLASamples(reports []*CampusForumReport) []map[string]interface{} {
@@ -4425,7 +4776,22 @@ func opsAlertFindings(alert *CampusOpsAlert, payload map[string]interface{}) []m
 			reporter = fmt.Sprintf("%s（%s）", reporter, reporterID)
 		}
 		findings = append(findings, map[string]interface{}{"title": "举报人：" + trimLimit(reporter, 80), "severity": "low"})
-	case CampusOpsAlertTypeReportOverdue, CampusOpsAlertTypeAuditOverdue, CampusOpsAlertTypeFeishuDegraded:
+	case CampusOpsAlertTypeAppealCreated:
+		targetLabel := map[string]string{"post": "帖子", "comment": "评论"}[opsPayloadString(payload, "target_type")]
+		if targetLabel == "" {
+			targetLabel = "内容"
+		}
+		findings = append(findings, map[string]interface{}{
+			"title":    fmt.Sprintf("申诉%s %s", targetLabel, opsPayloadString(payload, "target_id")),
+			"detail":   trimLimit(opsPayloadString(payload, "target_excerpt"), 180),
+			"severity": alert.Priority,
+		})
+		findings = append(findings, map[string]interface{}{
+			"title":    "原处理：" + trimLimit(firstNonEmpty(opsPayloadString(payload, "original_reason"), "未填写原因"), 80),
+			"detail":   trimLimit(opsPayloadString(payload, "reason"), 180),
+			"severity": "medium",
+		})
+	case CampusOpsAlertTypeReportOverdue, CampusOpsAlertTypeAuditOverdue, CampusOpsAlertTypeFeishuDegraded, CampusOpsAlertTypeAppealOverdue:
 		if samples, ok := payload["samples"].([]map[string]interface{}); ok {
 			for _, item := range samples {
 				findings = append(findings, map[string]interface{}{
@@ -4685,6 +5051,9 @@ func (uc *CampusUsecase) markReportsHandledByTarget(ctx context.Context, targetT
 			uc.notifyReportResult(ctx, report, status)
 		}
 	}
+	if status == CampusReportStatusDismissed {
+		uc.restoreAutoHiddenTarget(ctx, targetType, targetID)
+	}
 	return nil
 }
 
@@ -4713,12 +5082,22 @@ func (uc *CampusUsecase) processAIReplyTask(ctx context.Context, task *CampusAIR
 	if !ok || post == nil {
 		return fmt.Errorf("post not found")
 	}
-	ok, trigger, err := uc.repo.GetCommentByID(ctx, task.TriggerCommentID)
-	if err != nil {
-		return err
-	}
-	if !ok || trigger == nil || trigger.Status != CampusAuditStatusVisible {
-		return fmt.Errorf("trigger comment not visible")
+	var trigger *CampusForumComment
+	if task.Source == CampusAIReplySourceQuestion {
+		if trigger, err = uc.questionAITrigger(ctx, post); err != nil {
+			return err
+		}
+		if trigger == nil {
+			return uc.repo.MarkAIReplyTaskDone(ctx, task.ID, 0)
+		}
+	} else {
+		ok, trigger, err = uc.repo.GetCommentByID(ctx, task.TriggerCommentID)
+		if err != nil {
+			return err
+		}
+		if !ok || trigger == nil || trigger.Status != CampusAuditStatusVisible {
+			return fmt.Errorf("trigger comment not visible")
+		}
 	}
 	answer, err := uc.generateEzaiAnswer(ctx, task, post, trigger, task.Prompt)
 	if err != nil {
@@ -4728,16 +5107,23 @@ func (uc *CampusUsecase) processAIReplyTask(ctx context.Context, task *CampusAIR
 	if answer == "" {
 		answer = uc.defaultEzaiFallbackReply(ctx)
 	}
-	parentID := trigger.ID
+	parentID, replyToCommentID := trigger.ID, trigger.ID
 	if trigger.ParentID > 0 {
 		parentID = trigger.ParentID
 	}
+	replyToUserID := task.AskerID
+	title := "深汕e仔回复了你"
+	if task.Source == CampusAIReplySourceQuestion {
+		// 问题自动回答没有触发评论，直接作为一级回答挂在帖子下
+		parentID, replyToCommentID, replyToUserID = 0, 0, ""
+		title = "深汕e仔回答了你的问题"
+	}
 	comment := &CampusForumComment{
 		ID:               task.ID,
 		PostID:           task.PostID,
 		ParentID:         parentID,
-		ReplyToCommentID: trigger.ID,
-		ReplyToUserID:    task.AskerID,
+		ReplyToCommentID: replyToCommentID,
+		ReplyToUserID:    replyToUserID,
 		AuthorID:         uc.aiReplyConfig.BotUserID,
 		Content:          answer,
 		Status:           CampusAuditStatusVisible,
@@ -4749,7 +5135,7 @@ func (uc *CampusUsecase) processAIReplyTask(ctx context.Context, task *CampusAIR
 		TargetType:  "post",
 		TargetID:    task.PostID,
 		DedupeKey:   fmt.Sprintf("campus:ezai-reply:%d", task.ID),
-		Title:       "深汕e仔回复了你",
+		Title:       title,
 		Content:     trimLimit(answer, 80),
 		LinkPage:    "post-detail",
 		LinkParams:  map[string]string{"id": fmt.Sprintf("%d", task.PostID)},
@@ -4757,6 +5143,7 @@ func (uc *CampusUsecase) processAIReplyTask(ctx context.Context, task *CampusAIR
 	if err := uc.repo.CreateCommentWithOutbox(ctx, comment, notification); err != nil {
 		return err
 	}
+	uc.markQuestionAnswered(ctx, post, comment.AuthorID)
 	return uc.repo.MarkAIReplyTaskDone(ctx, task.ID, comment.ID)
 }
 
@@ -4770,9 +5157,10 @@ func (uc *CampusUsecase) generateEzaiAnswer(ctx context.Context, task *CampusAIR
 		persona = defaultEzaiPersonaConfig()
 	}
 	query := trimLimit(firstNonEmpty(prompt, trigger.Content), 500)
-	postContext := buildEzaiPostContext(post)
+	termWeek, calendarEvents := uc.ezaiCalendarContext(taskCtx, post.CampusCode, query)
+	postContext := buildEzaiPostContext(post, termWeek)
 	ragResp, ragDuration, ragErr := uc.queryKnowledgeForEzai(taskCtx, query, postContext)
-	knowledgeContext := buildEzaiKnowledgeContext(ragResp)
+	knowledgeContext := buildEzaiKnowledgeContext(ragResp, termWeek, calendarEvents)
 	userPrompt := buildEzaiUserPrompt(postContext, trigger.Content, query, knowledgeContext, ragResp)
 	if shouldUseEzaiNoKnowledgeReply(ragResp, knowledgeContext, ragErr) {
 		answer := sanitizeEzaiAnswerWithLimit(persona.NoKnowledgeReply, persona.MaxReplyChars)
@@ -4933,11 +5321,14 @@ func (uc *CampusUsecase) defaultEzaiFallbackReply(ctx context.Context) string {
 	return sanitizeEzaiAnswerWithLimit(persona.FallbackReply, persona.MaxReplyChars)
 }
 
-func buildEzaiPostContext(post *CampusForumPost) string {
+func buildEzaiPostContext(post *CampusForumPost, termWeek *CampusTermWeek) string {
 	if post == nil {
 		return "无帖子上下文"
 	}
 	var builder strings.Builder
+	if termWeek != nil && termWeek.InTerm {
+		builder.WriteString(fmt.Sprintf("当前：%s 学期第 %d 周（%s）\n", termWeek.Term, termWeek.Week, campusLocalNow().Format("2006-01-02")))
+	}
 	title := trimLimit(strings.TrimSpace(post.Title), 120)
 	if title == "" {
 		title = "未填写标题"
@@ -5012,11 +5403,14 @@ func (uc *CampusUsecase) recordRAGQueryLog(ctx context.Context, task *CampusAIRe
 	}
 }
 
-func buildEzaiKnowledgeContext(resp *CampusRAGQueryResponse) string {
+func buildEzaiKnowledgeContext(resp *CampusRAGQueryResponse, termWeek *CampusTermWeek, events []*CampusCalendarEvent) string {
+	var builder strings.Builder
+	if calendar := buildEzaiCalendarContext(termWeek, events); calendar != "" {
+		builder.WriteString(calendar + "\n")
+	}
 	if resp == nil || !resp.NeedKnowledge || resp.Confidence < ezaiMinRAGConfidence() || len(resp.Chunks) == 0 {
-		return ""
+		return strings.TrimSpace(builder.String())
 	}
-	var builder strings.Builder
 	count := 0
 	for _, chunk := range resp.Chunks {
 		if chunk == nil || strings.TrimSpace(chunk.Content) == "" {
@@ -5232,6 +5626,7 @@ func (uc *CampusUsecase) ReportContent(ctx context.Context, input *ReportCampusC
 		report.Comment = targetComment
 	}
 	uc.enqueueReportOpsAlert(ctx, report)
+	uc.autoHideReportedTarget(ctx, targetType, input.TargetID, targetPost, targetComment)
 	return nil
 }
 
@@ -5239,47 +5634,64 @@ func (uc *CampusUsecase) ListModerationPosts(ctx context.Context, input *ListCam
 	if !uc.isCampusOperator(ctx, input.UserID) {
 		return nil, apperror.Forbidden("没有审核权限")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	status := input.Status
 	if status < CampusAuditStatusPending || status > CampusAuditStatusDeleted {
 		status = CampusAuditStatusPending
 	}
-	posts, total, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
-		Statuses: []int32{status},
-		Sort:     "new",
-		Offset:   int((page - 1) * size),
-		Limit:    int(size),
-	})
+	query := ListCampusPostQuery{
+		CampusCode:    uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		OnlyOwnCampus: true,
+		Statuses:      []int32{status},
+		Sort:          "new",
+		ClaimFilter:   normalizeCampusClaimFilter(input.Claim),
+	}
+	if query.ClaimFilter != "" {
+		query.ClaimOperatorID = input.UserID
+	}
+	scope := campusCursorScope("moderation_posts", query.CampusCode, strconv.Itoa(int(status)), query.ClaimFilter, query.ClaimOperatorID)
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	posts, total, err := uc.repo.ListPosts(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取审核帖子失败")
 	}
-	if err := uc.assembler.HydratePosts(ctx, posts, input.UserID); err != nil {
+	if err := uc.assembler.HydratePostsForOperator(ctx, posts, input.UserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate moderation posts failed: %v", err)
 	}
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	uc.attachPostClaims(ctx, posts)
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: uc.postsNextCursor(scope, query, posts, total)}, nil
 }
 
 func (uc *CampusUsecase) ListModerationComments(ctx context.Context, input *ListCampusModerationInput) (*ListCampusCommentsOutput, error) {
 	if !uc.isCampusOperator(ctx, input.UserID) {
 		return nil, apperror.Forbidden("没有审核权限")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	status := input.Status
 	if status < CampusAuditStatusPending || status > CampusAuditStatusDeleted {
 		status = CampusAuditStatusPending
 	}
-	comments, total, err := uc.repo.ListComments(ctx, ListCampusCommentQuery{
-		Statuses: []int32{status},
-		Offset:   int((page - 1) * size),
-		Limit:    int(size),
-	})
+	query := ListCampusCommentQuery{
+		CampusCode: uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		Statuses:   []int32{status},
+	}
+	scope := campusCursorScope("moderation_comments", query.CampusCode, strconv.Itoa(int(status)))
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.After, query.Offset, query.Limit = cursor, offset, limit
+	comments, total, err := uc.repo.ListComments(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取审核评论失败")
 	}
-	if err := uc.assembler.HydrateComments(ctx, comments, input.UserID); err != nil {
+	if err := uc.assembler.HydrateCommentsForOperator(ctx, comments, input.UserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate moderation comments failed: %v", err)
 	}
-	return &ListCampusCommentsOutput{Comments: comments, Total: total}, nil
+	return &ListCampusCommentsOutput{Comments: comments, Total: total, NextCursor: uc.commentsNextCursor(scope, limit, comments)}, nil
 }
 
 func (uc *CampusUsecase) ReviewContent(ctx context.Context, input *ReviewCampusContentInput) error {
@@ -5303,6 +5715,13 @@ func (uc *CampusUsecase) ReviewContent(ctx context.Context, input *ReviewCampusC
 		return apperror.InvalidArgument("审核动作无效")
 	}
 	reason := strings.TrimSpace(input.Reason)
+	if err := uc.ensureOperatorTargetCampus(ctx, input.UserID, targetType, input.TargetID); err != nil {
+		return err
+	}
+	if err := uc.ensureModerationClaim(ctx, input.UserID, targetType, input.TargetID, input.Force); err != nil {
+		return err
+	}
+	autoHidden := false
 	if targetType == "post" {
 		ok, post, err := uc.repo.GetAnyPostByID(ctx, input.TargetID)
 		if err != nil {
@@ -5311,6 +5730,7 @@ func (uc *CampusUsecase) ReviewContent(ctx context.Context, input *ReviewCampusC
 		if !ok {
 			return apperror.NotFound("帖子不存在")
 		}
+		autoHidden = post != nil && isReportAutoHidden(post.Status, post.AuditReason)
 		if err := uc.repo.UpdatePostStatus(ctx, input.TargetID, status, reason); err != nil {
 			return apperror.Internal(err, "审核帖子失败")
 		}
@@ -5324,17 +5744,26 @@ func (uc *CampusUsecase) ReviewContent(ctx context.Context, input *ReviewCampusC
 			}
 		}
 	} else {
-		ok, _, err := uc.repo.GetAnyCommentByID(ctx, input.TargetID)
+		ok, comment, err := uc.repo.GetAnyCommentByID(ctx, input.TargetID)
 		if err != nil {
 			return apperror.Internal(err, "查询评论失败")
 		}
 		if !ok {
 			return apperror.NotFound("评论不存在")
 		}
+		autoHidden = comment != nil && isReportAutoHidden(comment.Status, comment.AuditReason)
 		if err := uc.repo.UpdateCommentStatus(ctx, input.TargetID, status, reason); err != nil {
 			return apperror.Internal(err, "审核评论失败")
 		}
 	}
+	if autoHidden {
+		reportStatus := CampusReportStatusUpheld
+		if status == CampusAuditStatusVisible {
+			reportStatus = CampusReportStatusDismissed
+		}
+		_ = uc.markReportsHandledByTarget(ctx, targetType, input.TargetID, reportStatus)
+	}
+	uc.releaseModerationClaim(ctx, targetType, input.TargetID)
 	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
 		ID:         uc.idGen.NextID(),
 		TargetType: targetType,
@@ -5446,10 +5875,10 @@ func (uc *CampusUsecase) AdminUpdateAgentSettings(ctx context.Context, input *Up
 	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAgentAuditAutoPass, formatFloatSetting(clampRatioFloat(input.AgentAuditAutoPassConfidence, defaultAgentAuditAutoPassConfidence())), input.UserID); err != nil {
 		return nil, apperror.Internal(err, "保存 AI 自动通过阈值失败")
 	}
-	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAuditHighRiskWords, formatAuditWords(normalizeAuditWords(input.AuditHighRiskWords, defaultAuditHighRiskWords)), input.UserID); err != nil {
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAuditHighRiskWords, formatAuditWords(normalizeAuditWords(input.AuditHighRiskWords, nil)), input.UserID); err != nil {
 		return nil, apperror.Internal(err, "保存高风险关键词失败")
 	}
-	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAuditReviewWords, formatAuditWords(normalizeAuditWords(input.AuditReviewWords, defaultAuditReviewWords)), input.UserID); err != nil {
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAuditReviewWords, formatAuditWords(normalizeAuditWords(input.AuditReviewWords, nil)), input.UserID); err != nil {
 		return nil, apperror.Internal(err, "保存需复核关键词失败")
 	}
 	return uc.getCampusAgentSettings(ctx), nil
@@ -5670,22 +6099,12 @@ func formatAuditWords(words []string) string {
 	return strings.Join(normalizeAuditWords(strings.Join(words, ","), []string{}), ",")
 }
 
-func defaultAuditHighRiskWordsValue() string {
-	return strings.Join(defaultAuditHighRiskWords, ",")
-}
-
-func defaultAuditReviewWordsValue() string {
-	return strings.Join(defaultAuditReviewWords, ",")
-}
-
 func (uc *CampusUsecase) auditHighRiskWords(ctx context.Context) []string {
-	value := uc.stringOpsSetting(ctx, campusOpsSettingAuditHighRiskWords, "CAMPUS_AUDIT_HIGH_RISK_WORDS", defaultAuditHighRiskWordsValue())
-	return normalizeAuditWords(value, defaultAuditHighRiskWords)
+	return normalizeAuditWords(uc.stringOpsSetting(ctx, campusOpsSettingAuditHighRiskWords, "CAMPUS_AUDIT_HIGH_RISK_WORDS", ""), nil)
 }
 
 func (uc *CampusUsecase) auditReviewWords(ctx context.Context) []string {
-	value := uc.stringOpsSetting(ctx, campusOpsSettingAuditReviewWords, "CAMPUS_AUDIT_REVIEW_WORDS", defaultAuditReviewWordsValue())
-	return normalizeAuditWords(value, defaultAuditReviewWords)
+	return normalizeAuditWords(uc.stringOpsSetting(ctx, campusOpsSettingAuditReviewWords, "CAMPUS_AUDIT_REVIEW_WORDS", ""), nil)
 }
 
 func normalizeAIBudgetWarnRatio(value string) string {
@@ -5979,7 +6398,8 @@ func (uc *CampusUsecase) AdminPreviewEzaiPersona(ctx context.Context, input *Pre
 		CategoryName: "后台预览",
 		PostType:     CampusPostTypeQuestion,
 	}
-	postContext := buildEzaiPostContext(post)
+	termWeek, calendarEvents := uc.ezaiCalendarContext(ctx, uc.operatorCampusScope(ctx, input.UserID, ""), question)
+	postContext := buildEzaiPostContext(post, termWeek)
 	var ragResp *CampusRAGQueryResponse
 	var ragErr error
 	var fallbackReason string
@@ -5989,7 +6409,7 @@ func (uc *CampusUsecase) AdminPreviewEzaiPersona(ctx context.Context, input *Pre
 			fallbackReason = "knowledge_error: " + trimLimit(ragErr.Error(), 120)
 		}
 	}
-	knowledgeContext := buildEzaiKnowledgeContext(ragResp)
+	knowledgeContext := buildEzaiKnowledgeContext(ragResp, termWeek, calendarEvents)
 	userPrompt := buildEzaiUserPrompt(postContext, question, question, knowledgeContext, ragResp)
 	systemPrompt := buildEzaiSystemPrompt(persona, knowledgeContext != "")
 	preview := &CampusEzaiPersonaPreview{
@@ -6154,13 +6574,14 @@ func (uc *CampusUsecase) AdminListPosts(ctx context.Context, input *ListCampusAd
 	if !uc.isCampusOperator(ctx, input.UserID) {
 		return nil, apperror.Forbidden("没有后台权限")
 	}
-	page, size := normalizePage(input.Page, input.Size)
-	statuses := []int32{}
-	if input.Status >= CampusAuditStatusPending && input.Status <= CampusAuditStatusDeleted {
+	statuses := []int32{CampusAuditStatusPending, CampusAuditStatusVisible, CampusAuditStatusRejected, CampusAuditStatusDeleted}
+	if input.Status >= CampusAuditStatusPending && input.Status <= CampusAuditStatusScheduled {
 		statuses = []int32{input.Status}
 	}
 	onlyOfficial, onlyFeatured, onlyPinned, onlyReported := parseOpsFilter(input.OpsFilter)
-	posts, total, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
+	query := ListCampusPostQuery{
+		CampusCode:     uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		OnlyOwnCampus:  true,
 		CategoryCode:   strings.TrimSpace(input.CategoryCode),
 		PostType:       strings.TrimSpace(input.PostType),
 		Sort:           normalizeCampusPostSort(input.Sort, CampusPostSortNew),
@@ -6171,17 +6592,23 @@ func (uc *CampusUsecase) AdminListPosts(ctx context.Context, input *ListCampusAd
 		OnlyFeatured:   onlyFeatured,
 		OnlyPinned:     onlyPinned,
 		OnlyReported:   onlyReported,
-		Offset:         int((page - 1) * size),
-		Limit:          int(size),
-	})
+	}
+	scope := campusCursorScope("admin_posts", query.CampusCode, query.CategoryCode, query.PostType, query.Sort, query.Keyword, input.OpsFilter, strconv.Itoa(int(input.Status)))
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.Offset, query.Limit = offset, limit
+	applyPostCursor(&query, cursor)
+	posts, total, err := uc.repo.ListPosts(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取后台帖子失败")
 	}
-	if err := uc.assembler.HydratePosts(ctx, posts, input.UserID); err != nil {
+	if err := uc.assembler.HydratePostsForOperator(ctx, posts, input.UserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate admin posts failed: %v", err)
 	}
 	uc.attachLatestAIAuditTasks(ctx, posts)
-	return &ListCampusPostsOutput{Posts: posts, Total: total}, nil
+	return &ListCampusPostsOutput{Posts: posts, Total: total, NextCursor: uc.postsNextCursor(scope, query, posts, total)}, nil
 }
 
 func (uc *CampusUsecase) attachLatestAIAuditTasks(ctx context.Context, posts []*CampusForumPost) {
@@ -6253,6 +6680,12 @@ func (uc *CampusUsecase) AdminBatchPosts(ctx context.Context, input *BatchCampus
 		if !ok {
 			continue
 		}
+		if err := uc.ensureOperatorCampus(ctx, input.UserID, existing.CampusCode); err != nil {
+			return nil, err
+		}
+		if campusPostUnpublished(existing) {
+			continue
+		}
 		next := *existing
 		next.AuditReason = existing.AuditReason
 		switch action {
@@ -6308,17 +6741,23 @@ func (uc *CampusUsecase) AdminUpdatePost(ctx context.Context, input *UpdateCampu
 	if !ok {
 		return nil, apperror.NotFound("帖子不存在")
 	}
+	if err := uc.ensureOperatorCampus(ctx, input.UserID, existing.CampusCode); err != nil {
+		return nil, err
+	}
 	categoryCode := strings.TrimSpace(input.CategoryCode)
 	if categoryCode == "" {
 		categoryCode = existing.CategoryCode
 	}
-	ok, category, err := uc.repo.GetCategoryByCode(ctx, categoryCode)
+	ok, category, err := uc.repo.GetCategoryByCode(ctx, existing.CampusCode, categoryCode)
 	if err != nil {
 		return nil, apperror.Internal(err, "查询版块失败")
 	}
 	if !ok {
 		return nil, apperror.InvalidArgument("版块不存在")
 	}
+	if category.CampusCode != "" && category.CampusCode != existing.CampusCode {
+		return nil, apperror.InvalidArgument("版块不属于当前校区")
+	}
 	title := firstNonEmpty(input.Title, existing.Title)
 	content := firstNonEmpty(input.Content, existing.Content)
 	if len([]rune(title)) < 2 || len([]rune(title)) > 60 {
@@ -6343,8 +6782,21 @@ func (uc *CampusUsecase) AdminUpdatePost(ctx context.Context, input *UpdateCampu
 	if status < CampusAuditStatusPending || status > CampusAuditStatusDeleted {
 		status = existing.Status
 	}
+	isPinned, isFeatured, sortWeight := input.IsPinned, input.IsFeatured, clampSortWeight(input.SortWeight)
+	if campusPostUnpublished(existing) {
+		if status != CampusAuditStatusDeleted {
+			status = existing.Status
+		}
+		isPinned, isFeatured, sortWeight = false, false, 0
+	}
+	crossCampus := existing.IsCrossCampus
+	if input.CrossCampus != nil {
+		crossCampus = *input.CrossCampus
+	}
 	post := &CampusForumPost{
 		ID:             existing.ID,
+		CampusCode:     existing.CampusCode,
+		IsCrossCampus:  crossCampus,
 		CategoryCode:   category.Code,
 		CategoryName:   category.Name,
 		AuthorID:       existing.AuthorID,
@@ -6356,19 +6808,30 @@ func (uc *CampusUsecase) AdminUpdatePost(ctx context.Context, input *UpdateCampu
 		Extra:          mergeCampusPostExtra(existing.Extra, input.Extra),
 		CoverURL:       coverURL,
 		IsOfficial:     input.IsOfficial,
-		IsFeatured:     input.IsFeatured,
-		IsPinned:       input.IsPinned,
-		SortWeight:     clampSortWeight(input.SortWeight),
+		IsFeatured:     isFeatured,
+		IsPinned:       isPinned,
+		SortWeight:     sortWeight,
 		Status:         status,
 		AuditReason:    strings.TrimSpace(input.AuditReason),
 		LikeCount:      existing.LikeCount,
 		CommentCount:   existing.CommentCount,
 		CollectedCount: existing.CollectedCount,
+		EditCount:      existing.EditCount,
+		EditedAt:       existing.EditedAt,
 		CreatedAt:      existing.CreatedAt,
 	}
+	contentChanged := campusPostContentChanged(existing, post)
+	if contentChanged {
+		now := campusLocalNow()
+		post.EditCount++
+		post.EditedAt = &now
+	}
 	if err := uc.repo.UpdatePostByAdmin(ctx, post); err != nil {
 		return nil, apperror.Internal(err, "更新帖子失败")
 	}
+	if contentChanged {
+		uc.recordAdminPostRevision(ctx, existing, post, input.UserID)
+	}
 	if existing.Status != post.Status {
 		if post.Status == CampusAuditStatusRejected {
 			uc.notifyPostAuditResult(ctx, post, false, "这条内容暂未同步")
@@ -6376,7 +6839,7 @@ func (uc *CampusUsecase) AdminUpdatePost(ctx context.Context, input *UpdateCampu
 			uc.notifyPostAuditResult(ctx, post, false, "这条内容已下架")
 		}
 	}
-	_ = uc.assembler.HydratePosts(ctx, []*CampusForumPost{post}, input.UserID)
+	_ = uc.assembler.HydratePostsForOperator(ctx, []*CampusForumPost{post}, input.UserID)
 	return post, nil
 }
 
@@ -6391,6 +6854,11 @@ func (uc *CampusUsecase) AdminDeletePost(ctx context.Context, userID string, pos
 	if err != nil {
 		return apperror.Internal(err, "查询帖子失败")
 	}
+	if ok && post != nil {
+		if err := uc.ensureOperatorCampus(ctx, userID, post.CampusCode); err != nil {
+			return err
+		}
+	}
 	if err := uc.repo.DeletePost(ctx, postID); err != nil {
 		return apperror.Internal(err, "删除帖子失败")
 	}
@@ -6405,28 +6873,33 @@ func (uc *CampusUsecase) AdminListComments(ctx context.Context, input *ListCampu
 	if !uc.isCampusOperator(ctx, input.UserID) {
 		return nil, apperror.Forbidden("没有后台权限")
 	}
-	page, size := normalizePage(input.Page, input.Size)
 	statuses := []int32{}
 	if input.Status >= CampusAuditStatusPending && input.Status <= CampusAuditStatusDeleted {
 		statuses = []int32{input.Status}
 	}
-	comments, total, err := uc.repo.ListComments(ctx, ListCampusCommentQuery{
+	query := ListCampusCommentQuery{
+		CampusCode:     uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
 		PostID:         input.PostID,
 		Statuses:       statuses,
 		IncludeDeleted: true,
-		Offset:         int((page - 1) * size),
-		Limit:          int(size),
-	})
+	}
+	scope := campusCursorScope("admin_comments", query.CampusCode, strconv.FormatInt(input.PostID, 10), strconv.Itoa(int(input.Status)))
+	cursor, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	query.After, query.Offset, query.Limit = cursor, offset, limit
+	comments, total, err := uc.repo.ListComments(ctx, query)
 	if err != nil {
 		return nil, apperror.Internal(err, "获取后台评论失败")
 	}
-	if err := uc.assembler.HydrateComments(ctx, comments, input.UserID); err != nil {
+	if err := uc.assembler.HydrateCommentsForOperator(ctx, comments, input.UserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate admin comments failed: %v", err)
 	}
 	if err := uc.repo.FillCommentPosts(ctx, comments); err != nil {
 		uc.log.WithContext(ctx).Warnf("fill admin comment posts failed: %v", err)
 	}
-	return &ListCampusCommentsOutput{Comments: comments, Total: total}, nil
+	return &ListCampusCommentsOutput{Comments: comments, Total: total, NextCursor: uc.commentsNextCursor(scope, limit, comments)}, nil
 }
 
 func (uc *CampusUsecase) AdminAIReplyOverview(ctx context.Context, userID string) (*CampusAIReplyOverview, error) {
@@ -6485,7 +6958,7 @@ func (uc *CampusUsecase) AdminListAIReplyTasks(ctx context.Context, input *ListC
 	if err := uc.repo.AttachAIReplyTaskDetails(ctx, tasks); err != nil {
 		uc.log.WithContext(ctx).Warnf("attach ai reply task details failed: %v", err)
 	}
-	if err := uc.assembler.HydrateComments(ctx, collectAIReplyTaskComments(tasks), input.UserID); err != nil {
+	if err := uc.assembler.HydrateCommentsForOperator(ctx, collectAIReplyTaskComments(tasks), input.UserID); err != nil {
 		uc.log.WithContext(ctx).Warnf("hydrate ai reply task comments failed: %v", err)
 	}
 	return &ListCampusAIReplyTasksOutput{Tasks: tasks, Total: total}, nil
@@ -7772,6 +8245,9 @@ func (uc *CampusUsecase) AdminDeleteComment(ctx context.Context, userID string,
 	if commentID <= 0 {
 		return apperror.InvalidArgument("评论 ID 无效")
 	}
+	if err := uc.ensureOperatorCommentCampus(ctx, userID, commentID); err != nil {
+		return err
+	}
 	if err := uc.repo.DeleteComment(ctx, commentID); err != nil {
 		return apperror.Internal(err, "删除评论失败")
 	}
@@ -7787,10 +8263,13 @@ func (uc *CampusUsecase) AdminListReports(ctx context.Context, input *ListCampus
 	if status < 0 || status > 2 {
 		status = -1
 	}
-	reports, total, err := uc.repo.ListReports(ctx, status, int((page-1)*size), int(size))
+	reports, total, err := uc.repo.ListReports(ctx, uc.reportQuery(ctx, input, status, page, size))
 	if err != nil {
 		return nil, apperror.Internal(err, "获取举报列表失败")
 	}
+	uc.fillReportTargetAuthors(ctx, reports)
+	uc.weighReports(ctx, reports)
+	uc.attachReportClaims(ctx, reports)
 	return &ListCampusReportsOutput{Reports: reports, Total: total}, nil
 }
 
@@ -7808,6 +8287,12 @@ func (uc *CampusUsecase) AdminReviewReport(ctx context.Context, input *ReviewCam
 	if !ok || report == nil {
 		return apperror.NotFound("举报不存在")
 	}
+	if err := uc.ensureOperatorTargetCampus(ctx, input.UserID, report.TargetType, report.TargetID); err != nil {
+		return err
+	}
+	if err := uc.ensureModerationClaim(ctx, input.UserID, report.TargetType, report.TargetID, input.Force); err != nil {
+		return err
+	}
 	status := int32(1)
 	switch strings.TrimSpace(strings.ToLower(input.Action)) {
 	case "resolve", "handled", "approve", "pass":
@@ -7821,6 +8306,9 @@ func (uc *CampusUsecase) AdminReviewReport(ctx context.Context, input *ReviewCam
 		return apperror.Internal(err, "处理举报失败")
 	}
 	uc.notifyReportResult(ctx, report, status)
+	if status == CampusReportStatusDismissed {
+		uc.restoreAutoHiddenTargetIfAllDismissed(ctx, report.TargetType, report.TargetID)
+	}
 	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
 		ID:         uc.idGen.NextID(),
 		TargetType: "report",
@@ -8042,6 +8530,8 @@ func (uc *CampusUsecase) AdminListUsers(ctx context.Context, input *ListCampusAd
 			}
 		}
 	}
+	uc.attachUserSanctions(ctx, users)
+	uc.attachUserTrust(ctx, users)
 	return &ListCampusAdminUsersOutput{Users: users, Total: total}, nil
 }
 
@@ -8133,60 +8623,6 @@ func resolveWechatSession(ctx context.Context, code string) (*wechatSession, err
 	return &wechatSession{OpenID: out.OpenID, UnionID: out.UnionID}, nil
 }
 
-type auditDecision struct {
-	Provider string
-	Result   string
-	Reason   string
-	Blocked  bool
-	Pending  bool
-}
-
-func auditText(ctx context.Context, targetType, text string) auditDecision {
-	trimmed := strings.TrimSpace(text)
-	if trimmed == "" {
-		return auditDecision{Provider: "local", Result: "pass"}
-	}
-	lower := strings.ToLower(trimmed)
-	for _, word := range []string{"广告代刷", "赌博", "诈骗", "裸聊"} {
-		if strings.Contains(lower, word) {
-			return auditDecision{Provider: "local", Result: "reject", Reason: "内容包含违规信息", Blocked: true}
-		}
-	}
-	token := strings.TrimSpace(os.Getenv("WECHAT_CONTENT_SECURITY_TOKEN"))
-	if token == "" {
-		return auditDecision{Provider: "local", Result: "pass"}
-	}
-	if err := callWechatTextSecurity(ctx, token, trimmed); err != nil {
-		return auditDecision{Provider: "wechat", Result: "pending", Reason: "内容安全服务暂不可用，已进入人工审核", Pending: true}
-	}
-	return auditDecision{Provider: "wechat", Result: "pass"}
-}
-
-func callWechatTextSecurity(ctx context.Context, token, content string) error {
-	reqBody := strings.NewReader(fmt.Sprintf(`{"content":%q}`, content))
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.weixin.qq.com/wxa/msg_sec_check?access_token="+url.QueryEscape(token), reqBody)
-	if err != nil {
-		return err
-	}
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return err
-	}
-	defer resp.Body.Close()
-	var out struct {
-		ErrCode int64  `json:"errcode"`
-		ErrMsg  string `json:"errmsg"`
-	}
-	if err := jsonDecode(resp.Body, &out); err != nil {
-		return err
-	}
-	if out.ErrCode != 0 {
-		return fmt.Errorf("wechat content security rejected: %d %s", out.ErrCode, out.ErrMsg)
-	}
-	return nil
-}
-
 func normalizePage(page, size int32) (int32, int32) {
 	if page <= 0 {
 		page = 1
@@ -8257,6 +8693,8 @@ func normalizeCampusPostType(postType string) string {
 		return CampusPostTypeGuide
 	case CampusPostTypeClub:
 		return CampusPostTypeClub
+	case CampusPostTypePoll:
+		return CampusPostTypePoll
 	default:
 		return CampusPostTypeNote
 	}
@@ -8582,7 +9020,7 @@ func (uc *CampusUsecase) trackEvent(ctx context.Context, input *TrackCampusEvent
 
 func normalizeCampusEvent(event string) string {
 	switch strings.TrimSpace(strings.ToLower(event)) {
-	case "visit", "share", "login", "post_create", "publish_open", "publish_success", "post_detail_visit", "comment_create", "comment_like", "like", "collect", "feedback_create", "report_create":
+	case "visit", "share", "login", "post_create", "post_edit", "post_schedule", "answer_accept", "user_follow", "publish_open", "publish_success", "post_detail_visit", "comment_create", "comment_like", "like", "collect", "feedback_create", "report_create":
 		return strings.TrimSpace(strings.ToLower(event))
 	default:
 		return ""
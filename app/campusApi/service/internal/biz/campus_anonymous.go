@@ -0,0 +1,195 @@
+package biz
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const campusOpsSettingAnonymousCategories = "anonymous_categories"
+
+var (
+	campusAnonymousAdjectives = []string{"安静的", "路过的", "认真的", "好奇的", "温柔的", "勇敢的", "迷糊的", "早起的", "熬夜的", "开心的", "淡定的", "热心的"}
+	campusAnonymousNouns      = []string{"银杏", "松鼠", "海豚", "猫头鹰", "小鹿", "企鹅", "白鸽", "向日葵", "萤火虫", "考拉", "月亮", "蒲公英"}
+)
+
+type CampusAnonymousSettings struct {
+	CategoryCodes []string
+	UpdatedBy     string
+	UpdatedAt     time.Time
+}
+
+type GetCampusAnonymousSettingsInput struct {
+	UserID string
+}
+
+type UpdateCampusAnonymousSettingsInput struct {
+	UserID        string
+	CategoryCodes []string
+}
+
+func campusAnonymousName(postID int64) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strconv.FormatInt(postID, 10)))
+	sum := h.Sum32()
+	adjective := campusAnonymousAdjectives[sum%uint32(len(campusAnonymousAdjectives))]
+	noun := campusAnonymousNouns[(sum/uint32(len(campusAnonymousAdjectives)))%uint32(len(campusAnonymousNouns))]
+	return "匿名·" + adjective + noun
+}
+
+func campusAnonymousAuthor(postID int64) *CampusForumAuthor {
+	name := campusAnonymousName(postID)
+	return &CampusForumAuthor{Name: name, Nickname: name, IsAnonymous: true}
+}
+
+func (uc *CampusUsecase) anonymousCategoryCodes(ctx context.Context) []string {
+	return normalizeAuditWords(uc.stringOpsSetting(ctx, campusOpsSettingAnonymousCategories, "CAMPUS_ANONYMOUS_CATEGORIES", ""), nil)
+}
+
+func (uc *CampusUsecase) categoryAllowsAnonymous(ctx context.Context, code string) bool {
+	for _, item := range uc.anonymousCategoryCodes(ctx) {
+		if strings.EqualFold(item, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func (uc *CampusUsecase) AdminGetAnonymousSettings(ctx context.Context, input *GetCampusAnonymousSettingsInput) (*CampusAnonymousSettings, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	return uc.getCampusAnonymousSettings(ctx)
+}
+
+func (uc *CampusUsecase) AdminUpdateAnonymousSettings(ctx context.Context, input *UpdateCampusAnonymousSettingsInput) (*CampusAnonymousSettings, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	codes := normalizeAuditWords(strings.Join(input.CategoryCodes, ","), nil)
+	for _, code := range codes {
+		ok, _, err := uc.repo.GetCategoryByCode(ctx, "", code)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询版块失败")
+		}
+		if !ok {
+			return nil, apperror.InvalidArgument("版块不存在：" + code)
+		}
+	}
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAnonymousCategories, strings.Join(codes, ","), input.UserID); err != nil {
+		return nil, apperror.Internal(err, "保存匿名设置失败")
+	}
+	return uc.getCampusAnonymousSettings(ctx)
+}
+
+func (uc *CampusUsecase) getCampusAnonymousSettings(ctx context.Context) (*CampusAnonymousSettings, error) {
+	_, _, updatedBy, updatedAt, err := uc.repo.GetOpsSetting(ctx, campusOpsSettingAnonymousCategories)
+	if err != nil {
+		return nil, apperror.Internal(err, "读取匿名设置失败")
+	}
+	return &CampusAnonymousSettings{
+		CategoryCodes: uc.anonymousCategoryCodes(ctx),
+		UpdatedBy:     updatedBy,
+		UpdatedAt:     updatedAt,
+	}, nil
+}
+
+func (a *CampusPostAssembler) maskAnonymousPosts(posts []*CampusForumPost) {
+	for _, post := range posts {
+		if post != nil && post.IsAnonymous {
+			post.Author = campusAnonymousAuthor(post.ID)
+		}
+	}
+}
+
+func (a *CampusPostAssembler) maskAnonymousComments(ctx context.Context, comments []*CampusForumComment) {
+	postIDs := make([]int64, 0, len(comments))
+	seen := map[int64]bool{}
+	for _, comment := range comments {
+		if comment != nil && !seen[comment.PostID] {
+			seen[comment.PostID] = true
+			postIDs = append(postIDs, comment.PostID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	anonymousAuthors, err := a.repo.GetAnonymousPostAuthors(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load anonymous post authors failed: %v", err)
+		return
+	}
+	for _, comment := range comments {
+		if comment == nil {
+			continue
+		}
+		authorID, ok := anonymousAuthors[comment.PostID]
+		if !ok {
+			continue
+		}
+		if comment.AuthorID == authorID {
+			comment.Author = campusAnonymousAuthor(comment.PostID)
+		}
+		if comment.ReplyToUserID == authorID {
+			comment.ReplyToUserID = ""
+			comment.ReplyToUser = campusAnonymousAuthor(comment.PostID)
+		}
+	}
+}
+
+func (uc *CampusUsecase) fillReportTargetAuthors(ctx context.Context, reports []*CampusForumReport) {
+	userIDs := make([]string, 0, len(reports))
+	seen := map[string]struct{}{}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		if report.Target != nil {
+			appendUniqueUserID(&userIDs, seen, report.Target.AuthorID)
+		}
+		if report.Comment != nil {
+			appendUniqueUserID(&userIDs, seen, report.Comment.AuthorID)
+		}
+	}
+	authors, err := uc.assembler.LoadAuthors(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load report target authors failed: %v", err)
+		return
+	}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		if report.Target != nil {
+			report.Target.Author = authors[report.Target.AuthorID]
+		}
+		if report.Comment != nil {
+			report.Comment.Author = authors[report.Comment.AuthorID]
+		}
+	}
+}
+
+func anonymizeOutboxActor(outbox *CampusNotificationOutbox, post *CampusForumPost) {
+	if outbox != nil && post != nil && post.IsAnonymous && outbox.ActorID == post.AuthorID {
+		outbox.ActorID = ""
+	}
+}
+
+func (uc *CampusUsecase) anonymizeCommentOutboxActor(ctx context.Context, outbox *CampusNotificationOutbox, postID int64) {
+	if outbox == nil {
+		return
+	}
+	authors, err := uc.repo.GetAnonymousPostAuthors(ctx, []int64{postID})
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load anonymous post author failed: post_id=%d err=%v", postID, err)
+		outbox.ActorID = ""
+		return
+	}
+	if authorID, ok := authors[postID]; ok && authorID == outbox.ActorID {
+		outbox.ActorID = ""
+	}
+}
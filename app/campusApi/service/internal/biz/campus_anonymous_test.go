@@ -0,0 +1,41 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCampusAnonymousName(t *testing.T) {
+	name := campusAnonymousName(1849203847561)
+	if !strings.HasPrefix(name, "匿名·") {
+		t.Fatalf("unexpected pseudonym %q", name)
+	}
+	if campusAnonymousName(1849203847561) != name {
+		t.Fatal("pseudonym should be stable for the same post")
+	}
+	seen := map[string]bool{}
+	for id := int64(1); id <= 50; id++ {
+		seen[campusAnonymousName(id)] = true
+	}
+	if len(seen) < 10 {
+		t.Fatalf("pseudonyms should vary across posts, got %d distinct", len(seen))
+	}
+	author := campusAnonymousAuthor(7)
+	if author.UserID != "" || !author.IsAnonymous {
+		t.Fatalf("anonymous author should not expose user id: %+v", author)
+	}
+}
+
+func TestAnonymizeOutboxActor(t *testing.T) {
+	post := &CampusForumPost{ID: 1, AuthorID: "10", IsAnonymous: true}
+	outbox := &CampusNotificationOutbox{RecipientID: "20", ActorID: "10"}
+	anonymizeOutboxActor(outbox, post)
+	if outbox.ActorID != "" {
+		t.Fatalf("anonymous author should be hidden, got %q", outbox.ActorID)
+	}
+	outbox = &CampusNotificationOutbox{RecipientID: "10", ActorID: "20"}
+	anonymizeOutboxActor(outbox, post)
+	if outbox.ActorID != "20" {
+		t.Fatal("other actors should stay visible")
+	}
+}
@@ -0,0 +1,353 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusAppealStatusPending   = "pending"
+	CampusAppealStatusRestored  = "restored"
+	CampusAppealStatusConfirmed = "confirmed"
+
+	campusAppealReasonMaxRunes = 500
+)
+
+type CampusModerationAppeal struct {
+	ID             int64
+	TargetType     string
+	TargetID       int64
+	PostID         int64
+	UserID         string
+	User           *CampusForumAuthor
+	CampusCode     string
+	Reason         string
+	Status         string
+	OriginalStatus int32
+	OriginalReason string
+	TargetExcerpt  string
+	ReviewerID     string
+	ReviewNote     string
+	ReviewedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type CreateCampusAppealInput struct {
+	UserID     string
+	TargetType string
+	TargetID   int64
+	Reason     string
+}
+
+type ListCampusAppealsInput struct {
+	UserID     string
+	CampusCode string
+	Status     string
+	Page       int32
+	Size       int32
+}
+
+type ListCampusAppealsOutput struct {
+	Appeals []*CampusModerationAppeal
+	Total   int64
+}
+
+type CampusAppealQuery struct {
+	UserID     string
+	CampusCode string
+	Status     string
+	Offset     int
+	Limit      int
+}
+
+type ReviewCampusAppealInput struct {
+	UserID   string
+	AppealID int64
+	Action   string
+	Note     string
+}
+
+// 只有被驳回或被运营下架的内容可以申诉，作者自己删除的不算
+func campusAppealable(status int32, auditReason string) bool {
+	switch status {
+	case CampusAuditStatusRejected:
+		return true
+	case CampusAuditStatusDeleted:
+		return !campusUserDeletionReason(auditReason)
+	default:
+		return false
+	}
+}
+
+func campusUserDeletionReason(auditReason string) bool {
+	switch strings.TrimSpace(auditReason) {
+	case CampusAuditReasonUserDeleted, CampusAuditReasonPostDeleted, CampusAuditReasonParentCommentDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// CampusUserDeletionReasons 返回作者主动删除对应的全部 audit_reason，供统计违规时排除
+func CampusUserDeletionReasons() []string {
+	return []string{CampusAuditReasonUserDeleted, CampusAuditReasonPostDeleted, CampusAuditReasonParentCommentDeleted}
+}
+
+func normalizeCampusAppealStatus(status string) string {
+	switch strings.TrimSpace(strings.ToLower(status)) {
+	case CampusAppealStatusPending, CampusAppealStatusRestored, CampusAppealStatusConfirmed:
+		return strings.TrimSpace(strings.ToLower(status))
+	default:
+		return ""
+	}
+}
+
+func campusAppealTargetLabel(targetType string) string {
+	if targetType == "comment" {
+		return "评论"
+	}
+	return "帖子"
+}
+
+func (uc *CampusUsecase) CreateAppeal(ctx context.Context, input *CreateCampusAppealInput) (*CampusModerationAppeal, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	targetType := normalizeCampusTargetType(input.TargetType)
+	if targetType == "" || input.TargetID <= 0 {
+		return nil, apperror.InvalidArgument("申诉对象无效")
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, apperror.InvalidArgument("请说明申诉理由")
+	}
+	if len([]rune(reason)) > campusAppealReasonMaxRunes {
+		return nil, apperror.InvalidArgument("申诉理由不能超过 500 个字")
+	}
+	appeal := &CampusModerationAppeal{
+		ID:         uc.idGen.NextID(),
+		TargetType: targetType,
+		TargetID:   input.TargetID,
+		UserID:     input.UserID,
+		Reason:     reason,
+		Status:     CampusAppealStatusPending,
+	}
+	var authorID string
+	if targetType == "post" {
+		ok, post, err := uc.repo.GetAnyPostByID(ctx, input.TargetID)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询帖子失败")
+		}
+		if !ok || post == nil {
+			return nil, apperror.NotFound("帖子不存在")
+		}
+		authorID = post.AuthorID
+		appeal.PostID = post.ID
+		appeal.CampusCode = post.CampusCode
+		appeal.OriginalStatus = post.Status
+		appeal.OriginalReason = post.AuditReason
+		appeal.TargetExcerpt = trimLimit(firstNonEmpty(post.Title, post.Content), 120)
+	} else {
+		ok, comment, err := uc.repo.GetAnyCommentByID(ctx, input.TargetID)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询评论失败")
+		}
+		if !ok || comment == nil {
+			return nil, apperror.NotFound("评论不存在")
+		}
+		authorID = comment.AuthorID
+		appeal.PostID = comment.PostID
+		appeal.OriginalStatus = comment.Status
+		appeal.OriginalReason = comment.AuditReason
+		appeal.TargetExcerpt = trimLimit(comment.Content, 120)
+		if ok, post, err := uc.repo.GetAnyPostByID(ctx, comment.PostID); err == nil && ok && post != nil {
+			appeal.CampusCode = post.CampusCode
+		}
+	}
+	if authorID != input.UserID {
+		return nil, apperror.Forbidden("只能申诉自己发布的内容")
+	}
+	if !campusAppealable(appeal.OriginalStatus, appeal.OriginalReason) {
+		return nil, apperror.InvalidArgument("这条内容没有被驳回或下架，无需申诉")
+	}
+	appeal.CampusCode = firstNonEmpty(normalizeCampusCode(appeal.CampusCode), defaultCampusCode())
+	created, err := uc.repo.CreateModerationAppeal(ctx, appeal)
+	if err != nil {
+		return nil, apperror.Internal(err, "提交申诉失败")
+	}
+	if !created {
+		return nil, apperror.InvalidArgument("这条内容已经申诉过了，请等待处理结果")
+	}
+	label := campusAppealTargetLabel(targetType)
+	uc.queueUserSystemNotification(ctx, input.UserID, "appeal", appeal.ID,
+		"申诉已提交",
+		fmt.Sprintf("你对%s的申诉已提交，运营同学会尽快复核。", label),
+		"my-posts",
+		map[string]string{},
+		fmt.Sprintf("campus:appeal-created:%d", appeal.ID),
+	)
+	if err := uc.enqueueOpsAlert(ctx, CampusOpsAlertTypeAppealCreated, CampusOpsAlertPriorityNormal, "appeal", appeal.ID,
+		fmt.Sprintf("appeal:%d", appeal.ID),
+		"校园 e站收到内容申诉",
+		fmt.Sprintf("%s #%d 的作者提交申诉：%s", label, appeal.TargetID, trimLimit(reason, 120)),
+		map[string]interface{}{
+			"target_type":     targetType,
+			"target_id":       fmt.Sprintf("%d", appeal.TargetID),
+			"target_excerpt":  appeal.TargetExcerpt,
+			"user_id":         appeal.UserID,
+			"reason":          reason,
+			"original_reason": appeal.OriginalReason,
+			"admin_path":      "/admin/moderation?tab=appeals&status=pending",
+		}); err != nil {
+		uc.log.WithContext(ctx).Warnf("enqueue appeal ops alert failed: appeal_id=%d err=%v", appeal.ID, err)
+	}
+	return appeal, nil
+}
+
+func (uc *CampusUsecase) ListMyAppeals(ctx context.Context, userID string) ([]*CampusModerationAppeal, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	appeals, _, err := uc.repo.ListModerationAppeals(ctx, &CampusAppealQuery{UserID: userID, Limit: 50})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取申诉记录失败")
+	}
+	return appeals, nil
+}
+
+func (uc *CampusUsecase) AdminListAppeals(ctx context.Context, input *ListCampusAppealsInput) (*ListCampusAppealsOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	appeals, total, err := uc.repo.ListModerationAppeals(ctx, &CampusAppealQuery{
+		Status:     normalizeCampusAppealStatus(input.Status),
+		CampusCode: uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		Offset:     int((page - 1) * size),
+		Limit:      int(size),
+	})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取申诉列表失败")
+	}
+	userIDs := make([]string, 0, len(appeals))
+	seen := map[string]struct{}{}
+	for _, appeal := range appeals {
+		appendUniqueUserID(&userIDs, seen, appeal.UserID)
+	}
+	if authors, err := uc.assembler.LoadAuthors(ctx, userIDs); err != nil {
+		uc.log.WithContext(ctx).Warnf("load appeal authors failed: %v", err)
+	} else {
+		for _, appeal := range appeals {
+			appeal.User = authors[appeal.UserID]
+		}
+	}
+	return &ListCampusAppealsOutput{Appeals: appeals, Total: total}, nil
+}
+
+// 先按 pending 条件改状态占住申诉，恢复内容失败时再退回 pending，避免两个运营同时处理出现相反结论
+func (uc *CampusUsecase) AdminReviewAppeal(ctx context.Context, input *ReviewCampusAppealInput) (*CampusModerationAppeal, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	ok, appeal, err := uc.repo.GetModerationAppeal(ctx, input.AppealID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询申诉失败")
+	}
+	if !ok || appeal == nil {
+		return nil, apperror.NotFound("申诉不存在")
+	}
+	if err := uc.ensureOperatorCampus(ctx, input.UserID, appeal.CampusCode); err != nil {
+		return nil, err
+	}
+	var status string
+	switch strings.TrimSpace(strings.ToLower(input.Action)) {
+	case "restore", "approve":
+		status = CampusAppealStatusRestored
+	case "confirm", "reject":
+		status = CampusAppealStatusConfirmed
+	default:
+		return nil, apperror.InvalidArgument("申诉处理动作无效")
+	}
+	note := strings.TrimSpace(input.Note)
+	if len([]rune(note)) > 200 {
+		return nil, apperror.InvalidArgument("处理说明不能超过 200 个字")
+	}
+	if appeal.Status != CampusAppealStatusPending {
+		return nil, apperror.InvalidArgument("申诉已处理")
+	}
+	resolved, err := uc.repo.UpdateModerationAppealStatus(ctx, appeal.ID, CampusAppealStatusPending, status, input.UserID, note)
+	if err != nil {
+		return nil, apperror.Internal(err, "处理申诉失败")
+	}
+	if !resolved {
+		return nil, apperror.InvalidArgument("申诉已处理")
+	}
+	label := campusAppealTargetLabel(appeal.TargetType)
+	linkPage, linkParams := "my-posts", map[string]string{}
+	var title, content string
+	if status == CampusAppealStatusRestored {
+		if err := uc.ReviewContent(ctx, &ReviewCampusContentInput{
+			UserID:     input.UserID,
+			TargetType: appeal.TargetType,
+			TargetID:   appeal.TargetID,
+			Action:     "approve",
+			Reason:     trimLimit("申诉通过："+firstNonEmpty(note, "复核后恢复"), 255),
+		}); err != nil {
+			if _, rollbackErr := uc.repo.UpdateModerationAppealStatus(ctx, appeal.ID, status, CampusAppealStatusPending, "0", ""); rollbackErr != nil {
+				uc.log.WithContext(ctx).Warnf("reopen appeal failed: appeal_id=%d err=%v", appeal.ID, rollbackErr)
+			}
+			return nil, err
+		}
+		title = "申诉通过，内容已恢复"
+		content = fmt.Sprintf("你申诉的%s经复核已恢复展示。", label)
+		linkPage, linkParams = "post-detail", map[string]string{"id": fmt.Sprintf("%d", appeal.PostID)}
+	} else {
+		_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+			ID:         uc.idGen.NextID(),
+			TargetType: appeal.TargetType,
+			TargetID:   appeal.TargetID,
+			UserID:     input.UserID,
+			Provider:   "appeal",
+			Result:     "appeal_confirm",
+			Reason:     trimLimit(firstNonEmpty(note, appeal.OriginalReason), 255),
+		})
+		title = "申诉未通过"
+		content = fmt.Sprintf("你申诉的%s经复核维持原处理。", label)
+	}
+	if note != "" {
+		content += "说明：" + note
+	}
+	uc.queueUserSystemNotification(ctx, appeal.UserID, "appeal", appeal.ID,
+		title,
+		content,
+		linkPage,
+		linkParams,
+		fmt.Sprintf("campus:appeal-result:%d", appeal.ID),
+	)
+	now := time.Now()
+	appeal.Status = status
+	appeal.ReviewerID = input.UserID
+	appeal.ReviewNote = note
+	appeal.ReviewedAt = &now
+	return appeal, nil
+}
+
+func appealSLASamples(appeals []*CampusModerationAppeal) []map[string]interface{} {
+	samples := make([]map[string]interface{}, 0, len(appeals))
+	for _, appeal := range appeals {
+		if appeal == nil {
+			continue
+		}
+		samples = append(samples, map[string]interface{}{
+			"id":     fmt.Sprintf("appeal:%d", appeal.ID),
+			"title":  fmt.Sprintf("%s申诉：%s", campusAppealTargetLabel(appeal.TargetType), trimLimit(firstNonEmpty(appeal.TargetExcerpt, fmt.Sprintf("%d", appeal.TargetID)), 80)),
+			"detail": trimLimit(appeal.Reason, 180),
+		})
+	}
+	return samples
+}
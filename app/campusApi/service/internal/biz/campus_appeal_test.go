@@ -0,0 +1,24 @@
+package biz
+
+import "testing"
+
+func TestCampusAppealable(t *testing.T) {
+	cases := []struct {
+		status int32
+		reason string
+		want   bool
+	}{
+		{CampusAuditStatusRejected, "含联系方式", true},
+		{CampusAuditStatusDeleted, "举报处理下架", true},
+		{CampusAuditStatusDeleted, CampusAuditReasonUserDeleted, false},
+		{CampusAuditStatusDeleted, CampusAuditReasonPostDeleted, false},
+		{CampusAuditStatusDeleted, CampusAuditReasonParentCommentDeleted, false},
+		{CampusAuditStatusVisible, "", false},
+		{CampusAuditStatusPending, "等待人工审核", false},
+	}
+	for _, tc := range cases {
+		if got := campusAppealable(tc.status, tc.reason); got != tc.want {
+			t.Fatalf("campusAppealable(%d, %q) = %v, want %v", tc.status, tc.reason, got, tc.want)
+		}
+	}
+}
@@ -22,6 +22,14 @@ func NewCampusPostAssembler(repo CampusRepo, core CoreAdapter, logger log.Logger
 }
 
 func (a *CampusPostAssembler) HydratePosts(ctx context.Context, posts []*CampusForumPost, currentUserID string) error {
+	return a.hydratePosts(ctx, posts, currentUserID, false)
+}
+
+func (a *CampusPostAssembler) HydratePostsForOperator(ctx context.Context, posts []*CampusForumPost, currentUserID string) error {
+	return a.hydratePosts(ctx, posts, currentUserID, true)
+}
+
+func (a *CampusPostAssembler) hydratePosts(ctx context.Context, posts []*CampusForumPost, currentUserID string, reveal bool) error {
 	if len(posts) == 0 {
 		return nil
 	}
@@ -53,10 +61,26 @@ func (a *CampusPostAssembler) HydratePosts(ctx context.Context, posts []*CampusF
 		post.IsLiked = likeStatus[post.ID]
 		post.IsCollected = collectionStatus[post.ID]
 	}
+	a.hydratePolls(ctx, posts, currentUserID)
+	a.hydrateLostItems(ctx, posts)
+	a.hydrateQuestions(ctx, posts)
+	a.hydrateClubEvents(ctx, posts, currentUserID)
+	a.hydrateTags(ctx, posts)
+	if !reveal {
+		a.maskAnonymousPosts(posts)
+	}
 	return nil
 }
 
 func (a *CampusPostAssembler) HydrateComments(ctx context.Context, comments []*CampusForumComment, currentUserID string) error {
+	return a.hydrateComments(ctx, comments, currentUserID, false)
+}
+
+func (a *CampusPostAssembler) HydrateCommentsForOperator(ctx context.Context, comments []*CampusForumComment, currentUserID string) error {
+	return a.hydrateComments(ctx, comments, currentUserID, true)
+}
+
+func (a *CampusPostAssembler) hydrateComments(ctx context.Context, comments []*CampusForumComment, currentUserID string, reveal bool) error {
 	if len(comments) == 0 {
 		return nil
 	}
@@ -90,21 +114,25 @@ func (a *CampusPostAssembler) HydrateComments(ctx context.Context, comments []*C
 		}
 		comment.IsLiked = likeStatus[comment.ID]
 	}
+	if !reveal {
+		a.maskAnonymousComments(ctx, flat)
+	}
 	return nil
 }
 
-func (a *CampusPostAssembler) FillPreviewReplies(ctx context.Context, comments []*CampusForumComment, currentUserID string) error {
+func (a *CampusPostAssembler) FillPreviewReplies(ctx context.Context, comments []*CampusForumComment, currentUserID string, excludeAuthorIDs []string) error {
 	for _, comment := range comments {
 		if comment == nil || comment.ID <= 0 || comment.ReplyCount <= 0 {
 			continue
 		}
 		parentID := comment.ID
 		replies, _, err := a.repo.ListComments(ctx, ListCampusCommentQuery{
-			PostID:   comment.PostID,
-			ParentID: &parentID,
-			Statuses: []int32{CampusAuditStatusVisible},
-			Offset:   0,
-			Limit:    2,
+			PostID:           comment.PostID,
+			ParentID:         &parentID,
+			Statuses:         []int32{CampusAuditStatusVisible},
+			ExcludeAuthorIDs: excludeAuthorIDs,
+			Offset:           0,
+			Limit:            2,
 		})
 		if err != nil {
 			return err
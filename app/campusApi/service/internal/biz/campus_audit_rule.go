@@ -0,0 +1,685 @@
+package biz
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"lehu-video/pkg/apperror"
+	"lehu-video/pkg/textmatch"
+)
+
+const (
+	campusOpsSettingAuditRuleCategories = "audit_rule_categories"
+
+	CampusAuditRuleTypeKeyword = "keyword"
+	CampusAuditRuleTypeRegex   = "regex"
+	// 关键词和正文都转成拼音再匹配，能抓到拼音和同音字替换，误伤也更多，上线前先试运行
+	CampusAuditRuleTypePinyin = "pinyin"
+
+	campusAuditRuleCategoryHighRisk = "high_risk"
+	campusAuditRuleCategoryReview   = "review"
+
+	campusAuditRuleDefaultScore = 10
+	campusAuditRuleCacheTTL     = 30 * time.Second
+)
+
+type CampusAuditRule struct {
+	ID        int64
+	Name      string
+	Category  string
+	RuleType  string
+	Patterns  []string
+	Score     int32
+	Enabled   bool
+	HitCount  int64
+	LastHitAt *time.Time
+	CreatedBy string
+	UpdatedBy string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CampusAuditRuleCategory struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Threshold int32  `json:"threshold"`
+	Action    string `json:"action"`
+	RiskLevel string `json:"risk_level"`
+}
+
+type CampusAuditRuleHit struct {
+	RuleID   int64
+	RuleName string
+	Category string
+	Matched  string
+	Score    int32
+}
+
+type CampusAuditRuleEvaluation struct {
+	Decision  string
+	RiskLevel string
+	Category  string
+	Reason    string
+	Scores    map[string]int32
+	Hits      []CampusAuditRuleHit
+}
+
+type CampusAuditRuleConfig struct {
+	Rules      []*CampusAuditRule
+	Categories []*CampusAuditRuleCategory
+}
+
+type SaveCampusAuditRuleInput struct {
+	UserID   string
+	ID       int64
+	Name     string
+	Category string
+	RuleType string
+	Patterns string
+	Score    int32
+	Enabled  bool
+}
+
+type UpdateCampusAuditRuleCategoriesInput struct {
+	UserID     string
+	Categories []*CampusAuditRuleCategory
+}
+
+type DryRunCampusAuditRulesInput struct {
+	UserID     string
+	CampusCode string
+	Limit      int
+	Draft      *SaveCampusAuditRuleInput
+}
+
+type CampusAuditRuleDryRunItem struct {
+	PostID    int64
+	Title     string
+	Status    int32
+	Decision  string
+	RiskLevel string
+	Reason    string
+	Hits      []CampusAuditRuleHit
+}
+
+type CampusAuditRuleHitStat struct {
+	RuleID   int64
+	RuleName string
+	Category string
+	Count    int64
+}
+
+type CampusAuditRuleDryRun struct {
+	Scanned  int
+	Flagged  int
+	Rejected int
+	Items    []*CampusAuditRuleDryRunItem
+	RuleHits []*CampusAuditRuleHitStat
+}
+
+type campusAuditRuleCache struct {
+	mu       sync.Mutex
+	set      *campusAuditRuleSet
+	loadedAt time.Time
+}
+
+type campusAuditRegex struct {
+	rule    int
+	pattern string
+	re      *regexp.Regexp
+}
+
+type campusAuditRuleSet struct {
+	rules         []*CampusAuditRule
+	categories    map[string]*CampusAuditRuleCategory
+	matcher       *textmatch.Matcher
+	keywordRules  []int
+	pinyinMatcher *textmatch.Matcher
+	pinyinRules   []int
+	pinyinWords   []string
+	regexps       []campusAuditRegex
+}
+
+func defaultCampusAuditRuleCategories() []*CampusAuditRuleCategory {
+	return []*CampusAuditRuleCategory{
+		{Code: campusAuditRuleCategoryHighRisk, Name: "高风险", Threshold: campusAuditRuleDefaultScore, Action: CampusAIContentAuditDecisionReview, RiskLevel: "high"},
+		{Code: campusAuditRuleCategoryReview, Name: "需人工确认", Threshold: campusAuditRuleDefaultScore, Action: CampusAIContentAuditDecisionReview, RiskLevel: "medium"},
+		{Code: "contact", Name: "站外引流", Threshold: 2 * campusAuditRuleDefaultScore, Action: CampusAIContentAuditDecisionReview, RiskLevel: "medium"},
+	}
+}
+
+func normalizeCampusAuditRuleAction(action string) string {
+	switch action = strings.ToLower(strings.TrimSpace(action)); action {
+	case CampusAIContentAuditDecisionPass, CampusAIContentAuditDecisionReview, CampusAIContentAuditDecisionReject:
+		return action
+	default:
+		return ""
+	}
+}
+
+func campusAuditRuleActionRank(action string) int {
+	switch action {
+	case CampusAIContentAuditDecisionReject:
+		return 2
+	case CampusAIContentAuditDecisionReview:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func campusAuditRiskRank(level string) int {
+	switch level {
+	case "high":
+		return 2
+	case "medium":
+		return 1
+	default:
+		return 0
+	}
+}
+
+func normalizeCampusAuditRuleCategories(items []*CampusAuditRuleCategory) ([]*CampusAuditRuleCategory, error) {
+	out := make([]*CampusAuditRuleCategory, 0, len(items))
+	seen := map[string]bool{}
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+		code := strings.ToLower(strings.TrimSpace(item.Code))
+		if code == "" {
+			continue
+		}
+		if len(code) > 32 {
+			return nil, apperror.InvalidArgument("分类编码不能超过 32 个字符")
+		}
+		if seen[code] {
+			return nil, apperror.InvalidArgument("分类编码重复：" + code)
+		}
+		seen[code] = true
+		action := normalizeCampusAuditRuleAction(item.Action)
+		if action == "" {
+			return nil, apperror.InvalidArgument("分类处理动作只能是 pass、review 或 reject")
+		}
+		risk := normalizeAIContentAuditRiskLevel(item.RiskLevel)
+		threshold := item.Threshold
+		if threshold <= 0 {
+			threshold = campusAuditRuleDefaultScore
+		}
+		out = append(out, &CampusAuditRuleCategory{
+			Code:      code,
+			Name:      trimLimit(firstNonEmpty(strings.TrimSpace(item.Name), code), 32),
+			Threshold: threshold,
+			Action:    action,
+			RiskLevel: risk,
+		})
+	}
+	if len(out) == 0 {
+		return nil, apperror.InvalidArgument("至少需要一个规则分类")
+	}
+	if len(out) > 12 {
+		return nil, apperror.InvalidArgument("规则分类不能超过 12 个")
+	}
+	return out, nil
+}
+
+func (uc *CampusUsecase) auditRuleCategories(ctx context.Context) []*CampusAuditRuleCategory {
+	value := uc.stringOpsSetting(ctx, campusOpsSettingAuditRuleCategories, "CAMPUS_AUDIT_RULE_CATEGORIES", "")
+	if strings.TrimSpace(value) == "" {
+		return defaultCampusAuditRuleCategories()
+	}
+	var items []*CampusAuditRuleCategory
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		uc.log.WithContext(ctx).Warnf("parse campus audit rule categories failed: %v", err)
+		return defaultCampusAuditRuleCategories()
+	}
+	categories, err := normalizeCampusAuditRuleCategories(items)
+	if err != nil {
+		return defaultCampusAuditRuleCategories()
+	}
+	return categories
+}
+
+func splitCampusAuditRulePatterns(ruleType, value string) []string {
+	separators := "\n"
+	limit := 20
+	if ruleType != CampusAuditRuleTypeRegex {
+		separators, limit = ",，;；、\n", 200
+	}
+	out := make([]string, 0)
+	seen := map[string]bool{}
+	for _, item := range strings.FieldsFunc(value, func(r rune) bool { return strings.ContainsRune(separators, r) }) {
+		item = strings.TrimSpace(item)
+		key := item
+		switch ruleType {
+		case CampusAuditRuleTypeKeyword:
+			item = trimLimit(item, 32)
+			key = textmatch.Compact(item)
+		case CampusAuditRuleTypePinyin:
+			item = trimLimit(item, 32)
+			key = textmatch.Pinyin(item)
+		}
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, item)
+		if len(out) >= limit {
+			break
+		}
+	}
+	return out
+}
+
+func (uc *CampusUsecase) validateCampusAuditRule(ctx context.Context, input *SaveCampusAuditRuleInput) (*CampusAuditRule, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" || len([]rune(name)) > 64 {
+		return nil, apperror.InvalidArgument("规则名称需要 1-64 个字")
+	}
+	ruleType := strings.ToLower(strings.TrimSpace(input.RuleType))
+	if ruleType != CampusAuditRuleTypeKeyword && ruleType != CampusAuditRuleTypeRegex && ruleType != CampusAuditRuleTypePinyin {
+		return nil, apperror.InvalidArgument("规则类型只能是 keyword、pinyin 或 regex")
+	}
+	category := strings.ToLower(strings.TrimSpace(input.Category))
+	known := false
+	for _, item := range uc.auditRuleCategories(ctx) {
+		if item.Code == category {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return nil, apperror.InvalidArgument("规则分类不存在")
+	}
+	patterns := splitCampusAuditRulePatterns(ruleType, input.Patterns)
+	if len(patterns) == 0 {
+		return nil, apperror.InvalidArgument("请填写关键词或正则表达式")
+	}
+	if ruleType == CampusAuditRuleTypeRegex {
+		for _, pattern := range patterns {
+			if len([]rune(pattern)) > 200 {
+				return nil, apperror.InvalidArgument("正则表达式不能超过 200 个字符")
+			}
+			if _, err := regexp.Compile(pattern); err != nil {
+				return nil, apperror.InvalidArgument("正则表达式无效：" + pattern)
+			}
+		}
+	}
+	score := input.Score
+	if score <= 0 {
+		score = campusAuditRuleDefaultScore
+	}
+	if score > 100 {
+		score = 100
+	}
+	return &CampusAuditRule{
+		ID:        input.ID,
+		Name:      name,
+		Category:  category,
+		RuleType:  ruleType,
+		Patterns:  patterns,
+		Score:     score,
+		Enabled:   input.Enabled,
+		UpdatedBy: input.UserID,
+	}, nil
+}
+
+func newCampusAuditRuleSet(rules []*CampusAuditRule, categories []*CampusAuditRuleCategory) *campusAuditRuleSet {
+	set := &campusAuditRuleSet{categories: map[string]*CampusAuditRuleCategory{}}
+	for _, category := range categories {
+		set.categories[category.Code] = category
+	}
+	keywords := make([]string, 0)
+	pinyinKeywords := make([]string, 0)
+	for _, rule := range rules {
+		if rule == nil || !rule.Enabled {
+			continue
+		}
+		index := len(set.rules)
+		set.rules = append(set.rules, rule)
+		for _, pattern := range rule.Patterns {
+			switch rule.RuleType {
+			case CampusAuditRuleTypeKeyword:
+				if keyword := textmatch.Compact(pattern); keyword != "" {
+					keywords = append(keywords, keyword)
+					set.keywordRules = append(set.keywordRules, index)
+				}
+			case CampusAuditRuleTypePinyin:
+				if keyword := textmatch.Pinyin(pattern); keyword != "" {
+					pinyinKeywords = append(pinyinKeywords, keyword)
+					set.pinyinRules = append(set.pinyinRules, index)
+					set.pinyinWords = append(set.pinyinWords, pattern)
+				}
+			case CampusAuditRuleTypeRegex:
+				if re, err := regexp.Compile(pattern); err == nil {
+					set.regexps = append(set.regexps, campusAuditRegex{rule: index, pattern: pattern, re: re})
+				}
+			}
+		}
+	}
+	set.matcher = textmatch.NewMatcher(keywords)
+	if len(pinyinKeywords) > 0 {
+		set.pinyinMatcher = textmatch.NewMatcher(pinyinKeywords)
+	}
+	return set
+}
+
+func (s *campusAuditRuleSet) evaluate(text string) *CampusAuditRuleEvaluation {
+	out := &CampusAuditRuleEvaluation{Decision: CampusAIContentAuditDecisionPass, RiskLevel: "low", Scores: map[string]int32{}}
+	if s == nil {
+		return out
+	}
+	matched := map[int]bool{}
+	addHit := func(index int, value string) {
+		if matched[index] {
+			return
+		}
+		matched[index] = true
+		rule := s.rules[index]
+		out.Scores[rule.Category] += rule.Score
+		out.Hits = append(out.Hits, CampusAuditRuleHit{RuleID: rule.ID, RuleName: rule.Name, Category: rule.Category, Matched: trimLimit(value, 40), Score: rule.Score})
+	}
+	for _, index := range s.matcher.Match(textmatch.Compact(text)) {
+		addHit(s.keywordRules[index], s.matcher.Pattern(index))
+	}
+	if s.pinyinMatcher != nil {
+		for _, index := range s.pinyinMatcher.Match(textmatch.Pinyin(text)) {
+			addHit(s.pinyinRules[index], s.pinyinWords[index])
+		}
+	}
+	if len(s.regexps) > 0 {
+		plain := textmatch.Normalize(text)
+		for _, item := range s.regexps {
+			if matched[item.rule] {
+				continue
+			}
+			if value := item.re.FindString(plain); value != "" {
+				addHit(item.rule, value)
+			}
+		}
+	}
+	var chosen *CampusAuditRuleCategory
+	for code, score := range out.Scores {
+		category := s.categories[code]
+		if category == nil || score < category.Threshold || category.Action == CampusAIContentAuditDecisionPass {
+			continue
+		}
+		if chosen == nil ||
+			campusAuditRuleActionRank(category.Action) > campusAuditRuleActionRank(chosen.Action) ||
+			(category.Action == chosen.Action && campusAuditRiskRank(category.RiskLevel) > campusAuditRiskRank(chosen.RiskLevel)) ||
+			(category.Action == chosen.Action && category.RiskLevel == chosen.RiskLevel && score > out.Scores[chosen.Code]) {
+			chosen = category
+		}
+	}
+	if chosen == nil {
+		return out
+	}
+	out.Decision = chosen.Action
+	out.RiskLevel = chosen.RiskLevel
+	if chosen.Action == CampusAIContentAuditDecisionReject {
+		out.RiskLevel = "high"
+	}
+	out.Category = chosen.Code
+	words := make([]string, 0)
+	for _, hit := range out.Hits {
+		if hit.Category == chosen.Code {
+			words = append(words, hit.Matched)
+		}
+	}
+	out.Reason = fmt.Sprintf("命中%s规则：%s", chosen.Name, strings.Join(words, "、"))
+	return out
+}
+
+func classifyCampusPostByRuleSet(set *campusAuditRuleSet, title, content string) (campusContentRuleResult, *CampusAuditRuleEvaluation) {
+	evaluation := set.evaluate(title + "\n" + content)
+	if evaluation.Decision != CampusAIContentAuditDecisionPass {
+		evidence := make([]string, 0, len(evaluation.Hits))
+		for _, hit := range evaluation.Hits {
+			evidence = append(evidence, "rule:"+hit.RuleName+":"+hit.Matched)
+		}
+		return campusContentRuleResult{RiskLevel: evaluation.RiskLevel, Decision: evaluation.Decision, Reason: evaluation.Reason, Evidence: evidence}, evaluation
+	}
+	if len([]rune(strings.TrimSpace(title+content))) < 8 {
+		return campusContentRuleResult{RiskLevel: "medium", Decision: CampusAIContentAuditDecisionReview, Reason: "内容过短，语义不够明确", Evidence: []string{"too_short"}}, evaluation
+	}
+	return campusContentRuleResult{RiskLevel: "low", Decision: CampusAIContentAuditDecisionPass, Reason: "规则未发现明显风险", Evidence: []string{"rule_low_risk"}}, evaluation
+}
+
+func (uc *CampusUsecase) legacyAuditWordRules(ctx context.Context) []*CampusAuditRule {
+	rules := make([]*CampusAuditRule, 0, 2)
+	if words := uc.auditHighRiskWords(ctx); len(words) > 0 {
+		rules = append(rules, &CampusAuditRule{Name: "高风险关键词", Category: campusAuditRuleCategoryHighRisk, RuleType: CampusAuditRuleTypeKeyword, Patterns: words, Score: campusAuditRuleDefaultScore, Enabled: true})
+	}
+	if words := uc.auditReviewWords(ctx); len(words) > 0 {
+		rules = append(rules, &CampusAuditRule{Name: "需复核关键词", Category: campusAuditRuleCategoryReview, RuleType: CampusAuditRuleTypeKeyword, Patterns: words, Score: campusAuditRuleDefaultScore, Enabled: true})
+	}
+	return rules
+}
+
+func (uc *CampusUsecase) loadCampusAuditRules(ctx context.Context) []*CampusAuditRule {
+	rules, err := uc.repo.ListAuditRules(ctx)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus audit rules failed: %v", err)
+	}
+	return append(rules, uc.legacyAuditWordRules(ctx)...)
+}
+
+func (uc *CampusUsecase) auditRuleSet(ctx context.Context) *campusAuditRuleSet {
+	uc.auditRules.mu.Lock()
+	defer uc.auditRules.mu.Unlock()
+	if uc.auditRules.set != nil && time.Since(uc.auditRules.loadedAt) < campusAuditRuleCacheTTL {
+		return uc.auditRules.set
+	}
+	uc.auditRules.set = newCampusAuditRuleSet(uc.loadCampusAuditRules(ctx), uc.auditRuleCategories(ctx))
+	uc.auditRules.loadedAt = time.Now()
+	return uc.auditRules.set
+}
+
+func (uc *CampusUsecase) resetAuditRuleSet() {
+	uc.auditRules.mu.Lock()
+	uc.auditRules.set = nil
+	uc.auditRules.mu.Unlock()
+}
+
+func (uc *CampusUsecase) recordAuditRuleHits(ctx context.Context, evaluation *CampusAuditRuleEvaluation) {
+	if evaluation == nil {
+		return
+	}
+	ids := make([]int64, 0, len(evaluation.Hits))
+	for _, hit := range evaluation.Hits {
+		if hit.RuleID > 0 {
+			ids = append(ids, hit.RuleID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	if err := uc.repo.IncrAuditRuleHits(ctx, ids, time.Now()); err != nil {
+		uc.log.WithContext(ctx).Warnf("record campus audit rule hits failed: %v", err)
+	}
+}
+
+func (uc *CampusUsecase) classifyCampusPostByRules(ctx context.Context, title, content string) campusContentRuleResult {
+	result, evaluation := classifyCampusPostByRuleSet(uc.auditRuleSet(ctx), title, content)
+	uc.recordAuditRuleHits(ctx, evaluation)
+	return result
+}
+
+func (uc *CampusUsecase) evaluateCampusCommentRules(ctx context.Context, userID, content string) error {
+	evaluation := uc.auditRuleSet(ctx).evaluate(content)
+	uc.recordAuditRuleHits(ctx, evaluation)
+	if evaluation.Decision == CampusAIContentAuditDecisionReject && !uc.isCampusOperator(ctx, userID) {
+		return apperror.InvalidArgument("评论包含违规信息，请修改后再发布")
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) AdminGetAuditRules(ctx context.Context, userID string) (*CampusAuditRuleConfig, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	rules, err := uc.repo.ListAuditRules(ctx)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取审核规则失败")
+	}
+	return &CampusAuditRuleConfig{Rules: rules, Categories: uc.auditRuleCategories(ctx)}, nil
+}
+
+func (uc *CampusUsecase) AdminSaveAuditRule(ctx context.Context, input *SaveCampusAuditRuleInput) (*CampusAuditRule, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	rule, err := uc.validateCampusAuditRule(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if rule.ID > 0 {
+		ok, existing, err := uc.repo.GetAuditRule(ctx, rule.ID)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询审核规则失败")
+		}
+		if !ok {
+			return nil, apperror.NotFound("审核规则不存在")
+		}
+		rule.HitCount, rule.LastHitAt, rule.CreatedBy, rule.CreatedAt = existing.HitCount, existing.LastHitAt, existing.CreatedBy, existing.CreatedAt
+		if err := uc.repo.UpdateAuditRule(ctx, rule); err != nil {
+			return nil, apperror.Internal(err, "保存审核规则失败")
+		}
+	} else {
+		rule.ID = uc.idGen.NextID()
+		rule.CreatedBy = input.UserID
+		if err := uc.repo.CreateAuditRule(ctx, rule); err != nil {
+			return nil, apperror.Internal(err, "保存审核规则失败")
+		}
+	}
+	uc.resetAuditRuleSet()
+	_, saved, err := uc.repo.GetAuditRule(ctx, rule.ID)
+	if err != nil || saved == nil {
+		return rule, nil
+	}
+	return saved, nil
+}
+
+func (uc *CampusUsecase) AdminDeleteAuditRule(ctx context.Context, userID string, id int64) error {
+	if !uc.isCampusOperator(ctx, userID) {
+		return apperror.Forbidden("没有后台权限")
+	}
+	if id <= 0 {
+		return apperror.InvalidArgument("规则 ID 无效")
+	}
+	ok, err := uc.repo.DeleteAuditRule(ctx, id)
+	if err != nil {
+		return apperror.Internal(err, "删除审核规则失败")
+	}
+	if !ok {
+		return apperror.NotFound("审核规则不存在")
+	}
+	uc.resetAuditRuleSet()
+	return nil
+}
+
+func (uc *CampusUsecase) AdminUpdateAuditRuleCategories(ctx context.Context, input *UpdateCampusAuditRuleCategoriesInput) ([]*CampusAuditRuleCategory, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	categories, err := normalizeCampusAuditRuleCategories(input.Categories)
+	if err != nil {
+		return nil, err
+	}
+	value, _ := json.Marshal(categories)
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingAuditRuleCategories, string(value), input.UserID); err != nil {
+		return nil, apperror.Internal(err, "保存规则分类失败")
+	}
+	uc.resetAuditRuleSet()
+	return categories, nil
+}
+
+func (uc *CampusUsecase) AdminDryRunAuditRules(ctx context.Context, input *DryRunCampusAuditRulesInput) (*CampusAuditRuleDryRun, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 500 {
+		limit = 500
+	}
+	rules := uc.loadCampusAuditRules(ctx)
+	if input.Draft != nil {
+		draft, err := uc.validateCampusAuditRule(ctx, input.Draft)
+		if err != nil {
+			return nil, err
+		}
+		draft.Enabled = true
+		if draft.ID > 0 {
+			for index, rule := range rules {
+				if rule.ID == draft.ID {
+					rules = append(rules[:index:index], rules[index+1:]...)
+					break
+				}
+			}
+		} else {
+			draft.Name = "[草稿] " + draft.Name
+		}
+		rules = append(rules, draft)
+	}
+	set := newCampusAuditRuleSet(rules, uc.auditRuleCategories(ctx))
+	posts, _, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
+		CampusCode:     uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		OnlyOwnCampus:  true,
+		Sort:           CampusPostSortNew,
+		Statuses:       []int32{CampusAuditStatusPending, CampusAuditStatusVisible, CampusAuditStatusRejected, CampusAuditStatusDeleted},
+		IncludeDeleted: true,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取近期帖子失败")
+	}
+	out := &CampusAuditRuleDryRun{Scanned: len(posts)}
+	stats := map[string]*CampusAuditRuleHitStat{}
+	for _, post := range posts {
+		result, evaluation := classifyCampusPostByRuleSet(set, post.Title, post.Content)
+		for _, hit := range evaluation.Hits {
+			key := fmt.Sprintf("%d:%s", hit.RuleID, hit.RuleName)
+			if stats[key] == nil {
+				stats[key] = &CampusAuditRuleHitStat{RuleID: hit.RuleID, RuleName: hit.RuleName, Category: hit.Category}
+			}
+			stats[key].Count++
+		}
+		if len(evaluation.Hits) == 0 {
+			continue
+		}
+		if result.Decision != CampusAIContentAuditDecisionPass {
+			out.Flagged++
+		}
+		if result.Decision == CampusAIContentAuditDecisionReject {
+			out.Rejected++
+		}
+		out.Items = append(out.Items, &CampusAuditRuleDryRunItem{
+			PostID:    post.ID,
+			Title:     post.Title,
+			Status:    post.Status,
+			Decision:  result.Decision,
+			RiskLevel: result.RiskLevel,
+			Reason:    result.Reason,
+			Hits:      evaluation.Hits,
+		})
+	}
+	for _, stat := range stats {
+		out.RuleHits = append(out.RuleHits, stat)
+	}
+	sort.Slice(out.RuleHits, func(i, j int) bool {
+		if out.RuleHits[i].Count != out.RuleHits[j].Count {
+			return out.RuleHits[i].Count > out.RuleHits[j].Count
+		}
+		return out.RuleHits[i].RuleName < out.RuleHits[j].RuleName
+	})
+	return out, nil
+}
@@ -23,19 +23,63 @@ func TestNormalizeAuditWordsSplitsDedupesAndFallsBack(t *testing.T) {
 	}
 }
 
-func TestClassifyCampusPostByWordsUsesConfiguredWords(t *testing.T) {
-	got := classifyCampusPostByWords("校园墙", "这里有暗号甲", []string{"暗号甲"}, []string{"暗号乙"})
+func TestClassifyCampusPostByRuleSetUsesCategoryPolicies(t *testing.T) {
+	categories := append(defaultCampusAuditRuleCategories(), &CampusAuditRuleCategory{Code: "fraud", Name: "诈骗", Threshold: 10, Action: CampusAIContentAuditDecisionReject, RiskLevel: "high"})
+	set := newCampusAuditRuleSet([]*CampusAuditRule{
+		{ID: 1, Name: "暗号", Category: campusAuditRuleCategoryHighRisk, RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"暗号甲", "anhao"}, Score: 10, Enabled: true},
+		{ID: 2, Name: "复核", Category: campusAuditRuleCategoryReview, RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"暗号乙"}, Score: 10, Enabled: true},
+		{ID: 3, Name: "手机号", Category: "contact", RuleType: CampusAuditRuleTypeRegex, Patterns: []string{`1[3-9]\d{9}`}, Score: 10, Enabled: true},
+		{ID: 4, Name: "微信", Category: "contact", RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"加微信"}, Score: 10, Enabled: true},
+		{ID: 5, Name: "刷单", Category: "fraud", RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"刷单返利"}, Score: 10, Enabled: true},
+		{ID: 6, Name: "停用", Category: "fraud", RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"食堂"}, Score: 10, Enabled: false},
+	}, categories)
+
+	got, _ := classifyCampusPostByRuleSet(set, "校园墙", "这里有暗 号 甲")
 	if got.RiskLevel != "high" || got.Decision != CampusAIContentAuditDecisionReview {
 		t.Fatalf("high risk result = %#v", got)
 	}
-
-	got = classifyCampusPostByWords("校园墙", "这里有暗号乙", []string{"暗号甲"}, []string{"暗号乙"})
-	if got.RiskLevel != "medium" {
+	got, _ = classifyCampusPostByRuleSet(set, "校园墙", "有人说 A-N-H-A-O 吗")
+	if got.RiskLevel != "high" {
+		t.Fatalf("pinyin variant result = %#v", got)
+	}
+	got, _ = classifyCampusPostByRuleSet(set, "校园墙", "这里有暗号乙")
+	if got.RiskLevel != "medium" || got.Decision != CampusAIContentAuditDecisionReview {
 		t.Fatalf("review result = %#v", got)
 	}
 
-	got = classifyCampusPostByWords("食堂新品", "二楼套餐味道还不错", []string{"暗号甲"}, []string{"暗号乙"})
+	got, evaluation := classifyCampusPostByRuleSet(set, "出闲置", "电话 13812345678 联系")
+	if got.Decision != CampusAIContentAuditDecisionPass || len(evaluation.Hits) != 1 {
+		t.Fatalf("single contact hit should stay under threshold: %#v %#v", got, evaluation)
+	}
+	got, _ = classifyCampusPostByRuleSet(set, "出闲置", "加薇信或打 13812345678")
+	if got.Decision != CampusAIContentAuditDecisionReview {
+		t.Fatalf("contact threshold result = %#v", got)
+	}
+
+	got, _ = classifyCampusPostByRuleSet(set, "兼职", "刷 单 返 利，日结")
+	if got.Decision != CampusAIContentAuditDecisionReject || got.RiskLevel != "high" {
+		t.Fatalf("reject result = %#v", got)
+	}
+
+	got, _ = classifyCampusPostByRuleSet(set, "食堂新品", "二楼套餐味道还不错")
 	if got.RiskLevel != "low" || got.Decision != CampusAIContentAuditDecisionPass {
 		t.Fatalf("low risk result = %#v", got)
 	}
 }
+
+func TestCampusAuditPinyinRuleMatchesPinyinAndHomophones(t *testing.T) {
+	set := newCampusAuditRuleSet([]*CampusAuditRule{
+		{ID: 1, Name: "赌博", Category: campusAuditRuleCategoryHighRisk, RuleType: CampusAuditRuleTypePinyin, Patterns: []string{"赌博"}, Score: 10, Enabled: true},
+		{ID: 2, Name: "代考", Category: campusAuditRuleCategoryHighRisk, RuleType: CampusAuditRuleTypeKeyword, Patterns: []string{"代考"}, Score: 10, Enabled: true},
+	}, defaultCampusAuditRuleCategories())
+
+	for _, text := range []string{"周末一起 du博", "D-U-B-O 来不来", "线上堵博"} {
+		evaluation := set.evaluate(text)
+		if len(evaluation.Hits) != 1 || evaluation.Hits[0].RuleID != 1 || evaluation.Hits[0].Matched != "赌博" {
+			t.Fatalf("pinyin rule should match %q, got %#v", text, evaluation.Hits)
+		}
+	}
+	if evaluation := set.evaluate("找人 dai考"); len(evaluation.Hits) != 0 {
+		t.Fatalf("keyword rule should not fold han to pinyin, got %#v", evaluation.Hits)
+	}
+}
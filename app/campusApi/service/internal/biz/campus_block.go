@@ -0,0 +1,187 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusUserBlockKindBlock = "block"
+	CampusUserBlockKindMute  = "mute"
+
+	campusHiddenAuthorsMax = 500
+)
+
+type CampusUserBlock struct {
+	UserID       string
+	TargetUserID string
+	Kind         string
+	CreatedAt    time.Time
+	Target       *CampusForumAuthor
+}
+
+type SetCampusUserBlockInput struct {
+	UserID       string
+	TargetUserID string
+	Kind         string
+}
+
+type ListCampusUserBlocksInput struct {
+	UserID string
+	Kind   string
+	Cursor string
+	Page   int32
+	Size   int32
+}
+
+type ListCampusUserBlocksOutput struct {
+	Blocks     []*CampusUserBlock
+	Total      int64
+	NextCursor string
+}
+
+func normalizeCampusUserBlockKind(kind string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "", CampusUserBlockKindBlock:
+		return CampusUserBlockKindBlock, nil
+	case CampusUserBlockKindMute:
+		return CampusUserBlockKindMute, nil
+	}
+	return "", apperror.InvalidArgument("屏蔽类型无效")
+}
+
+func (uc *CampusUsecase) BlockUser(ctx context.Context, input *SetCampusUserBlockInput) (*CampusUserBlock, error) {
+	kind, err := normalizeCampusUserBlockKind(input.Kind)
+	if err != nil {
+		return nil, err
+	}
+	userID, targetUserID, err := normalizeCampusUserBlockTarget(input.UserID, input.TargetUserID)
+	if err != nil {
+		return nil, err
+	}
+	block := &CampusUserBlock{UserID: userID, TargetUserID: targetUserID, Kind: kind, CreatedAt: campusLocalNow()}
+	if err := uc.repo.SetUserBlock(ctx, block); err != nil {
+		return nil, apperror.Internal(err, "屏蔽用户失败")
+	}
+	if kind == CampusUserBlockKindBlock {
+		for _, pair := range [][2]string{{userID, targetUserID}, {targetUserID, userID}} {
+			if _, err := uc.core.UnfollowUser(ctx, pair[0], pair[1]); err != nil {
+				uc.log.WithContext(ctx).Warnf("unfollow blocked campus user failed: user_id=%s target_id=%s err=%v", pair[0], pair[1], err)
+			}
+		}
+	}
+	return block, nil
+}
+
+func (uc *CampusUsecase) UnblockUser(ctx context.Context, input *SetCampusUserBlockInput) error {
+	kind, err := normalizeCampusUserBlockKind(input.Kind)
+	if err != nil {
+		return err
+	}
+	userID, targetUserID, err := normalizeCampusUserBlockTarget(input.UserID, input.TargetUserID)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteUserBlock(ctx, userID, targetUserID, kind); err != nil {
+		return apperror.Internal(err, "取消屏蔽失败")
+	}
+	return nil
+}
+
+func normalizeCampusUserBlockTarget(userID, targetUserID string) (string, string, error) {
+	userID, targetUserID = strings.TrimSpace(userID), strings.TrimSpace(targetUserID)
+	if userID == "" {
+		return "", "", apperror.Unauthorized("请先登录")
+	}
+	if parseInt64String(targetUserID) <= 0 {
+		return "", "", apperror.InvalidArgument("用户 ID 无效")
+	}
+	if userID == targetUserID {
+		return "", "", apperror.InvalidArgument("不能屏蔽自己")
+	}
+	return userID, targetUserID, nil
+}
+
+func (uc *CampusUsecase) ListUserBlocks(ctx context.Context, input *ListCampusUserBlocksInput) (*ListCampusUserBlocksOutput, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	kind, err := normalizeCampusUserBlockKind(input.Kind)
+	if err != nil {
+		return nil, err
+	}
+	scope := campusCursorScope("user_blocks", input.UserID, kind)
+	_, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	blocks, total, err := uc.repo.ListUserBlocks(ctx, input.UserID, kind, offset, limit)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取屏蔽列表失败")
+	}
+	userIDs := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		userIDs = append(userIDs, block.TargetUserID)
+	}
+	authors, err := uc.assembler.LoadAuthors(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load blocked campus users failed: %v", err)
+	}
+	for _, block := range blocks {
+		block.Target = authors[block.TargetUserID]
+	}
+	return &ListCampusUserBlocksOutput{
+		Blocks:     blocks,
+		Total:      total,
+		NextCursor: uc.offsetNextCursor(scope, offset, len(blocks), limit, total),
+	}, nil
+}
+
+func (uc *CampusUsecase) hiddenAuthorIDs(ctx context.Context, viewerID string) []string {
+	if strings.TrimSpace(viewerID) == "" {
+		return nil
+	}
+	ids, err := uc.repo.ListBlockedUserIDs(ctx, viewerID, campusHiddenAuthorsMax)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load hidden campus authors failed: user_id=%s err=%v", viewerID, err)
+		return nil
+	}
+	return ids
+}
+
+func filterHiddenAuthorPosts(posts []*CampusForumPost, hidden []string) []*CampusForumPost {
+	if len(hidden) == 0 {
+		return posts
+	}
+	hiddenSet := make(map[string]struct{}, len(hidden))
+	for _, id := range hidden {
+		hiddenSet[id] = struct{}{}
+	}
+	filtered := posts[:0]
+	for _, post := range posts {
+		if post == nil {
+			continue
+		}
+		if _, ok := hiddenSet[post.AuthorID]; !ok {
+			filtered = append(filtered, post)
+		}
+	}
+	return filtered
+}
+
+func (uc *CampusUsecase) ensureNotBlockedBy(ctx context.Context, ownerID, actorID string) error {
+	if ownerID == "" || ownerID == actorID {
+		return nil
+	}
+	kind, err := uc.repo.GetUserBlockKind(ctx, ownerID, actorID)
+	if err != nil {
+		return apperror.Internal(err, "查询屏蔽关系失败")
+	}
+	if kind == CampusUserBlockKindBlock {
+		return apperror.Forbidden("由于对方的隐私设置，你无法进行此操作")
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package biz
+
+import "testing"
+
+func TestNormalizeCampusUserBlockKind(t *testing.T) {
+	if kind, err := normalizeCampusUserBlockKind(""); err != nil || kind != CampusUserBlockKindBlock {
+		t.Fatalf("empty kind should default to block, got %q err=%v", kind, err)
+	}
+	if kind, err := normalizeCampusUserBlockKind(" MUTE "); err != nil || kind != CampusUserBlockKindMute {
+		t.Fatalf("unexpected mute kind %q err=%v", kind, err)
+	}
+	if _, err := normalizeCampusUserBlockKind("hide"); err == nil {
+		t.Fatal("unknown kind should fail")
+	}
+	if _, _, err := normalizeCampusUserBlockTarget("1", "1"); err == nil {
+		t.Fatal("blocking yourself should fail")
+	}
+}
+
+func TestFilterHiddenAuthorPosts(t *testing.T) {
+	posts := []*CampusForumPost{{ID: 1, AuthorID: "1"}, {ID: 2, AuthorID: "2"}, nil, {ID: 3, AuthorID: "3"}}
+	filtered := filterHiddenAuthorPosts(posts, []string{"2"})
+	if len(filtered) != 2 || filtered[0].ID != 1 || filtered[1].ID != 3 {
+		t.Fatalf("unexpected filtered posts %+v", filtered)
+	}
+}
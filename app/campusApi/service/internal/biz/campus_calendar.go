@@ -0,0 +1,470 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusCalendarTypeExam       = "exam"
+	CampusCalendarTypeMakeupExam = "makeup_exam"
+	CampusCalendarTypeHoliday    = "holiday"
+	CampusCalendarTypeEvent      = "event"
+	CampusCalendarTypeTeaching   = "teaching"
+
+	CampusCalendarStatusHidden    int32 = 0
+	CampusCalendarStatusPublished int32 = 1
+
+	campusTermMaxWeeks          = 25
+	campusCalendarDefaultDays   = 90
+	campusCalendarMaxRangeDays  = 400
+	campusCalendarEzaiLookahead = 60
+	campusCalendarEzaiMaxItems  = 6
+)
+
+var campusCalendarIntentWords = []string{"考试", "补考", "重修", "缓考", "期末", "期中", "放假", "假期", "节假日", "调休", "开学", "校历", "第几周", "教学周", "活动", "什么时候", "几号", "哪天"}
+
+type CampusCalendarEvent struct {
+	ID          int64
+	CampusCode  string
+	Term        string
+	EventType   string
+	Title       string
+	Description string
+	Location    string
+	StartAt     time.Time
+	EndAt       time.Time
+	AllDay      bool
+	Status      int32
+	CreatedBy   string
+	UpdatedBy   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CampusTermWeek struct {
+	Term      string
+	Week      int32
+	TermStart time.Time
+	InTerm    bool
+}
+
+type ListCampusCalendarEventQuery struct {
+	CampusCode    string
+	Term          string
+	EventType     string
+	From          *time.Time
+	To            *time.Time
+	OnlyPublished bool
+	Offset        int
+	Limit         int
+}
+
+type ListCampusCalendarInput struct {
+	CurrentUserID string
+	CampusCode    string
+	EventType     string
+	From          *time.Time
+	To            *time.Time
+}
+
+type ListCampusCalendarOutput struct {
+	CampusCode string
+	TermWeek   *CampusTermWeek
+	Events     []*CampusCalendarEvent
+}
+
+type ListCampusAdminCalendarInput struct {
+	UserID     string
+	CampusCode string
+	Term       string
+	EventType  string
+	From       *time.Time
+	To         *time.Time
+	Page       int32
+	Size       int32
+}
+
+type ListCampusAdminCalendarOutput struct {
+	Events []*CampusCalendarEvent
+	Total  int64
+}
+
+type SaveCampusCalendarEventInput struct {
+	UserID      string
+	EventID     int64
+	CampusCode  string
+	Term        string
+	EventType   string
+	Title       string
+	Description string
+	Location    string
+	StartAt     *time.Time
+	EndAt       *time.Time
+	AllDay      bool
+	Hidden      bool
+}
+
+type DeleteCampusCalendarEventInput struct {
+	UserID  string
+	EventID int64
+}
+
+func (uc *CampusUsecase) ListCalendar(ctx context.Context, input *ListCampusCalendarInput) (*ListCampusCalendarOutput, error) {
+	campusCode := uc.resolveCampusCode(ctx, input.CurrentUserID, input.CampusCode)
+	from, to := normalizeCampusCalendarRange(input.From, input.To)
+	events, _, err := uc.repo.ListCalendarEvents(ctx, ListCampusCalendarEventQuery{
+		CampusCode:    campusCode,
+		EventType:     normalizeCampusCalendarType(input.EventType),
+		From:          &from,
+		To:            &to,
+		OnlyPublished: true,
+		Limit:         200,
+	})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取校历失败")
+	}
+	return &ListCampusCalendarOutput{
+		CampusCode: campusCode,
+		TermWeek:   uc.CampusTermWeekAt(ctx, campusCode, campusLocalNow()),
+		Events:     events,
+	}, nil
+}
+
+func (uc *CampusUsecase) AdminListCalendar(ctx context.Context, input *ListCampusAdminCalendarInput) (*ListCampusAdminCalendarOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	events, total, err := uc.repo.ListCalendarEvents(ctx, ListCampusCalendarEventQuery{
+		CampusCode: uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		Term:       strings.TrimSpace(input.Term),
+		EventType:  normalizeCampusCalendarType(input.EventType),
+		From:       input.From,
+		To:         input.To,
+		Offset:     int((page - 1) * size),
+		Limit:      int(size),
+	})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取校历失败")
+	}
+	return &ListCampusAdminCalendarOutput{Events: events, Total: total}, nil
+}
+
+func (uc *CampusUsecase) AdminCreateCalendarEvent(ctx context.Context, input *SaveCampusCalendarEventInput) (*CampusCalendarEvent, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	event := &CampusCalendarEvent{
+		ID:        uc.idGen.NextID(),
+		CreatedBy: strings.TrimSpace(input.UserID),
+	}
+	if err := uc.applyCampusCalendarInput(ctx, event, input); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.CreateCalendarEvent(ctx, event); err != nil {
+		return nil, apperror.Internal(err, "创建校历事项失败")
+	}
+	return event, nil
+}
+
+func (uc *CampusUsecase) AdminUpdateCalendarEvent(ctx context.Context, input *SaveCampusCalendarEventInput) (*CampusCalendarEvent, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	event, err := uc.getManagedCalendarEvent(ctx, input.UserID, input.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.applyCampusCalendarInput(ctx, event, input); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateCalendarEvent(ctx, event); err != nil {
+		return nil, apperror.Internal(err, "更新校历事项失败")
+	}
+	return event, nil
+}
+
+func (uc *CampusUsecase) AdminDeleteCalendarEvent(ctx context.Context, input *DeleteCampusCalendarEventInput) error {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return apperror.Forbidden("没有后台权限")
+	}
+	if _, err := uc.getManagedCalendarEvent(ctx, input.UserID, input.EventID); err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteCalendarEvent(ctx, input.EventID, input.UserID); err != nil {
+		return apperror.Internal(err, "删除校历事项失败")
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) CampusTermWeekAt(ctx context.Context, campusCode string, at time.Time) *CampusTermWeek {
+	return campusTermWeekAt(uc.timetableCalendarConfig(ctx, campusCode).TermStarts, at)
+}
+
+func (uc *CampusUsecase) getManagedCalendarEvent(ctx context.Context, userID string, eventID int64) (*CampusCalendarEvent, error) {
+	if eventID <= 0 {
+		return nil, apperror.InvalidArgument("校历事项 ID 无效")
+	}
+	ok, event, err := uc.repo.GetCalendarEvent(ctx, eventID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询校历事项失败")
+	}
+	if !ok || event == nil {
+		return nil, apperror.NotFound("校历事项不存在")
+	}
+	if event.CampusCode != "" {
+		if err := uc.ensureOperatorCampus(ctx, userID, event.CampusCode); err != nil {
+			return nil, err
+		}
+	} else if uc.operatorCampusScope(ctx, userID, "") != "" {
+		return nil, apperror.Forbidden("全校区事项仅管理员可修改")
+	}
+	return event, nil
+}
+
+func (uc *CampusUsecase) applyCampusCalendarInput(ctx context.Context, event *CampusCalendarEvent, input *SaveCampusCalendarEventInput) error {
+	title := trimLimit(input.Title, 120)
+	if len([]rune(title)) < 2 {
+		return apperror.InvalidArgument("标题至少 2 个字")
+	}
+	eventType := normalizeCampusCalendarType(input.EventType)
+	if eventType == "" {
+		return apperror.InvalidArgument("事项类型无效")
+	}
+	if input.StartAt == nil {
+		return apperror.InvalidArgument("请选择开始时间")
+	}
+	startAt := *input.StartAt
+	endAt := startAt
+	if input.EndAt != nil {
+		endAt = *input.EndAt
+	}
+	if input.AllDay {
+		startAt = time.Date(startAt.Year(), startAt.Month(), startAt.Day(), 0, 0, 0, 0, startAt.Location())
+		endAt = time.Date(endAt.Year(), endAt.Month(), endAt.Day(), 23, 59, 59, 0, endAt.Location())
+	}
+	if endAt.Before(startAt) {
+		return apperror.InvalidArgument("结束时间不能早于开始时间")
+	}
+	campusCode := normalizeCampusCode(input.CampusCode)
+	if uc.isCampusAdmin(ctx, input.UserID) {
+		if campusCode != "" && !campusCodeAllowed(campusCode) {
+			return apperror.InvalidArgument("校区暂未开放")
+		}
+	} else {
+		campusCode = firstNonEmpty(uc.operatorCampusScope(ctx, input.UserID, ""), defaultCampusCode())
+	}
+	term := strings.TrimSpace(input.Term)
+	if term != "" && !campusTermValid(term) {
+		return apperror.InvalidArgument("学期格式应为 2025-2026-1")
+	}
+	if term == "" {
+		if week := uc.CampusTermWeekAt(ctx, firstNonEmpty(campusCode, defaultCampusCode()), startAt); week != nil {
+			term = week.Term
+		}
+	}
+	event.CampusCode = campusCode
+	event.Term = term
+	event.EventType = eventType
+	event.Title = title
+	event.Description = trimLimit(input.Description, 1000)
+	event.Location = trimLimit(input.Location, 120)
+	event.StartAt = startAt
+	event.EndAt = endAt
+	event.AllDay = input.AllDay
+	event.Status = CampusCalendarStatusPublished
+	if input.Hidden {
+		event.Status = CampusCalendarStatusHidden
+	}
+	event.UpdatedBy = strings.TrimSpace(input.UserID)
+	return nil
+}
+
+func (uc *CampusUsecase) ezaiCalendarContext(ctx context.Context, campusCode, query string) (*CampusTermWeek, []*CampusCalendarEvent) {
+	campusCode = firstNonEmpty(normalizeCampusCode(campusCode), defaultCampusCode())
+	now := campusLocalNow()
+	termWeek := uc.CampusTermWeekAt(ctx, campusCode, now)
+	if !campusCalendarQueryIntent(query) {
+		return termWeek, nil
+	}
+	from := now.AddDate(0, 0, -1)
+	to := now.AddDate(0, 0, campusCalendarEzaiLookahead)
+	events, _, err := uc.repo.ListCalendarEvents(ctx, ListCampusCalendarEventQuery{
+		CampusCode:    campusCode,
+		From:          &from,
+		To:            &to,
+		OnlyPublished: true,
+		Limit:         50,
+	})
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load ezai calendar context failed: campus=%s err=%v", campusCode, err)
+		return termWeek, nil
+	}
+	return termWeek, selectEzaiCalendarEvents(query, events)
+}
+
+func campusTermWeekAt(termStarts map[string]string, at time.Time) *CampusTermWeek {
+	var best *CampusTermWeek
+	for term, value := range termStarts {
+		start, err := time.ParseInLocation("2006-01-02", value, at.Location())
+		if err != nil {
+			continue
+		}
+		start = campusWeekMonday(start)
+		if start.After(at) || (best != nil && !start.After(best.TermStart)) {
+			continue
+		}
+		best = &CampusTermWeek{Term: term, TermStart: start}
+	}
+	if best == nil {
+		return nil
+	}
+	best.Week = int32(at.Sub(best.TermStart).Hours()/24/7) + 1
+	best.InTerm = best.Week <= campusTermMaxWeeks
+	return best
+}
+
+func campusCalendarQueryIntent(query string) bool {
+	for _, word := range campusCalendarIntentWords {
+		if strings.Contains(query, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func selectEzaiCalendarEvents(query string, events []*CampusCalendarEvent) []*CampusCalendarEvent {
+	type scored struct {
+		event *CampusCalendarEvent
+		score int
+	}
+	items := make([]scored, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		score := 0
+		for _, runeWord := range campusCalendarKeywords(event) {
+			if strings.Contains(query, runeWord) {
+				score += 2
+			}
+		}
+		if strings.Contains(query, "考") && (event.EventType == CampusCalendarTypeExam || event.EventType == CampusCalendarTypeMakeupExam) {
+			score++
+		}
+		if strings.Contains(query, "假") && event.EventType == CampusCalendarTypeHoliday {
+			score++
+		}
+		items = append(items, scored{event: event, score: score})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].score != items[j].score {
+			return items[i].score > items[j].score
+		}
+		return items[i].event.StartAt.Before(items[j].event.StartAt)
+	})
+	out := make([]*CampusCalendarEvent, 0, campusCalendarEzaiMaxItems)
+	for _, item := range items {
+		if len(out) >= campusCalendarEzaiMaxItems {
+			break
+		}
+		out = append(out, item.event)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].StartAt.Before(out[j].StartAt) })
+	return out
+}
+
+func campusCalendarKeywords(event *CampusCalendarEvent) []string {
+	words := []string{campusCalendarTypeLabel(event.EventType)}
+	for _, field := range strings.FieldsFunc(event.Title, func(r rune) bool {
+		return r == ' ' || r == '/' || r == '、' || r == '，' || r == ',' || r == '（' || r == '）' || r == '(' || r == ')'
+	}) {
+		if len([]rune(field)) >= 2 {
+			words = append(words, field)
+		}
+	}
+	return words
+}
+
+func buildEzaiCalendarContext(termWeek *CampusTermWeek, events []*CampusCalendarEvent) string {
+	var builder strings.Builder
+	for i, event := range events {
+		when := event.StartAt.Format("2006-01-02 15:04")
+		if event.AllDay {
+			when = event.StartAt.Format("2006-01-02")
+			if event.EndAt.Format("2006-01-02") != when {
+				when += " 至 " + event.EndAt.Format("2006-01-02")
+			}
+		} else if event.EndAt.After(event.StartAt) {
+			when += " 至 " + event.EndAt.Format("2006-01-02 15:04")
+		}
+		line := fmt.Sprintf("[校历%d] %s：%s；时间：%s", i+1, campusCalendarTypeLabel(event.EventType), trimLimit(event.Title, 80), when)
+		if event.Location != "" {
+			line += "；地点：" + trimLimit(event.Location, 60)
+		}
+		if event.Description != "" {
+			line += "；说明：" + trimLimit(event.Description, 160)
+		}
+		if termWeek != nil && termWeek.InTerm && !event.StartAt.Before(termWeek.TermStart) {
+			line += fmt.Sprintf("；教学周：第 %d 周", int32(event.StartAt.Sub(termWeek.TermStart).Hours()/24/7)+1)
+		}
+		builder.WriteString(line + "\n")
+	}
+	return strings.TrimSpace(builder.String())
+}
+
+func campusCalendarTypeLabel(eventType string) string {
+	switch eventType {
+	case CampusCalendarTypeExam:
+		return "考试"
+	case CampusCalendarTypeMakeupExam:
+		return "补考"
+	case CampusCalendarTypeHoliday:
+		return "放假"
+	case CampusCalendarTypeEvent:
+		return "活动"
+	case CampusCalendarTypeTeaching:
+		return "教学安排"
+	default:
+		return "校历"
+	}
+}
+
+func normalizeCampusCalendarType(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case CampusCalendarTypeExam:
+		return CampusCalendarTypeExam
+	case CampusCalendarTypeMakeupExam, "makeup":
+		return CampusCalendarTypeMakeupExam
+	case CampusCalendarTypeHoliday:
+		return CampusCalendarTypeHoliday
+	case CampusCalendarTypeEvent:
+		return CampusCalendarTypeEvent
+	case CampusCalendarTypeTeaching:
+		return CampusCalendarTypeTeaching
+	default:
+		return ""
+	}
+}
+
+func normalizeCampusCalendarRange(from, to *time.Time) (time.Time, time.Time) {
+	now := campusLocalNow()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if from != nil {
+		start = *from
+	}
+	end := start.AddDate(0, 0, campusCalendarDefaultDays)
+	if to != nil && to.After(start) {
+		end = *to
+	}
+	if end.Sub(start) > campusCalendarMaxRangeDays*24*time.Hour {
+		end = start.AddDate(0, 0, campusCalendarMaxRangeDays)
+	}
+	return start, end
+}
@@ -0,0 +1,50 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCampusTermWeekAtPicksLatestStartedTerm(t *testing.T) {
+	loc := time.FixedZone("Asia/Shanghai", 8*60*60)
+	terms := map[string]string{
+		"2025-2026-1": "2025-09-01",
+		"2025-2026-2": "2026-02-25",
+	}
+	week := campusTermWeekAt(terms, time.Date(2026, 3, 10, 9, 0, 0, 0, loc))
+	if week == nil || week.Term != "2025-2026-2" || week.Week != 3 || !week.InTerm {
+		t.Fatalf("week = %+v", week)
+	}
+	if got := campusTermWeekAt(terms, time.Date(2025, 8, 1, 0, 0, 0, 0, loc)); got != nil {
+		t.Fatalf("date before any term should have no week, got %+v", got)
+	}
+	if got := campusTermWeekAt(terms, time.Date(2026, 9, 1, 0, 0, 0, 0, loc)); got == nil || got.InTerm {
+		t.Fatalf("summer after last term should be out of term, got %+v", got)
+	}
+}
+
+func TestEzaiCalendarContextPrefersMatchingEvents(t *testing.T) {
+	loc := time.FixedZone("Asia/Shanghai", 8*60*60)
+	base := time.Date(2026, 6, 1, 0, 0, 0, 0, loc)
+	events := []*CampusCalendarEvent{
+		{Title: "校园歌手大赛", EventType: CampusCalendarTypeEvent, StartAt: base, EndAt: base},
+		{Title: "高等数学期末考试", EventType: CampusCalendarTypeExam, StartAt: base.AddDate(0, 0, 20), EndAt: base.AddDate(0, 0, 20).Add(2 * time.Hour), Location: "A203"},
+		{Title: "端午节放假", EventType: CampusCalendarTypeHoliday, StartAt: base.AddDate(0, 0, 5), EndAt: base.AddDate(0, 0, 7), AllDay: true},
+	}
+	if campusCalendarQueryIntent("食堂几点开门") {
+		t.Fatalf("unrelated question should not pull calendar")
+	}
+	query := "高等数学期末考试什么时候"
+	if !campusCalendarQueryIntent(query) {
+		t.Fatalf("exam question should pull calendar")
+	}
+	selected := selectEzaiCalendarEvents(query, events)
+	text := buildEzaiCalendarContext(&CampusTermWeek{Term: "2025-2026-2", TermStart: base.AddDate(0, 0, -14*7), InTerm: true}, selected)
+	if !strings.Contains(text, "考试：高等数学期末考试") || !strings.Contains(text, "地点：A203") || !strings.Contains(text, "第 17 周") {
+		t.Fatalf("calendar context = %s", text)
+	}
+	if !strings.Contains(text, "2026-06-06 至 2026-06-08") {
+		t.Fatalf("all-day range not rendered: %s", text)
+	}
+}
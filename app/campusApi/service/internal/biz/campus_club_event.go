@@ -0,0 +1,490 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusClubRegistrationRegistered = "registered"
+	CampusClubRegistrationWaitlisted = "waitlisted"
+	CampusClubRegistrationCancelled  = "cancelled"
+
+	campusClubMaxCapacity      = 5000
+	campusClubMaxLeadTime      = 180 * 24 * time.Hour
+	campusClubCheckinOpensFor  = 2 * time.Hour
+	campusClubCheckinClosesFor = 12 * time.Hour
+	campusClubCheckinWindow    = 10 * time.Minute
+)
+
+type CampusClubEvent struct {
+	PostID          int64
+	CampusCode      string
+	OrganizerID     string
+	Capacity        int32
+	StartsAt        time.Time
+	SignupDeadline  time.Time
+	Place           string
+	CheckinCode     string
+	ReminderSentAt  *time.Time
+	RegisteredCount int64
+	WaitlistCount   int64
+	CheckedInCount  int64
+	MyRegistration  *CampusClubRegistration
+	CreatedAt       time.Time
+}
+
+func (e *CampusClubEvent) SignupClosed(now time.Time) bool {
+	return e == nil || !now.Before(e.SignupDeadline)
+}
+
+func (e *CampusClubEvent) Full() bool {
+	return e != nil && e.Capacity > 0 && e.RegisteredCount >= int64(e.Capacity)
+}
+
+type CampusClubRegistration struct {
+	PostID      int64
+	UserID      string
+	User        *CampusForumAuthor
+	Status      string
+	CheckedInAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type CampusClubEventInput struct {
+	Capacity       int32
+	StartsAt       *time.Time
+	SignupDeadline *time.Time
+	Place          string
+}
+
+type ClubEventActionInput struct {
+	UserID string
+	PostID int64
+	Code   string
+}
+
+type ListCampusClubRegistrationsInput struct {
+	UserID string
+	PostID int64
+	Status string
+	Page   int32
+	Size   int32
+}
+
+type ListCampusClubRegistrationsOutput struct {
+	Registrations []*CampusClubRegistration
+	Total         int64
+}
+
+type CampusClubAttendeeCSV struct {
+	Filename string
+	Content  []byte
+}
+
+func newCampusClubEvent(input *CampusClubEventInput, extra map[string]string, now time.Time) (*CampusClubEvent, error) {
+	if input == nil {
+		return nil, nil
+	}
+	if input.StartsAt == nil {
+		return nil, apperror.InvalidArgument("请填写活动时间")
+	}
+	startsAt := *input.StartsAt
+	if !startsAt.After(now) {
+		return nil, apperror.InvalidArgument("活动时间需要晚于现在")
+	}
+	if startsAt.Sub(now) > campusClubMaxLeadTime {
+		return nil, apperror.InvalidArgument("活动时间最多提前 180 天发布")
+	}
+	if input.Capacity < 0 || input.Capacity > campusClubMaxCapacity {
+		return nil, apperror.InvalidArgument(fmt.Sprintf("报名人数上限需要在 0-%d 之间，0 表示不限", campusClubMaxCapacity))
+	}
+	deadline := startsAt
+	if input.SignupDeadline != nil {
+		deadline = *input.SignupDeadline
+	}
+	if !deadline.After(now) || deadline.After(startsAt) {
+		return nil, apperror.InvalidArgument("报名截止时间需要在现在和活动开始之间")
+	}
+	code, err := newCampusClubCheckinCode()
+	if err != nil {
+		return nil, apperror.Internal(err, "生成签到码失败")
+	}
+	return &CampusClubEvent{
+		Capacity:       input.Capacity,
+		StartsAt:       startsAt,
+		SignupDeadline: deadline,
+		Place:          trimLimit(firstNonEmpty(strings.TrimSpace(input.Place), extra["activity_place"]), 80),
+		CheckinCode:    code,
+	}, nil
+}
+
+func newCampusClubCheckinCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+func campusClubCheckinMaxAttempts() int64 {
+	if limit := envInt64("CAMPUS_CLUB_CHECKIN_MAX_ATTEMPTS", 5); limit > 0 {
+		return limit
+	}
+	return 5
+}
+
+func campusClubReminderBefore() time.Duration {
+	if value := strings.TrimSpace(os.Getenv("CAMPUS_CLUB_REMINDER_BEFORE")); value == "0" || envBoolFalse(value) {
+		return 0
+	}
+	return envDurationBiz("CAMPUS_CLUB_REMINDER_BEFORE", 2*time.Hour)
+}
+
+func (a *CampusPostAssembler) hydrateClubEvents(ctx context.Context, posts []*CampusForumPost, currentUserID string) {
+	postIDs := make([]int64, 0)
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypeClub {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	events, err := a.repo.GetClubEvents(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load campus club events failed: %v", err)
+		return
+	}
+	registrations := map[int64]*CampusClubRegistration{}
+	if currentUserID != "" && currentUserID != "0" {
+		if registrations, err = a.repo.GetClubRegistrations(ctx, currentUserID, postIDs); err != nil {
+			a.log.WithContext(ctx).Warnf("load campus club registrations failed: %v", err)
+		}
+	}
+	for _, post := range posts {
+		if post == nil || post.PostType != CampusPostTypeClub {
+			continue
+		}
+		post.ClubEvent = events[post.ID]
+		if post.ClubEvent == nil {
+			continue
+		}
+		post.ClubEvent.MyRegistration = registrations[post.ID]
+		if post.ClubEvent.OrganizerID != currentUserID {
+			post.ClubEvent.CheckinCode = ""
+		}
+	}
+}
+
+func (uc *CampusUsecase) loadClubEventPost(ctx context.Context, postID int64) (*CampusForumPost, *CampusClubEvent, error) {
+	if postID <= 0 {
+		return nil, nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetPostByID(ctx, postID)
+	if err != nil {
+		return nil, nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post.Status != CampusAuditStatusVisible || post.PostType != CampusPostTypeClub {
+		return nil, nil, apperror.NotFound("活动不存在")
+	}
+	events, err := uc.repo.GetClubEvents(ctx, []int64{post.ID})
+	if err != nil {
+		return nil, nil, apperror.Internal(err, "查询活动失败")
+	}
+	event := events[post.ID]
+	if event == nil {
+		return nil, nil, apperror.NotFound("这条帖子没有开放报名")
+	}
+	return post, event, nil
+}
+
+func (uc *CampusUsecase) reloadClubEvent(ctx context.Context, post *CampusForumPost, userID string) (*CampusClubEvent, error) {
+	post.ClubEvent = nil
+	uc.assembler.hydrateClubEvents(ctx, []*CampusForumPost{post}, userID)
+	if post.ClubEvent == nil {
+		return nil, apperror.Internal(nil, "查询活动失败")
+	}
+	return post.ClubEvent, nil
+}
+
+func (uc *CampusUsecase) RegisterClubEvent(ctx context.Context, input *ClubEventActionInput) (*CampusClubEvent, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	post, event, err := uc.loadClubEventPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if event.OrganizerID == input.UserID {
+		return nil, apperror.InvalidArgument("组织者无需报名")
+	}
+	now := campusLocalNow()
+	if event.SignupClosed(now) {
+		return nil, apperror.InvalidArgument("报名已截止")
+	}
+	if _, err := uc.repo.RegisterClubEvent(ctx, post.ID, input.UserID, now); err != nil {
+		return nil, apperror.Internal(err, "报名失败")
+	}
+	return uc.reloadClubEvent(ctx, post, input.UserID)
+}
+
+func (uc *CampusUsecase) CancelClubRegistration(ctx context.Context, input *ClubEventActionInput) (*CampusClubEvent, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	post, event, err := uc.loadClubEventPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	now := campusLocalNow()
+	if !now.Before(event.StartsAt) {
+		return nil, apperror.InvalidArgument("活动已开始，不能取消报名")
+	}
+	promotedUserID, err := uc.repo.CancelClubRegistration(ctx, post.ID, input.UserID, now)
+	if err != nil {
+		return nil, apperror.Internal(err, "取消报名失败")
+	}
+	if promotedUserID != "" {
+		uc.notifyClubEventUser(ctx, post, event, promotedUserID, "promoted", "候补转正，报名成功", "「"+post.Title+"」有人取消报名，你已从候补转为正式报名。")
+	}
+	return uc.reloadClubEvent(ctx, post, input.UserID)
+}
+
+func (uc *CampusUsecase) CheckInClubEvent(ctx context.Context, input *ClubEventActionInput) (*CampusClubEvent, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	post, event, err := uc.loadClubEventPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	now := campusLocalNow()
+	if now.Before(event.StartsAt.Add(-campusClubCheckinOpensFor)) || now.After(event.StartsAt.Add(campusClubCheckinClosesFor)) {
+		return nil, apperror.InvalidArgument("不在签到时间内")
+	}
+	// 签到码只有 6 位数字，按用户和活动限制尝试次数，防止穷举
+	key := fmt.Sprintf("campus:club-checkin:%d:%s", post.ID, input.UserID)
+	allowed, err := uc.repo.AllowCampusRequest(ctx, key, campusClubCheckinMaxAttempts(), campusClubCheckinWindow)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("club checkin attempt limit failed: post_id=%d user_id=%s err=%v", post.ID, input.UserID, err)
+	}
+	if !allowed {
+		return nil, apperror.TooManyRequests("签到码尝试次数过多，请 10 分钟后再试")
+	}
+	if strings.TrimSpace(input.Code) != event.CheckinCode {
+		return nil, apperror.InvalidArgument("签到码不正确")
+	}
+	registrations, err := uc.repo.GetClubRegistrations(ctx, input.UserID, []int64{post.ID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询报名失败")
+	}
+	registration := registrations[post.ID]
+	if registration == nil || registration.Status != CampusClubRegistrationRegistered {
+		return nil, apperror.Forbidden("你还没有报名成功，不能签到")
+	}
+	if registration.CheckedInAt == nil {
+		if err := uc.repo.CheckInClubEvent(ctx, post.ID, input.UserID, now); err != nil {
+			return nil, apperror.Internal(err, "签到失败")
+		}
+	}
+	return uc.reloadClubEvent(ctx, post, input.UserID)
+}
+
+func (uc *CampusUsecase) ensureClubOrganizer(ctx context.Context, userID string, post *CampusForumPost) error {
+	if strings.TrimSpace(userID) == "" {
+		return apperror.Unauthorized("请先登录")
+	}
+	if post.AuthorID == userID {
+		return nil
+	}
+	if !uc.isCampusOperator(ctx, userID) {
+		return apperror.Forbidden("只有组织者可以查看报名名单")
+	}
+	return uc.ensureOperatorCampus(ctx, userID, post.CampusCode)
+}
+
+func (uc *CampusUsecase) ListClubRegistrations(ctx context.Context, input *ListCampusClubRegistrationsInput) (*ListCampusClubRegistrationsOutput, error) {
+	post, _, err := uc.loadClubEventPost(ctx, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureClubOrganizer(ctx, input.UserID, post); err != nil {
+		return nil, err
+	}
+	status := strings.ToLower(strings.TrimSpace(input.Status))
+	switch status {
+	case "", CampusClubRegistrationRegistered, CampusClubRegistrationWaitlisted, CampusClubRegistrationCancelled:
+	default:
+		return nil, apperror.InvalidArgument("状态筛选无效")
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	registrations, total, err := uc.repo.ListClubRegistrations(ctx, post.ID, status, int((page-1)*size), int(size))
+	if err != nil {
+		return nil, apperror.Internal(err, "获取报名名单失败")
+	}
+	uc.fillClubRegistrationUsers(ctx, registrations)
+	return &ListCampusClubRegistrationsOutput{Registrations: registrations, Total: total}, nil
+}
+
+func (uc *CampusUsecase) ExportClubRegistrationsCSV(ctx context.Context, userID string, postID int64) (*CampusClubAttendeeCSV, error) {
+	post, _, err := uc.loadClubEventPost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.ensureClubOrganizer(ctx, userID, post); err != nil {
+		return nil, err
+	}
+	registrations, _, err := uc.repo.ListClubRegistrations(ctx, post.ID, "", 0, campusClubMaxCapacity*2)
+	if err != nil {
+		return nil, apperror.Internal(err, "导出报名名单失败")
+	}
+	uc.fillClubRegistrationUsers(ctx, registrations)
+	content, err := buildCampusClubAttendeeCSV(registrations)
+	if err != nil {
+		return nil, apperror.Internal(err, "导出报名名单失败")
+	}
+	return &CampusClubAttendeeCSV{
+		Filename: fmt.Sprintf("club-event-%d-attendees.csv", post.ID),
+		Content:  content,
+	}, nil
+}
+
+func buildCampusClubAttendeeCSV(registrations []*CampusClubRegistration) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"序号", "用户ID", "昵称", "学校", "状态", "报名时间", "签到时间"}); err != nil {
+		return nil, err
+	}
+	statusLabels := map[string]string{
+		CampusClubRegistrationRegistered: "已报名",
+		CampusClubRegistrationWaitlisted: "候补",
+		CampusClubRegistrationCancelled:  "已取消",
+	}
+	for i, registration := range registrations {
+		nickname, school := "", ""
+		if registration.User != nil {
+			nickname = firstNonEmpty(registration.User.Nickname, registration.User.Name)
+			school = registration.User.SchoolName
+		}
+		checkedInAt := ""
+		if registration.CheckedInAt != nil {
+			checkedInAt = registration.CheckedInAt.Format("2006-01-02 15:04")
+		}
+		if err := writer.Write([]string{
+			fmt.Sprintf("%d", i+1),
+			registration.UserID,
+			campusCSVCell(nickname),
+			campusCSVCell(school),
+			firstNonEmpty(statusLabels[registration.Status], registration.Status),
+			registration.CreatedAt.Format("2006-01-02 15:04"),
+			checkedInAt,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// 用户填写的文字以公式字符开头时加单引号，防止在 Excel/WPS 打开时被当成公式执行
+func campusCSVCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (uc *CampusUsecase) fillClubRegistrationUsers(ctx context.Context, registrations []*CampusClubRegistration) {
+	userIDs := make([]string, 0, len(registrations))
+	seen := map[string]struct{}{}
+	for _, registration := range registrations {
+		appendUniqueUserID(&userIDs, seen, registration.UserID)
+	}
+	authors, err := uc.assembler.LoadAuthors(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load club registration users failed: %v", err)
+		return
+	}
+	for _, registration := range registrations {
+		registration.User = authors[registration.UserID]
+	}
+}
+
+func (uc *CampusUsecase) ProcessClubEventReminders(ctx context.Context, limit int) (int, error) {
+	before := campusClubReminderBefore()
+	if before <= 0 {
+		return 0, nil
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	now := campusLocalNow()
+	events, err := uc.repo.ListDueClubReminders(ctx, now, now.Add(before), limit)
+	if err != nil {
+		return 0, err
+	}
+	sent := 0
+	for _, event := range events {
+		ok, post, err := uc.repo.GetPostByID(ctx, event.PostID)
+		if err != nil || !ok {
+			continue
+		}
+		registrations, _, err := uc.repo.ListClubRegistrations(ctx, event.PostID, CampusClubRegistrationRegistered, 0, campusClubMaxCapacity)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("list club registrations for reminder failed: post_id=%d err=%v", event.PostID, err)
+			continue
+		}
+		content := fmt.Sprintf("「%s」将于 %s 开始", post.Title, event.StartsAt.Format("01-02 15:04"))
+		if event.Place != "" {
+			content += "，地点：" + event.Place
+		}
+		outboxes := make([]*CampusNotificationOutbox, 0, len(registrations))
+		for _, registration := range registrations {
+			outboxes = append(outboxes, uc.clubEventOutbox(post, registration.UserID, "reminder", "你报名的活动快开始了", content))
+		}
+		marked, err := uc.repo.MarkClubReminderSent(ctx, event.PostID, now, outboxes)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("send club event reminders failed: post_id=%d err=%v", event.PostID, err)
+			continue
+		}
+		if marked {
+			sent += len(outboxes)
+		}
+	}
+	return sent, nil
+}
+
+func (uc *CampusUsecase) notifyClubEventUser(ctx context.Context, post *CampusForumPost, event *CampusClubEvent, userID, kind, title, content string) {
+	if err := uc.repo.CreateNotificationOutbox(ctx, uc.clubEventOutbox(post, userID, kind, title, content)); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue club event notification failed: post_id=%d user_id=%s err=%v", event.PostID, userID, err)
+	}
+}
+
+func (uc *CampusUsecase) clubEventOutbox(post *CampusForumPost, userID, kind, title, content string) *CampusNotificationOutbox {
+	return &CampusNotificationOutbox{
+		ID:          uc.idGen.NextID(),
+		RecipientID: userID,
+		ActorID:     "0",
+		EventType:   CampusNotificationTypeSystem,
+		TargetType:  "post",
+		TargetID:    post.ID,
+		DedupeKey:   fmt.Sprintf("campus:club-%s:%d:%s", kind, post.ID, userID),
+		Title:       title,
+		Content:     trimLimit(content, 80),
+		LinkPage:    "post-detail",
+		LinkParams:  map[string]string{"id": fmt.Sprintf("%d", post.ID)},
+		Status:      CampusNotificationOutboxStatusPending,
+	}
+}
@@ -0,0 +1,76 @@
+package biz
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewCampusClubEvent(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	if event, err := newCampusClubEvent(nil, nil, now); err != nil || event != nil {
+		t.Fatalf("club post without event should stay a plain post, got %+v err=%v", event, err)
+	}
+	if _, err := newCampusClubEvent(&CampusClubEventInput{Capacity: 10}, nil, now); err == nil {
+		t.Fatal("missing start time should fail")
+	}
+	startsAt := now.Add(48 * time.Hour)
+	late := startsAt.Add(time.Hour)
+	if _, err := newCampusClubEvent(&CampusClubEventInput{StartsAt: &startsAt, SignupDeadline: &late}, nil, now); err == nil {
+		t.Fatal("deadline after start should fail")
+	}
+	if _, err := newCampusClubEvent(&CampusClubEventInput{StartsAt: &startsAt, Capacity: -1}, nil, now); err == nil {
+		t.Fatal("negative capacity should fail")
+	}
+	event, err := newCampusClubEvent(&CampusClubEventInput{StartsAt: &startsAt, Capacity: 30}, map[string]string{"activity_place": "大学生活动中心"}, now)
+	if err != nil {
+		t.Fatalf("new event: %v", err)
+	}
+	if !event.SignupDeadline.Equal(startsAt) || event.Place != "大学生活动中心" || len(event.CheckinCode) != 6 {
+		t.Fatalf("unexpected event %+v", event)
+	}
+	event.RegisteredCount = 30
+	if !event.Full() || event.SignupClosed(now) || !event.SignupClosed(startsAt) {
+		t.Fatalf("unexpected capacity/deadline state %+v", event)
+	}
+}
+
+func TestBuildCampusClubAttendeeCSV(t *testing.T) {
+	checkedIn := time.Date(2026, 10, 18, 18, 55, 0, 0, time.Local)
+	content, err := buildCampusClubAttendeeCSV([]*CampusClubRegistration{
+		{UserID: "1", User: &CampusForumAuthor{Nickname: "小林"}, Status: CampusClubRegistrationRegistered, CheckedInAt: &checkedIn},
+		{UserID: "2", Status: CampusClubRegistrationWaitlisted},
+	})
+	if err != nil {
+		t.Fatalf("build csv: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(content), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(records) != 3 || records[1][2] != "小林" || records[1][6] != "2026-10-18 18:55" || records[2][4] != "候补" {
+		t.Fatalf("unexpected csv %v", records)
+	}
+}
+
+func TestBuildCampusClubAttendeeCSVEscapesFormulas(t *testing.T) {
+	content, err := buildCampusClubAttendeeCSV([]*CampusClubRegistration{
+		{UserID: "1", User: &CampusForumAuthor{Nickname: `=HYPERLINK("http://x","y")`, SchoolName: "+cmd|' /C calc'!A0"}, Status: CampusClubRegistrationRegistered},
+		{UserID: "2", User: &CampusForumAuthor{Nickname: "-1", SchoolName: "@SUM(1)"}, Status: CampusClubRegistrationRegistered},
+	})
+	if err != nil {
+		t.Fatalf("build csv: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(string(content), "\ufeff"))).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	for _, record := range records[1:] {
+		for _, cell := range record[2:4] {
+			if !strings.HasPrefix(cell, "'") {
+				t.Fatalf("formula cell should be escaped, got %q", cell)
+			}
+		}
+	}
+}
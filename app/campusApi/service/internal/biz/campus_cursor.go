@@ -0,0 +1,145 @@
+package biz
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+type CampusListCursor struct {
+	Scope   string `json:"s"`
+	At      int64  `json:"t,omitempty"`
+	ID      int64  `json:"i,omitempty"`
+	Offset  int    `json:"o,omitempty"`
+	Version int64  `json:"v,omitempty"`
+}
+
+func (c *CampusListCursor) SortAt() time.Time {
+	if c == nil || c.At == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, c.At)
+}
+
+func campusCursorScope(parts ...string) string {
+	return shortHash(strings.Join(parts, "|"), 16)
+}
+
+func (uc *CampusUsecase) encodeListCursor(cursor *CampusListCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw, err := json.Marshal(cursor)
+	if err != nil {
+		return ""
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + uc.signListCursor(payload)
+}
+
+func (uc *CampusUsecase) decodeListCursor(token, scope string) (*CampusListCursor, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, nil
+	}
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok || payload == "" || !hmac.Equal([]byte(uc.signListCursor(payload)), []byte(sig)) {
+		return nil, apperror.InvalidArgument("分页游标无效")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, apperror.InvalidArgument("分页游标无效")
+	}
+	var cursor CampusListCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil || cursor.Offset < 0 || cursor.ID < 0 {
+		return nil, apperror.InvalidArgument("分页游标无效")
+	}
+	if cursor.Scope != scope {
+		return nil, apperror.InvalidArgument("分页游标与当前筛选条件不匹配，请刷新列表")
+	}
+	return &cursor, nil
+}
+
+func (uc *CampusUsecase) signListCursor(payload string) string {
+	mac := hmac.New(sha256.New, []byte(uc.authSecret))
+	mac.Write([]byte("list-cursor:" + payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:22]
+}
+
+func (uc *CampusUsecase) campusListPage(token, scope string, page, size int32) (*CampusListCursor, int, int, error) {
+	page, size = normalizePage(page, size)
+	cursor, err := uc.decodeListCursor(token, scope)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if cursor != nil {
+		return cursor, cursor.Offset, int(size), nil
+	}
+	return nil, int((page - 1) * size), int(size), nil
+}
+
+func (uc *CampusUsecase) keysetNextCursor(scope string, count, limit int, at time.Time, id int64) string {
+	if count < limit || limit <= 0 || id <= 0 {
+		return ""
+	}
+	return uc.encodeListCursor(&CampusListCursor{Scope: scope, At: at.UnixNano(), ID: id})
+}
+
+func (uc *CampusUsecase) offsetNextCursor(scope string, offset, count, limit int, total int64) string {
+	next := offset + count
+	if count < limit || limit <= 0 || int64(next) >= total {
+		return ""
+	}
+	return uc.encodeListCursor(&CampusListCursor{Scope: scope, Offset: next})
+}
+
+func (uc *CampusUsecase) postsNextCursor(scope string, query ListCampusPostQuery, posts []*CampusForumPost, total int64) string {
+	if query.KeysetPageable() {
+		if len(posts) == 0 || posts[len(posts)-1] == nil {
+			return ""
+		}
+		last := posts[len(posts)-1]
+		return uc.keysetNextCursor(scope, len(posts), query.Limit, last.CreatedAt, last.ID)
+	}
+	return uc.offsetNextCursor(scope, query.Offset, len(posts), query.Limit, total)
+}
+
+func (uc *CampusUsecase) commentsNextCursor(scope string, limit int, comments []*CampusForumComment) string {
+	if len(comments) == 0 || comments[len(comments)-1] == nil {
+		return ""
+	}
+	last := comments[len(comments)-1]
+	return uc.keysetNextCursor(scope, len(comments), limit, last.CreatedAt, last.ID)
+}
+
+func applyPostCursor(query *ListCampusPostQuery, cursor *CampusListCursor) {
+	if cursor == nil || !query.KeysetPageable() {
+		return
+	}
+	query.Offset = 0
+	query.After = cursor
+}
+
+func (q ListCampusPostQuery) KeysetPageable() bool {
+	return (q.Sort == CampusPostSortNew || q.Sort == CampusPostSortFollowing) && q.CollectedByUserID == "" && q.Keyword == ""
+}
+
+func campusCursorOffset(ids []int64, version int64, cursor *CampusListCursor) int {
+	if cursor == nil {
+		return 0
+	}
+	if cursor.Version == version || cursor.ID <= 0 {
+		return cursor.Offset
+	}
+	for i, id := range ids {
+		if id == cursor.ID {
+			return i + 1
+		}
+	}
+	return cursor.Offset
+}
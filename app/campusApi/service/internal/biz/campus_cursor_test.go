@@ -0,0 +1,47 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCampusListCursorRoundTripAndTamper(t *testing.T) {
+	uc := &CampusUsecase{authSecret: "secret"}
+	scope := campusCursorScope("posts", "shenshan", "", "", CampusPostSortNew, "", "")
+	at := time.Date(2026, 10, 16, 8, 30, 0, 123000000, time.UTC)
+	token := uc.keysetNextCursor(scope, 20, 20, at, 42)
+	if token == "" {
+		t.Fatal("full page should emit a next cursor")
+	}
+	cursor, err := uc.decodeListCursor(token, scope)
+	if err != nil || cursor.ID != 42 || !cursor.SortAt().Equal(at) {
+		t.Fatalf("decode = %+v, %v", cursor, err)
+	}
+	if _, err := uc.decodeListCursor(token, campusCursorScope("posts", "other")); err == nil {
+		t.Fatal("cursor should be bound to its list scope")
+	}
+	payload, sig, _ := strings.Cut(token, ".")
+	if _, err := uc.decodeListCursor(payload+"x."+sig, scope); err == nil {
+		t.Fatal("tampered payload should be rejected")
+	}
+	if _, err := (&CampusUsecase{authSecret: "other"}).decodeListCursor(token, scope); err == nil {
+		t.Fatal("cursor signed with another secret should be rejected")
+	}
+	if token := uc.keysetNextCursor(scope, 7, 20, at, 42); token != "" {
+		t.Fatalf("short page should end the list, got %q", token)
+	}
+}
+
+func TestCampusCursorOffsetReanchorsAfterPoolRebuild(t *testing.T) {
+	cursor := &CampusListCursor{Offset: 2, ID: 30, Version: 1}
+	if got := campusCursorOffset([]int64{10, 20, 30, 40}, 1, cursor); got != 2 {
+		t.Fatalf("same snapshot offset = %d, want 2", got)
+	}
+	if got := campusCursorOffset([]int64{50, 10, 20, 30, 40}, 2, cursor); got != 4 {
+		t.Fatalf("rebuilt snapshot offset = %d, want 4", got)
+	}
+	if got := campusCursorOffset([]int64{50, 60, 70}, 2, cursor); got != 2 {
+		t.Fatalf("missing anchor offset = %d, want 2", got)
+	}
+}
@@ -0,0 +1,290 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusPostSortFollowing = "following"
+
+	campusFollowingFeedMaxAuthors = 1000
+	campusFollowFanoutMaxBatches  = 20
+)
+
+type CampusFollowUser struct {
+	UserID        string
+	Name          string
+	Nickname      string
+	Avatar        string
+	Signature     string
+	FollowCount   int64
+	FollowerCount int64
+	IsFollowing   bool
+}
+
+type FollowCampusUserInput struct {
+	UserID       string
+	TargetUserID string
+}
+
+type CampusFollowState struct {
+	TargetUserID string
+	Following    bool
+	Changed      bool
+}
+
+type ListCampusFollowInput struct {
+	UserID        string
+	CurrentUserID string
+	Cursor        string
+	Page          int32
+	Size          int32
+}
+
+type ListCampusFollowOutput struct {
+	Users      []*CampusFollowUser
+	Total      int64
+	NextCursor string
+}
+
+type CampusPostFanout struct {
+	PostID        int64
+	AuthorID      string
+	LastUserID    string
+	NotifiedCount int64
+	DoneAt        *time.Time
+}
+
+func campusFollowFanoutWindow() time.Duration {
+	if value := strings.TrimSpace(os.Getenv("CAMPUS_FOLLOW_FANOUT_WINDOW")); value == "0" || envBoolFalse(value) {
+		return 0
+	}
+	return envDurationBiz("CAMPUS_FOLLOW_FANOUT_WINDOW", 6*time.Hour)
+}
+
+func campusFollowFanoutBatch() int32 {
+	batch := envInt64("CAMPUS_FOLLOW_FANOUT_BATCH", 500)
+	if batch <= 0 {
+		batch = 500
+	}
+	if batch > 1000 {
+		batch = 1000
+	}
+	return int32(batch)
+}
+
+func (uc *CampusUsecase) FollowUser(ctx context.Context, input *FollowCampusUserInput) (*CampusFollowState, error) {
+	userID, targetUserID, err := normalizeFollowInput(input)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := uc.core.FollowUser(ctx, userID, targetUserID)
+	if err != nil {
+		return nil, campusFollowError(err, "关注失败")
+	}
+	if changed {
+		uc.trackEvent(ctx, &TrackCampusEventInput{
+			UserID:     userID,
+			EventType:  "user_follow",
+			Page:       "user-profile",
+			TargetType: "user",
+			TargetID:   parseInt64String(targetUserID),
+		})
+	}
+	return &CampusFollowState{TargetUserID: targetUserID, Following: true, Changed: changed}, nil
+}
+
+func (uc *CampusUsecase) UnfollowUser(ctx context.Context, input *FollowCampusUserInput) (*CampusFollowState, error) {
+	userID, targetUserID, err := normalizeFollowInput(input)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := uc.core.UnfollowUser(ctx, userID, targetUserID)
+	if err != nil {
+		return nil, campusFollowError(err, "取消关注失败")
+	}
+	return &CampusFollowState{TargetUserID: targetUserID, Following: false, Changed: changed}, nil
+}
+
+func normalizeFollowInput(input *FollowCampusUserInput) (string, string, error) {
+	userID := strings.TrimSpace(input.UserID)
+	targetUserID := strings.TrimSpace(input.TargetUserID)
+	if userID == "" {
+		return "", "", apperror.Unauthorized("请先登录")
+	}
+	if parseInt64String(targetUserID) <= 0 {
+		return "", "", apperror.InvalidArgument("用户 ID 无效")
+	}
+	if userID == targetUserID {
+		return "", "", apperror.InvalidArgument("不能关注自己")
+	}
+	return userID, targetUserID, nil
+}
+
+func campusFollowError(err error, message string) error {
+	if appErr := apperror.From(err); appErr.Code >= apperror.CodeInvalidArgument && appErr.Code < apperror.CodeInternal {
+		return appErr
+	}
+	return apperror.Internal(err, message)
+}
+
+func (uc *CampusUsecase) ListFollowers(ctx context.Context, input *ListCampusFollowInput) (*ListCampusFollowOutput, error) {
+	return uc.listFollowUsers(ctx, input, true)
+}
+
+func (uc *CampusUsecase) ListFollowing(ctx context.Context, input *ListCampusFollowInput) (*ListCampusFollowOutput, error) {
+	return uc.listFollowUsers(ctx, input, false)
+}
+
+func (uc *CampusUsecase) listFollowUsers(ctx context.Context, input *ListCampusFollowInput, followers bool) (*ListCampusFollowOutput, error) {
+	userID := strings.TrimSpace(input.UserID)
+	if parseInt64String(userID) <= 0 {
+		return nil, apperror.InvalidArgument("用户 ID 无效")
+	}
+	kind := "following"
+	if followers {
+		kind = "followers"
+	}
+	scope := campusCursorScope("user_"+kind, userID)
+	_, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	page := int32(offset/limit) + 1
+	var total int64
+	var users []*UserBaseInfo
+	if followers {
+		total, users, err = uc.core.ListFollowers(ctx, userID, page, int32(limit))
+	} else {
+		total, users, err = uc.core.ListFollowing(ctx, userID, page, int32(limit))
+	}
+	if err != nil {
+		return nil, apperror.Internal(err, "获取关注列表失败")
+	}
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+	following := map[string]bool{}
+	if currentUserID := strings.TrimSpace(input.CurrentUserID); currentUserID != "" && len(userIDs) > 0 {
+		if following, err = uc.core.CheckFollowing(ctx, currentUserID, userIDs); err != nil {
+			uc.log.WithContext(ctx).Warnf("check campus following failed: user_id=%s err=%v", currentUserID, err)
+			following = map[string]bool{}
+		}
+	}
+	items := make([]*CampusFollowUser, 0, len(users))
+	for _, user := range users {
+		items = append(items, &CampusFollowUser{
+			UserID:        user.ID,
+			Name:          firstNonEmpty(user.Nickname, user.Name, "深汕同学"),
+			Nickname:      user.Nickname,
+			Avatar:        user.Avatar,
+			Signature:     user.Signature,
+			FollowCount:   user.FollowCount,
+			FollowerCount: user.FollowerCount,
+			IsFollowing:   following[user.ID],
+		})
+	}
+	return &ListCampusFollowOutput{
+		Users:      items,
+		Total:      total,
+		NextCursor: uc.offsetNextCursor(scope, offset, len(items), limit, total),
+	}, nil
+}
+
+func (uc *CampusUsecase) followingAuthorIDs(ctx context.Context, userID string) ([]string, error) {
+	ids, err := uc.core.ListFollowingIDs(ctx, userID, campusFollowingFeedMaxAuthors)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取关注列表失败")
+	}
+	return ids, nil
+}
+
+func (uc *CampusUsecase) ProcessFollowerPostFanout(ctx context.Context, limit int) (int, error) {
+	window := campusFollowFanoutWindow()
+	if window <= 0 {
+		return 0, nil
+	}
+	if limit <= 0 {
+		limit = 20
+	}
+	fanouts, err := uc.repo.ListDueFollowFanouts(ctx, campusLocalNow().Add(-window), limit)
+	if err != nil {
+		return 0, err
+	}
+	postIDs := make([]int64, 0, len(fanouts))
+	for _, fanout := range fanouts {
+		postIDs = append(postIDs, fanout.PostID)
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, postIDs, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return 0, err
+	}
+	postMap := make(map[int64]*CampusForumPost, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	batch := campusFollowFanoutBatch()
+	notified := 0
+	for _, fanout := range fanouts {
+		post := postMap[fanout.PostID]
+		if post == nil {
+			continue
+		}
+		authorName := "你关注的同学"
+		if author, err := uc.core.GetUserBaseInfo(ctx, fanout.AuthorID, ""); err == nil && author != nil {
+			authorName = firstNonEmpty(author.Nickname, author.Name, authorName)
+		}
+		for i := 0; i < campusFollowFanoutMaxBatches && fanout.DoneAt == nil; i++ {
+			count, err := uc.fanoutFollowerBatch(ctx, post, fanout, authorName, batch)
+			if err != nil {
+				uc.log.WithContext(ctx).Warnf("fanout campus post to followers failed: post_id=%d err=%v", post.ID, err)
+				break
+			}
+			notified += count
+		}
+	}
+	return notified, nil
+}
+
+func (uc *CampusUsecase) fanoutFollowerBatch(ctx context.Context, post *CampusForumPost, fanout *CampusPostFanout, authorName string, batch int32) (int, error) {
+	followerIDs, err := uc.core.ListFollowerIDs(ctx, fanout.AuthorID, fanout.LastUserID, batch)
+	if err != nil {
+		return 0, err
+	}
+	outboxes := make([]*CampusNotificationOutbox, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		outboxes = append(outboxes, uc.buildNotificationOutbox(&CampusNotification{
+			RecipientID: followerID,
+			ActorID:     fanout.AuthorID,
+			EventType:   CampusNotificationTypeSystem,
+			TargetType:  "post",
+			TargetID:    post.ID,
+			DedupeKey:   fmt.Sprintf("campus:follow-post:%d:%s", post.ID, followerID),
+			Title:       trimLimit(authorName+" 发布了新帖子", 80),
+			Content:     trimLimit(post.Title, 80),
+			LinkPage:    "post-detail",
+			LinkParams:  map[string]string{"id": fmt.Sprintf("%d", post.ID)},
+		}, true))
+	}
+	next := *fanout
+	if len(followerIDs) > 0 {
+		next.LastUserID = followerIDs[len(followerIDs)-1]
+	}
+	next.NotifiedCount += int64(len(followerIDs))
+	if len(followerIDs) < int(batch) {
+		now := campusLocalNow()
+		next.DoneAt = &now
+	}
+	if err := uc.repo.SaveFollowFanoutBatch(ctx, &next, outboxes); err != nil {
+		return 0, err
+	}
+	*fanout = next
+	return len(followerIDs), nil
+}
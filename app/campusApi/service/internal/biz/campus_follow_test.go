@@ -0,0 +1,40 @@
+package biz
+
+import "testing"
+
+func TestNormalizeFollowInput(t *testing.T) {
+	if _, _, err := normalizeFollowInput(&FollowCampusUserInput{TargetUserID: "2"}); err == nil {
+		t.Fatal("anonymous follow should fail")
+	}
+	if _, _, err := normalizeFollowInput(&FollowCampusUserInput{UserID: "1", TargetUserID: "abc"}); err == nil {
+		t.Fatal("invalid target should fail")
+	}
+	if _, _, err := normalizeFollowInput(&FollowCampusUserInput{UserID: "1", TargetUserID: " 1 "}); err == nil {
+		t.Fatal("following yourself should fail")
+	}
+	userID, targetUserID, err := normalizeFollowInput(&FollowCampusUserInput{UserID: "1", TargetUserID: " 2 "})
+	if err != nil || userID != "1" || targetUserID != "2" {
+		t.Fatalf("unexpected follow input %q %q err=%v", userID, targetUserID, err)
+	}
+}
+
+func TestFollowingFeedKeysetPageable(t *testing.T) {
+	query := ListCampusPostQuery{Sort: CampusPostSortFollowing, AuthorIDs: []string{"2"}}
+	if !query.KeysetPageable() {
+		t.Fatal("following feed should page by keyset")
+	}
+	if query.EligibleForRecommendPool() {
+		t.Fatal("following feed should not use the recommend pool")
+	}
+}
+
+func TestCampusFollowFanoutBatch(t *testing.T) {
+	t.Setenv("CAMPUS_FOLLOW_FANOUT_BATCH", "5000")
+	if got := campusFollowFanoutBatch(); got != 1000 {
+		t.Fatalf("batch should clamp to 1000, got %d", got)
+	}
+	t.Setenv("CAMPUS_FOLLOW_FANOUT_WINDOW", "0")
+	if campusFollowFanoutWindow() != 0 {
+		t.Fatal("window 0 should disable fanout")
+	}
+}
@@ -0,0 +1,528 @@
+package biz
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"lehu-video/pkg/apperror"
+	"lehu-video/pkg/imagehash"
+)
+
+const (
+	CampusImageAuditTargetPost   = "post"
+	CampusImageAuditTargetUpload = "upload"
+
+	CampusImageAuditDecisionSkip = "skip"
+
+	campusOpsSettingImageHashBlocklist = "image_hash_blocklist"
+
+	campusImageAuditTaskMaxRetry = 3
+	campusImageAuditReuseWindow  = 24 * time.Hour
+	campusWechatImageMaxBytes    = 1 << 20
+)
+
+type CampusImageAuditTask struct {
+	ID          int64
+	TargetType  string
+	TargetID    int64
+	ImageURL    string
+	Status      string
+	Provider    string
+	Decision    string
+	Label       string
+	Reason      string
+	RawResult   string
+	RetryCount  int32
+	NextRetryAt *time.Time
+	LockedUntil *time.Time
+	LastError   string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ProcessedAt *time.Time
+}
+
+type CampusImageAuditResult struct {
+	Provider string
+	Decision string
+	Label    string
+	Reason   string
+	Raw      map[string]interface{}
+}
+
+type CampusImageAuditor interface {
+	Name() string
+	AuditImage(ctx context.Context, imageURL string, img image.Image) (*CampusImageAuditResult, error)
+}
+
+type campusNoopImageAuditor struct{}
+
+func (campusNoopImageAuditor) Name() string { return "noop" }
+
+func (campusNoopImageAuditor) AuditImage(context.Context, string, image.Image) (*CampusImageAuditResult, error) {
+	return &CampusImageAuditResult{Provider: "noop", Decision: CampusAIContentAuditDecisionPass}, nil
+}
+
+type campusImageAuditChain []CampusImageAuditor
+
+func (c campusImageAuditChain) Name() string {
+	names := make([]string, 0, len(c))
+	for _, auditor := range c {
+		names = append(names, auditor.Name())
+	}
+	return strings.Join(names, ",")
+}
+
+// 有审核器跳过时整体记为 skip，避免未真正审核的图片被当作通过结果复用
+func (c campusImageAuditChain) AuditImage(ctx context.Context, imageURL string, img image.Image) (*CampusImageAuditResult, error) {
+	last := &CampusImageAuditResult{Provider: c.Name(), Decision: CampusAIContentAuditDecisionPass}
+	var skipped *CampusImageAuditResult
+	for _, auditor := range c {
+		result, err := auditor.AuditImage(ctx, imageURL, img)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", auditor.Name(), err)
+		}
+		if result == nil {
+			continue
+		}
+		if result.Decision == CampusImageAuditDecisionSkip {
+			if skipped == nil {
+				skipped = result
+			}
+			continue
+		}
+		if result.Decision != CampusAIContentAuditDecisionPass {
+			return result, nil
+		}
+		last = result
+	}
+	if skipped != nil {
+		return skipped, nil
+	}
+	return last, nil
+}
+
+type campusHashImageAuditor struct {
+	blocklist   func(ctx context.Context) []*CampusImageHashBlock
+	maxDistance int
+}
+
+func (a *campusHashImageAuditor) Name() string { return "hash" }
+
+func (a *campusHashImageAuditor) AuditImage(ctx context.Context, _ string, img image.Image) (*CampusImageAuditResult, error) {
+	phash, dhash := imagehash.PHash(img), imagehash.DHash(img)
+	raw := map[string]interface{}{"phash": imagehash.Format(phash), "dhash": imagehash.Format(dhash)}
+	for _, block := range a.blocklist(ctx) {
+		if distance, ok := block.match(phash, dhash, a.maxDistance); ok {
+			raw["matched_block"] = fmt.Sprintf("%d", block.ID)
+			raw["distance"] = distance
+			return &CampusImageAuditResult{
+				Provider: a.Name(),
+				Decision: CampusAIContentAuditDecisionReject,
+				Label:    "hash_blocklist",
+				Reason:   "图片命中违规图片库",
+				Raw:      raw,
+			}, nil
+		}
+	}
+	return &CampusImageAuditResult{Provider: a.Name(), Decision: CampusAIContentAuditDecisionPass, Raw: raw}, nil
+}
+
+type campusWechatImageAuditor struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (a *campusWechatImageAuditor) Name() string { return "wechat" }
+
+func (a *campusWechatImageAuditor) AuditImage(ctx context.Context, _ string, img image.Image) (*CampusImageAuditResult, error) {
+	token := strings.TrimSpace(os.Getenv("WECHAT_CONTENT_SECURITY_TOKEN"))
+	if token == "" {
+		return &CampusImageAuditResult{Provider: a.Name(), Decision: CampusImageAuditDecisionSkip, Label: "skipped", Reason: "微信内容安全未配置"}, nil
+	}
+	media, err := encodeCampusWechatAuditImage(img)
+	if err != nil {
+		return nil, err
+	}
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile("media", "image.jpg")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := part.Write(media); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint+"?access_token="+url.QueryEscape(token), &body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	var out struct {
+		ErrCode int64  `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	}
+	if err := jsonDecode(io.LimitReader(resp.Body, 1<<16), &out); err != nil {
+		return nil, err
+	}
+	raw := map[string]interface{}{"errcode": out.ErrCode, "errmsg": out.ErrMsg}
+	switch out.ErrCode {
+	case 0:
+		return &CampusImageAuditResult{Provider: a.Name(), Decision: CampusAIContentAuditDecisionPass, Raw: raw}, nil
+	case 87014:
+		return &CampusImageAuditResult{
+			Provider: a.Name(),
+			Decision: CampusAIContentAuditDecisionReject,
+			Label:    "wechat_risky",
+			Reason:   "微信内容安全识别为违规图片",
+			Raw:      raw,
+		}, nil
+	default:
+		return nil, fmt.Errorf("wechat img_sec_check failed: %d %s", out.ErrCode, out.ErrMsg)
+	}
+}
+
+func encodeCampusWechatAuditImage(img image.Image) ([]byte, error) {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("empty image")
+	}
+	scale := 1.0
+	if ratio := 750.0 / float64(width); ratio < scale {
+		scale = ratio
+	}
+	if ratio := 1334.0 / float64(height); ratio < scale {
+		scale = ratio
+	}
+	src := img
+	if scale < 1 {
+		resized := image.NewRGBA(image.Rect(0, 0, max(1, int(float64(width)*scale)), max(1, int(float64(height)*scale))))
+		draw.ApproxBiLinear.Scale(resized, resized.Bounds(), img, bounds, draw.Src, nil)
+		src = resized
+	}
+	for _, quality := range []int{85, 65, 45} {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: quality}); err != nil {
+			return nil, err
+		}
+		if buf.Len() <= campusWechatImageMaxBytes {
+			return buf.Bytes(), nil
+		}
+	}
+	return nil, fmt.Errorf("image too large for wechat img_sec_check")
+}
+
+func parseCampusImageHashes(value string) []uint64 {
+	out := []uint64{}
+	for _, item := range normalizeAuditWords(value, nil) {
+		hash, err := imagehash.Parse(item)
+		if err != nil {
+			continue
+		}
+		out = append(out, hash)
+	}
+	return out
+}
+
+func containsCampusImage(images []string, imageURL string) bool {
+	for _, item := range images {
+		if item == imageURL {
+			return true
+		}
+	}
+	return false
+}
+
+func (uc *CampusUsecase) loadCampusImageAuditor() CampusImageAuditor {
+	chain := campusImageAuditChain{}
+	seen := map[string]bool{}
+	for _, name := range strings.Split(strings.ToLower(os.Getenv("CAMPUS_IMAGE_AUDITORS")), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		switch name {
+		case "hash":
+			chain = append(chain, &campusHashImageAuditor{
+				blocklist:   uc.imageHashBlocklist,
+				maxDistance: int(envInt64("CAMPUS_IMAGE_HASH_MAX_DISTANCE", 6)),
+			})
+		case "wechat":
+			chain = append(chain, &campusWechatImageAuditor{
+				endpoint: "https://api.weixin.qq.com/wxa/img_sec_check",
+				client:   &http.Client{Timeout: envDurationBiz("CAMPUS_IMAGE_AUDIT_TIMEOUT", 10*time.Second)},
+			})
+		case "noop":
+		default:
+			uc.log.Warnf("unknown campus image auditor ignored: %s", name)
+		}
+	}
+	if len(chain) == 0 {
+		return campusNoopImageAuditor{}
+	}
+	return chain
+}
+
+func (uc *CampusUsecase) imageAuditEnabled() bool {
+	if uc.imageAuditor == nil {
+		return false
+	}
+	_, noop := uc.imageAuditor.(campusNoopImageAuditor)
+	return !noop
+}
+
+func (uc *CampusUsecase) postImageChecksEnabled() bool {
+	return uc.imageAuditEnabled() || campusImageDuplicateAction() != campusImageDuplicateActionOff
+}
+
+func (uc *CampusUsecase) enqueueUploadImageAudit(ctx context.Context, fileID, imageURL string) {
+	id := parseInt64String(fileID)
+	if !uc.imageAuditEnabled() || id <= 0 || strings.TrimSpace(imageURL) == "" {
+		return
+	}
+	task := &CampusImageAuditTask{ID: uc.idGen.NextID(), TargetType: CampusImageAuditTargetUpload, TargetID: id, ImageURL: strings.TrimSpace(imageURL)}
+	if err := uc.repo.CreateImageAuditTasks(ctx, []*CampusImageAuditTask{task}); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue campus upload image audit failed: file_id=%s err=%v", fileID, err)
+	}
+}
+
+func (uc *CampusUsecase) enqueuePostImageAudit(ctx context.Context, post *CampusForumPost) {
+	if !uc.postImageChecksEnabled() || post == nil || post.ID <= 0 || len(post.Images) == 0 {
+		return
+	}
+	tasks := make([]*CampusImageAuditTask, 0, len(post.Images))
+	for _, imageURL := range post.Images {
+		tasks = append(tasks, &CampusImageAuditTask{ID: uc.idGen.NextID(), TargetType: CampusImageAuditTargetPost, TargetID: post.ID, ImageURL: imageURL})
+	}
+	if err := uc.repo.CreateImageAuditTasks(ctx, tasks); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue campus post image audit failed: post_id=%d err=%v", post.ID, err)
+	}
+}
+
+func (uc *CampusUsecase) ProcessPendingImageAuditTasks(ctx context.Context, limit int) error {
+	if limit <= 0 {
+		limit = 10
+	}
+	tasks, err := uc.repo.ClaimImageAuditTasks(ctx, limit, time.Minute)
+	if err != nil {
+		return apperror.Internal(err, "领取图片审核任务失败")
+	}
+	var firstErr error
+	for _, task := range tasks {
+		if task == nil {
+			continue
+		}
+		if err := uc.processImageAuditTask(ctx, task); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			uc.log.WithContext(ctx).Warnf("process campus image audit task failed: id=%d err=%v", task.ID, err)
+			uc.markImageAuditTaskRetry(ctx, task, err)
+		}
+	}
+	return firstErr
+}
+
+func (uc *CampusUsecase) processImageAuditTask(ctx context.Context, task *CampusImageAuditTask) error {
+	var post *CampusForumPost
+	if task.TargetType == CampusImageAuditTargetPost {
+		ok, item, err := uc.repo.GetAnyPostByID(ctx, task.TargetID)
+		if err != nil {
+			return err
+		}
+		if !ok || item == nil || item.Status == CampusAuditStatusDeleted || item.Status == CampusAuditStatusRejected || !containsCampusImage(item.Images, task.ImageURL) {
+			return uc.repo.MarkImageAuditTaskDone(ctx, task.ID, "", CampusImageAuditDecisionSkip, "", "帖子已删除或图片已移除", "")
+		}
+		post = item
+	}
+	result, err := uc.auditCampusImage(ctx, task.ImageURL)
+	if err != nil {
+		return err
+	}
+	if post != nil && campusImageAuditPassed(result.Decision) {
+		duplicate, err := uc.checkRejectedImageDuplicate(ctx, post, task.ImageURL)
+		if err != nil {
+			return err
+		}
+		if duplicate != nil {
+			result = duplicate
+		}
+	}
+	raw, _ := json.Marshal(result.Raw)
+	if err := uc.repo.MarkImageAuditTaskDone(ctx, task.ID, result.Provider, result.Decision, result.Label, result.Reason, string(raw)); err != nil {
+		return err
+	}
+	if post == nil || campusImageAuditPassed(result.Decision) {
+		return nil
+	}
+	if result.Provider == "duplicate" && result.Decision == CampusAIContentAuditDecisionReject {
+		return uc.rejectPostForImageDuplicate(ctx, post, task.ImageURL, result)
+	}
+	return uc.holdPostForImageAudit(ctx, post, task.ImageURL, result)
+}
+
+func (uc *CampusUsecase) auditCampusImage(ctx context.Context, imageURL string) (*CampusImageAuditResult, error) {
+	ok, previous, err := uc.repo.GetRecentImageAuditByURL(ctx, imageURL, time.Now().Add(-campusImageAuditReuseWindow))
+	if err != nil {
+		return nil, err
+	}
+	if ok && previous != nil {
+		return &CampusImageAuditResult{
+			Provider: previous.Provider,
+			Decision: previous.Decision,
+			Label:    previous.Label,
+			Reason:   previous.Reason,
+			Raw:      map[string]interface{}{"reused_task_id": fmt.Sprintf("%d", previous.ID)},
+		}, nil
+	}
+	if !uc.imageAuditEnabled() {
+		return &CampusImageAuditResult{Provider: "noop", Decision: CampusAIContentAuditDecisionPass}, nil
+	}
+	if err := validateMomentsImageURL(imageURL); err != nil {
+		return &CampusImageAuditResult{
+			Provider: "local",
+			Decision: CampusAIContentAuditDecisionReview,
+			Label:    "external_image",
+			Reason:   "图片不在站内存储，需要人工确认",
+			Raw:      map[string]interface{}{"error": err.Error()},
+		}, nil
+	}
+	img, err := fetchMomentsSourceImage(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SaveImageFingerprint(ctx, campusImageFingerprintOf(imageURL, img, CampusImageFingerprintSourceAudit)); err != nil {
+		uc.log.WithContext(ctx).Warnf("save campus image fingerprint failed: url=%s err=%v", imageURL, err)
+	}
+	result, err := uc.imageAuditor.AuditImage(ctx, imageURL, img)
+	if err != nil {
+		return nil, err
+	}
+	switch result.Decision {
+	case CampusAIContentAuditDecisionReject, CampusAIContentAuditDecisionReview, CampusImageAuditDecisionSkip:
+	default:
+		result.Decision = CampusAIContentAuditDecisionPass
+	}
+	return result, nil
+}
+
+// skip 表示审核器未配置而没有真正审核，不拦截帖子，但也不会作为通过结果被复用
+func campusImageAuditPassed(decision string) bool {
+	return decision == CampusAIContentAuditDecisionPass || decision == CampusImageAuditDecisionSkip
+}
+
+func (uc *CampusUsecase) holdPostForImageAudit(ctx context.Context, post *CampusForumPost, imageURL string, result *CampusImageAuditResult) error {
+	if post.Status != CampusAuditStatusVisible && post.Status != CampusAuditStatusPending {
+		return nil
+	}
+	reason := firstNonEmpty(result.Reason, "图片需要人工复核")
+	if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusPending, reason); err != nil {
+		return err
+	}
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: "post",
+		TargetID:   post.ID,
+		UserID:     post.AuthorID,
+		Provider:   "image:" + result.Provider,
+		Result:     result.Decision,
+		Reason:     reason,
+	})
+	riskLevel := "medium"
+	if result.Decision == CampusAIContentAuditDecisionReject {
+		riskLevel = "high"
+	}
+	evidence := []string{"image:" + trimLimit(imageURL, 200)}
+	if result.Label != "" {
+		evidence = append(evidence, "label:"+result.Label)
+	}
+	if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, riskLevel, reason, evidence); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue campus image audit alert failed: post_id=%d err=%v", post.ID, err)
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) postImageAuditHoldReason(ctx context.Context, post *CampusForumPost) string {
+	if post == nil || len(post.Images) == 0 {
+		return ""
+	}
+	tasks, err := uc.repo.ListImageAuditTasks(ctx, CampusImageAuditTargetPost, post.ID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus post image audits failed: post_id=%d err=%v", post.ID, err)
+		return ""
+	}
+	for _, task := range tasks {
+		if task == nil || !containsCampusImage(post.Images, task.ImageURL) {
+			continue
+		}
+		if task.Status == CampusAIContentAuditTaskStatusFailed {
+			return "图片审核失败，等待人工复核"
+		}
+		if task.Decision == CampusAIContentAuditDecisionReject || task.Decision == CampusAIContentAuditDecisionReview {
+			return firstNonEmpty(task.Reason, "图片需要人工复核")
+		}
+	}
+	return ""
+}
+
+func (uc *CampusUsecase) markImageAuditTaskRetry(ctx context.Context, task *CampusImageAuditTask, processErr error) {
+	nextRetryCount := task.RetryCount + 1
+	final := nextRetryCount >= campusImageAuditTaskMaxRetry
+	var nextRetryAt *time.Time
+	if !final {
+		next := time.Now().Add(time.Duration(nextRetryCount*nextRetryCount) * time.Minute)
+		nextRetryAt = &next
+	}
+	if err := uc.repo.MarkImageAuditTaskRetry(ctx, task.ID, nextRetryCount, nextRetryAt, processErr.Error(), final); err != nil {
+		uc.log.WithContext(ctx).Warnf("mark campus image audit task retry failed: id=%d err=%v", task.ID, err)
+	}
+	if !final || task.TargetType != CampusImageAuditTargetPost {
+		return
+	}
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, task.TargetID)
+	if err == nil && ok && post != nil {
+		_ = uc.holdPostForImageAudit(ctx, post, task.ImageURL, &CampusImageAuditResult{
+			Provider: uc.imageAuditor.Name(),
+			Decision: CampusAIContentAuditDecisionReview,
+			Label:    "audit_failed",
+			Reason:   "图片审核失败，等待人工复核",
+		})
+	}
+}
+
+func (uc *CampusUsecase) AdminListPostImageAudits(ctx context.Context, userID string, postID int64) ([]*CampusImageAuditTask, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	if postID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	tasks, err := uc.repo.ListImageAuditTasks(ctx, CampusImageAuditTargetPost, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取图片审核记录失败")
+	}
+	return tasks, nil
+}
@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"testing"
+
+	"lehu-video/pkg/imagehash"
+)
+
+func campusTestPattern(width, height int) image.Image {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := uint8((x * 200) / width)
+			if (x*5/width+y*3/height)%2 == 0 {
+				value = 255 - value
+			}
+			img.SetGray(x, y, color.Gray{Y: value})
+		}
+	}
+	return img
+}
+
+func TestCampusImageAuditChain(t *testing.T) {
+	img := campusTestPattern(200, 200)
+	legacy := parseCampusImageHashes(imagehash.Format(imagehash.DHash(img)) + ",not-a-hash")
+	if len(legacy) != 1 {
+		t.Fatalf("invalid hashes should be skipped, got %v", legacy)
+	}
+	hashAuditor := &campusHashImageAuditor{
+		blocklist: func(context.Context) []*CampusImageHashBlock {
+			return []*CampusImageHashBlock{{ID: 1, PHash: ^imagehash.PHash(img)}, {ID: 2, DHash: legacy[0]}}
+		},
+		maxDistance: 6,
+	}
+	chain := campusImageAuditChain{campusNoopImageAuditor{}, hashAuditor}
+	result, err := chain.AuditImage(context.Background(), "https://cdn.example.com/a.jpg", img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Decision != CampusAIContentAuditDecisionReject || result.Provider != "hash" || result.Raw["matched_block"] != "2" {
+		t.Fatalf("blocklisted image should be rejected, got %+v", result)
+	}
+	hashAuditor.blocklist = func(context.Context) []*CampusImageHashBlock {
+		return []*CampusImageHashBlock{{ID: 3, PHash: ^imagehash.PHash(img), DHash: imagehash.DHash(img)}}
+	}
+	result, err = chain.AuditImage(context.Background(), "https://cdn.example.com/a.jpg", img)
+	if err != nil || result.Decision != CampusAIContentAuditDecisionPass {
+		t.Fatalf("clean image should pass, got %+v err=%v", result, err)
+	}
+
+	t.Setenv("WECHAT_CONTENT_SECURITY_TOKEN", "")
+	chain = append(chain, &campusWechatImageAuditor{})
+	result, err = chain.AuditImage(context.Background(), "https://cdn.example.com/a.jpg", img)
+	if err != nil || result.Decision != CampusImageAuditDecisionSkip || result.Provider != "wechat" {
+		t.Fatalf("unconfigured wechat auditor should skip, got %+v err=%v", result, err)
+	}
+	if !campusImageAuditPassed(result.Decision) {
+		t.Fatalf("skipped audit should not hold the post")
+	}
+}
@@ -0,0 +1,334 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"os"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+	"lehu-video/pkg/imagehash"
+)
+
+const (
+	CampusImageFingerprintSourceUpload = "upload"
+	CampusImageFingerprintSourceAudit  = "audit"
+
+	campusImageDuplicateActionOff    = "off"
+	campusImageDuplicateActionReview = "review"
+	campusImageDuplicateActionReject = "reject"
+)
+
+type CampusImageFingerprint struct {
+	ImageURL  string
+	FileID    int64
+	PHash     uint64
+	DHash     uint64
+	Source    string
+	CreatedAt time.Time
+}
+
+type CampusImageHashBlock struct {
+	ID           int64
+	PHash        uint64
+	DHash        uint64
+	ImageURL     string
+	SourcePostID int64
+	Reason       string
+	CreatedBy    string
+	CreatedAt    time.Time
+}
+
+// 只比对窗口内最近 ScanLimit 个被驳回或下架的帖子，避免每张图都扫全部审核记录
+type CampusImageDuplicateQuery struct {
+	PHash         uint64
+	ExcludePostID int64
+	MaxDistance   int
+	Since         time.Time
+	ScanLimit     int
+}
+
+type CampusImageDuplicate struct {
+	PostID   int64
+	ImageURL string
+	Status   int32
+	Distance int
+}
+
+type CreateCampusImageHashBlockInput struct {
+	UserID   string
+	PostID   int64
+	ImageURL string
+	PHash    string
+	DHash    string
+	Reason   string
+}
+
+func campusImageFingerprintOf(imageURL string, img image.Image, source string) *CampusImageFingerprint {
+	return &CampusImageFingerprint{
+		ImageURL: imageURL,
+		PHash:    imagehash.PHash(img),
+		DHash:    imagehash.DHash(img),
+		Source:   source,
+	}
+}
+
+func (b *CampusImageHashBlock) match(phash, dhash uint64, maxDistance int) (int, bool) {
+	if b == nil {
+		return 0, false
+	}
+	if b.PHash != 0 && phash != 0 {
+		distance := imagehash.Distance(b.PHash, phash)
+		return distance, distance <= maxDistance
+	}
+	if b.DHash != 0 {
+		distance := imagehash.Distance(b.DHash, dhash)
+		return distance, distance <= maxDistance
+	}
+	return 0, false
+}
+
+func campusImageDuplicateAction() string {
+	switch action := strings.ToLower(strings.TrimSpace(os.Getenv("CAMPUS_IMAGE_DUPLICATE_ACTION"))); action {
+	case campusImageDuplicateActionOff, campusImageDuplicateActionReject:
+		return action
+	default:
+		return campusImageDuplicateActionReview
+	}
+}
+
+func (uc *CampusUsecase) imageHashBlocklist(ctx context.Context) []*CampusImageHashBlock {
+	blocks, err := uc.repo.ListImageHashBlocks(ctx)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus image hash blocklist failed: %v", err)
+	}
+	for _, hash := range parseCampusImageHashes(uc.stringOpsSetting(ctx, campusOpsSettingImageHashBlocklist, "CAMPUS_IMAGE_HASH_BLOCKLIST", "")) {
+		blocks = append(blocks, &CampusImageHashBlock{DHash: hash, Reason: "运营配置黑名单"})
+	}
+	return blocks
+}
+
+func (uc *CampusUsecase) saveUploadImageFingerprint(ctx context.Context, fileID, imageURL string, info *FileInfo) {
+	if info == nil || strings.TrimSpace(imageURL) == "" || info.PHash == "" {
+		return
+	}
+	phash, err := imagehash.Parse(info.PHash)
+	if err != nil {
+		return
+	}
+	dhash, _ := imagehash.Parse(info.DHash)
+	if err := uc.repo.SaveImageFingerprint(ctx, &CampusImageFingerprint{
+		ImageURL: strings.TrimSpace(imageURL),
+		FileID:   parseInt64String(fileID),
+		PHash:    phash,
+		DHash:    dhash,
+		Source:   CampusImageFingerprintSourceUpload,
+	}); err != nil {
+		uc.log.WithContext(ctx).Warnf("save campus upload image fingerprint failed: file_id=%s err=%v", fileID, err)
+	}
+}
+
+func (uc *CampusUsecase) loadImageFingerprint(ctx context.Context, imageURL string) (*CampusImageFingerprint, error) {
+	ok, fingerprint, err := uc.repo.GetImageFingerprint(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return fingerprint, nil
+	}
+	if err := validateMomentsImageURL(imageURL); err != nil {
+		return nil, nil
+	}
+	img, err := fetchMomentsSourceImage(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint = campusImageFingerprintOf(imageURL, img, CampusImageFingerprintSourceAudit)
+	if err := uc.repo.SaveImageFingerprint(ctx, fingerprint); err != nil {
+		uc.log.WithContext(ctx).Warnf("save campus image fingerprint failed: url=%s err=%v", imageURL, err)
+	}
+	return fingerprint, nil
+}
+
+func (uc *CampusUsecase) checkRejectedImageDuplicate(ctx context.Context, post *CampusForumPost, imageURL string) (*CampusImageAuditResult, error) {
+	action := campusImageDuplicateAction()
+	if action == campusImageDuplicateActionOff || post == nil {
+		return nil, nil
+	}
+	fingerprint, err := uc.loadImageFingerprint(ctx, imageURL)
+	if err != nil || fingerprint == nil || fingerprint.PHash == 0 {
+		return nil, err
+	}
+	ok, duplicate, err := uc.repo.FindRejectedImageDuplicate(ctx, CampusImageDuplicateQuery{
+		PHash:         fingerprint.PHash,
+		ExcludePostID: post.ID,
+		MaxDistance:   int(envInt64("CAMPUS_IMAGE_DUPLICATE_MAX_DISTANCE", 8)),
+		Since:         campusLocalNow().Add(-envDurationBiz("CAMPUS_IMAGE_DUPLICATE_WINDOW", 90*24*time.Hour)),
+		ScanLimit:     int(envInt64("CAMPUS_IMAGE_DUPLICATE_SCAN_LIMIT", 5000)),
+	})
+	if err != nil || !ok {
+		return nil, err
+	}
+	decision := CampusAIContentAuditDecisionReview
+	if action == campusImageDuplicateActionReject {
+		decision = CampusAIContentAuditDecisionReject
+	}
+	return &CampusImageAuditResult{
+		Provider: "duplicate",
+		Decision: decision,
+		Label:    "rejected_duplicate",
+		Reason:   "图片与已驳回或下架的帖子图片高度相似",
+		Raw: map[string]interface{}{
+			"phash":          imagehash.Format(fingerprint.PHash),
+			"matched_post":   fmt.Sprintf("%d", duplicate.PostID),
+			"matched_image":  duplicate.ImageURL,
+			"matched_status": duplicate.Status,
+			"distance":       duplicate.Distance,
+		},
+	}, nil
+}
+
+func (uc *CampusUsecase) rejectPostForImageDuplicate(ctx context.Context, post *CampusForumPost, imageURL string, result *CampusImageAuditResult) error {
+	if post.Status != CampusAuditStatusVisible && post.Status != CampusAuditStatusPending {
+		return nil
+	}
+	if err := uc.repo.UpdatePostStatus(ctx, post.ID, CampusAuditStatusRejected, result.Reason); err != nil {
+		return err
+	}
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: "post",
+		TargetID:   post.ID,
+		UserID:     post.AuthorID,
+		Provider:   "image:" + result.Provider,
+		Result:     CampusAIContentAuditDecisionReject,
+		Reason:     result.Reason,
+	})
+	post.Status = CampusAuditStatusRejected
+	post.AuditReason = result.Reason
+	uc.notifyPostAuditResult(ctx, post, false, "这条内容暂未同步")
+	evidence := []string{"image:" + trimLimit(imageURL, 200), "label:" + result.Label}
+	if matched, ok := result.Raw["matched_post"].(string); ok {
+		evidence = append(evidence, "matched_post:"+matched)
+	}
+	if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReject, "high", result.Reason, evidence); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue campus image duplicate alert failed: post_id=%d err=%v", post.ID, err)
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) AdminListImageHashBlocks(ctx context.Context, userID string) ([]*CampusImageHashBlock, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	blocks, err := uc.repo.ListImageHashBlocks(ctx)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取违规图片库失败")
+	}
+	return blocks, nil
+}
+
+func (uc *CampusUsecase) AdminCreateImageHashBlocks(ctx context.Context, input *CreateCampusImageHashBlockInput) ([]*CampusImageHashBlock, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	reason := trimLimit(firstNonEmpty(strings.TrimSpace(input.Reason), "运营加入违规图片库"), 255)
+	blocks := make([]*CampusImageHashBlock, 0)
+	newBlock := func(fingerprint *CampusImageFingerprint, postID int64) *CampusImageHashBlock {
+		return &CampusImageHashBlock{
+			ID:           uc.idGen.NextID(),
+			PHash:        fingerprint.PHash,
+			DHash:        fingerprint.DHash,
+			ImageURL:     fingerprint.ImageURL,
+			SourcePostID: postID,
+			Reason:       reason,
+			CreatedBy:    input.UserID,
+		}
+	}
+	switch {
+	case input.PostID > 0:
+		if err := uc.ensureOperatorPostCampus(ctx, input.UserID, input.PostID); err != nil {
+			return nil, err
+		}
+		ok, post, err := uc.repo.GetAnyPostByID(ctx, input.PostID)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询帖子失败")
+		}
+		if !ok || post == nil {
+			return nil, apperror.NotFound("帖子不存在")
+		}
+		if len(post.Images) == 0 {
+			return nil, apperror.InvalidArgument("帖子没有图片")
+		}
+		for _, imageURL := range post.Images {
+			fingerprint, err := uc.loadImageFingerprint(ctx, imageURL)
+			if err != nil {
+				return nil, apperror.Internal(err, "计算图片指纹失败")
+			}
+			if fingerprint != nil {
+				blocks = append(blocks, newBlock(fingerprint, post.ID))
+			}
+		}
+		if len(blocks) == 0 {
+			return nil, apperror.InvalidArgument("帖子图片不在站内存储，无法计算指纹")
+		}
+	case strings.TrimSpace(input.ImageURL) != "":
+		imageURL := strings.TrimSpace(input.ImageURL)
+		if err := validateMomentsImageURL(imageURL); err != nil {
+			return nil, apperror.InvalidArgument("只能加入站内图片")
+		}
+		fingerprint, err := uc.loadImageFingerprint(ctx, imageURL)
+		if err != nil {
+			return nil, apperror.Internal(err, "计算图片指纹失败")
+		}
+		if fingerprint == nil {
+			return nil, apperror.InvalidArgument("只能加入站内图片")
+		}
+		blocks = append(blocks, newBlock(fingerprint, 0))
+	default:
+		block := &CampusImageHashBlock{ID: uc.idGen.NextID(), Reason: reason, CreatedBy: input.UserID}
+		if value := strings.TrimSpace(input.PHash); value != "" {
+			hash, err := imagehash.Parse(value)
+			if err != nil {
+				return nil, apperror.InvalidArgument("phash 格式无效")
+			}
+			block.PHash = hash
+		}
+		if value := strings.TrimSpace(input.DHash); value != "" {
+			hash, err := imagehash.Parse(value)
+			if err != nil {
+				return nil, apperror.InvalidArgument("dhash 格式无效")
+			}
+			block.DHash = hash
+		}
+		if block.PHash == 0 && block.DHash == 0 {
+			return nil, apperror.InvalidArgument("请提供帖子、图片地址或图片哈希")
+		}
+		blocks = append(blocks, block)
+	}
+	for _, block := range blocks {
+		if err := uc.repo.CreateImageHashBlock(ctx, block); err != nil {
+			return nil, apperror.Internal(err, "加入违规图片库失败")
+		}
+	}
+	return blocks, nil
+}
+
+func (uc *CampusUsecase) AdminDeleteImageHashBlock(ctx context.Context, userID string, id int64) error {
+	if !uc.isCampusOperator(ctx, userID) {
+		return apperror.Forbidden("没有后台权限")
+	}
+	if id <= 0 {
+		return apperror.InvalidArgument("黑名单 ID 无效")
+	}
+	ok, err := uc.repo.DeleteImageHashBlock(ctx, id)
+	if err != nil {
+		return apperror.Internal(err, "移除违规图片失败")
+	}
+	if !ok {
+		return apperror.NotFound("黑名单记录不存在")
+	}
+	return nil
+}
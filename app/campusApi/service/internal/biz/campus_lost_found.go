@@ -0,0 +1,530 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusLostKindLost  = "lost"
+	CampusLostKindFound = "found"
+
+	CampusLostStatusOpen     = "open"
+	CampusLostStatusReturned = "returned"
+
+	CampusLostCategoryCard        = "card"
+	CampusLostCategoryElectronics = "electronics"
+	CampusLostCategoryKeys        = "keys"
+	CampusLostCategoryBag         = "bag"
+	CampusLostCategoryClothing    = "clothing"
+	CampusLostCategoryBook        = "book"
+	CampusLostCategoryOther       = "other"
+
+	campusLostMatchLookback    = 30 * 24 * time.Hour
+	campusLostMatchTimeSlack   = 72 * time.Hour
+	campusLostMatchCandidates  = 200
+	campusLostMatchPerItem     = 3
+	campusLostDefaultThreshold = 0.55
+)
+
+type CampusLostItem struct {
+	PostID     int64
+	CampusCode string
+	AuthorID   string
+	Kind       string
+	Category   string
+	Place      string
+	EventStart *time.Time
+	EventEnd   *time.Time
+	Status     string
+	ResolvedAt *time.Time
+	ResolvedBy string
+	ScannedAt  *time.Time
+	CreatedAt  time.Time
+	Post       *CampusForumPost
+}
+
+type CampusLostItemInput struct {
+	Kind       string
+	Category   string
+	Place      string
+	EventStart *time.Time
+	EventEnd   *time.Time
+}
+
+type CampusLostMatch struct {
+	ID          int64
+	LostPostID  int64
+	FoundPostID int64
+	Score       float64
+	Reasons     []string
+	CreatedAt   time.Time
+	Counterpart *CampusForumPost
+}
+
+type ResolveCampusLostItemInput struct {
+	UserID      string
+	PostID      int64
+	MatchPostID int64
+}
+
+var campusLostCategoryAliases = map[string]string{
+	"card":        CampusLostCategoryCard,
+	"校园卡":         CampusLostCategoryCard,
+	"饭卡":          CampusLostCategoryCard,
+	"证件":          CampusLostCategoryCard,
+	"身份证":         CampusLostCategoryCard,
+	"electronics": CampusLostCategoryElectronics,
+	"电子产品":        CampusLostCategoryElectronics,
+	"手机":          CampusLostCategoryElectronics,
+	"耳机":          CampusLostCategoryElectronics,
+	"keys":        CampusLostCategoryKeys,
+	"钥匙":          CampusLostCategoryKeys,
+	"bag":         CampusLostCategoryBag,
+	"包":           CampusLostCategoryBag,
+	"钱包":          CampusLostCategoryBag,
+	"clothing":    CampusLostCategoryClothing,
+	"衣物":          CampusLostCategoryClothing,
+	"衣服":          CampusLostCategoryClothing,
+	"book":        CampusLostCategoryBook,
+	"书本":          CampusLostCategoryBook,
+	"书":           CampusLostCategoryBook,
+	"other":       CampusLostCategoryOther,
+	"其他":          CampusLostCategoryOther,
+}
+
+func normalizeCampusLostKind(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case CampusLostKindLost, "寻物", "丢失", "失物":
+		return CampusLostKindLost
+	case CampusLostKindFound, "招领", "捡到", "拾到":
+		return CampusLostKindFound
+	default:
+		return ""
+	}
+}
+
+func normalizeCampusLostCategory(value string) string {
+	if category, ok := campusLostCategoryAliases[strings.ToLower(strings.TrimSpace(value))]; ok {
+		return category
+	}
+	return CampusLostCategoryOther
+}
+
+func newCampusLostItem(input *CampusLostItemInput, extra map[string]string, now time.Time) (*CampusLostItem, error) {
+	if input == nil {
+		input = &CampusLostItemInput{Kind: extra["lost_kind"], Place: extra["location"]}
+	}
+	kind := normalizeCampusLostKind(input.Kind)
+	if kind == "" {
+		return nil, apperror.InvalidArgument("请选择是丢失还是捡到")
+	}
+	item := &CampusLostItem{
+		Kind:     kind,
+		Category: normalizeCampusLostCategory(input.Category),
+		Place:    trimLimit(firstNonEmpty(input.Place, extra["location"]), 80),
+		Status:   CampusLostStatusOpen,
+	}
+	start, end := input.EventStart, input.EventEnd
+	if start != nil && end != nil && end.Before(*start) {
+		return nil, apperror.InvalidArgument("时间范围无效")
+	}
+	if start != nil && start.After(now.Add(time.Hour)) {
+		return nil, apperror.InvalidArgument("丢失/捡到时间不能晚于现在")
+	}
+	if start == nil && end != nil {
+		start = end
+	}
+	if start != nil && end == nil {
+		end = start
+	}
+	item.EventStart, item.EventEnd = start, end
+	return item, nil
+}
+
+func campusLostMatchThreshold() float64 {
+	value := envFloatBiz("CAMPUS_LOST_MATCH_THRESHOLD", campusLostDefaultThreshold)
+	if value <= 0 || value > 1 {
+		return campusLostDefaultThreshold
+	}
+	return value
+}
+
+func scoreCampusLostMatch(lost, found *CampusLostItem) (float64, []string) {
+	if lost == nil || found == nil || lost.Post == nil || found.Post == nil {
+		return 0, nil
+	}
+	score := 0.0
+	reasons := make([]string, 0, 4)
+	switch {
+	case lost.Category == found.Category && lost.Category != CampusLostCategoryOther:
+		score += 0.35
+		reasons = append(reasons, "category")
+	case lost.Category == CampusLostCategoryOther || found.Category == CampusLostCategoryOther:
+		score += 0.1
+	}
+	if placeScore := campusLostPlaceSimilarity(lost.Place, found.Place); placeScore > 0 {
+		score += 0.25 * placeScore
+		if placeScore >= 0.5 {
+			reasons = append(reasons, "place")
+		}
+	}
+	if timeScore := campusLostTimeProximity(lost, found); timeScore > 0 {
+		score += 0.2 * timeScore
+		if timeScore >= 0.5 {
+			reasons = append(reasons, "time")
+		}
+	}
+	textScore := campusTokenJaccard(lost.Post.Title+" "+lost.Post.Content, found.Post.Title+" "+found.Post.Content)
+	score += 0.2 * math.Min(1, textScore*2)
+	if textScore >= 0.25 {
+		reasons = append(reasons, "text")
+	}
+	return math.Round(score*1000) / 1000, reasons
+}
+
+func campusLostPlaceSimilarity(a, b string) float64 {
+	a, b = strings.TrimSpace(strings.ToLower(a)), strings.TrimSpace(strings.ToLower(b))
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b || strings.Contains(a, b) || strings.Contains(b, a) {
+		return 1
+	}
+	return campusTokenJaccard(a, b)
+}
+
+func campusLostTimeProximity(lost, found *CampusLostItem) float64 {
+	lostStart, lostEnd := campusLostWindow(lost)
+	foundStart, _ := campusLostWindow(found)
+	if foundStart.Before(lostStart.Add(-time.Hour)) {
+		return 0
+	}
+	if !foundStart.After(lostEnd.Add(campusLostMatchTimeSlack)) {
+		if !foundStart.After(lostEnd.Add(24 * time.Hour)) {
+			return 1
+		}
+		return 0.5
+	}
+	return 0
+}
+
+func campusLostWindow(item *CampusLostItem) (time.Time, time.Time) {
+	if item.EventStart != nil && item.EventEnd != nil {
+		return *item.EventStart, *item.EventEnd
+	}
+	at := item.CreatedAt
+	if item.Post != nil && !item.Post.CreatedAt.IsZero() {
+		at = item.Post.CreatedAt
+	}
+	return at.Add(-24 * time.Hour), at
+}
+
+func campusTokenJaccard(a, b string) float64 {
+	left := map[string]struct{}{}
+	for _, token := range campusSearchTokens(a) {
+		left[token] = struct{}{}
+	}
+	right := map[string]struct{}{}
+	for _, token := range campusSearchTokens(b) {
+		right[token] = struct{}{}
+	}
+	if len(left) == 0 || len(right) == 0 {
+		return 0
+	}
+	shared := 0
+	for token := range left {
+		if _, ok := right[token]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(left)+len(right)-shared)
+}
+
+func (a *CampusPostAssembler) hydrateLostItems(ctx context.Context, posts []*CampusForumPost) {
+	postIDs := make([]int64, 0)
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypeLost {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	items, err := a.repo.ListLostItemsByPostIDs(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load campus lost items failed: %v", err)
+		return
+	}
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypeLost {
+			post.LostItem = items[post.ID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) ProcessLostFoundMatches(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	now := campusLocalNow()
+	items, err := uc.repo.ListUnscannedLostItems(ctx, now.Add(-campusLostMatchLookback), limit)
+	if err != nil {
+		return 0, err
+	}
+	matched := 0
+	for _, item := range items {
+		count, err := uc.matchLostItem(ctx, item, now)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("match campus lost item failed: post_id=%d err=%v", item.PostID, err)
+			continue
+		}
+		matched += count
+	}
+	return matched, nil
+}
+
+func (uc *CampusUsecase) matchLostItem(ctx context.Context, item *CampusLostItem, now time.Time) (int, error) {
+	if err := uc.attachLostItemPosts(ctx, []*CampusLostItem{item}); err != nil {
+		return 0, err
+	}
+	if item.Post == nil || item.Status != CampusLostStatusOpen {
+		return 0, uc.repo.MarkLostItemScanned(ctx, item.PostID, now)
+	}
+	opposite := CampusLostKindLost
+	if item.Kind == CampusLostKindLost {
+		opposite = CampusLostKindFound
+	}
+	candidates, err := uc.repo.ListOpenLostItems(ctx, item.CampusCode, opposite, now.Add(-campusLostMatchLookback), campusLostMatchCandidates)
+	if err != nil {
+		return 0, err
+	}
+	if err := uc.attachLostItemPosts(ctx, candidates); err != nil {
+		return 0, err
+	}
+	threshold := campusLostMatchThreshold()
+	matches := make([]*CampusLostMatch, 0)
+	for _, candidate := range candidates {
+		if candidate.Post == nil || candidate.PostID == item.PostID || candidate.AuthorID == item.AuthorID {
+			continue
+		}
+		lost, found := item, candidate
+		if item.Kind == CampusLostKindFound {
+			lost, found = candidate, item
+		}
+		score, reasons := scoreCampusLostMatch(lost, found)
+		if score < threshold {
+			continue
+		}
+		matches = append(matches, &CampusLostMatch{
+			ID:          uc.idGen.NextID(),
+			LostPostID:  lost.PostID,
+			FoundPostID: found.PostID,
+			Score:       score,
+			Reasons:     reasons,
+			Counterpart: candidate.Post,
+		})
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > campusLostMatchPerItem {
+		matches = matches[:campusLostMatchPerItem]
+	}
+	created := 0
+	for _, match := range matches {
+		lostPost, foundPost := match.Counterpart, item.Post
+		if item.Kind == CampusLostKindLost {
+			lostPost, foundPost = item.Post, match.Counterpart
+		}
+		outboxes := []*CampusNotificationOutbox{
+			uc.lostMatchNotification(match, lostPost, foundPost, true),
+			uc.lostMatchNotification(match, lostPost, foundPost, false),
+		}
+		ok, err := uc.repo.CreateLostMatch(ctx, match, outboxes)
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+	return created, uc.repo.MarkLostItemScanned(ctx, item.PostID, now)
+}
+
+func (uc *CampusUsecase) attachLostItemPosts(ctx context.Context, items []*CampusLostItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	postIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		postIDs = append(postIDs, item.PostID)
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, postIDs, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return err
+	}
+	postMap := make(map[int64]*CampusForumPost, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	for _, item := range items {
+		item.Post = postMap[item.PostID]
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) lostMatchNotification(match *CampusLostMatch, lostPost, foundPost *CampusForumPost, toLoser bool) *CampusNotificationOutbox {
+	recipient, target := foundPost, lostPost
+	title := "有人可能在找你捡到的东西"
+	content := "「" + lostPost.Title + "」和你发布的招领信息很像，去看看吧。"
+	if toLoser {
+		recipient, target = lostPost, foundPost
+		title = "可能有人捡到了你的东西"
+		content = "「" + foundPost.Title + "」和你的寻物信息很像，去看看吧。"
+	}
+	return &CampusNotificationOutbox{
+		ID:          uc.idGen.NextID(),
+		RecipientID: recipient.AuthorID,
+		ActorID:     "0",
+		EventType:   CampusNotificationTypeSystem,
+		TargetType:  "post",
+		TargetID:    target.ID,
+		DedupeKey:   fmt.Sprintf("campus:lost-match:%d:%d:%t", match.LostPostID, match.FoundPostID, toLoser),
+		Title:       title,
+		Content:     trimLimit(content, 80),
+		LinkPage:    "post-detail",
+		LinkParams:  map[string]string{"id": fmt.Sprintf("%d", target.ID)},
+		Status:      CampusNotificationOutboxStatusPending,
+	}
+}
+
+func (uc *CampusUsecase) ListLostMatches(ctx context.Context, userID string, postID int64) ([]*CampusLostMatch, error) {
+	item, err := uc.loadOwnLostItem(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := uc.repo.ListLostMatches(ctx, item.PostID)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取匹配结果失败")
+	}
+	counterpartIDs := make([]int64, 0, len(matches))
+	for _, match := range matches {
+		counterpartIDs = append(counterpartIDs, campusLostCounterpartID(match, item.PostID))
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, counterpartIDs, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取匹配结果失败")
+	}
+	if err := uc.assembler.HydratePosts(ctx, posts, userID); err != nil {
+		uc.log.WithContext(ctx).Warnf("hydrate lost match posts failed: %v", err)
+	}
+	postMap := make(map[int64]*CampusForumPost, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	out := make([]*CampusLostMatch, 0, len(matches))
+	for _, match := range matches {
+		if match.Counterpart = postMap[campusLostCounterpartID(match, item.PostID)]; match.Counterpart != nil {
+			out = append(out, match)
+		}
+	}
+	return out, nil
+}
+
+func (uc *CampusUsecase) ResolveLostItem(ctx context.Context, input *ResolveCampusLostItemInput) (*CampusLostItem, error) {
+	item, err := uc.loadOwnLostItem(ctx, input.UserID, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	if item.Status == CampusLostStatusReturned {
+		return item, nil
+	}
+	var counterpart *CampusLostItem
+	if input.MatchPostID > 0 {
+		matches, err := uc.repo.ListLostMatches(ctx, item.PostID)
+		if err != nil {
+			return nil, apperror.Internal(err, "获取匹配结果失败")
+		}
+		paired := false
+		for _, match := range matches {
+			if campusLostCounterpartID(match, item.PostID) == input.MatchPostID {
+				paired = true
+				break
+			}
+		}
+		if !paired {
+			return nil, apperror.InvalidArgument("这两条信息没有匹配记录")
+		}
+		items, err := uc.repo.ListLostItemsByPostIDs(ctx, []int64{input.MatchPostID})
+		if err != nil {
+			return nil, apperror.Internal(err, "查询失物信息失败")
+		}
+		// 对方的帖子只有对方自己能关闭，这里只通知对方确认
+		counterpart = items[input.MatchPostID]
+	}
+	now := campusLocalNow()
+	if err := uc.repo.ResolveLostItems(ctx, []int64{item.PostID}, input.UserID, now); err != nil {
+		return nil, apperror.Internal(err, "标记归还失败")
+	}
+	item.Status = CampusLostStatusReturned
+	item.ResolvedAt = &now
+	item.ResolvedBy = input.UserID
+	if counterpart != nil && counterpart.Status != CampusLostStatusReturned {
+		uc.notifyLostCounterpartResolved(ctx, item, counterpart)
+	}
+	return item, nil
+}
+
+func (uc *CampusUsecase) notifyLostCounterpartResolved(ctx context.Context, item, counterpart *CampusLostItem) {
+	content := "失主已确认找回物品，如果确实已交接，请到你的招领帖里标记已归还。"
+	if item.Kind == CampusLostKindFound {
+		content = "捡到东西的同学已标记物品归还给你，如果已经拿到，请到你的帖子里确认找回。"
+	}
+	uc.queueUserSystemNotification(ctx, counterpart.AuthorID, "post", counterpart.PostID,
+		"请确认物品是否已归还",
+		content,
+		"post-detail", map[string]string{"id": fmt.Sprintf("%d", counterpart.PostID)},
+		fmt.Sprintf("campus:lost-resolve:%d:%d", item.PostID, counterpart.PostID))
+}
+
+func (uc *CampusUsecase) loadOwnLostItem(ctx context.Context, userID string, postID int64) (*CampusLostItem, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	if postID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	items, err := uc.repo.ListLostItemsByPostIDs(ctx, []int64{postID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询失物信息失败")
+	}
+	item := items[postID]
+	if item == nil {
+		return nil, apperror.NotFound("失物信息不存在")
+	}
+	if item.AuthorID != userID {
+		if !uc.isCampusOperator(ctx, userID) {
+			return nil, apperror.Forbidden("只能处理自己发布的失物信息")
+		}
+		if err := uc.ensureOperatorCampus(ctx, userID, item.CampusCode); err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+func campusLostCounterpartID(match *CampusLostMatch, postID int64) int64 {
+	if match.LostPostID == postID {
+		return match.FoundPostID
+	}
+	return match.LostPostID
+}
+
+func campusLostItemClosed(post *CampusForumPost) bool {
+	return post != nil && post.LostItem != nil && post.LostItem.Status == CampusLostStatusReturned
+}
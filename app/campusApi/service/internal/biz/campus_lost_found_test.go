@@ -0,0 +1,63 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCampusLostItem(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	if _, err := newCampusLostItem(nil, map[string]string{}, now); err == nil {
+		t.Fatal("missing kind should fail")
+	}
+	item, err := newCampusLostItem(nil, map[string]string{"lost_kind": "招领", "location": "图书馆三楼"}, now)
+	if err != nil {
+		t.Fatalf("derive from extra: %v", err)
+	}
+	if item.Kind != CampusLostKindFound || item.Place != "图书馆三楼" || item.Category != CampusLostCategoryOther {
+		t.Fatalf("unexpected item %+v", item)
+	}
+	start, end := now.Add(-2*time.Hour), now.Add(-3*time.Hour)
+	if _, err := newCampusLostItem(&CampusLostItemInput{Kind: "lost", EventStart: &start, EventEnd: &end}, nil, now); err == nil {
+		t.Fatal("reversed window should fail")
+	}
+	item, err = newCampusLostItem(&CampusLostItemInput{Kind: "lost", Category: "校园卡", EventStart: &start}, nil, now)
+	if err != nil {
+		t.Fatalf("structured input: %v", err)
+	}
+	if item.Category != CampusLostCategoryCard || item.EventEnd == nil || !item.EventEnd.Equal(start) {
+		t.Fatalf("unexpected item %+v", item)
+	}
+}
+
+func TestScoreCampusLostMatch(t *testing.T) {
+	lostAt := time.Date(2026, 10, 15, 12, 0, 0, 0, time.Local)
+	foundAt := lostAt.Add(3 * time.Hour)
+	lost := &CampusLostItem{
+		Kind: CampusLostKindLost, Category: CampusLostCategoryCard, Place: "二食堂",
+		EventStart: &lostAt, EventEnd: &lostAt,
+		Post: &CampusForumPost{Title: "丢了校园卡", Content: "在二食堂丢了一张蓝色校园卡"},
+	}
+	found := &CampusLostItem{
+		Kind: CampusLostKindFound, Category: CampusLostCategoryCard, Place: "二食堂一楼",
+		EventStart: &foundAt, EventEnd: &foundAt,
+		Post: &CampusForumPost{Title: "捡到校园卡", Content: "二食堂一楼捡到蓝色校园卡"},
+	}
+	score, reasons := scoreCampusLostMatch(lost, found)
+	if score < campusLostDefaultThreshold {
+		t.Fatalf("similar items should match, score=%v reasons=%v", score, reasons)
+	}
+	other := &CampusLostItem{
+		Kind: CampusLostKindFound, Category: CampusLostCategoryElectronics, Place: "体育馆",
+		EventStart: &foundAt, EventEnd: &foundAt,
+		Post: &CampusForumPost{Title: "捡到耳机", Content: "体育馆看台捡到白色耳机"},
+	}
+	if score, _ := scoreCampusLostMatch(lost, other); score >= campusLostDefaultThreshold {
+		t.Fatalf("unrelated items should not match, score=%v", score)
+	}
+	early := lostAt.Add(-48 * time.Hour)
+	found.EventStart, found.EventEnd = &early, &early
+	if got := campusLostTimeProximity(lost, found); got != 0 {
+		t.Fatalf("found before lost should not overlap, got %v", got)
+	}
+}
@@ -0,0 +1,343 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusClaimFilterMine      = "mine"
+	CampusClaimFilterAvailable = "available"
+)
+
+// 审核认领按内容（帖子/评论）加锁，审核队列和举报队列共用同一把锁
+type CampusModerationClaim struct {
+	TargetType string
+	TargetID   int64
+	OperatorID string
+	Operator   *CampusForumAuthor
+	AssignedBy string
+	ClaimedAt  time.Time
+	ExpiresAt  time.Time
+}
+
+type ClaimCampusModerationInput struct {
+	UserID     string
+	TargetType string
+	TargetID   int64
+}
+
+type ReleaseCampusModerationInput struct {
+	UserID     string
+	TargetType string
+	TargetID   int64
+	Force      bool
+}
+
+type AssignCampusModerationInput struct {
+	UserID     string
+	TargetType string
+	TargetID   int64
+	AssigneeID string
+}
+
+type CampusModerationOperatorStats struct {
+	OperatorID     string
+	Operator       *CampusForumAuthor
+	Reviewed       int64
+	Approved       int64
+	Rejected       int64
+	Deleted        int64
+	ReportsHandled int64
+	AppealsHandled int64
+	ActiveClaims   int64
+	LastActionAt   time.Time
+}
+
+type ListCampusModerationStatsInput struct {
+	UserID string
+	Days   int32
+}
+
+type ListCampusModerationStatsOutput struct {
+	Operators []*CampusModerationOperatorStats
+	Since     time.Time
+}
+
+func campusModerationClaimTTL() time.Duration {
+	return envDurationBiz("CAMPUS_MODERATION_CLAIM_TTL", 15*time.Minute)
+}
+
+func campusModerationAssignTTL() time.Duration {
+	return envDurationBiz("CAMPUS_MODERATION_ASSIGN_TTL", 2*time.Hour)
+}
+
+func normalizeCampusClaimFilter(filter string) string {
+	switch strings.TrimSpace(strings.ToLower(filter)) {
+	case CampusClaimFilterMine:
+		return CampusClaimFilterMine
+	case CampusClaimFilterAvailable, "unclaimed":
+		return CampusClaimFilterAvailable
+	default:
+		return ""
+	}
+}
+
+func (uc *CampusUsecase) moderationClaimTarget(ctx context.Context, userID, targetType string, targetID int64) (string, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return "", apperror.Forbidden("没有审核权限")
+	}
+	targetType = normalizeCampusTargetType(targetType)
+	if targetType == "" || targetID <= 0 {
+		return "", apperror.InvalidArgument("认领对象无效")
+	}
+	if err := uc.ensureOperatorTargetCampus(ctx, userID, targetType, targetID); err != nil {
+		return "", err
+	}
+	return targetType, nil
+}
+
+func (uc *CampusUsecase) ClaimModerationItem(ctx context.Context, input *ClaimCampusModerationInput) (*CampusModerationClaim, error) {
+	targetType, err := uc.moderationClaimTarget(ctx, input.UserID, input.TargetType, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	claim := &CampusModerationClaim{
+		TargetType: targetType,
+		TargetID:   input.TargetID,
+		OperatorID: input.UserID,
+		ClaimedAt:  now,
+		ExpiresAt:  now.Add(campusModerationClaimTTL()),
+	}
+	acquired, current, err := uc.repo.ClaimModerationItem(ctx, claim, false)
+	if err != nil {
+		return nil, apperror.Internal(err, "认领失败")
+	}
+	if !acquired {
+		return nil, uc.moderationClaimConflict(ctx, current)
+	}
+	uc.fillModerationClaimOperators(ctx, []*CampusModerationClaim{current})
+	return current, nil
+}
+
+func (uc *CampusUsecase) ReleaseModerationItem(ctx context.Context, input *ReleaseCampusModerationInput) error {
+	targetType, err := uc.moderationClaimTarget(ctx, input.UserID, input.TargetType, input.TargetID)
+	if err != nil {
+		return err
+	}
+	ok, claim, err := uc.repo.GetActiveModerationClaim(ctx, targetType, input.TargetID, time.Now())
+	if err != nil {
+		return apperror.Internal(err, "查询认领失败")
+	}
+	if !ok {
+		return nil
+	}
+	operatorID := input.UserID
+	if claim.OperatorID != input.UserID {
+		if !input.Force || !uc.isCampusAdmin(ctx, input.UserID) {
+			return apperror.Forbidden("只能释放自己认领的内容")
+		}
+		operatorID = ""
+	}
+	if _, err := uc.repo.ReleaseModerationClaim(ctx, targetType, input.TargetID, operatorID); err != nil {
+		return apperror.Internal(err, "释放认领失败")
+	}
+	if operatorID == "" {
+		uc.recordModerationClaimAudit(ctx, input.UserID, targetType, input.TargetID, "force_release", "释放 "+claim.OperatorID+" 的认领")
+	}
+	return nil
+}
+
+// 管理员可以把内容指派给某个运营，覆盖当前认领
+func (uc *CampusUsecase) AssignModerationItem(ctx context.Context, input *AssignCampusModerationInput) (*CampusModerationClaim, error) {
+	targetType, err := uc.moderationClaimTarget(ctx, input.UserID, input.TargetType, input.TargetID)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.isCampusAdmin(ctx, input.UserID) {
+		return nil, apperror.Forbidden("只有管理员可以指派审核")
+	}
+	assigneeID := strings.TrimSpace(input.AssigneeID)
+	if assigneeID == "" || !uc.isCampusOperator(ctx, assigneeID) {
+		return nil, apperror.InvalidArgument("只能指派给运营账号")
+	}
+	if err := uc.ensureOperatorTargetCampus(ctx, assigneeID, targetType, input.TargetID); err != nil {
+		return nil, apperror.InvalidArgument("该运营不负责这条内容所在的校区")
+	}
+	now := time.Now()
+	claim := &CampusModerationClaim{
+		TargetType: targetType,
+		TargetID:   input.TargetID,
+		OperatorID: assigneeID,
+		AssignedBy: input.UserID,
+		ClaimedAt:  now,
+		ExpiresAt:  now.Add(campusModerationAssignTTL()),
+	}
+	_, current, err := uc.repo.ClaimModerationItem(ctx, claim, true)
+	if err != nil {
+		return nil, apperror.Internal(err, "指派失败")
+	}
+	uc.recordModerationClaimAudit(ctx, input.UserID, targetType, input.TargetID, "assign", "指派给 "+assigneeID)
+	uc.fillModerationClaimOperators(ctx, []*CampusModerationClaim{current})
+	return current, nil
+}
+
+// 没人认领或自己认领时可以直接处理；被别人认领时只有管理员带 force 才能处理
+func (uc *CampusUsecase) ensureModerationClaim(ctx context.Context, userID, targetType string, targetID int64, force bool) error {
+	ok, claim, err := uc.repo.GetActiveModerationClaim(ctx, targetType, targetID, time.Now())
+	if err != nil {
+		return apperror.Internal(err, "查询认领失败")
+	}
+	if !ok || claim.OperatorID == userID {
+		return nil
+	}
+	if force && uc.isCampusAdmin(ctx, userID) {
+		uc.recordModerationClaimAudit(ctx, userID, targetType, targetID, "force_review", "越过 "+claim.OperatorID+" 的认领处理")
+		return nil
+	}
+	return uc.moderationClaimConflict(ctx, claim)
+}
+
+func (uc *CampusUsecase) moderationClaimConflict(ctx context.Context, claim *CampusModerationClaim) error {
+	if claim == nil {
+		return apperror.Conflict("该内容已被其他运营认领")
+	}
+	uc.fillModerationClaimOperators(ctx, []*CampusModerationClaim{claim})
+	name := "其他运营"
+	if claim.Operator != nil {
+		name = firstNonEmpty(claim.Operator.Name, claim.Operator.Nickname, name)
+	}
+	return apperror.Conflict(fmt.Sprintf("该内容已被%s认领（%s 到期），请等待释放或联系管理员", name, claim.ExpiresAt.In(campusLocalNow().Location()).Format("15:04")))
+}
+
+func (uc *CampusUsecase) recordModerationClaimAudit(ctx context.Context, userID, targetType string, targetID int64, result, reason string) {
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Provider:   "claim",
+		Result:     result,
+		Reason:     trimLimit(reason, 255),
+	})
+}
+
+func (uc *CampusUsecase) releaseModerationClaim(ctx context.Context, targetType string, targetID int64) {
+	if _, err := uc.repo.ReleaseModerationClaim(ctx, targetType, targetID, ""); err != nil {
+		uc.log.WithContext(ctx).Warnf("release moderation claim failed: target=%s:%d err=%v", targetType, targetID, err)
+	}
+}
+
+func (uc *CampusUsecase) fillModerationClaimOperators(ctx context.Context, claims []*CampusModerationClaim) {
+	ids := make([]string, 0, len(claims))
+	seen := map[string]struct{}{}
+	for _, claim := range claims {
+		if claim != nil {
+			appendUniqueUserID(&ids, seen, claim.OperatorID)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	authors, err := uc.assembler.LoadAuthors(ctx, ids)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load moderation claim operators failed: %v", err)
+		return
+	}
+	for _, claim := range claims {
+		if claim != nil {
+			claim.Operator = authors[claim.OperatorID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) loadModerationClaims(ctx context.Context, targetType string, ids []int64) map[int64]*CampusModerationClaim {
+	if len(ids) == 0 {
+		return map[int64]*CampusModerationClaim{}
+	}
+	claims, err := uc.repo.ListActiveModerationClaims(ctx, targetType, ids, time.Now())
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("list moderation claims failed: type=%s err=%v", targetType, err)
+		return map[int64]*CampusModerationClaim{}
+	}
+	list := make([]*CampusModerationClaim, 0, len(claims))
+	for _, claim := range claims {
+		list = append(list, claim)
+	}
+	uc.fillModerationClaimOperators(ctx, list)
+	return claims
+}
+
+func (uc *CampusUsecase) attachPostClaims(ctx context.Context, posts []*CampusForumPost) {
+	ids := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		if post != nil {
+			ids = append(ids, post.ID)
+		}
+	}
+	claims := uc.loadModerationClaims(ctx, "post", ids)
+	for _, post := range posts {
+		if post != nil {
+			post.Claim = claims[post.ID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) attachReportClaims(ctx context.Context, reports []*CampusForumReport) {
+	ids := map[string][]int64{}
+	for _, report := range reports {
+		if report != nil {
+			ids[report.TargetType] = append(ids[report.TargetType], report.TargetID)
+		}
+	}
+	claims := map[string]map[int64]*CampusModerationClaim{}
+	for targetType, targetIDs := range ids {
+		claims[targetType] = uc.loadModerationClaims(ctx, targetType, targetIDs)
+	}
+	for _, report := range reports {
+		if report != nil {
+			report.Claim = claims[report.TargetType][report.TargetID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) ExpireModerationClaims(ctx context.Context) (int64, error) {
+	return uc.repo.DeleteExpiredModerationClaims(ctx, time.Now())
+}
+
+func (uc *CampusUsecase) AdminModerationOperatorStats(ctx context.Context, input *ListCampusModerationStatsInput) (*ListCampusModerationStatsOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	days := input.Days
+	if days <= 0 {
+		days = 7
+	}
+	if days > 90 {
+		days = 90
+	}
+	now := time.Now()
+	since := now.AddDate(0, 0, -int(days))
+	stats, err := uc.repo.ListModerationOperatorStats(ctx, since, now)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取审核统计失败")
+	}
+	ids := make([]string, 0, len(stats))
+	seen := map[string]struct{}{}
+	for _, item := range stats {
+		appendUniqueUserID(&ids, seen, item.OperatorID)
+	}
+	if authors, err := uc.assembler.LoadAuthors(ctx, ids); err == nil {
+		for _, item := range stats {
+			item.Operator = authors[item.OperatorID]
+		}
+	} else {
+		uc.log.WithContext(ctx).Warnf("load moderation stats operators failed: %v", err)
+	}
+	return &ListCampusModerationStatsOutput{Operators: stats, Since: since}, nil
+}
@@ -0,0 +1,79 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type claimStubRepo struct {
+	CampusRepo
+	claim  *CampusModerationClaim
+	roles  map[string]string
+	audits []*CampusAuditLog
+}
+
+func (r *claimStubRepo) GetActiveModerationClaim(ctx context.Context, targetType string, targetID int64, now time.Time) (bool, *CampusModerationClaim, error) {
+	return r.claim != nil, r.claim, nil
+}
+
+func (r *claimStubRepo) GetCampusOperatorRole(ctx context.Context, userID string) (string, error) {
+	return r.roles[userID], nil
+}
+
+func (r *claimStubRepo) CreateAuditLog(ctx context.Context, log *CampusAuditLog) error {
+	r.audits = append(r.audits, log)
+	return nil
+}
+
+type claimStubIDGen struct{}
+
+func (claimStubIDGen) NextID() int64 { return 1 }
+
+func TestNormalizeCampusClaimFilter(t *testing.T) {
+	cases := map[string]string{
+		"mine":       CampusClaimFilterMine,
+		" Available": CampusClaimFilterAvailable,
+		"unclaimed":  CampusClaimFilterAvailable,
+		"":           "",
+		"all":        "",
+	}
+	for input, want := range cases {
+		if got := normalizeCampusClaimFilter(input); got != want {
+			t.Fatalf("normalizeCampusClaimFilter(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestEnsureModerationClaim(t *testing.T) {
+	t.Setenv("LEHU_CAMPUS_ADMIN_ALLOW_ALL", "")
+	t.Setenv("LEHU_CAMPUS_ADMIN_USER_IDS", "")
+	expires := time.Date(2026, 10, 16, 4, 30, 0, 0, time.UTC)
+	repo := &claimStubRepo{
+		claim: &CampusModerationClaim{TargetType: "post", TargetID: 9, OperatorID: "1", ExpiresAt: expires},
+		roles: map[string]string{"1": "operator", "2": "operator", "3": "admin"},
+	}
+	uc := &CampusUsecase{repo: repo, idGen: claimStubIDGen{}, assembler: &CampusPostAssembler{}}
+	ctx := context.Background()
+
+	if err := uc.ensureModerationClaim(ctx, "1", "post", 9, false); err != nil {
+		t.Fatalf("claimer should pass, got %v", err)
+	}
+	err := uc.ensureModerationClaim(ctx, "2", "post", 9, false)
+	if err == nil || !strings.Contains(err.Error(), "12:30 到期") {
+		t.Fatalf("non-claimer should be rejected with local expiry, got %v", err)
+	}
+	if err := uc.ensureModerationClaim(ctx, "2", "post", 9, true); err == nil {
+		t.Fatal("forced non-admin should be rejected")
+	}
+	if len(repo.audits) != 0 {
+		t.Fatalf("rejected attempts should not be audited, got %d", len(repo.audits))
+	}
+	if err := uc.ensureModerationClaim(ctx, "3", "post", 9, true); err != nil {
+		t.Fatalf("forced admin should pass, got %v", err)
+	}
+	if len(repo.audits) != 1 || repo.audits[0].Result != "force_review" {
+		t.Fatalf("forced admin review should be audited, got %+v", repo.audits)
+	}
+}
@@ -0,0 +1,351 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusPollChoiceSingle = "single"
+	CampusPollChoiceMulti  = "multi"
+
+	CampusPollStatusOpen   = "open"
+	CampusPollStatusClosed = "closed"
+
+	campusPollMinOptions      = 2
+	campusPollMaxOptions      = 10
+	campusPollOptionMaxRunes  = 30
+	campusPollDefaultDuration = 7 * 24 * time.Hour
+	campusPollMaxDuration     = 30 * 24 * time.Hour
+	campusPollVoterPreview    = 10
+)
+
+type CampusPoll struct {
+	PostID     int64
+	ChoiceMode string
+	MaxChoices int32
+	ClosesAt   *time.Time
+	Anonymous  bool
+	Status     string
+	ClosedAt   *time.Time
+	Options    []*CampusPollOption
+	VoterCount int64
+	MyChoices  []int32
+	CreatedAt  time.Time
+}
+
+type CampusPollOption struct {
+	ID        int32
+	Text      string
+	VoteCount int64
+	Voters    []*CampusForumAuthor
+}
+
+type CampusPollInput struct {
+	Options    []string
+	ChoiceMode string
+	MaxChoices int32
+	ClosesAt   *time.Time
+	Anonymous  bool
+}
+
+type VoteCampusPollInput struct {
+	UserID    string
+	PostID    int64
+	OptionIDs []int32
+}
+
+func (p *CampusPoll) IsClosed(now time.Time) bool {
+	if p == nil {
+		return true
+	}
+	return p.Status == CampusPollStatusClosed || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+func (p *CampusPoll) HasVoted() bool {
+	return p != nil && len(p.MyChoices) > 0
+}
+
+func newCampusPoll(input *CampusPollInput, now time.Time) (*CampusPoll, error) {
+	if input == nil {
+		return nil, apperror.InvalidArgument("请填写投票选项")
+	}
+	options := make([]*CampusPollOption, 0, len(input.Options))
+	seen := map[string]struct{}{}
+	for _, raw := range input.Options {
+		text := strings.TrimSpace(raw)
+		if text == "" {
+			continue
+		}
+		if len([]rune(text)) > campusPollOptionMaxRunes {
+			return nil, apperror.InvalidArgument(fmt.Sprintf("投票选项不能超过 %d 个字", campusPollOptionMaxRunes))
+		}
+		if _, ok := seen[text]; ok {
+			return nil, apperror.InvalidArgument("投票选项不能重复")
+		}
+		seen[text] = struct{}{}
+		options = append(options, &CampusPollOption{ID: int32(len(options) + 1), Text: text})
+	}
+	if len(options) < campusPollMinOptions || len(options) > campusPollMaxOptions {
+		return nil, apperror.InvalidArgument(fmt.Sprintf("投票需要 %d-%d 个选项", campusPollMinOptions, campusPollMaxOptions))
+	}
+	poll := &CampusPoll{
+		ChoiceMode: CampusPollChoiceSingle,
+		MaxChoices: 1,
+		Anonymous:  input.Anonymous,
+		Status:     CampusPollStatusOpen,
+		Options:    options,
+	}
+	switch strings.ToLower(strings.TrimSpace(input.ChoiceMode)) {
+	case "", CampusPollChoiceSingle:
+	case CampusPollChoiceMulti:
+		poll.ChoiceMode = CampusPollChoiceMulti
+		poll.MaxChoices = input.MaxChoices
+		if poll.MaxChoices <= 1 || poll.MaxChoices > int32(len(options)) {
+			poll.MaxChoices = int32(len(options))
+		}
+	default:
+		return nil, apperror.InvalidArgument("投票方式无效")
+	}
+	closesAt := now.Add(campusPollDefaultDuration)
+	if input.ClosesAt != nil && !input.ClosesAt.IsZero() {
+		if !input.ClosesAt.After(now) {
+			return nil, apperror.InvalidArgument("投票截止时间需要晚于当前时间")
+		}
+		if input.ClosesAt.Sub(now) > campusPollMaxDuration {
+			return nil, apperror.InvalidArgument("投票最长持续 30 天")
+		}
+		closesAt = *input.ClosesAt
+	}
+	poll.ClosesAt = &closesAt
+	return poll, nil
+}
+
+func normalizeCampusPollChoices(poll *CampusPoll, optionIDs []int32) ([]int32, error) {
+	valid := make(map[int32]struct{}, len(poll.Options))
+	for _, option := range poll.Options {
+		valid[option.ID] = struct{}{}
+	}
+	seen := map[int32]struct{}{}
+	choices := make([]int32, 0, len(optionIDs))
+	for _, id := range optionIDs {
+		if _, ok := valid[id]; !ok {
+			return nil, apperror.InvalidArgument("投票选项不存在")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		choices = append(choices, id)
+	}
+	if len(choices) == 0 {
+		return nil, apperror.InvalidArgument("请选择投票选项")
+	}
+	if poll.ChoiceMode != CampusPollChoiceMulti && len(choices) > 1 {
+		return nil, apperror.InvalidArgument("这是单选投票")
+	}
+	if int32(len(choices)) > poll.MaxChoices {
+		return nil, apperror.InvalidArgument(fmt.Sprintf("最多选择 %d 项", poll.MaxChoices))
+	}
+	return choices, nil
+}
+
+func (a *CampusPostAssembler) hydratePolls(ctx context.Context, posts []*CampusForumPost, currentUserID string) {
+	postIDs := make([]int64, 0)
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypePoll {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	polls, err := a.repo.GetPostPolls(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load campus polls failed: %v", err)
+		return
+	}
+	choices := map[int64][]int32{}
+	if currentUserID != "" && currentUserID != "0" {
+		if choices, err = a.repo.ListPollChoices(ctx, currentUserID, postIDs); err != nil {
+			a.log.WithContext(ctx).Warnf("load campus poll choices failed: %v", err)
+		}
+	}
+	for _, post := range posts {
+		if post == nil || post.PostType != CampusPostTypePoll {
+			continue
+		}
+		post.Poll = polls[post.ID]
+		if post.Poll != nil {
+			post.Poll.MyChoices = choices[post.ID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) GetPostPoll(ctx context.Context, input *GetCampusPostInput) (*CampusPoll, error) {
+	post, err := uc.GetPost(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if post.Poll == nil {
+		return nil, apperror.NotFound("这条帖子没有投票")
+	}
+	if !post.Poll.Anonymous {
+		uc.fillPollVoters(ctx, post.Poll)
+	}
+	return post.Poll, nil
+}
+
+func (uc *CampusUsecase) VotePoll(ctx context.Context, input *VoteCampusPollInput) (*CampusPoll, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	if input.PostID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetPostByID(ctx, input.PostID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post.PostType != CampusPostTypePoll {
+		return nil, apperror.NotFound("投票不存在")
+	}
+	polls, err := uc.repo.GetPostPolls(ctx, []int64{post.ID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询投票失败")
+	}
+	poll := polls[post.ID]
+	if poll == nil {
+		return nil, apperror.NotFound("投票不存在")
+	}
+	if poll.IsClosed(campusLocalNow()) {
+		return nil, apperror.InvalidArgument("投票已结束")
+	}
+	choices, err := normalizeCampusPollChoices(poll, input.OptionIDs)
+	if err != nil {
+		return nil, err
+	}
+	voted, err := uc.repo.VotePoll(ctx, post.ID, input.UserID, choices)
+	if err != nil {
+		return nil, apperror.Internal(err, "投票失败")
+	}
+	if !voted {
+		return nil, apperror.InvalidArgument("你已经投过票了")
+	}
+	return uc.GetPostPoll(ctx, &GetCampusPostInput{PostID: post.ID, CurrentUserID: input.UserID})
+}
+
+func (uc *CampusUsecase) ClosePoll(ctx context.Context, userID string, postID int64) (*CampusPoll, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	if postID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post.Status == CampusAuditStatusDeleted || post.PostType != CampusPostTypePoll {
+		return nil, apperror.NotFound("投票不存在")
+	}
+	if post.AuthorID != userID {
+		if !uc.isCampusOperator(ctx, userID) {
+			return nil, apperror.Forbidden("只能结束自己发起的投票")
+		}
+		if err := uc.ensureOperatorCampus(ctx, userID, post.CampusCode); err != nil {
+			return nil, err
+		}
+	}
+	var outbox *CampusNotificationOutbox
+	if post.AuthorID != userID {
+		outbox = uc.pollClosedNotification(post)
+	}
+	if _, err := uc.repo.ClosePoll(ctx, postID, campusLocalNow(), outbox); err != nil {
+		return nil, apperror.Internal(err, "结束投票失败")
+	}
+	return uc.GetPostPoll(ctx, &GetCampusPostInput{PostID: postID, CurrentUserID: userID})
+}
+
+func (uc *CampusUsecase) ProcessDuePolls(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	now := campusLocalNow()
+	postIDs, err := uc.repo.ListDuePollPostIDs(ctx, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	closed := 0
+	for _, postID := range postIDs {
+		ok, post, err := uc.repo.GetAnyPostByID(ctx, postID)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("load campus poll post failed: post_id=%d err=%v", postID, err)
+			continue
+		}
+		var outbox *CampusNotificationOutbox
+		if ok && post.Status == CampusAuditStatusVisible {
+			outbox = uc.pollClosedNotification(post)
+		}
+		changed, err := uc.repo.ClosePoll(ctx, postID, now, outbox)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("close campus poll failed: post_id=%d err=%v", postID, err)
+			continue
+		}
+		if changed {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+func (uc *CampusUsecase) pollClosedNotification(post *CampusForumPost) *CampusNotificationOutbox {
+	if post == nil || strings.TrimSpace(post.AuthorID) == "" || post.AuthorID == "0" {
+		return nil
+	}
+	return &CampusNotificationOutbox{
+		ID:          uc.idGen.NextID(),
+		RecipientID: post.AuthorID,
+		ActorID:     "0",
+		EventType:   CampusNotificationTypeSystem,
+		TargetType:  "post",
+		TargetID:    post.ID,
+		DedupeKey:   fmt.Sprintf("campus:poll-closed:%d", post.ID),
+		Title:       "你发起的投票已结束",
+		Content:     trimLimit("「"+post.Title+"」的投票已截止，去看看结果吧。", 80),
+		LinkPage:    "post-detail",
+		LinkParams:  map[string]string{"id": fmt.Sprintf("%d", post.ID)},
+		Status:      CampusNotificationOutboxStatusPending,
+	}
+}
+
+func (uc *CampusUsecase) fillPollVoters(ctx context.Context, poll *CampusPoll) {
+	voters, err := uc.repo.ListPollVoters(ctx, poll.PostID, campusPollVoterPreview)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus poll voters failed: post_id=%d err=%v", poll.PostID, err)
+		return
+	}
+	userIDs := make([]string, 0)
+	seen := map[string]struct{}{}
+	for _, ids := range voters {
+		for _, id := range ids {
+			appendUniqueUserID(&userIDs, seen, id)
+		}
+	}
+	authors, err := uc.assembler.LoadAuthors(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus poll voter profiles failed: post_id=%d err=%v", poll.PostID, err)
+		return
+	}
+	for _, option := range poll.Options {
+		for _, id := range voters[option.ID] {
+			if author := authors[id]; author != nil {
+				option.Voters = append(option.Voters, author)
+			}
+		}
+	}
+}
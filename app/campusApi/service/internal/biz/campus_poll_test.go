@@ -0,0 +1,59 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCampusPoll(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	if _, err := newCampusPoll(&CampusPollInput{Options: []string{"二食堂", " "}}, now); err == nil {
+		t.Fatal("poll with one option should fail")
+	}
+	if _, err := newCampusPoll(&CampusPollInput{Options: []string{"二食堂", "二食堂"}}, now); err == nil {
+		t.Fatal("duplicate options should fail")
+	}
+	poll, err := newCampusPoll(&CampusPollInput{Options: []string{"一食堂", "二食堂", "外卖"}}, now)
+	if err != nil {
+		t.Fatalf("new poll: %v", err)
+	}
+	if poll.ChoiceMode != CampusPollChoiceSingle || poll.MaxChoices != 1 || len(poll.Options) != 3 || poll.Options[2].ID != 3 {
+		t.Fatalf("unexpected poll %+v", poll)
+	}
+	if !poll.ClosesAt.Equal(now.Add(campusPollDefaultDuration)) {
+		t.Fatalf("default closes at = %v", poll.ClosesAt)
+	}
+	multi, err := newCampusPoll(&CampusPollInput{Options: []string{"a", "b", "c"}, ChoiceMode: "multi", MaxChoices: 9}, now)
+	if err != nil {
+		t.Fatalf("multi poll: %v", err)
+	}
+	if multi.MaxChoices != 3 {
+		t.Fatalf("max choices should clamp to option count, got %d", multi.MaxChoices)
+	}
+	past := now.Add(-time.Hour)
+	if _, err := newCampusPoll(&CampusPollInput{Options: []string{"a", "b"}, ClosesAt: &past}, now); err == nil {
+		t.Fatal("past deadline should fail")
+	}
+}
+
+func TestNormalizeCampusPollChoices(t *testing.T) {
+	poll := &CampusPoll{ChoiceMode: CampusPollChoiceSingle, MaxChoices: 1, Options: []*CampusPollOption{{ID: 1}, {ID: 2}, {ID: 3}}}
+	if _, err := normalizeCampusPollChoices(poll, []int32{1, 2}); err == nil {
+		t.Fatal("single choice poll should reject two options")
+	}
+	if _, err := normalizeCampusPollChoices(poll, []int32{4}); err == nil {
+		t.Fatal("unknown option should fail")
+	}
+	if choices, err := normalizeCampusPollChoices(poll, []int32{2, 2}); err != nil || len(choices) != 1 {
+		t.Fatalf("duplicate choice should collapse, got %v err=%v", choices, err)
+	}
+	poll.ChoiceMode, poll.MaxChoices = CampusPollChoiceMulti, 2
+	if _, err := normalizeCampusPollChoices(poll, []int32{1, 2, 3}); err == nil {
+		t.Fatal("choices over max should fail")
+	}
+	closesAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	poll.ClosesAt = &closesAt
+	if !poll.IsClosed(closesAt) || poll.IsClosed(closesAt.Add(-time.Second)) {
+		t.Fatal("poll should close exactly at deadline")
+	}
+}
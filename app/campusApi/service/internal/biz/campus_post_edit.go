@@ -0,0 +1,343 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusPostEditorAuthor = "author"
+	CampusPostEditorAdmin  = "admin"
+)
+
+type CampusPostRevision struct {
+	ID           int64
+	PostID       int64
+	Revision     int32
+	EditorID     string
+	EditorRole   string
+	Title        string
+	Content      string
+	Images       []string
+	MediaType    string
+	Extra        map[string]string
+	CoverURL     string
+	StatusBefore int32
+	CreatedAt    time.Time
+}
+
+type UpdateCampusPostInput struct {
+	UserID    string
+	PostID    int64
+	Title     string
+	Content   string
+	Images    []string
+	MediaType string
+	Extra     map[string]string
+	CoverURL  string
+	Tags      []string
+}
+
+type campusPostAuditPlan struct {
+	Status        int32
+	AuditReason   string
+	AIMode        bool
+	EnqueueAI     bool
+	Rule          campusContentRuleResult
+	AlertReason   string
+	AlertRisk     string
+	AlertEvidence []string
+	Trust         *CampusUserTrustScore
+	TrustedSkip   bool
+}
+
+func (uc *CampusUsecase) planCampusPostAudit(ctx context.Context, authorID, title, content string) (*campusPostAuditPlan, error) {
+	plan := &campusPostAuditPlan{Status: CampusAuditStatusVisible}
+	settings, err := uc.getCampusAuditSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rule := uc.classifyCampusPostByRules(ctx, title, content)
+	if rule.Decision == CampusAIContentAuditDecisionReject {
+		return nil, apperror.InvalidArgument("内容包含违规信息，请修改后再发布")
+	}
+	switch settings.PostAuditMode {
+	case CampusPostAuditModeManual:
+		plan.Status = CampusAuditStatusPending
+		plan.AuditReason = "等待人工审核"
+	case CampusPostAuditModeAI:
+		plan.AIMode = true
+		plan.Rule = rule
+		plan.AlertRisk = plan.Rule.RiskLevel
+		plan.AlertEvidence = plan.Rule.Evidence
+		if !uc.agentAuditEnabled(ctx) || !campusAgentModelConfigured() {
+			if plan.Rule.RiskLevel != "low" {
+				plan.Status = CampusAuditStatusPending
+				plan.AuditReason = "同步中"
+				if !uc.agentAuditEnabled(ctx) {
+					plan.AlertReason = "Agent 初审未启用，等待人工复核"
+				} else {
+					plan.AlertReason = "Agent 模型未配置，等待人工复核"
+				}
+			}
+		} else if allowed, skippedReason := uc.aiBudgetAllowsModel(ctx, "content_audit", "post", "pending"); !allowed {
+			if plan.Rule.RiskLevel != "low" {
+				plan.Status = CampusAuditStatusPending
+				plan.AuditReason = "同步中"
+				plan.AlertReason = skippedReason
+			}
+		} else {
+			plan.Status = CampusAuditStatusPending
+			plan.AuditReason = "同步中"
+			plan.EnqueueAI = true
+		}
+	}
+	uc.applyCampusTrustRouting(ctx, plan, authorID, settings.PostAuditMode)
+	return plan, nil
+}
+
+func (uc *CampusUsecase) dispatchCampusPostAudit(ctx context.Context, post *CampusForumPost, plan *campusPostAuditPlan) {
+	if plan != nil && plan.TrustedSkip && post != nil && post.Status == CampusAuditStatusVisible {
+		_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+			ID:         uc.idGen.NextID(),
+			TargetType: "post",
+			TargetID:   post.ID,
+			UserID:     post.AuthorID,
+			Provider:   "trust",
+			Result:     CampusAIContentAuditDecisionPass,
+			Reason:     fmt.Sprintf("作者信用分 %d，规则低风险，跳过 AI 初审", plan.Trust.Score),
+		})
+	}
+	if plan == nil || post == nil || post.Status != CampusAuditStatusPending {
+		return
+	}
+	if plan.AIMode && plan.EnqueueAI {
+		if err := uc.enqueuePostAIContentAudit(ctx, post); err != nil {
+			uc.log.WithContext(ctx).Warnf("queue campus post ai audit failed: post_id=%d err=%v", post.ID, err)
+			reason := firstNonEmpty(plan.Rule.Reason, "AI 审核任务入队失败，等待人工复核")
+			riskLevel := firstNonEmpty(plan.AlertRisk, plan.Rule.RiskLevel, "medium")
+			evidence := plan.AlertEvidence
+			if len(evidence) == 0 {
+				evidence = []string{"ai_audit_queue_failed"}
+			}
+			if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, riskLevel, reason, evidence); err != nil {
+				uc.log.WithContext(ctx).Warnf("queue campus ai audit fallback alert failed: post_id=%d err=%v", post.ID, err)
+			}
+		}
+		return
+	}
+	reason := firstNonEmpty(plan.AlertReason, plan.Rule.Reason, plan.AuditReason, "新帖需要人工确认")
+	riskLevel := firstNonEmpty(plan.AlertRisk, "medium")
+	evidence := plan.AlertEvidence
+	if len(evidence) == 0 {
+		evidence = []string{"manual_review"}
+	}
+	if err := uc.enqueueAuditOpsAlert(ctx, post, CampusAIContentAuditDecisionReview, riskLevel, reason, evidence); err != nil {
+		uc.log.WithContext(ctx).Warnf("queue campus manual audit alert failed: post_id=%d err=%v", post.ID, err)
+	}
+}
+
+func (uc *CampusUsecase) UpdatePost(ctx context.Context, input *UpdateCampusPostInput) (*CampusForumPost, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	if input.PostID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	if err := uc.ensureNotSanctioned(ctx, input.UserID, CampusSanctionTypePostBan, CampusSanctionTypeSuspend); err != nil {
+		return nil, err
+	}
+	ok, existing, err := uc.repo.GetAnyPostByID(ctx, input.PostID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || existing.Status == CampusAuditStatusDeleted {
+		return nil, apperror.NotFound("帖子不存在")
+	}
+	if existing.AuthorID != input.UserID {
+		return nil, apperror.Forbidden("只能编辑自己的帖子")
+	}
+	window := campusPostEditWindow()
+	if window <= 0 {
+		return nil, apperror.Forbidden("帖子暂不支持编辑")
+	}
+	if campusLocalNow().Sub(existing.CreatedAt) > window {
+		return nil, apperror.Forbidden("已超过可编辑时间，可以撤回后重新发布")
+	}
+	title := strings.TrimSpace(input.Title)
+	content := strings.TrimSpace(input.Content)
+	if len([]rune(title)) < 2 || len([]rune(title)) > 60 {
+		return nil, apperror.InvalidArgument("标题需要 2-60 个字")
+	}
+	if len([]rune(content)) < 2 || len([]rune(content)) > 2000 {
+		return nil, apperror.InvalidArgument("正文需要 2-2000 个字")
+	}
+	images := input.Images
+	if images == nil {
+		images = existing.Images
+	}
+	images = sanitizeImages(images, 9)
+	mediaType, coverURL, err := normalizeCampusPostMedia(firstNonEmpty(input.MediaType, existing.MediaType), images, input.CoverURL)
+	if err != nil {
+		return nil, err
+	}
+	if mediaType != CampusPostMediaImage {
+		images = []string{}
+	}
+	extra := existing.Extra
+	if input.Extra != nil {
+		extra = sanitizeCampusPostExtra(input.Extra)
+	}
+	existingTags, err := uc.repo.GetPostTags(ctx, []int64{existing.ID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子标签失败")
+	}
+	explicitTags := input.Tags
+	if explicitTags == nil {
+		explicitTags = explicitCampusTagNames(existingTags[existing.ID])
+	}
+	tags, err := uc.resolveCampusPostTags(ctx, content, explicitTags)
+	if err != nil {
+		return nil, err
+	}
+	post := *existing
+	post.Title = title
+	post.Content = content
+	post.Images = images
+	post.MediaType = mediaType
+	post.CoverURL = coverURL
+	post.Extra = extra
+	post.Tags = tags
+	if !campusPostContentChanged(existing, &post) {
+		if campusPostTagsChanged(existingTags[existing.ID], tags) {
+			if err := uc.repo.ReplacePostTags(ctx, existing.ID, tags); err != nil {
+				return nil, apperror.Internal(err, "更新帖子标签失败")
+			}
+		}
+		_ = uc.assembler.HydratePosts(ctx, []*CampusForumPost{existing}, input.UserID)
+		return existing, nil
+	}
+	var auditPlan *campusPostAuditPlan
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		plan, err := uc.planCampusPostAudit(ctx, existing.AuthorID, title, content)
+		if err != nil {
+			return nil, err
+		}
+		if existing.Status == CampusAuditStatusRejected && plan.Status == CampusAuditStatusVisible {
+			plan.Status = CampusAuditStatusPending
+			plan.AuditReason = "编辑后等待复核"
+			plan.AlertReason = "被拒绝的内容已编辑，等待人工复核"
+		}
+		if holdReason := uc.postImageAuditHoldReason(ctx, &post); holdReason != "" && plan.Status == CampusAuditStatusVisible {
+			plan.Status = CampusAuditStatusPending
+			plan.AuditReason = holdReason
+			plan.AlertReason = holdReason
+		}
+		auditPlan = plan
+		post.Status, post.AuditReason = plan.Status, plan.AuditReason
+	}
+	now := campusLocalNow()
+	post.EditCount = existing.EditCount + 1
+	post.EditedAt = &now
+	revision := campusPostRevisionFrom(existing, input.UserID, CampusPostEditorAuthor)
+	revision.ID = uc.idGen.NextID()
+	revision.Revision = post.EditCount
+	if err := uc.repo.UpdatePostContent(ctx, &post, revision); err != nil {
+		return nil, apperror.Internal(err, "编辑帖子失败")
+	}
+	uc.dispatchCampusPostAudit(ctx, &post, auditPlan)
+	if auditPlan != nil {
+		uc.enqueuePostImageAudit(ctx, &post)
+	}
+	uc.trackEvent(ctx, &TrackCampusEventInput{
+		UserID:     input.UserID,
+		EventType:  "post_edit",
+		Page:       "publish",
+		TargetType: "post",
+		TargetID:   post.ID,
+	})
+	_ = uc.assembler.HydratePosts(ctx, []*CampusForumPost{&post}, input.UserID)
+	return &post, nil
+}
+
+func (uc *CampusUsecase) AdminListPostRevisions(ctx context.Context, userID string, postID int64) ([]*CampusPostRevision, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	if postID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok {
+		return nil, apperror.NotFound("帖子不存在")
+	}
+	if err := uc.ensureOperatorCampus(ctx, userID, post.CampusCode); err != nil {
+		return nil, err
+	}
+	revisions, err := uc.repo.ListPostRevisions(ctx, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取修订记录失败")
+	}
+	return revisions, nil
+}
+
+func (uc *CampusUsecase) recordAdminPostRevision(ctx context.Context, existing, post *CampusForumPost, operatorID string) {
+	revision := campusPostRevisionFrom(existing, operatorID, CampusPostEditorAdmin)
+	revision.ID = uc.idGen.NextID()
+	revision.Revision = post.EditCount
+	if err := uc.repo.CreatePostRevision(ctx, revision); err != nil {
+		uc.log.WithContext(ctx).Warnf("record campus admin post revision failed: post_id=%d err=%v", post.ID, err)
+	}
+}
+
+func campusPostRevisionFrom(post *CampusForumPost, editorID, role string) *CampusPostRevision {
+	return &CampusPostRevision{
+		PostID:       post.ID,
+		EditorID:     editorID,
+		EditorRole:   role,
+		Title:        post.Title,
+		Content:      post.Content,
+		Images:       append([]string(nil), post.Images...),
+		MediaType:    post.MediaType,
+		Extra:        post.Extra,
+		CoverURL:     post.CoverURL,
+		StatusBefore: post.Status,
+	}
+}
+
+func campusPostContentChanged(before, after *CampusForumPost) bool {
+	if before.Title != after.Title || before.Content != after.Content || before.MediaType != after.MediaType || before.CoverURL != after.CoverURL {
+		return true
+	}
+	if strings.Join(before.Images, "\n") != strings.Join(after.Images, "\n") {
+		return true
+	}
+	if len(before.Extra) == 0 && len(after.Extra) == 0 {
+		return false
+	}
+	return !reflect.DeepEqual(before.Extra, after.Extra)
+}
+
+func CampusPostEditableUntil(post *CampusForumPost) time.Time {
+	window := campusPostEditWindow()
+	if post == nil || window <= 0 || post.CreatedAt.IsZero() {
+		return time.Time{}
+	}
+	return post.CreatedAt.Add(window)
+}
+
+func campusPostEditWindow() time.Duration {
+	if value := strings.TrimSpace(os.Getenv("CAMPUS_POST_EDIT_WINDOW")); value == "0" || envBoolFalse(value) {
+		return 0
+	}
+	return envDurationBiz("CAMPUS_POST_EDIT_WINDOW", 24*time.Hour)
+}
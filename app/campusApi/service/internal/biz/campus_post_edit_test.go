@@ -0,0 +1,42 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCampusPostContentChanged(t *testing.T) {
+	base := &CampusForumPost{Title: "二食堂新窗口", Content: "麻辣烫好吃", Images: []string{"a.jpg"}, MediaType: CampusPostMediaImage}
+	same := *base
+	same.Extra = map[string]string{}
+	if campusPostContentChanged(base, &same) {
+		t.Fatal("empty extra should not count as an edit")
+	}
+	retitled := *base
+	retitled.Title = "二食堂新窗口测评"
+	if !campusPostContentChanged(base, &retitled) {
+		t.Fatal("title change should count as an edit")
+	}
+	reordered := *base
+	reordered.Images = []string{"b.jpg", "a.jpg"}
+	if !campusPostContentChanged(base, &reordered) {
+		t.Fatal("image change should count as an edit")
+	}
+	tagged := *base
+	tagged.Extra = map[string]string{"location": "二食堂"}
+	if !campusPostContentChanged(base, &tagged) {
+		t.Fatal("extra change should count as an edit")
+	}
+}
+
+func TestCampusPostEditableUntil(t *testing.T) {
+	t.Setenv("CAMPUS_POST_EDIT_WINDOW", "2h")
+	created := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	if got := CampusPostEditableUntil(&CampusForumPost{CreatedAt: created}); !got.Equal(created.Add(2 * time.Hour)) {
+		t.Fatalf("editable until = %v", got)
+	}
+	t.Setenv("CAMPUS_POST_EDIT_WINDOW", "0")
+	if got := CampusPostEditableUntil(&CampusForumPost{CreatedAt: created}); !got.IsZero() {
+		t.Fatalf("disabled window should be zero, got %v", got)
+	}
+}
@@ -0,0 +1,298 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusPostPublishNow       = "now"
+	CampusPostPublishDraft     = "draft"
+	CampusPostPublishScheduled = "scheduled"
+
+	CampusPostScheduleDraft     = "draft"
+	CampusPostScheduleScheduled = "scheduled"
+	CampusPostSchedulePublished = "published"
+
+	campusPostScheduleMaxAhead = 90 * 24 * time.Hour
+)
+
+type CampusPostSchedule struct {
+	PostID      int64
+	CampusCode  string
+	State       string
+	PublishAt   *time.Time
+	IsPinned    bool
+	IsFeatured  bool
+	SortWeight  int32
+	CreatedBy   string
+	UpdatedBy   string
+	LastError   string
+	PublishedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Post        *CampusForumPost
+}
+
+type ListCampusPostSchedulesInput struct {
+	UserID     string
+	CampusCode string
+	State      string
+	Cursor     string
+	Page       int32
+	Size       int32
+}
+
+type ListCampusPostSchedulesOutput struct {
+	Schedules  []*CampusPostSchedule
+	Total      int64
+	NextCursor string
+}
+
+type UpdateCampusPostScheduleInput struct {
+	UserID     string
+	PostID     int64
+	PublishAt  *time.Time
+	IsPinned   bool
+	IsFeatured bool
+	SortWeight int32
+}
+
+func newCampusPostSchedule(input *CreateCampusPostInput, isOperator bool) (*CampusPostSchedule, error) {
+	mode := strings.ToLower(strings.TrimSpace(input.PublishMode))
+	if mode == "" || mode == CampusPostPublishNow {
+		return nil, nil
+	}
+	if !isOperator {
+		return nil, apperror.Forbidden("只有运营可以保存草稿或定时发布")
+	}
+	schedule := &CampusPostSchedule{
+		IsPinned:   input.IsPinned,
+		IsFeatured: input.IsFeatured,
+		SortWeight: clampSortWeight(input.SortWeight),
+		CreatedBy:  input.UserID,
+		UpdatedBy:  input.UserID,
+	}
+	switch mode {
+	case CampusPostPublishDraft:
+		schedule.State = CampusPostScheduleDraft
+	case CampusPostPublishScheduled:
+		publishAt, err := validateCampusPostPublishAt(input.PublishAt, campusLocalNow())
+		if err != nil {
+			return nil, err
+		}
+		schedule.State = CampusPostScheduleScheduled
+		schedule.PublishAt = &publishAt
+	default:
+		return nil, apperror.InvalidArgument("发布方式无效")
+	}
+	return schedule, nil
+}
+
+func validateCampusPostPublishAt(publishAt *time.Time, now time.Time) (time.Time, error) {
+	if publishAt == nil || publishAt.IsZero() {
+		return time.Time{}, apperror.InvalidArgument("请选择定时发布时间")
+	}
+	if !publishAt.After(now) {
+		return time.Time{}, apperror.InvalidArgument("定时发布时间需要晚于当前时间")
+	}
+	if publishAt.Sub(now) > campusPostScheduleMaxAhead {
+		return time.Time{}, apperror.InvalidArgument("定时发布时间不能超过 90 天")
+	}
+	return *publishAt, nil
+}
+
+func (s *CampusPostSchedule) postStatus() int32 {
+	if s.State == CampusPostScheduleScheduled {
+		return CampusAuditStatusScheduled
+	}
+	return CampusAuditStatusDraft
+}
+
+func campusPostUnpublished(post *CampusForumPost) bool {
+	return post != nil && (post.Status == CampusAuditStatusDraft || post.Status == CampusAuditStatusScheduled)
+}
+
+func (uc *CampusUsecase) createScheduledPost(ctx context.Context, post *CampusForumPost, schedule *CampusPostSchedule) (*CampusForumPost, error) {
+	schedule.PostID = post.ID
+	schedule.CampusCode = post.CampusCode
+	post.Status = schedule.postStatus()
+	post.AuditReason = ""
+	post.IsPinned, post.IsFeatured, post.SortWeight = false, false, 0
+	if err := uc.repo.CreateScheduledPost(ctx, post, schedule); err != nil {
+		return nil, apperror.Internal(err, "保存草稿失败")
+	}
+	uc.trackEvent(ctx, &TrackCampusEventInput{
+		UserID:     post.AuthorID,
+		EventType:  "post_schedule",
+		Page:       "admin",
+		TargetType: "post",
+		TargetID:   post.ID,
+	})
+	_ = uc.assembler.HydratePosts(ctx, []*CampusForumPost{post}, post.AuthorID)
+	return post, nil
+}
+
+func (uc *CampusUsecase) AdminListPostSchedules(ctx context.Context, input *ListCampusPostSchedulesInput) (*ListCampusPostSchedulesOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	state := strings.ToLower(strings.TrimSpace(input.State))
+	states := []string{CampusPostScheduleDraft, CampusPostScheduleScheduled}
+	switch state {
+	case "":
+	case CampusPostScheduleDraft, CampusPostScheduleScheduled, CampusPostSchedulePublished:
+		states = []string{state}
+	default:
+		return nil, apperror.InvalidArgument("状态筛选无效")
+	}
+	campusCode := uc.operatorCampusScope(ctx, input.UserID, input.CampusCode)
+	scope := campusCursorScope("admin_post_schedules", campusCode, state)
+	_, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	schedules, total, err := uc.repo.ListPostSchedules(ctx, campusCode, states, offset, limit)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取定时发布列表失败")
+	}
+	postIDs := make([]int64, 0, len(schedules))
+	for _, schedule := range schedules {
+		postIDs = append(postIDs, schedule.PostID)
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, postIDs, nil)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取定时发布列表失败")
+	}
+	if err := uc.assembler.HydratePostsForOperator(ctx, posts, input.UserID); err != nil {
+		uc.log.WithContext(ctx).Warnf("hydrate scheduled posts failed: %v", err)
+	}
+	postMap := make(map[int64]*CampusForumPost, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	for _, schedule := range schedules {
+		schedule.Post = postMap[schedule.PostID]
+	}
+	return &ListCampusPostSchedulesOutput{
+		Schedules:  schedules,
+		Total:      total,
+		NextCursor: uc.offsetNextCursor(scope, offset, len(schedules), limit, total),
+	}, nil
+}
+
+func (uc *CampusUsecase) AdminReschedulePost(ctx context.Context, input *UpdateCampusPostScheduleInput) (*CampusPostSchedule, error) {
+	schedule, err := uc.loadUnpublishedPostSchedule(ctx, input.UserID, input.PostID)
+	if err != nil {
+		return nil, err
+	}
+	publishAt, err := validateCampusPostPublishAt(input.PublishAt, campusLocalNow())
+	if err != nil {
+		return nil, err
+	}
+	schedule.State = CampusPostScheduleScheduled
+	schedule.PublishAt = &publishAt
+	schedule.IsPinned = input.IsPinned
+	schedule.IsFeatured = input.IsFeatured
+	schedule.SortWeight = clampSortWeight(input.SortWeight)
+	schedule.UpdatedBy = input.UserID
+	schedule.LastError = ""
+	if err := uc.repo.UpdatePostSchedule(ctx, schedule, schedule.postStatus()); err != nil {
+		return nil, apperror.Internal(err, "设置定时发布失败")
+	}
+	return uc.reloadPostSchedule(ctx, input.UserID, schedule)
+}
+
+func (uc *CampusUsecase) AdminCancelPostSchedule(ctx context.Context, userID string, postID int64) (*CampusPostSchedule, error) {
+	schedule, err := uc.loadUnpublishedPostSchedule(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule.State != CampusPostScheduleScheduled {
+		return nil, apperror.InvalidArgument("这条内容没有定时发布")
+	}
+	schedule.State = CampusPostScheduleDraft
+	schedule.PublishAt = nil
+	schedule.UpdatedBy = userID
+	schedule.LastError = ""
+	if err := uc.repo.UpdatePostSchedule(ctx, schedule, schedule.postStatus()); err != nil {
+		return nil, apperror.Internal(err, "取消定时发布失败")
+	}
+	return uc.reloadPostSchedule(ctx, userID, schedule)
+}
+
+func (uc *CampusUsecase) loadUnpublishedPostSchedule(ctx context.Context, userID string, postID int64) (*CampusPostSchedule, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	if postID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post.Status == CampusAuditStatusDeleted {
+		return nil, apperror.NotFound("帖子不存在")
+	}
+	if err := uc.ensureOperatorCampus(ctx, userID, post.CampusCode); err != nil {
+		return nil, err
+	}
+	ok, schedule, err := uc.repo.GetPostSchedule(ctx, postID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询定时发布失败")
+	}
+	if !ok || !campusPostUnpublished(post) {
+		return nil, apperror.InvalidArgument("这条内容已经发布")
+	}
+	schedule.Post = post
+	return schedule, nil
+}
+
+func (uc *CampusUsecase) reloadPostSchedule(ctx context.Context, userID string, schedule *CampusPostSchedule) (*CampusPostSchedule, error) {
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, schedule.PostID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if ok {
+		_ = uc.assembler.HydratePostsForOperator(ctx, []*CampusForumPost{post}, userID)
+		schedule.Post = post
+	}
+	return schedule, nil
+}
+
+func (uc *CampusUsecase) ProcessDueScheduledPosts(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	schedules, err := uc.repo.ListDuePostSchedules(ctx, campusLocalNow(), limit)
+	if err != nil {
+		return 0, err
+	}
+	published := 0
+	for _, schedule := range schedules {
+		ok, err := uc.repo.PublishScheduledPost(ctx, schedule.PostID, campusLocalNow())
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("publish scheduled campus post failed: post_id=%d err=%v", schedule.PostID, err)
+			if markErr := uc.repo.MarkPostScheduleError(ctx, schedule.PostID, truncateRunes(err.Error(), 250)); markErr != nil {
+				uc.log.WithContext(ctx).Warnf("mark campus post schedule error failed: post_id=%d err=%v", schedule.PostID, markErr)
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		published++
+		uc.trackEvent(ctx, &TrackCampusEventInput{
+			UserID:     schedule.CreatedBy,
+			EventType:  "post_create",
+			Page:       "schedule",
+			TargetType: "post",
+			TargetID:   schedule.PostID,
+		})
+	}
+	return published, nil
+}
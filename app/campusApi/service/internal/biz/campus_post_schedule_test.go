@@ -0,0 +1,53 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCampusPostSchedule(t *testing.T) {
+	input := &CreateCampusPostInput{UserID: "7", IsPinned: true, SortWeight: 300}
+	if schedule, err := newCampusPostSchedule(input, true); err != nil || schedule != nil {
+		t.Fatalf("empty mode should publish now, got %+v err=%v", schedule, err)
+	}
+	input.PublishMode = CampusPostPublishDraft
+	if _, err := newCampusPostSchedule(input, false); err == nil {
+		t.Fatal("non operator should not save drafts")
+	}
+	draft, err := newCampusPostSchedule(input, true)
+	if err != nil {
+		t.Fatalf("draft: %v", err)
+	}
+	if draft.State != CampusPostScheduleDraft || draft.postStatus() != CampusAuditStatusDraft || !draft.IsPinned || draft.SortWeight != 300 {
+		t.Fatalf("unexpected draft %+v", draft)
+	}
+	input.PublishMode = CampusPostPublishScheduled
+	if _, err := newCampusPostSchedule(input, true); err == nil {
+		t.Fatal("scheduled mode without publish_at should fail")
+	}
+	at := campusLocalNow().Add(2 * time.Hour)
+	input.PublishAt = &at
+	scheduled, err := newCampusPostSchedule(input, true)
+	if err != nil {
+		t.Fatalf("scheduled: %v", err)
+	}
+	if scheduled.postStatus() != CampusAuditStatusScheduled || !scheduled.PublishAt.Equal(at) {
+		t.Fatalf("unexpected schedule %+v", scheduled)
+	}
+}
+
+func TestValidateCampusPostPublishAt(t *testing.T) {
+	now := time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local)
+	past := now.Add(-time.Minute)
+	if _, err := validateCampusPostPublishAt(&past, now); err == nil {
+		t.Fatal("past publish time should fail")
+	}
+	far := now.Add(91 * 24 * time.Hour)
+	if _, err := validateCampusPostPublishAt(&far, now); err == nil {
+		t.Fatal("publish time beyond 90 days should fail")
+	}
+	next := now.Add(24 * time.Hour)
+	if got, err := validateCampusPostPublishAt(&next, now); err != nil || !got.Equal(next) {
+		t.Fatalf("publish at = %v err=%v", got, err)
+	}
+}
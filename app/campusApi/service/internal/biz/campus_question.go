@@ -0,0 +1,319 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusAIReplySourceMention  = "mention"
+	CampusAIReplySourceQuestion = "question"
+
+	CampusQuestionStateUnanswered = "unanswered"
+	CampusQuestionStateUnaccepted = "unaccepted"
+)
+
+type CampusQuestion struct {
+	PostID            int64
+	CampusCode        string
+	AuthorID          string
+	AcceptedCommentID int64
+	AcceptedAt        *time.Time
+	AnsweredAt        *time.Time
+	AITaskID          int64
+	AIQueuedAt        *time.Time
+	CreatedAt         time.Time
+	Post              *CampusForumPost
+}
+
+func (q *CampusQuestion) Accepted() bool {
+	return q != nil && q.AcceptedCommentID > 0
+}
+
+type AcceptCampusAnswerInput struct {
+	UserID    string
+	PostID    int64
+	CommentID int64
+}
+
+type ListCampusQuestionsInput struct {
+	UserID     string
+	CampusCode string
+	State      string
+	Cursor     string
+	Page       int32
+	Size       int32
+}
+
+type ListCampusQuestionsOutput struct {
+	Questions  []*CampusQuestion
+	Total      int64
+	NextCursor string
+}
+
+func campusQuestionAIAfter() time.Duration {
+	if value := strings.TrimSpace(os.Getenv("CAMPUS_QUESTION_AI_AFTER")); value == "0" || envBoolFalse(value) {
+		return 0
+	}
+	return envDurationBiz("CAMPUS_QUESTION_AI_AFTER", 6*time.Hour)
+}
+
+func (a *CampusPostAssembler) hydrateQuestions(ctx context.Context, posts []*CampusForumPost) {
+	postIDs := make([]int64, 0)
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypeQuestion {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	questions, err := a.repo.ListQuestionsByPostIDs(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load campus questions failed: %v", err)
+		return
+	}
+	for _, post := range posts {
+		if post != nil && post.PostType == CampusPostTypeQuestion {
+			post.Question = questions[post.ID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) loadQuestion(ctx context.Context, postID int64) (*CampusQuestion, error) {
+	questions, err := uc.repo.ListQuestionsByPostIDs(ctx, []int64{postID})
+	if err != nil {
+		return nil, err
+	}
+	return questions[postID], nil
+}
+
+func (uc *CampusUsecase) AcceptAnswer(ctx context.Context, input *AcceptCampusAnswerInput) (*CampusQuestion, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	if input.PostID <= 0 {
+		return nil, apperror.InvalidArgument("帖子 ID 无效")
+	}
+	ok, post, err := uc.repo.GetPostByID(ctx, input.PostID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post.Status != CampusAuditStatusVisible {
+		return nil, apperror.NotFound("帖子不存在")
+	}
+	if post.PostType != CampusPostTypeQuestion {
+		return nil, apperror.InvalidArgument("只有问答帖可以采纳回答")
+	}
+	if post.AuthorID != input.UserID {
+		return nil, apperror.Forbidden("只有提问者可以采纳回答")
+	}
+	question, err := uc.loadQuestion(ctx, post.ID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询问题失败")
+	}
+	if question == nil {
+		return nil, apperror.NotFound("问题不存在")
+	}
+	if question.AcceptedCommentID == input.CommentID {
+		return question, nil
+	}
+	now := campusLocalNow()
+	var outbox *CampusNotificationOutbox
+	if input.CommentID > 0 {
+		ok, comment, err := uc.repo.GetCommentByID(ctx, input.CommentID)
+		if err != nil {
+			return nil, apperror.Internal(err, "查询评论失败")
+		}
+		if !ok || comment.PostID != post.ID || comment.Status != CampusAuditStatusVisible {
+			return nil, apperror.NotFound("评论不存在")
+		}
+		if comment.ParentID > 0 {
+			return nil, apperror.InvalidArgument("只能采纳一级评论")
+		}
+		if comment.AuthorID == post.AuthorID {
+			return nil, apperror.InvalidArgument("不能采纳自己的评论")
+		}
+		outbox = uc.buildNotificationOutbox(&CampusNotification{
+			RecipientID: comment.AuthorID,
+			ActorID:     input.UserID,
+			EventType:   CampusNotificationTypeSystem,
+			TargetType:  "post",
+			TargetID:    post.ID,
+			DedupeKey:   fmt.Sprintf("campus:answer-accepted:%d:%d", post.ID, comment.ID),
+			Title:       "你的回答被采纳了",
+			Content:     trimLimit("「"+post.Title+"」的提问者采纳了你的回答。", 80),
+			LinkPage:    "post-detail",
+			LinkParams:  map[string]string{"id": fmt.Sprintf("%d", post.ID), "comment": fmt.Sprintf("%d", comment.ID)},
+		}, true)
+		anonymizeOutboxActor(outbox, post)
+	}
+	if err := uc.repo.AcceptAnswer(ctx, post.ID, input.CommentID, now, outbox); err != nil {
+		return nil, apperror.Internal(err, "采纳回答失败")
+	}
+	if input.CommentID > 0 {
+		uc.trackEvent(ctx, &TrackCampusEventInput{
+			UserID:     input.UserID,
+			EventType:  "answer_accept",
+			Page:       "post-detail",
+			TargetType: "comment",
+			TargetID:   input.CommentID,
+		})
+	}
+	return uc.loadQuestion(ctx, post.ID)
+}
+
+func (uc *CampusUsecase) markQuestionAnswered(ctx context.Context, post *CampusForumPost, answererID string) {
+	if post == nil || post.PostType != CampusPostTypeQuestion || answererID == post.AuthorID {
+		return
+	}
+	if err := uc.repo.MarkQuestionAnswered(ctx, post.ID, campusLocalNow()); err != nil {
+		uc.log.WithContext(ctx).Warnf("mark campus question answered failed: post_id=%d err=%v", post.ID, err)
+	}
+}
+
+func (uc *CampusUsecase) surfaceAcceptedAnswer(ctx context.Context, post *CampusForumPost, query *ListCampusCommentQuery) *CampusForumComment {
+	if post == nil || post.PostType != CampusPostTypeQuestion {
+		return nil
+	}
+	question, err := uc.loadQuestion(ctx, query.PostID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus question failed: post_id=%d err=%v", query.PostID, err)
+		return nil
+	}
+	if !question.Accepted() {
+		return nil
+	}
+	query.ExcludeIDs = append(query.ExcludeIDs, question.AcceptedCommentID)
+	if query.After != nil || query.Offset > 0 {
+		return nil
+	}
+	ok, comment, err := uc.repo.GetCommentByID(ctx, question.AcceptedCommentID)
+	if err != nil || !ok || comment.Status != CampusAuditStatusVisible || comment.ParentID > 0 {
+		return nil
+	}
+	for _, authorID := range query.ExcludeAuthorIDs {
+		if authorID == comment.AuthorID {
+			return nil
+		}
+	}
+	comment.IsAccepted = true
+	return comment
+}
+
+func (uc *CampusUsecase) AdminListQuestions(ctx context.Context, input *ListCampusQuestionsInput) (*ListCampusQuestionsOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	state := strings.ToLower(strings.TrimSpace(input.State))
+	switch state {
+	case "":
+		state = CampusQuestionStateUnanswered
+	case CampusQuestionStateUnanswered, CampusQuestionStateUnaccepted:
+	default:
+		return nil, apperror.InvalidArgument("状态筛选无效")
+	}
+	campusCode := uc.operatorCampusScope(ctx, input.UserID, input.CampusCode)
+	scope := campusCursorScope("admin_questions", campusCode, state)
+	_, offset, limit, err := uc.campusListPage(input.Cursor, scope, input.Page, input.Size)
+	if err != nil {
+		return nil, err
+	}
+	questions, total, err := uc.repo.ListQuestions(ctx, campusCode, state, offset, limit)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取问答列表失败")
+	}
+	postIDs := make([]int64, 0, len(questions))
+	for _, question := range questions {
+		postIDs = append(postIDs, question.PostID)
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, postIDs, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取问答列表失败")
+	}
+	if err := uc.assembler.HydratePostsForOperator(ctx, posts, input.UserID); err != nil {
+		uc.log.WithContext(ctx).Warnf("hydrate question posts failed: %v", err)
+	}
+	postMap := make(map[int64]*CampusForumPost, len(posts))
+	for _, post := range posts {
+		postMap[post.ID] = post
+	}
+	for _, question := range questions {
+		question.Post = postMap[question.PostID]
+	}
+	return &ListCampusQuestionsOutput{
+		Questions:  questions,
+		Total:      total,
+		NextCursor: uc.offsetNextCursor(scope, offset, len(questions), limit, total),
+	}, nil
+}
+
+func (uc *CampusUsecase) ProcessUnansweredQuestions(ctx context.Context, limit int) (int, error) {
+	after := campusQuestionAIAfter()
+	botUserID := strings.TrimSpace(uc.aiReplyConfig.BotUserID)
+	if after <= 0 || botUserID == "" || !uc.ezaiModelConfigured() || !uc.ezaiAutoReplyEnabled(ctx) {
+		return 0, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+	questions, err := uc.repo.ListAIDueQuestions(ctx, campusLocalNow().Add(-after), limit)
+	if err != nil {
+		return 0, err
+	}
+	postIDs := make([]int64, 0, len(questions))
+	for _, question := range questions {
+		postIDs = append(postIDs, question.PostID)
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, postIDs, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return 0, err
+	}
+	queued := 0
+	for _, post := range posts {
+		if post.AuthorID == botUserID {
+			continue
+		}
+		// 问题自动回答没有触发评论，TriggerCommentID 固定为 0，唯一键按 (post_id, trigger_comment_id) 保证每帖一条
+		task := &CampusAIReplyTask{
+			ID:               uc.idGen.NextID(),
+			PostID:           post.ID,
+			TriggerCommentID: 0,
+			AskerID:          post.AuthorID,
+			BotUserID:        botUserID,
+			Source:           CampusAIReplySourceQuestion,
+			Prompt:           trimLimit(post.Title+"\n"+post.Content, 500),
+			Status:           CampusAIReplyTaskStatusPending,
+		}
+		ok, err := uc.repo.QueueQuestionAIReply(ctx, post.ID, task)
+		if err != nil {
+			uc.log.WithContext(ctx).Warnf("queue question ai reply failed: post_id=%d err=%v", post.ID, err)
+			continue
+		}
+		if ok {
+			queued++
+		}
+	}
+	return queued, nil
+}
+
+func (uc *CampusUsecase) questionAITrigger(ctx context.Context, post *CampusForumPost) (*CampusForumComment, error) {
+	question, err := uc.loadQuestion(ctx, post.ID)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil || question.AnsweredAt != nil || question.Accepted() || post.Status != CampusAuditStatusVisible {
+		return nil, nil
+	}
+	return &CampusForumComment{
+		PostID:   post.ID,
+		AuthorID: post.AuthorID,
+		Content:  post.Title + "\n" + post.Content,
+		Status:   CampusAuditStatusVisible,
+	}, nil
+}
@@ -0,0 +1,21 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCampusQuestionAIAfter(t *testing.T) {
+	t.Setenv("CAMPUS_QUESTION_AI_AFTER", "")
+	if got := campusQuestionAIAfter(); got != 6*time.Hour {
+		t.Fatalf("default delay = %v", got)
+	}
+	t.Setenv("CAMPUS_QUESTION_AI_AFTER", "90m")
+	if got := campusQuestionAIAfter(); got != 90*time.Minute {
+		t.Fatalf("configured delay = %v", got)
+	}
+	t.Setenv("CAMPUS_QUESTION_AI_AFTER", "0")
+	if got := campusQuestionAIAfter(); got != 0 {
+		t.Fatalf("disabled delay = %v", got)
+	}
+}
@@ -0,0 +1,294 @@
+package biz
+
+import (
+	"context"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	CampusInteractionLike    = "like"
+	CampusInteractionCollect = "collect"
+	CampusInteractionComment = "comment"
+	CampusInteractionView    = "view"
+
+	campusPersonalFeedMaxEntries = 5000
+	campusAffinityHalfLife       = 14 * 24 * time.Hour
+)
+
+type CampusInteractionSignal struct {
+	PostID       int64
+	CategoryCode string
+	PostType     string
+	Kind         string
+	CreatedAt    time.Time
+}
+
+type CampusUserAffinity struct {
+	Categories map[string]float64
+	PostTypes  map[string]float64
+	Interacted map[int64]bool
+	Signals    int
+}
+
+type campusPersonalRankConfig struct {
+	AffinityWeight float64
+	ExploreEvery   int
+	ExploreWindow  time.Duration
+}
+
+type campusPersonalFeedEntry struct {
+	ids       []int64
+	version   int64
+	expiresAt time.Time
+}
+
+type campusAffinityEntry struct {
+	affinity  *CampusUserAffinity
+	expiresAt time.Time
+}
+
+type CampusPersonalFeed struct {
+	mu         sync.Mutex
+	feeds      map[string]*campusPersonalFeedEntry
+	affinities map[string]*campusAffinityEntry
+}
+
+func NewCampusPersonalFeed() *CampusPersonalFeed {
+	return &CampusPersonalFeed{
+		feeds:      map[string]*campusPersonalFeedEntry{},
+		affinities: map[string]*campusAffinityEntry{},
+	}
+}
+
+func (f *CampusPersonalFeed) getFeed(key string, now time.Time) (*campusPersonalFeedEntry, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry := f.feeds[key]
+	if entry == nil || now.After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry, true
+}
+
+func (f *CampusPersonalFeed) setFeed(key string, ids []int64, version int64, expiresAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.feeds) >= campusPersonalFeedMaxEntries {
+		f.feeds = map[string]*campusPersonalFeedEntry{}
+	}
+	f.feeds[key] = &campusPersonalFeedEntry{ids: ids, version: version, expiresAt: expiresAt}
+}
+
+func (f *CampusPersonalFeed) getAffinity(userID string, now time.Time) (*CampusUserAffinity, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry := f.affinities[userID]
+	if entry == nil || now.After(entry.expiresAt) {
+		return nil, false
+	}
+	return entry.affinity, true
+}
+
+func (f *CampusPersonalFeed) setAffinity(userID string, affinity *CampusUserAffinity, expiresAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.affinities) >= campusPersonalFeedMaxEntries {
+		f.affinities = map[string]*campusAffinityEntry{}
+	}
+	f.affinities[userID] = &campusAffinityEntry{affinity: affinity, expiresAt: expiresAt}
+}
+
+func (uc *CampusUsecase) personalRecommendFeed(ctx context.Context, campusCode, userID string) ([]int64, int64, bool) {
+	userID = strings.TrimSpace(userID)
+	if uc.personalFeed == nil || userID == "" || !campusPersonalFeedEnabled() {
+		return nil, 0, false
+	}
+	candidates, version := uc.recommendPool.Candidates(campusCode)
+	if len(candidates) == 0 {
+		return nil, 0, false
+	}
+	now := time.Now()
+	key := campusCode + ":" + userID + ":" + strconv.FormatInt(version, 10)
+	if entry, ok := uc.personalFeed.getFeed(key, now); ok {
+		return entry.ids, entry.version, true
+	}
+	affinity, err := uc.userAffinity(ctx, userID, now)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus user affinity failed, fallback to pool: user_id=%s err=%v", userID, err)
+		return nil, 0, false
+	}
+	if affinity.Signals < int(envInt64("CAMPUS_RECOMMEND_MIN_SIGNALS", 3)) {
+		return nil, 0, false
+	}
+	ids := rankCampusRecommendForUser(candidates, affinity, loadCampusPersonalRankConfig(), now)
+	uc.personalFeed.setFeed(key, ids, now.UnixNano(), now.Add(envDurationBiz("CAMPUS_RECOMMEND_PERSONAL_TTL", 10*time.Minute)))
+	return ids, now.UnixNano(), true
+}
+
+func (uc *CampusUsecase) userAffinity(ctx context.Context, userID string, now time.Time) (*CampusUserAffinity, error) {
+	if affinity, ok := uc.personalFeed.getAffinity(userID, now); ok {
+		return affinity, nil
+	}
+	lookback := envDurationBiz("CAMPUS_RECOMMEND_SIGNAL_LOOKBACK", 60*24*time.Hour)
+	signals, err := uc.repo.ListUserInteractionSignals(ctx, userID, now.Add(-lookback), 200)
+	if err != nil {
+		return nil, err
+	}
+	affinity := buildCampusUserAffinity(signals, now)
+	uc.personalFeed.setAffinity(userID, affinity, now.Add(envDurationBiz("CAMPUS_RECOMMEND_AFFINITY_TTL", 30*time.Minute)))
+	return affinity, nil
+}
+
+func buildCampusUserAffinity(signals []*CampusInteractionSignal, now time.Time) *CampusUserAffinity {
+	affinity := &CampusUserAffinity{
+		Categories: map[string]float64{},
+		PostTypes:  map[string]float64{},
+		Interacted: map[int64]bool{},
+	}
+	for _, signal := range signals {
+		if signal == nil || signal.PostID <= 0 {
+			continue
+		}
+		weight := campusInteractionWeight(signal.Kind)
+		if weight <= 0 {
+			continue
+		}
+		age := now.Sub(signal.CreatedAt)
+		if age < 0 {
+			age = 0
+		}
+		weight *= math.Pow(0.5, float64(age)/float64(campusAffinityHalfLife))
+		if signal.CategoryCode != "" {
+			affinity.Categories[signal.CategoryCode] += weight
+		}
+		if signal.PostType != "" {
+			affinity.PostTypes[signal.PostType] += weight
+		}
+		if signal.Kind != CampusInteractionView {
+			affinity.Interacted[signal.PostID] = true
+		}
+		affinity.Signals++
+	}
+	normalizeCampusAffinity(affinity.Categories)
+	normalizeCampusAffinity(affinity.PostTypes)
+	return affinity
+}
+
+// 置顶帖按池内顺序固定在最前，其余帖子再按兴趣重排并穿插探索位；
+// 运营加权（sort_weight > 0）的帖子保留池内位置分，不参与兴趣加权和已互动降权
+func rankCampusRecommendForUser(candidates []*CampusRecommendCandidate, affinity *CampusUserAffinity, cfg campusPersonalRankConfig, now time.Time) []int64 {
+	type scored struct {
+		candidate *CampusRecommendCandidate
+		score     float64
+	}
+	out := make([]int64, 0, len(candidates))
+	rest := make([]*CampusRecommendCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.IsPinned {
+			out = append(out, candidate.PostID)
+		} else {
+			rest = append(rest, candidate)
+		}
+	}
+	total := float64(len(rest))
+	ranked := make([]scored, 0, len(rest))
+	for i, candidate := range rest {
+		score := 1 - float64(i)/total
+		if candidate.SortWeight <= 0 {
+			match := affinity.Categories[candidate.CategoryCode]*0.7 + affinity.PostTypes[candidate.PostType]*0.3
+			score = (1-cfg.AffinityWeight)*score + cfg.AffinityWeight*match
+			if affinity.Interacted[candidate.PostID] {
+				score *= 0.5
+			}
+		}
+		ranked = append(ranked, scored{candidate: candidate, score: score})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	fresh := make([]*CampusRecommendCandidate, 0)
+	if cfg.ExploreEvery > 1 {
+		for _, candidate := range rest {
+			if !affinity.Interacted[candidate.PostID] && now.Sub(candidate.CreatedAt) <= cfg.ExploreWindow {
+				fresh = append(fresh, candidate)
+			}
+		}
+		sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].CreatedAt.After(fresh[j].CreatedAt) })
+	}
+
+	pinned := len(out)
+	used := make(map[int64]bool, len(candidates))
+	next, nextFresh := 0, 0
+	for len(out) < len(candidates) {
+		if cfg.ExploreEvery > 1 && (len(out)-pinned+1)%cfg.ExploreEvery == 0 {
+			for nextFresh < len(fresh) && used[fresh[nextFresh].PostID] {
+				nextFresh++
+			}
+			if nextFresh < len(fresh) {
+				used[fresh[nextFresh].PostID] = true
+				out = append(out, fresh[nextFresh].PostID)
+				continue
+			}
+		}
+		for next < len(ranked) && used[ranked[next].candidate.PostID] {
+			next++
+		}
+		if next >= len(ranked) {
+			break
+		}
+		used[ranked[next].candidate.PostID] = true
+		out = append(out, ranked[next].candidate.PostID)
+	}
+	return out
+}
+
+func loadCampusPersonalRankConfig() campusPersonalRankConfig {
+	weight := envFloatBiz("CAMPUS_RECOMMEND_AFFINITY_WEIGHT", 0.4)
+	if weight > 1 {
+		weight = 1
+	}
+	return campusPersonalRankConfig{
+		AffinityWeight: weight,
+		ExploreEvery:   int(envInt64("CAMPUS_RECOMMEND_EXPLORE_EVERY", 5)),
+		ExploreWindow:  envDurationBiz("CAMPUS_RECOMMEND_EXPLORE_WINDOW", 48*time.Hour),
+	}
+}
+
+func campusPersonalFeedEnabled() bool {
+	return !envBoolFalse(os.Getenv("CAMPUS_RECOMMEND_PERSONALIZED"))
+}
+
+func campusInteractionWeight(kind string) float64 {
+	switch kind {
+	case CampusInteractionComment:
+		return 5
+	case CampusInteractionCollect:
+		return 4
+	case CampusInteractionLike:
+		return 3
+	case CampusInteractionView:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func normalizeCampusAffinity(values map[string]float64) {
+	maxValue := 0.0
+	for _, value := range values {
+		if value > maxValue {
+			maxValue = value
+		}
+	}
+	if maxValue <= 0 {
+		return
+	}
+	for key, value := range values {
+		values[key] = value / maxValue
+	}
+}
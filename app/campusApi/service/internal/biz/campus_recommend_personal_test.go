@@ -0,0 +1,96 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildCampusUserAffinityDecaysAndNormalizes(t *testing.T) {
+	now := time.Now()
+	affinity := buildCampusUserAffinity([]*CampusInteractionSignal{
+		{PostID: 1, CategoryCode: "study", PostType: "question", Kind: CampusInteractionComment, CreatedAt: now},
+		{PostID: 2, CategoryCode: "study", PostType: "note", Kind: CampusInteractionLike, CreatedAt: now.Add(-time.Hour)},
+		{PostID: 3, CategoryCode: "food", PostType: "note", Kind: CampusInteractionCollect, CreatedAt: now.Add(-28 * 24 * time.Hour)},
+		{PostID: 4, CategoryCode: "food", PostType: "note", Kind: CampusInteractionView, CreatedAt: now},
+	}, now)
+	if affinity.Signals != 4 || affinity.Categories["study"] != 1 {
+		t.Fatalf("affinity = %+v", affinity)
+	}
+	if food := affinity.Categories["food"]; food <= 0 || food >= 0.5 {
+		t.Fatalf("old collect should decay, food = %f", food)
+	}
+	if !affinity.Interacted[3] || affinity.Interacted[4] {
+		t.Fatalf("views should not count as interacted: %+v", affinity.Interacted)
+	}
+}
+
+func TestRankCampusRecommendForUserBlendsAffinityAndExploration(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-7 * 24 * time.Hour)
+	candidates := []*CampusRecommendCandidate{
+		{PostID: 1, CategoryCode: "food", CreatedAt: old},
+		{PostID: 2, CategoryCode: "food", CreatedAt: old},
+		{PostID: 3, CategoryCode: "study", CreatedAt: old},
+		{PostID: 4, CategoryCode: "study", CreatedAt: old},
+		{PostID: 5, CategoryCode: "food", CreatedAt: old},
+		{PostID: 6, CategoryCode: "life", CreatedAt: now.Add(-time.Hour)},
+	}
+	affinity := &CampusUserAffinity{
+		Categories: map[string]float64{"study": 1},
+		PostTypes:  map[string]float64{},
+		Interacted: map[int64]bool{4: true},
+	}
+	ids := rankCampusRecommendForUser(candidates, affinity, campusPersonalRankConfig{AffinityWeight: 0.5, ExploreEvery: 3, ExploreWindow: 48 * time.Hour}, now)
+	if len(ids) != len(candidates) {
+		t.Fatalf("ranking should keep every candidate, got %v", ids)
+	}
+	if ids[0] != 3 {
+		t.Fatalf("preferred category should lead, got %v", ids)
+	}
+	if ids[2] != 6 {
+		t.Fatalf("exploration slot should surface the fresh post, got %v", ids)
+	}
+	for i, id := range ids {
+		if id == 4 && i < 3 {
+			t.Fatalf("already interacted post should be demoted, got %v", ids)
+		}
+	}
+}
+
+func TestRankCampusRecommendForUserKeepsPinnedFirst(t *testing.T) {
+	now := time.Now()
+	old := now.Add(-7 * 24 * time.Hour)
+	candidates := []*CampusRecommendCandidate{
+		{PostID: 1, CategoryCode: "notice", IsPinned: true, CreatedAt: old},
+		{PostID: 2, CategoryCode: "food", CreatedAt: old},
+		{PostID: 3, CategoryCode: "study", CreatedAt: old},
+		{PostID: 4, CategoryCode: "life", CreatedAt: now.Add(-time.Hour)},
+	}
+	affinity := &CampusUserAffinity{
+		Categories: map[string]float64{"study": 1},
+		PostTypes:  map[string]float64{},
+		Interacted: map[int64]bool{1: true},
+	}
+	ids := rankCampusRecommendForUser(candidates, affinity, campusPersonalRankConfig{AffinityWeight: 1, ExploreEvery: 2, ExploreWindow: 48 * time.Hour}, now)
+	if len(ids) != len(candidates) || ids[0] != 1 {
+		t.Fatalf("pinned post should stay at index 0, got %v", ids)
+	}
+	if ids[1] != 3 || ids[2] != 4 {
+		t.Fatalf("personalization should apply after the pinned post, got %v", ids)
+	}
+}
+
+func TestCampusRecommendPoolExcludeAuthors(t *testing.T) {
+	pool := &CampusRecommendPool{}
+	pool.Set("a", []int64{1, 2, 3, 4}, nil)
+	pool.SetCandidates("a", []*CampusForumPost{
+		{ID: 1, AuthorID: "10"},
+		{ID: 2, AuthorID: "20"},
+		{ID: 3, AuthorID: "10"},
+	})
+	ids, _ := pool.Snapshot("a", CampusPostSortRecommend)
+	got := pool.ExcludeAuthors("a", ids, []string{"10"})
+	if len(got) != 2 || got[0] != 2 || got[1] != 4 {
+		t.Fatalf("hidden authors should be removed before paging, got %v", got)
+	}
+}
@@ -11,42 +11,150 @@ import (
 
 type CampusRecommendPool struct {
 	mu        sync.RWMutex
-	recommend []int64
-	hot       []int64
+	campuses  map[string]*campusRecommendPoolEntry
 	updatedAt time.Time
 	log       *log.Helper
 }
 
+type campusRecommendPoolEntry struct {
+	recommend  []int64
+	hot        []int64
+	candidates map[int64]*CampusRecommendCandidate
+	version    int64
+}
+
+type CampusRecommendCandidate struct {
+	PostID       int64
+	AuthorID     string
+	CategoryCode string
+	PostType     string
+	IsPinned     bool
+	SortWeight   int32
+	CreatedAt    time.Time
+}
+
 func NewCampusRecommendPool(logger log.Logger) *CampusRecommendPool {
-	return &CampusRecommendPool{log: log.NewHelper(logger)}
+	return &CampusRecommendPool{campuses: map[string]*campusRecommendPoolEntry{}, log: log.NewHelper(logger)}
 }
 
-func (p *CampusRecommendPool) Set(recommend, hot []int64) {
+func (p *CampusRecommendPool) Set(campusCode string, recommend, hot []int64) {
 	if p == nil {
 		return
 	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.recommend = append([]int64(nil), recommend...)
-	p.hot = append([]int64(nil), hot...)
+	if p.campuses == nil {
+		p.campuses = map[string]*campusRecommendPoolEntry{}
+	}
+	var candidates map[int64]*CampusRecommendCandidate
+	if previous := p.campuses[campusCode]; previous != nil {
+		candidates = previous.candidates
+	}
 	p.updatedAt = time.Now()
+	p.campuses[campusCode] = &campusRecommendPoolEntry{
+		recommend:  append([]int64(nil), recommend...),
+		hot:        append([]int64(nil), hot...),
+		candidates: candidates,
+		version:    p.updatedAt.UnixNano(),
+	}
 }
 
-func (p *CampusRecommendPool) Get(sort string, offset, limit int) ([]int64, bool) {
-	if p == nil || offset < 0 || limit <= 0 {
-		return nil, false
+func (p *CampusRecommendPool) SetCandidates(campusCode string, posts []*CampusForumPost) {
+	if p == nil {
+		return
+	}
+	candidates := make(map[int64]*CampusRecommendCandidate, len(posts))
+	for _, post := range posts {
+		if post == nil || post.ID <= 0 {
+			continue
+		}
+		candidates[post.ID] = &CampusRecommendCandidate{
+			PostID:       post.ID,
+			AuthorID:     post.AuthorID,
+			CategoryCode: post.CategoryCode,
+			PostType:     post.PostType,
+			IsPinned:     post.IsPinned,
+			SortWeight:   post.SortWeight,
+			CreatedAt:    post.CreatedAt,
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if entry := p.campuses[campusCode]; entry != nil {
+		entry.candidates = candidates
+	}
+}
+
+func (p *CampusRecommendPool) Candidates(campusCode string) ([]*CampusRecommendCandidate, int64) {
+	if p == nil {
+		return nil, 0
 	}
 	p.mu.RLock()
 	defer p.mu.RUnlock()
-	var ids []int64
-	switch sort {
-	case CampusPostSortHot:
-		ids = p.hot
-	case CampusPostSortRecommend:
-		ids = p.recommend
-	default:
+	entry := p.campuses[campusCode]
+	if entry == nil || len(entry.candidates) == 0 {
+		return nil, 0
+	}
+	out := make([]*CampusRecommendCandidate, 0, len(entry.recommend))
+	for _, id := range entry.recommend {
+		if candidate := entry.candidates[id]; candidate != nil {
+			out = append(out, candidate)
+		}
+	}
+	return out, entry.version
+}
+
+// 去掉被屏蔽作者的帖子，在分页之前调用，保证每页条数和总数准确；不在候选里的帖子保留
+func (p *CampusRecommendPool) ExcludeAuthors(campusCode string, ids []int64, authorIDs []string) []int64 {
+	if p == nil || len(authorIDs) == 0 || len(ids) == 0 {
+		return ids
+	}
+	excluded := make(map[string]struct{}, len(authorIDs))
+	for _, id := range authorIDs {
+		excluded[id] = struct{}{}
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	entry := p.campuses[campusCode]
+	if entry == nil || len(entry.candidates) == 0 {
+		return ids
+	}
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if candidate := entry.candidates[id]; candidate != nil {
+			if _, ok := excluded[candidate.AuthorID]; ok {
+				continue
+			}
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+func (p *CampusRecommendPool) Retain(campusCodes []string) {
+	if p == nil {
+		return
+	}
+	keep := make(map[string]bool, len(campusCodes))
+	for _, code := range campusCodes {
+		keep[code] = true
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for code := range p.campuses {
+		if !keep[code] {
+			delete(p.campuses, code)
+		}
+	}
+}
+
+func (p *CampusRecommendPool) Get(campusCode, sort string, offset, limit int) ([]int64, bool) {
+	if p == nil || offset < 0 || limit <= 0 {
 		return nil, false
 	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	ids := p.idsLocked(campusCode, sort)
 	if len(ids) == 0 || offset >= len(ids) {
 		return nil, false
 	}
@@ -57,19 +165,40 @@ func (p *CampusRecommendPool) Get(sort string, offset, limit int) ([]int64, bool
 	return append([]int64(nil), ids[offset:end]...), true
 }
 
-func (p *CampusRecommendPool) Total(sort string) int64 {
+func (p *CampusRecommendPool) Snapshot(campusCode, sort string) ([]int64, int64) {
+	if p == nil {
+		return nil, 0
+	}
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	entry := p.campuses[campusCode]
+	if entry == nil {
+		return nil, 0
+	}
+	return p.idsLocked(campusCode, sort), entry.version
+}
+
+func (p *CampusRecommendPool) Total(campusCode, sort string) int64 {
 	if p == nil {
 		return 0
 	}
 	p.mu.RLock()
 	defer p.mu.RUnlock()
+	return int64(len(p.idsLocked(campusCode, sort)))
+}
+
+func (p *CampusRecommendPool) idsLocked(campusCode, sort string) []int64 {
+	entry := p.campuses[campusCode]
+	if entry == nil {
+		return nil
+	}
 	switch sort {
 	case CampusPostSortHot:
-		return int64(len(p.hot))
+		return entry.hot
 	case CampusPostSortRecommend:
-		return int64(len(p.recommend))
+		return entry.recommend
 	default:
-		return 0
+		return nil
 	}
 }
 
@@ -77,51 +206,95 @@ func (uc *CampusUsecase) RefreshCampusRecommendPool(ctx context.Context) error {
 	if uc.recommendPool == nil {
 		return nil
 	}
+	campusCodes, err := uc.repo.ListPostCampusCodes(ctx)
+	if err != nil {
+		return apperror.Internal(err, "获取校区列表失败")
+	}
+	campusCodes = dedupeStrings(append(campusCodes, configuredCampusCodes()...))
+	for _, campusCode := range campusCodes {
+		if err := uc.refreshCampusRecommendPoolFor(ctx, campusCode); err != nil {
+			return err
+		}
+	}
+	uc.recommendPool.Retain(campusCodes)
+	return nil
+}
+
+func (uc *CampusUsecase) refreshCampusRecommendPoolFor(ctx context.Context, campusCode string) error {
 	recommend, _, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
-		Sort:     CampusPostSortRecommend,
-		Statuses: []int32{CampusAuditStatusVisible},
-		Offset:   0,
-		Limit:    200,
+		CampusCode: campusCode,
+		Sort:       CampusPostSortRecommend,
+		Statuses:   []int32{CampusAuditStatusVisible},
+		Offset:     0,
+		Limit:      200,
 	})
 	if err != nil {
 		return apperror.Internal(err, "刷新推荐池失败")
 	}
 	hot, _, err := uc.repo.ListPosts(ctx, ListCampusPostQuery{
-		Sort:     CampusPostSortHot,
-		Statuses: []int32{CampusAuditStatusVisible},
-		Offset:   0,
-		Limit:    200,
+		CampusCode: campusCode,
+		Sort:       CampusPostSortHot,
+		Statuses:   []int32{CampusAuditStatusVisible},
+		Offset:     0,
+		Limit:      200,
 	})
 	if err != nil {
 		return apperror.Internal(err, "刷新热门池失败")
 	}
-	uc.recommendPool.Set(postIDs(recommend), postIDs(hot))
+	uc.recommendPool.Set(campusCode, postIDs(recommend), postIDs(hot))
+	uc.recommendPool.SetCandidates(campusCode, append(append([]*CampusForumPost(nil), recommend...), hot...))
 	return nil
 }
 
-func (uc *CampusUsecase) listPostsFromPool(ctx context.Context, query ListCampusPostQuery) ([]*CampusForumPost, int64, bool, error) {
+func (uc *CampusUsecase) listPostsFromPool(ctx context.Context, query ListCampusPostQuery, currentUserID string, cursor *CampusListCursor) ([]*CampusForumPost, int64, *CampusListCursor, bool, error) {
 	if uc.recommendPool == nil || !query.EligibleForRecommendPool() {
-		return nil, 0, false, nil
+		return nil, 0, nil, false, nil
+	}
+	var ids []int64
+	var version int64
+	if query.Sort == CampusPostSortRecommend {
+		if personal, personalVersion, ok := uc.personalRecommendFeed(ctx, query.CampusCode, currentUserID); ok {
+			ids, version = personal, personalVersion
+		}
+	}
+	if len(ids) == 0 {
+		ids, version = uc.recommendPool.Snapshot(query.CampusCode, query.Sort)
 	}
-	ids, ok := uc.recommendPool.Get(query.Sort, query.Offset, query.Limit)
-	if !ok || len(ids) == 0 {
-		return nil, 0, false, nil
+	ids = uc.recommendPool.ExcludeAuthors(query.CampusCode, ids, query.ExcludeAuthorIDs)
+	start := query.Offset
+	if cursor != nil {
+		start = campusCursorOffset(ids, version, cursor)
 	}
-	posts, err := uc.repo.ListPostsByIDs(ctx, ids, query.Statuses)
+	if len(ids) == 0 || start < 0 || start >= len(ids) || query.Limit <= 0 {
+		return nil, 0, nil, false, nil
+	}
+	end := start + query.Limit
+	if end > len(ids) {
+		end = len(ids)
+	}
+	page := append([]int64(nil), ids[start:end]...)
+	posts, err := uc.repo.ListPostsByIDs(ctx, page, query.Statuses)
 	if err != nil {
-		return nil, 0, true, err
+		return nil, 0, nil, true, err
+	}
+	var next *CampusListCursor
+	if end < len(ids) {
+		next = &CampusListCursor{Offset: end, ID: page[len(page)-1], Version: version}
 	}
-	return posts, uc.recommendPool.Total(query.Sort), true, nil
+	return posts, int64(len(ids)), next, true, nil
 }
 
 func (q ListCampusPostQuery) EligibleForRecommendPool() bool {
 	if q.Sort != CampusPostSortRecommend && q.Sort != CampusPostSortHot {
 		return false
 	}
-	return q.CategoryCode == "" &&
+	return q.CampusCode != "" &&
+		!q.OnlyOwnCampus &&
+		q.CategoryCode == "" &&
 		q.PostType == "" &&
 		q.Keyword == "" &&
 		q.AuthorID == "" &&
+		q.TagID == 0 &&
 		q.CollectedByUserID == "" &&
 		!q.IncludeDeleted &&
 		q.OnlyOfficial == nil &&
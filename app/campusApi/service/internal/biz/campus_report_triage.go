@@ -0,0 +1,388 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusReportStatusUpheld    int32 = 1
+	CampusReportStatusDismissed int32 = 2
+
+	campusReportAutoHideReason = "多人举报，等待复核"
+
+	// 注册不满该时长的举报人权重减半
+	campusReporterNewAccountAge = 7 * 24 * time.Hour
+	// 已认证、非新号且历史举报不比新人差的举报人才算可信
+	campusCredibleReporterWeight = 0.75
+)
+
+type CampusReporterStats struct {
+	Upheld    int64
+	Dismissed int64
+	Verified  bool
+	JoinedAt  time.Time
+}
+
+type CampusReportGroup struct {
+	TargetType      string
+	TargetID        int64
+	Target          *CampusForumPost
+	Comment         *CampusForumComment
+	ReportCount     int64
+	PendingCount    int64
+	Reasons         []string
+	Reports         []*CampusForumReport
+	WeightedScore   float64
+	AutoHidden      bool
+	Claim           *CampusModerationClaim
+	FirstReportedAt time.Time
+	LastReportedAt  time.Time
+}
+
+type ListCampusReportGroupsOutput struct {
+	Groups    []*CampusReportGroup
+	Total     int64
+	Threshold float64
+}
+
+type ReviewCampusReportGroupInput struct {
+	UserID     string
+	TargetType string
+	TargetID   int64
+	Action     string
+	Reason     string
+	Force      bool
+}
+
+// 按历史举报的成立率给举报人加权：没有记录时 0.75，全部成立趋近 2.0，全部被驳回趋近 0；
+// 未完成校园认证、注册不满 7 天各再减半，防止几个新号就能把内容举报下去
+func campusReporterWeight(stats *CampusReporterStats, now time.Time) float64 {
+	if stats == nil {
+		stats = &CampusReporterStats{}
+	}
+	weight := 2 * (float64(stats.Upheld) + 1.5) / float64(stats.Upheld+stats.Dismissed+4)
+	if !stats.Verified {
+		weight /= 2
+	}
+	if stats.JoinedAt.IsZero() || now.Sub(stats.JoinedAt) < campusReporterNewAccountAge {
+		weight /= 2
+	}
+	return math.Round(weight*100) / 100
+}
+
+func campusReportAutoHideThreshold() float64 {
+	return envFloatBiz("CAMPUS_REPORT_AUTO_HIDE_SCORE", 3)
+}
+
+func campusReportAutoHideMinReporters() int {
+	return int(envInt64("CAMPUS_REPORT_AUTO_HIDE_MIN_REPORTERS", 2))
+}
+
+// 待处理举报里可信举报人的人数，同一人多次举报只算一次
+func campusCredibleReporterCount(reports []*CampusForumReport) int {
+	seen := map[string]struct{}{}
+	for _, report := range reports {
+		if report != nil && report.Status == CampusAuditStatusPending && report.ReporterWeight >= campusCredibleReporterWeight {
+			seen[report.ReporterID] = struct{}{}
+		}
+	}
+	return len(seen)
+}
+
+func campusReportWeightedScore(reports []*CampusForumReport) float64 {
+	score := 0.0
+	for _, report := range reports {
+		if report != nil && report.Status == CampusAuditStatusPending {
+			score += report.ReporterWeight
+		}
+	}
+	return math.Round(score*100) / 100
+}
+
+func isReportAutoHidden(status int32, auditReason string) bool {
+	return status == CampusAuditStatusPending && auditReason == campusReportAutoHideReason
+}
+
+func (uc *CampusUsecase) weighReports(ctx context.Context, reports []*CampusForumReport) {
+	reporterIDs := make([]string, 0, len(reports))
+	seen := map[string]struct{}{}
+	for _, report := range reports {
+		if report != nil {
+			appendUniqueUserID(&reporterIDs, seen, report.ReporterID)
+		}
+	}
+	if len(reporterIDs) == 0 {
+		return
+	}
+	stats, err := uc.repo.ListReporterStats(ctx, reporterIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load reporter stats failed: err=%v", err)
+		stats = map[string]*CampusReporterStats{}
+	}
+	now := time.Now()
+	for _, report := range reports {
+		if report != nil {
+			report.ReporterWeight = campusReporterWeight(stats[report.ReporterID], now)
+		}
+	}
+}
+
+// 待处理举报的加权分达到阈值、且可信举报人够数时先把可见内容转入待审，驳回这些举报即可恢复
+func (uc *CampusUsecase) autoHideReportedTarget(ctx context.Context, targetType string, targetID int64, post *CampusForumPost, comment *CampusForumComment) {
+	threshold := campusReportAutoHideThreshold()
+	if threshold <= 0 {
+		return
+	}
+	var authorID, excerpt string
+	switch {
+	case targetType == "post" && post != nil && post.Status == CampusAuditStatusVisible:
+		authorID, excerpt = post.AuthorID, firstNonEmpty(post.Title, post.Content)
+	case targetType == "comment" && comment != nil && comment.Status == CampusAuditStatusVisible:
+		authorID, excerpt = comment.AuthorID, comment.Content
+	default:
+		return
+	}
+	if uc.isCampusOperator(ctx, authorID) {
+		return
+	}
+	reports, err := uc.repo.ListReportsByTarget(ctx, targetType, targetID, CampusAuditStatusPending)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("list reports for auto hide failed: target_type=%s target_id=%d err=%v", targetType, targetID, err)
+		return
+	}
+	uc.weighReports(ctx, reports)
+	score := campusReportWeightedScore(reports)
+	credible := campusCredibleReporterCount(reports)
+	if score < threshold || credible < campusReportAutoHideMinReporters() {
+		return
+	}
+	if targetType == "post" {
+		err = uc.repo.UpdatePostStatus(ctx, targetID, CampusAuditStatusPending, campusReportAutoHideReason)
+	} else {
+		err = uc.repo.UpdateCommentStatus(ctx, targetID, CampusAuditStatusPending, campusReportAutoHideReason)
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("auto hide reported target failed: target_type=%s target_id=%d err=%v", targetType, targetID, err)
+		return
+	}
+	reasons := campusReportReasons(reports)
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     "0",
+		Provider:   "report",
+		Result:     "auto_hide",
+		Reason:     trimLimit(fmt.Sprintf("加权举报分 %.2f ≥ %.2f（%d 人举报，可信 %d 人：%s），原状态 %d", score, threshold, len(reports), credible, strings.Join(reasons, "、"), CampusAuditStatusVisible), 255),
+	})
+	label := campusAppealTargetLabel(targetType)
+	linkPage, linkParams := "my-posts", map[string]string{}
+	if targetType == "comment" && comment != nil {
+		linkPage, linkParams = "post-detail", map[string]string{"id": fmt.Sprintf("%d", comment.PostID)}
+	}
+	uc.queueUserSystemNotification(ctx, authorID, targetType, targetID,
+		"内容暂时隐藏",
+		fmt.Sprintf("你的%s收到多位同学举报，已暂时隐藏等待运营复核。", label),
+		linkPage,
+		linkParams,
+		fmt.Sprintf("campus:report-auto-hide:%s:%d:%d", targetType, targetID, time.Now().Unix()/3600),
+	)
+	adminPath := "/admin/moderation?tab=reports&view=groups"
+	if err := uc.enqueueOpsAlert(ctx, CampusOpsAlertTypeReportAutoHidden, CampusOpsAlertPriorityHigh, targetType, targetID,
+		fmt.Sprintf("report_auto_hide:%s:%d:%s", targetType, targetID, campusLocalNow().Format("2006010215")),
+		"校园 e站举报自动隐藏",
+		fmt.Sprintf("%s %d 加权举报分 %.2f，已自动转入待审：%s", label, targetID, score, trimLimit(excerpt, 80)),
+		map[string]interface{}{
+			"target_type": targetType,
+			"target_id":   fmt.Sprintf("%d", targetID),
+			"score":       score,
+			"threshold":   threshold,
+			"count":       len(reports),
+			"credible":    credible,
+			"evidence":    []string{"举报理由：" + strings.Join(reasons, "、"), fmt.Sprintf("加权分 %.2f / 阈值 %.2f", score, threshold)},
+			"admin_path":  adminPath,
+		}); err != nil {
+		uc.log.WithContext(ctx).Warnf("enqueue report auto hide alert failed: target_type=%s target_id=%d err=%v", targetType, targetID, err)
+	}
+}
+
+func (uc *CampusUsecase) restoreAutoHiddenTarget(ctx context.Context, targetType string, targetID int64) {
+	var status int32
+	var reason string
+	if targetType == "post" {
+		ok, post, err := uc.repo.GetAnyPostByID(ctx, targetID)
+		if err != nil || !ok || post == nil {
+			return
+		}
+		status, reason = post.Status, post.AuditReason
+	} else {
+		ok, comment, err := uc.repo.GetAnyCommentByID(ctx, targetID)
+		if err != nil || !ok || comment == nil {
+			return
+		}
+		status, reason = comment.Status, comment.AuditReason
+	}
+	if !isReportAutoHidden(status, reason) {
+		return
+	}
+	var err error
+	if targetType == "post" {
+		err = uc.repo.UpdatePostStatus(ctx, targetID, CampusAuditStatusVisible, "")
+	} else {
+		err = uc.repo.UpdateCommentStatus(ctx, targetID, CampusAuditStatusVisible, "")
+	}
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("restore auto hidden target failed: target_type=%s target_id=%d err=%v", targetType, targetID, err)
+		return
+	}
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     "0",
+		Provider:   "report",
+		Result:     "auto_hide_revert",
+		Reason:     "举报被驳回，恢复展示",
+	})
+}
+
+// 逐条驳回举报时，只有目标上的举报全部被驳回才恢复自动隐藏的内容；还有待处理或已成立的举报就继续等复核
+func (uc *CampusUsecase) restoreAutoHiddenTargetIfAllDismissed(ctx context.Context, targetType string, targetID int64) {
+	reports, err := uc.repo.ListReportsByTarget(ctx, targetType, targetID, -1)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("list reports before restore failed: target_type=%s target_id=%d err=%v", targetType, targetID, err)
+		return
+	}
+	for _, report := range reports {
+		if report != nil && report.Status != CampusReportStatusDismissed {
+			return
+		}
+	}
+	uc.restoreAutoHiddenTarget(ctx, targetType, targetID)
+}
+
+func campusReportReasons(reports []*CampusForumReport) []string {
+	reasons := make([]string, 0, len(reports))
+	seen := map[string]struct{}{}
+	for _, report := range reports {
+		if report == nil {
+			continue
+		}
+		reason := firstNonEmpty(report.Reason, "其他")
+		if _, ok := seen[reason]; ok {
+			continue
+		}
+		seen[reason] = struct{}{}
+		reasons = append(reasons, reason)
+	}
+	return reasons
+}
+
+func (uc *CampusUsecase) reportQuery(ctx context.Context, input *ListCampusReportsInput, status, page, size int32) *CampusReportQuery {
+	query := &CampusReportQuery{
+		Status:      status,
+		CampusCode:  uc.operatorCampusScope(ctx, input.UserID, input.CampusCode),
+		ClaimFilter: normalizeCampusClaimFilter(input.Claim),
+		Offset:      int((page - 1) * size),
+		Limit:       int(size),
+	}
+	if query.ClaimFilter != "" {
+		query.ClaimOperatorID = input.UserID
+	}
+	return query
+}
+
+func (uc *CampusUsecase) AdminListReportGroups(ctx context.Context, input *ListCampusReportsInput) (*ListCampusReportGroupsOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	status := input.Status
+	if status < 0 || status > 2 {
+		status = -1
+	}
+	groups, total, err := uc.repo.ListReportGroups(ctx, uc.reportQuery(ctx, input, status, page, size))
+	if err != nil {
+		return nil, apperror.Internal(err, "获取举报列表失败")
+	}
+	reports := make([]*CampusForumReport, 0)
+	for _, group := range groups {
+		reports = append(reports, group.Reports...)
+	}
+	uc.weighReports(ctx, reports)
+	uc.fillReportTargetAuthors(ctx, reports)
+	uc.attachReportClaims(ctx, reports)
+	for _, group := range groups {
+		group.Reasons = campusReportReasons(group.Reports)
+		group.WeightedScore = campusReportWeightedScore(group.Reports)
+		if len(group.Reports) > 0 {
+			group.Target, group.Comment = group.Reports[0].Target, group.Reports[0].Comment
+			group.Claim = group.Reports[0].Claim
+		}
+		if group.Target != nil && group.TargetType == "post" {
+			group.AutoHidden = isReportAutoHidden(group.Target.Status, group.Target.AuditReason)
+		}
+		if group.Comment != nil {
+			group.AutoHidden = isReportAutoHidden(group.Comment.Status, group.Comment.AuditReason)
+		}
+	}
+	return &ListCampusReportGroupsOutput{Groups: groups, Total: total, Threshold: campusReportAutoHideThreshold()}, nil
+}
+
+// 成立时下架内容并把待处理举报记为成立；驳回时把举报记为驳回，被自动隐藏的内容随之恢复
+func (uc *CampusUsecase) AdminReviewReportGroup(ctx context.Context, input *ReviewCampusReportGroupInput) error {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return apperror.Forbidden("没有后台权限")
+	}
+	targetType := normalizeCampusTargetType(input.TargetType)
+	if targetType == "" || input.TargetID <= 0 {
+		return apperror.InvalidArgument("举报对象无效")
+	}
+	if err := uc.ensureOperatorTargetCampus(ctx, input.UserID, targetType, input.TargetID); err != nil {
+		return err
+	}
+	if err := uc.ensureModerationClaim(ctx, input.UserID, targetType, input.TargetID, input.Force); err != nil {
+		return err
+	}
+	reason := strings.TrimSpace(input.Reason)
+	action := strings.TrimSpace(strings.ToLower(input.Action))
+	switch action {
+	case "uphold", "resolve", "takedown":
+		if err := uc.ReviewContent(ctx, &ReviewCampusContentInput{
+			UserID:     input.UserID,
+			TargetType: targetType,
+			TargetID:   input.TargetID,
+			Action:     "delete",
+			Reason:     firstNonEmpty(reason, "举报成立下架"),
+			Force:      input.Force,
+		}); err != nil {
+			return err
+		}
+		if err := uc.markReportsHandledByTarget(ctx, targetType, input.TargetID, CampusReportStatusUpheld); err != nil {
+			return apperror.Internal(err, "处理举报失败")
+		}
+	case "dismiss", "reject":
+		if err := uc.markReportsHandledByTarget(ctx, targetType, input.TargetID, CampusReportStatusDismissed); err != nil {
+			return apperror.Internal(err, "处理举报失败")
+		}
+		uc.releaseModerationClaim(ctx, targetType, input.TargetID)
+		_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+			ID:         uc.idGen.NextID(),
+			TargetType: targetType,
+			TargetID:   input.TargetID,
+			UserID:     input.UserID,
+			Provider:   "manual",
+			Result:     "dismiss_reports",
+			Reason:     reason,
+		})
+	default:
+		return apperror.InvalidArgument("举报处理动作无效")
+	}
+	return nil
+}
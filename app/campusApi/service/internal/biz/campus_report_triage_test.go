@@ -0,0 +1,97 @@
+package biz
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCampusReporterWeight(t *testing.T) {
+	now := time.Now()
+	joined := now.Add(-30 * 24 * time.Hour)
+	if got := campusReporterWeight(nil, now); got != 0.19 {
+		t.Fatalf("campusReporterWeight(nil) = %v, want 0.19", got)
+	}
+	if got := campusReporterWeight(&CampusReporterStats{Verified: true, JoinedAt: joined}, now); got != 0.75 {
+		t.Fatalf("campusReporterWeight(verified) = %v, want 0.75", got)
+	}
+	if got := campusReporterWeight(&CampusReporterStats{Verified: true, JoinedAt: now.Add(-time.Hour)}, now); got >= campusCredibleReporterWeight {
+		t.Fatalf("new account should not be credible, got %v", got)
+	}
+	if got := campusReporterWeight(&CampusReporterStats{JoinedAt: joined}, now); got >= campusCredibleReporterWeight {
+		t.Fatalf("unverified account should not be credible, got %v", got)
+	}
+	low := campusReporterWeight(&CampusReporterStats{Dismissed: 10, Verified: true, JoinedAt: joined}, now)
+	high := campusReporterWeight(&CampusReporterStats{Upheld: 10, Verified: true, JoinedAt: joined}, now)
+	if low >= 0.5 || high <= 1.5 || high > 2 {
+		t.Fatalf("unexpected weights low=%v high=%v", low, high)
+	}
+}
+
+func TestCampusCredibleReporterCount(t *testing.T) {
+	reports := []*CampusForumReport{
+		{ReporterID: "1", Status: CampusAuditStatusPending, ReporterWeight: 0.75},
+		{ReporterID: "1", Status: CampusAuditStatusPending, ReporterWeight: 0.75},
+		{ReporterID: "2", Status: CampusAuditStatusPending, ReporterWeight: 0.19},
+		{ReporterID: "3", Status: CampusReportStatusDismissed, ReporterWeight: 2},
+		{ReporterID: "4", Status: CampusAuditStatusPending, ReporterWeight: 1.2},
+	}
+	if got := campusCredibleReporterCount(reports); got != 2 {
+		t.Fatalf("campusCredibleReporterCount = %d, want 2", got)
+	}
+}
+
+func TestCampusReportWeightedScore(t *testing.T) {
+	reports := []*CampusForumReport{
+		{Status: CampusAuditStatusPending, ReporterWeight: 1.5},
+		{Status: CampusAuditStatusPending, ReporterWeight: 0.75},
+		{Status: CampusReportStatusDismissed, ReporterWeight: 2},
+		nil,
+	}
+	if got := campusReportWeightedScore(reports); got != 2.25 {
+		t.Fatalf("campusReportWeightedScore = %v, want 2.25", got)
+	}
+}
+
+type reportRestoreStubRepo struct {
+	CampusRepo
+	reports  []*CampusForumReport
+	post     *CampusForumPost
+	restored bool
+}
+
+func (r *reportRestoreStubRepo) ListReportsByTarget(ctx context.Context, targetType string, targetID int64, status int32) ([]*CampusForumReport, error) {
+	return r.reports, nil
+}
+
+func (r *reportRestoreStubRepo) GetAnyPostByID(ctx context.Context, id int64) (bool, *CampusForumPost, error) {
+	return true, r.post, nil
+}
+
+func (r *reportRestoreStubRepo) UpdatePostStatus(ctx context.Context, id int64, status int32, reason string) error {
+	r.restored = status == CampusAuditStatusVisible
+	return nil
+}
+
+func (r *reportRestoreStubRepo) CreateAuditLog(ctx context.Context, log *CampusAuditLog) error {
+	return nil
+}
+
+func TestRestoreAutoHiddenTargetIfAllDismissed(t *testing.T) {
+	hidden := &CampusForumPost{ID: 9, Status: CampusAuditStatusPending, AuditReason: campusReportAutoHideReason}
+	repo := &reportRestoreStubRepo{post: hidden, reports: []*CampusForumReport{
+		{ID: 1, Status: CampusReportStatusDismissed},
+		{ID: 2, Status: CampusAuditStatusPending},
+	}}
+	uc := &CampusUsecase{repo: repo, idGen: claimStubIDGen{}}
+	ctx := context.Background()
+	uc.restoreAutoHiddenTargetIfAllDismissed(ctx, "post", 9)
+	if repo.restored {
+		t.Fatal("target should stay hidden while reports are pending")
+	}
+	repo.reports[1].Status = CampusReportStatusDismissed
+	uc.restoreAutoHiddenTargetIfAllDismissed(ctx, "post", 9)
+	if !repo.restored {
+		t.Fatal("target should be restored after the last report is dismissed")
+	}
+}
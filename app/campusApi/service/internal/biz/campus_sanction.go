@@ -0,0 +1,359 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusSanctionTypeMute    = "mute"
+	CampusSanctionTypePostBan = "post_ban"
+	CampusSanctionTypeSuspend = "suspend"
+
+	CampusSanctionStatusActive  = "active"
+	CampusSanctionStatusExpired = "expired"
+	CampusSanctionStatusRevoked = "revoked"
+
+	campusSanctionMaxHours      = 24 * 365
+	campusSanctionDefaultAppeal = "如有异议，可在「意见反馈」中说明情况申诉。"
+)
+
+type CampusUserSanction struct {
+	ID           int64
+	UserID       string
+	Type         string
+	Reason       string
+	AppealNote   string
+	Status       string
+	StartsAt     time.Time
+	ExpiresAt    *time.Time
+	CreatedBy    string
+	RevokedBy    string
+	RevokedAt    *time.Time
+	RevokeReason string
+	CreatedAt    time.Time
+}
+
+type CreateCampusUserSanctionInput struct {
+	UserID        string
+	TargetUserID  string
+	Type          string
+	DurationHours int32
+	Reason        string
+	AppealNote    string
+}
+
+type RevokeCampusUserSanctionInput struct {
+	UserID     string
+	SanctionID int64
+	Reason     string
+}
+
+func (s *CampusUserSanction) activeAt(now time.Time) bool {
+	if s == nil || s.Status != CampusSanctionStatusActive {
+		return false
+	}
+	return s.ExpiresAt == nil || s.ExpiresAt.After(now)
+}
+
+func campusSanctionTypeIn(sanctionType string, types []string) bool {
+	for _, item := range types {
+		if item == sanctionType {
+			return true
+		}
+	}
+	return false
+}
+
+func campusSanctionTypeName(sanctionType string) string {
+	switch sanctionType {
+	case CampusSanctionTypeMute:
+		return "禁言"
+	case CampusSanctionTypePostBan:
+		return "禁止发帖"
+	case CampusSanctionTypeSuspend:
+		return "封禁账号"
+	default:
+		return sanctionType
+	}
+}
+
+func campusSanctionUntilText(s *CampusUserSanction) string {
+	if s.ExpiresAt == nil {
+		return "永久"
+	}
+	return s.ExpiresAt.In(campusLocalNow().Location()).Format("2006-01-02 15:04")
+}
+
+func campusSanctionMessage(s *CampusUserSanction) string {
+	var action string
+	switch s.Type {
+	case CampusSanctionTypeMute:
+		action = "你已被禁言，暂时不能评论"
+	case CampusSanctionTypePostBan:
+		action = "你已被禁止发帖"
+	default:
+		action = "你的账号已被封禁"
+	}
+	message := fmt.Sprintf("%s（至 %s）", action, campusSanctionUntilText(s))
+	if s.Reason != "" {
+		message += "，原因：" + s.Reason
+	}
+	return message + "。" + firstNonEmpty(s.AppealNote, campusSanctionDefaultAppeal)
+}
+
+func (uc *CampusUsecase) activeUserSanctions(ctx context.Context, userID string) ([]*CampusUserSanction, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, nil
+	}
+	return uc.repo.GetActiveUserSanctions(ctx, userID)
+}
+
+// 同时命中多条时取结束最晚的一条，永久处罚优先
+func (uc *CampusUsecase) activeUserSanction(ctx context.Context, userID string, types ...string) (*CampusUserSanction, error) {
+	sanctions, err := uc.activeUserSanctions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	var found *CampusUserSanction
+	for _, sanction := range sanctions {
+		if !sanction.activeAt(now) || !campusSanctionTypeIn(sanction.Type, types) {
+			continue
+		}
+		if found == nil || sanction.ExpiresAt == nil || (found.ExpiresAt != nil && sanction.ExpiresAt.After(*found.ExpiresAt)) {
+			found = sanction
+		}
+	}
+	return found, nil
+}
+
+// 查不到处罚状态时拒绝请求，不能因为缓存或数据库故障放过被封禁的账号
+func (uc *CampusUsecase) ensureNotSanctioned(ctx context.Context, userID string, types ...string) error {
+	sanction, err := uc.activeUserSanction(ctx, userID, types...)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load user sanctions failed: user_id=%s err=%v", userID, err)
+		return apperror.DependencyUnavailable(err, "暂时无法确认账号状态，请稍后再试")
+	}
+	if sanction != nil {
+		return apperror.Forbidden(campusSanctionMessage(sanction))
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) CheckUserSuspended(ctx context.Context, userID string) error {
+	return uc.ensureNotSanctioned(ctx, userID, CampusSanctionTypeSuspend)
+}
+
+func (uc *CampusUsecase) ListMySanctions(ctx context.Context, userID string) ([]*CampusUserSanction, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	active, err := uc.repo.ListActiveUserSanctions(ctx, []string{userID})
+	if err != nil {
+		return nil, apperror.Internal(err, "获取处罚记录失败")
+	}
+	return active[userID], nil
+}
+
+func (uc *CampusUsecase) AdminCreateUserSanction(ctx context.Context, input *CreateCampusUserSanctionInput) (*CampusUserSanction, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	targetUserID := strings.TrimSpace(input.TargetUserID)
+	if parseInt64String(targetUserID) <= 0 {
+		return nil, apperror.InvalidArgument("用户 ID 无效")
+	}
+	if targetUserID == input.UserID || uc.isCampusOperator(ctx, targetUserID) {
+		return nil, apperror.InvalidArgument("不能处罚运营账号，请先移除其后台权限")
+	}
+	if err := uc.ensureOperatorCampus(ctx, input.UserID, uc.userCampusCode(ctx, targetUserID)); err != nil {
+		return nil, err
+	}
+	sanctionType := strings.TrimSpace(strings.ToLower(input.Type))
+	switch sanctionType {
+	case CampusSanctionTypeMute, CampusSanctionTypePostBan, CampusSanctionTypeSuspend:
+	default:
+		return nil, apperror.InvalidArgument("处罚类型无效")
+	}
+	if input.DurationHours < 0 || input.DurationHours > campusSanctionMaxHours {
+		return nil, apperror.InvalidArgument("处罚时长需要在 1 小时到 365 天之间")
+	}
+	if input.DurationHours == 0 && !uc.isCampusAdmin(ctx, input.UserID) {
+		return nil, apperror.Forbidden("永久处罚需要管理员操作")
+	}
+	reason := strings.TrimSpace(input.Reason)
+	if reason == "" {
+		return nil, apperror.InvalidArgument("请填写处罚原因")
+	}
+	if len([]rune(reason)) > 120 {
+		return nil, apperror.InvalidArgument("处罚原因不能超过 120 个字")
+	}
+	appealNote := strings.TrimSpace(input.AppealNote)
+	if len([]rune(appealNote)) > 200 {
+		return nil, apperror.InvalidArgument("申诉说明不能超过 200 个字")
+	}
+	now := time.Now()
+	sanction := &CampusUserSanction{
+		ID:         uc.idGen.NextID(),
+		UserID:     targetUserID,
+		Type:       sanctionType,
+		Reason:     reason,
+		AppealNote: firstNonEmpty(appealNote, campusSanctionDefaultAppeal),
+		Status:     CampusSanctionStatusActive,
+		StartsAt:   now,
+		CreatedBy:  input.UserID,
+		CreatedAt:  now,
+	}
+	if input.DurationHours > 0 {
+		expiresAt := now.Add(time.Duration(input.DurationHours) * time.Hour)
+		sanction.ExpiresAt = &expiresAt
+	}
+	active, err := uc.repo.ListActiveUserSanctions(ctx, []string{targetUserID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询处罚记录失败")
+	}
+	for _, previous := range active[targetUserID] {
+		if previous.Type != sanctionType {
+			continue
+		}
+		if _, err := uc.repo.RevokeUserSanction(ctx, previous.ID, input.UserID, "被新的处罚替换"); err != nil {
+			return nil, apperror.Internal(err, "更新处罚记录失败")
+		}
+	}
+	if err := uc.repo.CreateUserSanction(ctx, sanction); err != nil {
+		return nil, apperror.Internal(err, "创建处罚失败")
+	}
+	uc.recordSanctionAudit(ctx, sanction, input.UserID, "manual", "sanction", reason)
+	uc.queueUserSystemNotification(ctx, targetUserID, "sanction", sanction.ID,
+		"账号处罚通知",
+		campusSanctionMessage(sanction),
+		"community",
+		map[string]string{},
+		fmt.Sprintf("campus:sanction:%d", sanction.ID),
+	)
+	return sanction, nil
+}
+
+func (uc *CampusUsecase) AdminListUserSanctions(ctx context.Context, userID, targetUserID string) ([]*CampusUserSanction, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	targetUserID = strings.TrimSpace(targetUserID)
+	if parseInt64String(targetUserID) <= 0 {
+		return nil, apperror.InvalidArgument("用户 ID 无效")
+	}
+	sanctions, err := uc.repo.ListUserSanctions(ctx, targetUserID, 50)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取处罚记录失败")
+	}
+	return sanctions, nil
+}
+
+func (uc *CampusUsecase) AdminRevokeUserSanction(ctx context.Context, input *RevokeCampusUserSanctionInput) error {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return apperror.Forbidden("没有后台权限")
+	}
+	ok, sanction, err := uc.repo.GetUserSanction(ctx, input.SanctionID)
+	if err != nil {
+		return apperror.Internal(err, "查询处罚记录失败")
+	}
+	if !ok {
+		return apperror.NotFound("处罚记录不存在")
+	}
+	if err := uc.ensureOperatorCampus(ctx, input.UserID, uc.userCampusCode(ctx, sanction.UserID)); err != nil {
+		return err
+	}
+	if !sanction.activeAt(time.Now()) {
+		return apperror.InvalidArgument("处罚已结束")
+	}
+	reason := trimLimit(strings.TrimSpace(input.Reason), 120)
+	revoked, err := uc.repo.RevokeUserSanction(ctx, sanction.ID, input.UserID, reason)
+	if err != nil {
+		return apperror.Internal(err, "解除处罚失败")
+	}
+	if !revoked {
+		return apperror.InvalidArgument("处罚已结束")
+	}
+	uc.recordSanctionAudit(ctx, sanction, input.UserID, "manual", "lift", firstNonEmpty(reason, "后台提前解除"))
+	uc.queueUserSystemNotification(ctx, sanction.UserID, "sanction", sanction.ID,
+		"处罚已解除",
+		fmt.Sprintf("你的%s处罚已提前解除，请遵守社区规范。", campusSanctionTypeName(sanction.Type)),
+		"community",
+		map[string]string{},
+		fmt.Sprintf("campus:sanction-lift:%d", sanction.ID),
+	)
+	return nil
+}
+
+// ExpireUserSanctions 把到期的处罚标记为已结束并通知用户，按状态条件更新，多实例同时跑也只会通知一次
+func (uc *CampusUsecase) ExpireUserSanctions(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	due, err := uc.repo.ListDueUserSanctions(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+	expired := 0
+	for _, sanction := range due {
+		ok, err := uc.repo.ExpireUserSanction(ctx, sanction.ID)
+		if err != nil {
+			return expired, err
+		}
+		if !ok {
+			continue
+		}
+		expired++
+		uc.recordSanctionAudit(ctx, sanction, "0", "system", "expire", "处罚到期自动解除")
+		uc.queueUserSystemNotification(ctx, sanction.UserID, "sanction", sanction.ID,
+			"处罚已到期",
+			fmt.Sprintf("你的%s处罚已到期解除，请遵守社区规范。", campusSanctionTypeName(sanction.Type)),
+			"community",
+			map[string]string{},
+			fmt.Sprintf("campus:sanction-expire:%d", sanction.ID),
+		)
+	}
+	return expired, nil
+}
+
+func (uc *CampusUsecase) recordSanctionAudit(ctx context.Context, sanction *CampusUserSanction, operatorID, provider, action, reason string) {
+	_ = uc.repo.CreateAuditLog(ctx, &CampusAuditLog{
+		ID:         uc.idGen.NextID(),
+		TargetType: "user",
+		TargetID:   parseInt64String(sanction.UserID),
+		UserID:     operatorID,
+		Provider:   provider,
+		Result:     action + ":" + sanction.Type,
+		Reason:     trimLimit(fmt.Sprintf("%s（至 %s）", reason, campusSanctionUntilText(sanction)), 255),
+	})
+}
+
+func (uc *CampusUsecase) attachUserSanctions(ctx context.Context, users []*CampusAdminUser) {
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		if user != nil && user.User != nil {
+			userIDs = append(userIDs, user.User.ID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	active, err := uc.repo.ListActiveUserSanctions(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load admin user sanctions failed: err=%v", err)
+		return
+	}
+	for _, user := range users {
+		if user != nil && user.User != nil {
+			user.Sanctions = active[user.User.ID]
+			sort.Slice(user.Sanctions, func(i, j int) bool { return user.Sanctions[i].CreatedAt.After(user.Sanctions[j].CreatedAt) })
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package biz
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"lehu-video/pkg/apperror"
+)
+
+type sanctionStubRepo struct {
+	CampusRepo
+	sanctions map[string][]*CampusUserSanction
+	err       error
+}
+
+func (r *sanctionStubRepo) GetActiveUserSanctions(ctx context.Context, userID string) ([]*CampusUserSanction, error) {
+	return r.sanctions[userID], r.err
+}
+
+func TestActiveUserSanctionPrefersLongestActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(48 * time.Hour)
+	uc := &CampusUsecase{repo: &sanctionStubRepo{sanctions: map[string][]*CampusUserSanction{
+		"7": {
+			{ID: 1, Type: CampusSanctionTypePostBan, Status: CampusSanctionStatusActive, ExpiresAt: &past},
+			{ID: 2, Type: CampusSanctionTypeMute, Status: CampusSanctionStatusActive, ExpiresAt: &soon},
+			{ID: 3, Type: CampusSanctionTypeSuspend, Status: CampusSanctionStatusActive, ExpiresAt: &later, Reason: "刷屏广告"},
+		},
+	}}}
+	ctx := context.Background()
+	if got, err := uc.activeUserSanction(ctx, "7", CampusSanctionTypePostBan); err != nil || got != nil {
+		t.Fatalf("expired post ban should not apply, got %+v %v", got, err)
+	}
+	if got, err := uc.activeUserSanction(ctx, "7", CampusSanctionTypeMute, CampusSanctionTypeSuspend); err != nil || got == nil || got.ID != 3 {
+		t.Fatalf("expected longest sanction 3, got %+v %v", got, err)
+	}
+	err := uc.ensureNotSanctioned(ctx, "7", CampusSanctionTypeSuspend)
+	if err == nil || !strings.Contains(err.Error(), "刷屏广告") || !strings.Contains(err.Error(), campusSanctionDefaultAppeal) {
+		t.Fatalf("unexpected suspend error %v", err)
+	}
+	if err := uc.ensureNotSanctioned(ctx, "8", CampusSanctionTypeSuspend); err != nil {
+		t.Fatalf("unsanctioned user should pass, got %v", err)
+	}
+}
+
+func TestEnsureNotSanctionedFailsClosed(t *testing.T) {
+	uc := &CampusUsecase{repo: &sanctionStubRepo{err: errors.New("redis down")}, log: log.NewHelper(log.DefaultLogger)}
+	err := uc.ensureNotSanctioned(context.Background(), "7", CampusSanctionTypeSuspend)
+	if appErr := apperror.From(err); appErr == nil || appErr.Code != apperror.CodeDependencyUnavailable {
+		t.Fatalf("sanction lookup failure should reject the request, got %v", err)
+	}
+}
+
+func TestUpdatePostRejectsPostBan(t *testing.T) {
+	later := time.Now().Add(time.Hour)
+	uc := &CampusUsecase{repo: &sanctionStubRepo{sanctions: map[string][]*CampusUserSanction{
+		"7": {{ID: 1, Type: CampusSanctionTypePostBan, Status: CampusSanctionStatusActive, ExpiresAt: &later, Reason: "发布违规内容"}},
+	}}}
+	_, err := uc.UpdatePost(context.Background(), &UpdateCampusPostInput{UserID: "7", PostID: 1, Title: "改个标题", Content: "改个正文"})
+	if appErr := apperror.From(err); appErr == nil || appErr.Code != apperror.CodeForbidden || !strings.Contains(appErr.Message, "发布违规内容") {
+		t.Fatalf("post-banned user should not edit posts, got %v", err)
+	}
+}
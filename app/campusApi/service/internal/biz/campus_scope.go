@@ -0,0 +1,148 @@
+package biz
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	defaultCampusCodeValue = "szpu_shenshan"
+	campusCodeMaxLength    = 32
+)
+
+func defaultCampusCode() string {
+	if code := normalizeCampusCode(os.Getenv("LEHU_DEFAULT_CAMPUS_CODE")); code != "" {
+		return code
+	}
+	return defaultCampusCodeValue
+}
+
+func configuredCampusCodes() []string {
+	out := []string{defaultCampusCode()}
+	seen := map[string]bool{out[0]: true}
+	for _, raw := range strings.Split(os.Getenv("LEHU_CAMPUS_CODES"), ",") {
+		code := normalizeCampusCode(raw)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+		out = append(out, code)
+	}
+	return out
+}
+
+func campusCodeAllowed(code string) bool {
+	code = normalizeCampusCode(code)
+	if code == "" {
+		return false
+	}
+	for _, item := range configuredCampusCodes() {
+		if item == code {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeCampusCode(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || len(value) > campusCodeMaxLength {
+		return ""
+	}
+	for _, r := range value {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			continue
+		}
+		return ""
+	}
+	return value
+}
+
+func (uc *CampusUsecase) resolveCampusCode(ctx context.Context, userID, requested string) string {
+	if code := normalizeCampusCode(requested); code != "" && campusCodeAllowed(code) {
+		return code
+	}
+	if code := uc.userCampusCode(ctx, userID); code != "" {
+		return code
+	}
+	return defaultCampusCode()
+}
+
+func (uc *CampusUsecase) userCampusCode(ctx context.Context, userID string) string {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return ""
+	}
+	ok, profile, err := uc.repo.GetProfileByUserID(ctx, userID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus profile for campus code failed: user_id=%s err=%v", userID, err)
+		return ""
+	}
+	if !ok || profile == nil {
+		return ""
+	}
+	return normalizeCampusCode(profile.CampusCode)
+}
+
+func (uc *CampusUsecase) operatorCampusScope(ctx context.Context, userID, requested string) string {
+	if uc.isCampusAdmin(ctx, userID) {
+		return normalizeCampusCode(requested)
+	}
+	return firstNonEmpty(uc.userCampusCode(ctx, userID), defaultCampusCode())
+}
+
+func (uc *CampusUsecase) ensureOperatorCampus(ctx context.Context, userID, campusCode string) error {
+	scope := uc.operatorCampusScope(ctx, userID, "")
+	if scope == "" {
+		return nil
+	}
+	if normalizeCampusCode(campusCode) == "" {
+		campusCode = defaultCampusCode()
+	}
+	if normalizeCampusCode(campusCode) != scope {
+		return apperror.Forbidden("不能管理其他校区的内容")
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) ensureOperatorPostCampus(ctx context.Context, userID string, postID int64) error {
+	if uc.operatorCampusScope(ctx, userID, "") == "" {
+		return nil
+	}
+	ok, post, err := uc.repo.GetAnyPostByID(ctx, postID)
+	if err != nil {
+		return apperror.Internal(err, "查询帖子失败")
+	}
+	if !ok || post == nil {
+		return nil
+	}
+	return uc.ensureOperatorCampus(ctx, userID, post.CampusCode)
+}
+
+func (uc *CampusUsecase) ensureOperatorCommentCampus(ctx context.Context, userID string, commentID int64) error {
+	if uc.operatorCampusScope(ctx, userID, "") == "" {
+		return nil
+	}
+	ok, comment, err := uc.repo.GetAnyCommentByID(ctx, commentID)
+	if err != nil {
+		return apperror.Internal(err, "查询评论失败")
+	}
+	if !ok || comment == nil {
+		return nil
+	}
+	return uc.ensureOperatorPostCampus(ctx, userID, comment.PostID)
+}
+
+func (uc *CampusUsecase) ensureOperatorTargetCampus(ctx context.Context, userID, targetType string, targetID int64) error {
+	switch targetType {
+	case "post":
+		return uc.ensureOperatorPostCampus(ctx, userID, targetID)
+	case "comment":
+		return uc.ensureOperatorCommentCampus(ctx, userID, targetID)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,53 @@
+package biz
+
+import "testing"
+
+func TestNormalizeCampusCode(t *testing.T) {
+	cases := map[string]string{
+		" SZPU_Shenshan ":                      "szpu_shenshan",
+		"campus-2":                             "campus-2",
+		"":                                     "",
+		"校区":                                   "",
+		"a b":                                  "",
+		"abcdefghijklmnopqrstuvwxyz0123456789": "",
+	}
+	for input, want := range cases {
+		if got := normalizeCampusCode(input); got != want {
+			t.Fatalf("normalizeCampusCode(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestConfiguredCampusCodesIncludesDefault(t *testing.T) {
+	t.Setenv("LEHU_DEFAULT_CAMPUS_CODE", "")
+	t.Setenv("LEHU_CAMPUS_CODES", "north, SZPU_SHENSHAN ,bad code,north")
+	got := configuredCampusCodes()
+	if len(got) != 2 || got[0] != defaultCampusCodeValue || got[1] != "north" {
+		t.Fatalf("configuredCampusCodes() = %v", got)
+	}
+	if !campusCodeAllowed("NORTH") || campusCodeAllowed("south") {
+		t.Fatalf("campusCodeAllowed mismatch")
+	}
+}
+
+func TestCampusRecommendPoolIsolatesCampuses(t *testing.T) {
+	pool := &CampusRecommendPool{}
+	pool.Set("a", []int64{1, 2, 3}, []int64{3})
+	pool.Set("b", []int64{9}, nil)
+
+	ids, ok := pool.Get("a", CampusPostSortRecommend, 1, 5)
+	if !ok || len(ids) != 2 || ids[0] != 2 {
+		t.Fatalf("pool.Get(a) = %v, %v", ids, ok)
+	}
+	if total := pool.Total("b", CampusPostSortRecommend); total != 1 {
+		t.Fatalf("pool.Total(b) = %d, want 1", total)
+	}
+	if _, ok := pool.Get("b", CampusPostSortHot, 0, 5); ok {
+		t.Fatalf("empty hot pool should miss")
+	}
+
+	pool.Retain([]string{"b"})
+	if total := pool.Total("a", CampusPostSortRecommend); total != 0 {
+		t.Fatalf("retained pool kept campus a: %d", total)
+	}
+}
@@ -0,0 +1,483 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusSearchDocPost    = "post"
+	CampusSearchDocComment = "comment"
+
+	CampusSearchBackendMySQL = "mysql"
+	CampusSearchBackendBleve = "bleve"
+
+	campusSearchSnippetRunes   = 80
+	campusSearchReindexBatch   = 200
+	campusSearchMaxKeywordRune = 64
+)
+
+type CampusSearchIndex interface {
+	Backend() string
+	Upsert(ctx context.Context, docs []*CampusSearchDocument) error
+	Delete(ctx context.Context, docType string, docIDs []int64) error
+	DeleteByPost(ctx context.Context, postID int64) error
+	Prune(ctx context.Context, indexedBefore time.Time) (int64, error)
+	Count(ctx context.Context) (int64, error)
+	Search(ctx context.Context, query *CampusSearchQuery) ([]*CampusSearchHit, int64, error)
+}
+
+type CampusSearchDocument struct {
+	DocType       string
+	DocID         int64
+	PostID        int64
+	CampusCode    string
+	IsCrossCampus bool
+	CategoryCode  string
+	PostType      string
+	AuthorID      string
+	Title         string
+	Content       string
+	CreatedAt     time.Time
+	IndexedAt     time.Time
+}
+
+type CampusSearchQuery struct {
+	Keyword          string
+	DocTypes         []string
+	CampusCode       string
+	CategoryCode     string
+	PostType         string
+	ExcludeAuthorIDs []string
+	Offset           int
+	Limit            int
+}
+
+type CampusSearchHit struct {
+	Document *CampusSearchDocument
+	Score    float64
+}
+
+type CampusSearchHighlight struct {
+	Title   string
+	Snippet string
+}
+
+type SearchCampusInput struct {
+	CurrentUserID string
+	CampusCode    string
+	Keyword       string
+	Type          string
+	CategoryCode  string
+	Page          int32
+	Size          int32
+}
+
+type CampusSearchResult struct {
+	DocType   string
+	Post      *CampusForumPost
+	CommentID int64
+	Score     float64
+	Highlight *CampusSearchHighlight
+	CreatedAt time.Time
+}
+
+type SearchCampusOutput struct {
+	Results []*CampusSearchResult
+	Total   int64
+	Backend string
+}
+
+type CampusSearchReindexStatus struct {
+	Backend    string
+	Running    bool
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	Posts      int64
+	Comments   int64
+	Pruned     int64
+	Documents  int64
+	Error      string
+}
+
+type campusSearchReindexState struct {
+	mu     sync.Mutex
+	status CampusSearchReindexStatus
+}
+
+func (uc *CampusUsecase) SearchCampus(ctx context.Context, input *SearchCampusInput) (*SearchCampusOutput, error) {
+	keyword := normalizeCampusSearchKeyword(input.Keyword)
+	if len([]rune(keyword)) < 2 {
+		return nil, apperror.InvalidArgument("搜索关键词至少 2 个字")
+	}
+	docTypes := []string{CampusSearchDocPost, CampusSearchDocComment}
+	switch strings.TrimSpace(input.Type) {
+	case CampusSearchDocPost:
+		docTypes = []string{CampusSearchDocPost}
+	case CampusSearchDocComment:
+		docTypes = []string{CampusSearchDocComment}
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	hits, total, err := uc.search.Search(ctx, &CampusSearchQuery{
+		Keyword:      keyword,
+		DocTypes:     docTypes,
+		CampusCode:   uc.resolveCampusCode(ctx, input.CurrentUserID, input.CampusCode),
+		CategoryCode: strings.TrimSpace(input.CategoryCode),
+		Offset:       int((page - 1) * size),
+		Limit:        int(size),
+	})
+	if err != nil {
+		return nil, apperror.DependencyUnavailable(err, "搜索服务暂不可用")
+	}
+	posts, err := uc.loadCampusSearchPosts(ctx, hits, input.CurrentUserID)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取搜索结果失败")
+	}
+	results := make([]*CampusSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		doc := hit.Document
+		post := posts[doc.PostID]
+		if post == nil {
+			continue
+		}
+		result := &CampusSearchResult{
+			DocType:   doc.DocType,
+			Post:      post,
+			Score:     hit.Score,
+			Highlight: buildCampusSearchHighlight(doc, keyword),
+			CreatedAt: doc.CreatedAt,
+		}
+		if doc.DocType == CampusSearchDocComment {
+			result.CommentID = doc.DocID
+		}
+		results = append(results, result)
+	}
+	total = adjustCampusSearchTotal(total, len(hits), len(results))
+	return &SearchCampusOutput{Results: results, Total: total, Backend: uc.search.Backend()}, nil
+}
+
+func (uc *CampusUsecase) searchPostsByKeyword(ctx context.Context, query ListCampusPostQuery, currentUserID string) ([]*CampusForumPost, int64, bool) {
+	keyword := normalizeCampusSearchKeyword(query.Keyword)
+	if len([]rune(keyword)) < 2 {
+		return nil, 0, false
+	}
+	hits, total, err := uc.search.Search(ctx, &CampusSearchQuery{
+		Keyword:          keyword,
+		DocTypes:         []string{CampusSearchDocPost},
+		CampusCode:       query.CampusCode,
+		CategoryCode:     query.CategoryCode,
+		PostType:         query.PostType,
+		ExcludeAuthorIDs: query.ExcludeAuthorIDs,
+		Offset:           query.Offset,
+		Limit:            query.Limit,
+	})
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("campus search failed, fallback to like query: backend=%s err=%v", uc.search.Backend(), err)
+		return nil, 0, false
+	}
+	posts, err := uc.loadCampusSearchPosts(ctx, hits, currentUserID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus search posts failed, fallback to like query: %v", err)
+		return nil, 0, false
+	}
+	out := make([]*CampusForumPost, 0, len(hits))
+	for _, hit := range hits {
+		if post := posts[hit.Document.PostID]; post != nil {
+			post.SearchHighlight = buildCampusSearchHighlight(hit.Document, keyword)
+			out = append(out, post)
+		}
+	}
+	return out, adjustCampusSearchTotal(total, len(hits), len(out)), true
+}
+
+// 索引里可能残留已删除或当前用户不可见的帖子，按本页被过滤掉的条数扣减总数
+func adjustCampusSearchTotal(total int64, hits, kept int) int64 {
+	total -= int64(hits - kept)
+	if total < int64(kept) {
+		return int64(kept)
+	}
+	return total
+}
+
+func (uc *CampusUsecase) loadCampusSearchPosts(ctx context.Context, hits []*CampusSearchHit, currentUserID string) (map[int64]*CampusForumPost, error) {
+	ids := make([]int64, 0, len(hits))
+	seen := map[int64]bool{}
+	for _, hit := range hits {
+		if hit == nil || hit.Document == nil || seen[hit.Document.PostID] {
+			continue
+		}
+		seen[hit.Document.PostID] = true
+		ids = append(ids, hit.Document.PostID)
+	}
+	out := map[int64]*CampusForumPost{}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	posts, err := uc.repo.ListPostsByIDs(ctx, ids, []int32{CampusAuditStatusVisible})
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.assembler.HydratePosts(ctx, posts, currentUserID); err != nil {
+		uc.log.WithContext(ctx).Warnf("hydrate campus search posts failed: %v", err)
+	}
+	for _, post := range posts {
+		if post != nil {
+			out[post.ID] = post
+		}
+	}
+	return out, nil
+}
+
+func (uc *CampusUsecase) AdminReindexSearch(ctx context.Context, userID string) (*CampusSearchReindexStatus, error) {
+	if !uc.isCampusAdmin(ctx, userID) {
+		return nil, apperror.Forbidden("仅管理员可以重建搜索索引")
+	}
+	if !uc.startSearchReindex() {
+		return nil, apperror.Conflict("搜索索引正在重建，请稍后再看")
+	}
+	go func() {
+		taskCtx, cancel := context.WithTimeout(context.Background(), envDurationBiz("CAMPUS_SEARCH_REINDEX_TIMEOUT", 30*time.Minute))
+		defer cancel()
+		uc.runSearchReindex(taskCtx)
+	}()
+	return uc.AdminSearchStatus(ctx, userID)
+}
+
+func (uc *CampusUsecase) AdminSearchStatus(ctx context.Context, userID string) (*CampusSearchReindexStatus, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	uc.searchReindex.mu.Lock()
+	status := uc.searchReindex.status
+	uc.searchReindex.mu.Unlock()
+	status.Backend = uc.search.Backend()
+	if count, err := uc.search.Count(ctx); err == nil {
+		status.Documents = count
+	}
+	return &status, nil
+}
+
+func (uc *CampusUsecase) WarmCampusSearchIndex(ctx context.Context) error {
+	if count, err := uc.search.Count(ctx); err != nil || count > 0 {
+		return err
+	}
+	if !uc.startSearchReindex() {
+		return nil
+	}
+	return uc.runSearchReindex(ctx)
+}
+
+func (uc *CampusUsecase) startSearchReindex() bool {
+	uc.searchReindex.mu.Lock()
+	defer uc.searchReindex.mu.Unlock()
+	if uc.searchReindex.status.Running {
+		return false
+	}
+	now := time.Now()
+	uc.searchReindex.status = CampusSearchReindexStatus{Running: true, StartedAt: &now}
+	return true
+}
+
+func (uc *CampusUsecase) runSearchReindex(ctx context.Context) error {
+	startedAt := time.Now()
+	var posts, comments, pruned int64
+	var runErr error
+	for _, docType := range []string{CampusSearchDocPost, CampusSearchDocComment} {
+		var afterID int64
+		for runErr == nil {
+			docs, err := uc.repo.ListSearchDocuments(ctx, docType, afterID, campusSearchReindexBatch)
+			if err != nil {
+				runErr = err
+				break
+			}
+			if len(docs) == 0 {
+				break
+			}
+			if err := uc.search.Upsert(ctx, docs); err != nil {
+				runErr = err
+				break
+			}
+			afterID = docs[len(docs)-1].DocID
+			if docType == CampusSearchDocPost {
+				posts += int64(len(docs))
+			} else {
+				comments += int64(len(docs))
+			}
+			uc.searchReindex.mu.Lock()
+			uc.searchReindex.status.Posts = posts
+			uc.searchReindex.status.Comments = comments
+			uc.searchReindex.mu.Unlock()
+		}
+	}
+	if runErr == nil {
+		pruned, runErr = uc.search.Prune(ctx, startedAt)
+	}
+	finishedAt := time.Now()
+	uc.searchReindex.mu.Lock()
+	uc.searchReindex.status.Running = false
+	uc.searchReindex.status.FinishedAt = &finishedAt
+	uc.searchReindex.status.Pruned = pruned
+	if runErr != nil {
+		uc.searchReindex.status.Error = trimLimit(runErr.Error(), 300)
+	}
+	uc.searchReindex.mu.Unlock()
+	if runErr != nil {
+		uc.log.WithContext(ctx).Warnf("campus search reindex failed: backend=%s posts=%d comments=%d err=%v", uc.search.Backend(), posts, comments, runErr)
+		return runErr
+	}
+	uc.log.WithContext(ctx).Infof("campus search reindex done: backend=%s posts=%d comments=%d pruned=%d cost=%s", uc.search.Backend(), posts, comments, pruned, finishedAt.Sub(startedAt))
+	return nil
+}
+
+// mysql 走 ngram 全文索引，多实例共享；bleve 是进程内磁盘索引，只适合单实例部署。
+// 未知后端直接拒绝启动，避免拼写错误时静默退化
+func CampusSearchBackendFromEnv() (string, error) {
+	switch backend := strings.ToLower(strings.TrimSpace(os.Getenv("LEHU_CAMPUS_SEARCH_BACKEND"))); backend {
+	case "", CampusSearchBackendMySQL:
+		return CampusSearchBackendMySQL, nil
+	case CampusSearchBackendBleve:
+		return CampusSearchBackendBleve, nil
+	default:
+		return "", fmt.Errorf("unsupported LEHU_CAMPUS_SEARCH_BACKEND %q, use %q or %q", backend, CampusSearchBackendMySQL, CampusSearchBackendBleve)
+	}
+}
+
+func normalizeCampusSearchKeyword(value string) string {
+	return trimLimit(strings.Join(strings.Fields(value), " "), campusSearchMaxKeywordRune)
+}
+
+func buildCampusSearchHighlight(doc *CampusSearchDocument, keyword string) *CampusSearchHighlight {
+	terms := campusSearchHighlightTerms(keyword)
+	highlight := &CampusSearchHighlight{
+		Title:   highlightCampusSearchText(doc.Title, terms, 0),
+		Snippet: highlightCampusSearchText(doc.Content, terms, campusSearchSnippetRunes),
+	}
+	return highlight
+}
+
+func campusSearchHighlightTerms(keyword string) []string {
+	terms := make([]string, 0, 8)
+	for _, field := range strings.Fields(strings.ToLower(keyword)) {
+		terms = append(terms, field)
+		runes := []rune(field)
+		if len(runes) > 2 && campusSearchIsCJK(runes[0]) {
+			for i := 0; i+2 <= len(runes); i++ {
+				terms = append(terms, string(runes[i:i+2]))
+			}
+		}
+	}
+	terms = dedupeStrings(terms)
+	sort.SliceStable(terms, func(i, j int) bool { return len([]rune(terms[i])) > len([]rune(terms[j])) })
+	return terms
+}
+
+func highlightCampusSearchText(text string, terms []string, maxRunes int) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	matchAt := func(pos int) int {
+		for _, term := range terms {
+			termRunes := []rune(term)
+			if len(termRunes) == 0 || pos+len(termRunes) > len(lower) {
+				continue
+			}
+			if string(lower[pos:pos+len(termRunes)]) == term {
+				return len(termRunes)
+			}
+		}
+		return 0
+	}
+	start, end := 0, len(runes)
+	if maxRunes > 0 && len(runes) > maxRunes {
+		first := -1
+		for i := range lower {
+			if matchAt(i) > 0 {
+				first = i
+				break
+			}
+		}
+		if first > maxRunes/4 {
+			start = first - maxRunes/4
+		}
+		end = start + maxRunes
+		if end > len(runes) {
+			end = len(runes)
+			start = end - maxRunes
+		}
+	}
+	var builder strings.Builder
+	if start > 0 {
+		builder.WriteString("…")
+	}
+	for i := start; i < end; {
+		if n := matchAt(i); n > 0 {
+			if i+n > end {
+				n = end - i
+			}
+			builder.WriteString("<em>" + html.EscapeString(string(runes[i:i+n])) + "</em>")
+			i += n
+			continue
+		}
+		builder.WriteString(html.EscapeString(string(runes[i])))
+		i++
+	}
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+	return builder.String()
+}
+
+func campusSearchIsCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func campusSearchTokens(text string) []string {
+	tokens := make([]string, 0, len(text)/2)
+	var word []rune
+	var cjk []rune
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+			word = word[:0]
+		}
+	}
+	flushCJK := func() {
+		switch {
+		case len(cjk) == 1:
+			tokens = append(tokens, string(cjk))
+		case len(cjk) > 1:
+			for i := 0; i+2 <= len(cjk); i++ {
+				tokens = append(tokens, string(cjk[i:i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range text {
+		r = unicode.ToLower(r)
+		switch {
+		case campusSearchIsCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return tokens
+}
@@ -0,0 +1,204 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 进程内的搜索索引，仅供测试验证排序和过滤逻辑，线上使用 MySQL 全文索引或 Bleve
+const campusSearchBackendMemory = "memory"
+
+type campusMemorySearchEntry struct {
+	doc      *CampusSearchDocument
+	terms    map[string]float64
+	length   float64
+	postings []string
+}
+
+type CampusMemorySearchIndex struct {
+	mu       sync.RWMutex
+	docs     map[string]*campusMemorySearchEntry
+	postings map[string]map[string]float64
+	totalLen float64
+}
+
+func NewCampusMemorySearchIndex() *CampusMemorySearchIndex {
+	return &CampusMemorySearchIndex{
+		docs:     map[string]*campusMemorySearchEntry{},
+		postings: map[string]map[string]float64{},
+	}
+}
+
+func (idx *CampusMemorySearchIndex) Backend() string {
+	return campusSearchBackendMemory
+}
+
+func (idx *CampusMemorySearchIndex) Upsert(ctx context.Context, docs []*CampusSearchDocument) error {
+	now := time.Now()
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, doc := range docs {
+		if doc == nil || doc.DocID <= 0 {
+			continue
+		}
+		key := campusMemorySearchKey(doc.DocType, doc.DocID)
+		idx.removeLocked(key)
+		copied := *doc
+		copied.IndexedAt = now
+		entry := &campusMemorySearchEntry{doc: &copied, terms: map[string]float64{}}
+		for _, token := range campusSearchTokens(doc.Title) {
+			entry.terms[token] += 2
+			entry.length += 2
+		}
+		for _, token := range campusSearchTokens(doc.Content) {
+			entry.terms[token]++
+			entry.length++
+		}
+		for token, tf := range entry.terms {
+			if idx.postings[token] == nil {
+				idx.postings[token] = map[string]float64{}
+			}
+			idx.postings[token][key] = tf
+			entry.postings = append(entry.postings, token)
+		}
+		idx.docs[key] = entry
+		idx.totalLen += entry.length
+	}
+	return nil
+}
+
+func (idx *CampusMemorySearchIndex) Delete(ctx context.Context, docType string, docIDs []int64) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for _, id := range docIDs {
+		idx.removeLocked(campusMemorySearchKey(docType, id))
+	}
+	return nil
+}
+
+func (idx *CampusMemorySearchIndex) DeleteByPost(ctx context.Context, postID int64) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	for key, entry := range idx.docs {
+		if entry.doc.DocType == CampusSearchDocComment && entry.doc.PostID == postID {
+			idx.removeLocked(key)
+		}
+	}
+	return nil
+}
+
+func (idx *CampusMemorySearchIndex) Prune(ctx context.Context, indexedBefore time.Time) (int64, error) {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	var pruned int64
+	for key, entry := range idx.docs {
+		if entry.doc.IndexedAt.Before(indexedBefore) {
+			idx.removeLocked(key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+func (idx *CampusMemorySearchIndex) Count(ctx context.Context) (int64, error) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return int64(len(idx.docs)), nil
+}
+
+func (idx *CampusMemorySearchIndex) Search(ctx context.Context, query *CampusSearchQuery) ([]*CampusSearchHit, int64, error) {
+	tokens := dedupeStrings(campusSearchTokens(query.Keyword))
+	if len(tokens) == 0 {
+		return nil, 0, nil
+	}
+	minMatched := int(math.Ceil(float64(len(tokens)) * 0.6))
+	docTypes := map[string]bool{}
+	for _, docType := range query.DocTypes {
+		docTypes[docType] = true
+	}
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	docCount := float64(len(idx.docs))
+	avgLen := 1.0
+	if docCount > 0 && idx.totalLen > 0 {
+		avgLen = idx.totalLen / docCount
+	}
+	scores := map[string]float64{}
+	matched := map[string]int{}
+	for _, token := range tokens {
+		posting := idx.postings[token]
+		if len(posting) == 0 {
+			continue
+		}
+		idf := math.Log(1 + (docCount-float64(len(posting))+0.5)/(float64(len(posting))+0.5))
+		for key, tf := range posting {
+			entry := idx.docs[key]
+			norm := tf * 2.2 / (tf + 1.2*(0.25+0.75*entry.length/avgLen))
+			scores[key] += idf * norm
+			matched[key]++
+		}
+	}
+	hits := make([]*CampusSearchHit, 0, len(scores))
+	for key, score := range scores {
+		if matched[key] < minMatched {
+			continue
+		}
+		doc := idx.docs[key].doc
+		if len(docTypes) > 0 && !docTypes[doc.DocType] {
+			continue
+		}
+		if query.CampusCode != "" && doc.CampusCode != query.CampusCode && !doc.IsCrossCampus {
+			continue
+		}
+		if query.CategoryCode != "" && doc.CategoryCode != query.CategoryCode {
+			continue
+		}
+		if query.PostType != "" && doc.PostType != query.PostType {
+			continue
+		}
+		if slices.Contains(query.ExcludeAuthorIDs, doc.AuthorID) {
+			continue
+		}
+		copied := *doc
+		hits = append(hits, &CampusSearchHit{Document: &copied, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Document.CreatedAt.After(hits[j].Document.CreatedAt)
+	})
+	total := int64(len(hits))
+	if query.Offset >= len(hits) {
+		return []*CampusSearchHit{}, total, nil
+	}
+	hits = hits[query.Offset:]
+	if query.Limit > 0 && len(hits) > query.Limit {
+		hits = hits[:query.Limit]
+	}
+	return hits, total, nil
+}
+
+func (idx *CampusMemorySearchIndex) removeLocked(key string) {
+	entry := idx.docs[key]
+	if entry == nil {
+		return
+	}
+	for _, token := range entry.postings {
+		delete(idx.postings[token], key)
+		if len(idx.postings[token]) == 0 {
+			delete(idx.postings, token)
+		}
+	}
+	idx.totalLen -= entry.length
+	delete(idx.docs, key)
+}
+
+func campusMemorySearchKey(docType string, docID int64) string {
+	return fmt.Sprintf("%s:%d", docType, docID)
+}
@@ -0,0 +1,69 @@
+package biz
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCampusMemorySearchIndexRanksAndFilters(t *testing.T) {
+	idx := NewCampusMemorySearchIndex()
+	ctx := context.Background()
+	now := time.Now()
+	err := idx.Upsert(ctx, []*CampusSearchDocument{
+		{DocType: CampusSearchDocPost, DocID: 1, PostID: 1, CampusCode: "main", Title: "高数期末复习资料", Content: "整理了高等数学期末重点", CreatedAt: now},
+		{DocType: CampusSearchDocPost, DocID: 2, PostID: 2, CampusCode: "main", Title: "食堂推荐", Content: "二食堂的麻辣烫，复习累了可以去", CreatedAt: now},
+		{DocType: CampusSearchDocPost, DocID: 3, PostID: 3, CampusCode: "east", Title: "期末复习打卡", Content: "东校区图书馆", CreatedAt: now},
+		{DocType: CampusSearchDocComment, DocID: 10, PostID: 2, CampusCode: "main", Content: "期末复习加油 GPA 4.0", CreatedAt: now},
+	})
+	if err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	hits, total, err := idx.Search(ctx, &CampusSearchQuery{Keyword: "期末复习", CampusCode: "main", Limit: 10})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if total != 2 || hits[0].Document.DocID != 1 || hits[1].Document.DocType != CampusSearchDocComment {
+		t.Fatalf("unexpected hits: total=%d %+v", total, hits)
+	}
+
+	if _, total, _ := idx.Search(ctx, &CampusSearchQuery{Keyword: "gpa", DocTypes: []string{CampusSearchDocPost}}); total != 0 {
+		t.Fatalf("doc type filter ignored, total=%d", total)
+	}
+
+	if err := idx.DeleteByPost(ctx, 2); err != nil {
+		t.Fatalf("delete by post: %v", err)
+	}
+	if _, total, _ := idx.Search(ctx, &CampusSearchQuery{Keyword: "GPA"}); total != 0 {
+		t.Fatalf("comment should be removed with its post, total=%d", total)
+	}
+
+	pruned, _ := idx.Prune(ctx, time.Now().Add(time.Second))
+	if count, _ := idx.Count(ctx); pruned != 3 || count != 0 {
+		t.Fatalf("prune = %d, count = %d", pruned, count)
+	}
+}
+
+func TestHighlightCampusSearchText(t *testing.T) {
+	terms := campusSearchHighlightTerms("期末复习")
+	got := highlightCampusSearchText("整理了<b>期末复习</b>资料", terms, 0)
+	if got != "整理了&lt;b&gt;<em>期末复习</em>&lt;/b&gt;资料" {
+		t.Fatalf("highlight = %q", got)
+	}
+	long := strings.Repeat("无关内容", 40) + "期末考试安排" + strings.Repeat("其他", 40)
+	snippet := highlightCampusSearchText(long, terms, 40)
+	if !strings.HasPrefix(snippet, "…") || !strings.Contains(snippet, "<em>期末</em>") {
+		t.Fatalf("snippet should window around partial match: %q", snippet)
+	}
+}
+
+func TestAdjustCampusSearchTotal(t *testing.T) {
+	if got := adjustCampusSearchTotal(12, 10, 7); got != 9 {
+		t.Fatalf("total = %d, want 9", got)
+	}
+	if got := adjustCampusSearchTotal(3, 3, 1); got != 1 {
+		t.Fatalf("total = %d, want 1", got)
+	}
+}
@@ -0,0 +1,438 @@
+package biz
+
+import (
+	"context"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusTagStatusNormal int32 = 0
+	CampusTagStatusBanned int32 = 1
+	CampusTagStatusMerged int32 = 2
+
+	CampusPostTagSourceExplicit = "explicit"
+	CampusPostTagSourceContent  = "content"
+
+	campusPostTagsMax     = 5
+	campusTagNameMaxRunes = 20
+	campusTrendingTagsMax = 30
+)
+
+var campusHashtagPattern = regexp.MustCompile(`(?:^|[^0-9A-Za-z_/&])#([\p{Han}\p{L}\p{N}_]+)#?`)
+
+type CampusTag struct {
+	ID           int64
+	Name         string
+	DisplayName  string
+	Status       int32
+	MergedIntoID int64
+	IsFeatured   bool
+	PostCount    int64
+	TrendScore   float64
+	Source       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type CampusTagActivity struct {
+	CampusCode   string
+	TagID        int64
+	PostCount    int64
+	LikeCount    int64
+	CommentCount int64
+}
+
+// 话题在单个学校内的帖子数和热度
+type CampusTagStat struct {
+	CampusCode string
+	TagID      int64
+	PostCount  int64
+	TrendScore float64
+}
+
+type ListCampusTagQuery struct {
+	Keyword string
+	Status  *int32
+	Offset  int
+	Limit   int
+}
+
+type ListCampusAdminTagsInput struct {
+	UserID  string
+	Keyword string
+	Status  int32
+	Page    int32
+	Size    int32
+}
+
+type ListCampusAdminTagsOutput struct {
+	Tags  []*CampusTag
+	Total int64
+}
+
+type UpdateCampusTagInput struct {
+	UserID      string
+	TagID       int64
+	TargetTagID int64
+	Enabled     bool
+}
+
+type campusTagCandidate struct {
+	Name        string
+	DisplayName string
+	Source      string
+}
+
+func campusTagTrendingWindow() time.Duration {
+	if value := strings.TrimSpace(os.Getenv("CAMPUS_TAG_TRENDING_WINDOW")); value == "0" || envBoolFalse(value) {
+		return 0
+	}
+	return envDurationBiz("CAMPUS_TAG_TRENDING_WINDOW", 48*time.Hour)
+}
+
+func normalizeCampusTagName(raw string) (string, string, bool) {
+	display := strings.TrimSpace(raw)
+	display = strings.TrimSpace(strings.Trim(display, "#＃"))
+	runes := []rune(display)
+	if len(runes) == 0 || len(runes) > campusTagNameMaxRunes {
+		return "", "", false
+	}
+	allDigits := true
+	for _, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return "", "", false
+		}
+		if !unicode.IsDigit(r) {
+			allDigits = false
+		}
+	}
+	if allDigits {
+		return "", "", false
+	}
+	return strings.ToLower(display), display, true
+}
+
+func extractCampusHashtags(content string) []string {
+	matches := campusHashtagPattern.FindAllStringSubmatch(content, -1)
+	tags := make([]string, 0, len(matches))
+	for _, match := range matches {
+		tags = append(tags, match[1])
+	}
+	return tags
+}
+
+func collectCampusPostTags(content string, explicit []string) ([]campusTagCandidate, error) {
+	candidates := make([]campusTagCandidate, 0, campusPostTagsMax)
+	seen := map[string]struct{}{}
+	explicitCount := 0
+	for _, raw := range explicit {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		name, display, ok := normalizeCampusTagName(raw)
+		if !ok {
+			return nil, apperror.InvalidArgument("标签只能包含中英文、数字和下划线，最多 20 个字")
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		explicitCount++
+		if explicitCount > campusPostTagsMax {
+			return nil, apperror.InvalidArgument("最多添加 5 个标签")
+		}
+		seen[name] = struct{}{}
+		candidates = append(candidates, campusTagCandidate{Name: name, DisplayName: display, Source: CampusPostTagSourceExplicit})
+	}
+	for _, raw := range extractCampusHashtags(content) {
+		if len(candidates) >= campusPostTagsMax {
+			break
+		}
+		name, display, ok := normalizeCampusTagName(raw)
+		if !ok {
+			continue
+		}
+		if _, exists := seen[name]; exists {
+			continue
+		}
+		seen[name] = struct{}{}
+		candidates = append(candidates, campusTagCandidate{Name: name, DisplayName: display, Source: CampusPostTagSourceContent})
+	}
+	return candidates, nil
+}
+
+func (uc *CampusUsecase) resolveCampusPostTags(ctx context.Context, content string, explicit []string) ([]*CampusTag, error) {
+	candidates, err := collectCampusPostTags(content, explicit)
+	if err != nil || len(candidates) == 0 {
+		return nil, err
+	}
+	seeds := make([]*CampusTag, 0, len(candidates))
+	for _, candidate := range candidates {
+		seeds = append(seeds, &CampusTag{ID: uc.idGen.NextID(), Name: candidate.Name, DisplayName: candidate.DisplayName})
+	}
+	stored, err := uc.repo.EnsureTags(ctx, seeds)
+	if err != nil {
+		return nil, apperror.Internal(err, "保存标签失败")
+	}
+	mergedIDs := make([]int64, 0)
+	for _, tag := range stored {
+		if tag.Status == CampusTagStatusMerged && tag.MergedIntoID > 0 {
+			mergedIDs = append(mergedIDs, tag.MergedIntoID)
+		}
+	}
+	targets := map[int64]*CampusTag{}
+	if len(mergedIDs) > 0 {
+		if targets, err = uc.repo.GetTagsByIDs(ctx, mergedIDs); err != nil {
+			return nil, apperror.Internal(err, "查询标签失败")
+		}
+	}
+	tags := make([]*CampusTag, 0, len(candidates))
+	seen := map[int64]struct{}{}
+	for _, candidate := range candidates {
+		tag := stored[candidate.Name]
+		if tag != nil && tag.Status == CampusTagStatusMerged {
+			tag = targets[tag.MergedIntoID]
+		}
+		if tag == nil || tag.Status != CampusTagStatusNormal {
+			continue
+		}
+		if _, exists := seen[tag.ID]; exists {
+			continue
+		}
+		seen[tag.ID] = struct{}{}
+		resolved := *tag
+		resolved.Source = candidate.Source
+		tags = append(tags, &resolved)
+	}
+	return tags, nil
+}
+
+func explicitCampusTagNames(tags []*CampusTag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag != nil && tag.Source == CampusPostTagSourceExplicit {
+			names = append(names, tag.DisplayName)
+		}
+	}
+	return names
+}
+
+func campusPostTagsChanged(before, after []*CampusTag) bool {
+	if len(before) != len(after) {
+		return true
+	}
+	for i := range before {
+		if before[i].ID != after[i].ID || before[i].Source != after[i].Source {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *CampusPostAssembler) hydrateTags(ctx context.Context, posts []*CampusForumPost) {
+	postIDs := make([]int64, 0, len(posts))
+	for _, post := range posts {
+		if post != nil {
+			postIDs = append(postIDs, post.ID)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+	tags, err := a.repo.GetPostTags(ctx, postIDs)
+	if err != nil {
+		a.log.WithContext(ctx).Warnf("load campus post tags failed: %v", err)
+		return
+	}
+	for _, post := range posts {
+		if post != nil {
+			post.Tags = tags[post.ID]
+		}
+	}
+}
+
+func (uc *CampusUsecase) GetTag(ctx context.Context, name string) (*CampusTag, error) {
+	key, _, ok := normalizeCampusTagName(name)
+	if !ok {
+		return nil, apperror.InvalidArgument("话题无效")
+	}
+	found, tag, err := uc.repo.GetTagByName(ctx, key)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询话题失败")
+	}
+	if found && tag.Status == CampusTagStatusMerged && tag.MergedIntoID > 0 {
+		targets, err := uc.repo.GetTagsByIDs(ctx, []int64{tag.MergedIntoID})
+		if err != nil {
+			return nil, apperror.Internal(err, "查询话题失败")
+		}
+		tag = targets[tag.MergedIntoID]
+		found = tag != nil
+	}
+	if !found || tag.Status != CampusTagStatusNormal {
+		return nil, apperror.NotFound("话题不存在")
+	}
+	return tag, nil
+}
+
+// 话题页展示的帖子数和热度按学校计算，和 /forum/posts?tag= 的列表范围一致
+func (uc *CampusUsecase) GetCampusTag(ctx context.Context, userID, campusCode, name string) (*CampusTag, error) {
+	tag, err := uc.GetTag(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	campusCode = uc.resolveCampusCode(ctx, userID, campusCode)
+	stats, err := uc.repo.GetTagCampusStats(ctx, campusCode, []int64{tag.ID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询话题失败")
+	}
+	scoped := *tag
+	scoped.PostCount, scoped.TrendScore = 0, 0
+	if stat := stats[tag.ID]; stat != nil {
+		scoped.PostCount, scoped.TrendScore = stat.PostCount, stat.TrendScore
+	}
+	return &scoped, nil
+}
+
+func (uc *CampusUsecase) ListTrendingTags(ctx context.Context, userID, campusCode string, limit int) ([]*CampusTag, error) {
+	if limit <= 0 || limit > campusTrendingTagsMax {
+		limit = campusTrendingTagsMax
+	}
+	tags, err := uc.repo.ListTrendingTags(ctx, uc.resolveCampusCode(ctx, userID, campusCode), limit)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取热门话题失败")
+	}
+	return tags, nil
+}
+
+func campusTagTrendScore(activity *CampusTagActivity) float64 {
+	return float64(activity.PostCount)*3 + float64(activity.LikeCount) + float64(activity.CommentCount)*2
+}
+
+func (uc *CampusUsecase) RefreshTrendingTags(ctx context.Context) (int, error) {
+	if err := uc.repo.ReconcileTagPostCounts(ctx); err != nil {
+		return 0, err
+	}
+	window := campusTagTrendingWindow()
+	if window <= 0 {
+		return 0, uc.repo.SaveTagTrendScores(ctx, nil)
+	}
+	activities, err := uc.repo.ListTagActivity(ctx, campusLocalNow().Add(-window))
+	if err != nil {
+		return 0, err
+	}
+	scores := make([]*CampusTagStat, 0, len(activities))
+	for _, activity := range activities {
+		if score := campusTagTrendScore(activity); score > 0 {
+			scores = append(scores, &CampusTagStat{CampusCode: activity.CampusCode, TagID: activity.TagID, TrendScore: score})
+		}
+	}
+	if err := uc.repo.SaveTagTrendScores(ctx, scores); err != nil {
+		return 0, err
+	}
+	return len(scores), nil
+}
+
+func (uc *CampusUsecase) AdminListTags(ctx context.Context, input *ListCampusAdminTagsInput) (*ListCampusAdminTagsOutput, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	page, size := normalizePage(input.Page, input.Size)
+	query := ListCampusTagQuery{
+		Keyword: strings.ToLower(strings.TrimSpace(strings.TrimLeft(input.Keyword, "#"))),
+		Offset:  int((page - 1) * size),
+		Limit:   int(size),
+	}
+	if input.Status >= 0 {
+		status := input.Status
+		query.Status = &status
+	}
+	tags, total, err := uc.repo.ListTags(ctx, query)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取话题列表失败")
+	}
+	return &ListCampusAdminTagsOutput{Tags: tags, Total: total}, nil
+}
+
+func (uc *CampusUsecase) getManagedTag(ctx context.Context, userID string, tagID int64) (*CampusTag, error) {
+	if !uc.isCampusOperator(ctx, userID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	if tagID <= 0 {
+		return nil, apperror.InvalidArgument("话题 ID 无效")
+	}
+	tags, err := uc.repo.GetTagsByIDs(ctx, []int64{tagID})
+	if err != nil {
+		return nil, apperror.Internal(err, "查询话题失败")
+	}
+	if tags[tagID] == nil {
+		return nil, apperror.NotFound("话题不存在")
+	}
+	return tags[tagID], nil
+}
+
+func (uc *CampusUsecase) AdminMergeTag(ctx context.Context, input *UpdateCampusTagInput) (*CampusTag, error) {
+	source, err := uc.getManagedTag(ctx, input.UserID, input.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Status == CampusTagStatusMerged {
+		return nil, apperror.InvalidArgument("话题已被合并")
+	}
+	if input.TargetTagID == source.ID {
+		return nil, apperror.InvalidArgument("不能合并到自己")
+	}
+	target, err := uc.getManagedTag(ctx, input.UserID, input.TargetTagID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Status != CampusTagStatusNormal {
+		return nil, apperror.InvalidArgument("目标话题不可用")
+	}
+	if err := uc.repo.MergeTag(ctx, source.ID, target.ID); err != nil {
+		return nil, apperror.Internal(err, "合并话题失败")
+	}
+	tags, err := uc.repo.GetTagsByIDs(ctx, []int64{target.ID})
+	if err != nil || tags[target.ID] == nil {
+		return target, nil
+	}
+	return tags[target.ID], nil
+}
+
+func (uc *CampusUsecase) AdminSetTagBanned(ctx context.Context, input *UpdateCampusTagInput) (*CampusTag, error) {
+	tag, err := uc.getManagedTag(ctx, input.UserID, input.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if tag.Status == CampusTagStatusMerged {
+		return nil, apperror.InvalidArgument("话题已被合并")
+	}
+	tag.Status = CampusTagStatusNormal
+	if input.Enabled {
+		tag.Status = CampusTagStatusBanned
+		tag.IsFeatured = false
+	}
+	if err := uc.repo.UpdateTagFlags(ctx, tag); err != nil {
+		return nil, apperror.Internal(err, "更新话题失败")
+	}
+	return tag, nil
+}
+
+func (uc *CampusUsecase) AdminSetTagFeatured(ctx context.Context, input *UpdateCampusTagInput) (*CampusTag, error) {
+	tag, err := uc.getManagedTag(ctx, input.UserID, input.TagID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Enabled && tag.Status != CampusTagStatusNormal {
+		return nil, apperror.InvalidArgument("只能推荐正常状态的话题")
+	}
+	tag.IsFeatured = input.Enabled
+	if err := uc.repo.UpdateTagFlags(ctx, tag); err != nil {
+		return nil, apperror.Internal(err, "更新话题失败")
+	}
+	return tag, nil
+}
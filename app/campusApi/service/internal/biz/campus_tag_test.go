@@ -0,0 +1,77 @@
+package biz
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractCampusHashtags(t *testing.T) {
+	got := extractCampusHashtags("期末周来了#考试周#好累 #Go语言 http://a.com/page#anchor 第#1楼")
+	want := []string{"考试周", "Go语言"}
+	if !reflect.DeepEqual(got[:2], want) {
+		t.Fatalf("unexpected hashtags %v", got)
+	}
+	for _, tag := range got {
+		if tag == "anchor" {
+			t.Fatal("url anchors should not be extracted")
+		}
+	}
+}
+
+func TestCollectCampusPostTags(t *testing.T) {
+	candidates, err := collectCampusPostTags("#Go语言 #go语言 #新生 #1", []string{"#新生"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 2 || candidates[0].Name != "新生" || candidates[0].Source != CampusPostTagSourceExplicit {
+		t.Fatalf("unexpected candidates %+v", candidates)
+	}
+	if candidates[1].Name != "go语言" || candidates[1].DisplayName != "Go语言" {
+		t.Fatalf("tags should be case-insensitive, got %+v", candidates[1])
+	}
+	if _, err := collectCampusPostTags("", []string{"a b"}); err == nil {
+		t.Fatal("explicit tags with spaces should fail")
+	}
+	if _, err := collectCampusPostTags("", []string{"a", "b", "c", "d", "e", "f"}); err == nil {
+		t.Fatal("more than 5 explicit tags should fail")
+	}
+}
+
+type tagTrendStubRepo struct {
+	CampusRepo
+	activities []*CampusTagActivity
+	saved      []*CampusTagStat
+}
+
+func (r *tagTrendStubRepo) ReconcileTagPostCounts(ctx context.Context) error { return nil }
+
+func (r *tagTrendStubRepo) ListTagActivity(ctx context.Context, since time.Time) ([]*CampusTagActivity, error) {
+	return r.activities, nil
+}
+
+func (r *tagTrendStubRepo) SaveTagTrendScores(ctx context.Context, scores []*CampusTagStat) error {
+	r.saved = scores
+	return nil
+}
+
+func TestRefreshTrendingTagsScoresPerCampus(t *testing.T) {
+	repo := &tagTrendStubRepo{activities: []*CampusTagActivity{
+		{CampusCode: "a", TagID: 1, PostCount: 2, LikeCount: 4},
+		{CampusCode: "b", TagID: 1, PostCount: 1},
+		{CampusCode: "b", TagID: 2},
+	}}
+	uc := &CampusUsecase{repo: repo}
+	count, err := uc.RefreshTrendingTags(context.Background())
+	if err != nil || count != 2 {
+		t.Fatalf("RefreshTrendingTags = %d, %v", count, err)
+	}
+	want := []*CampusTagStat{
+		{CampusCode: "a", TagID: 1, TrendScore: 10},
+		{CampusCode: "b", TagID: 1, TrendScore: 3},
+	}
+	if !reflect.DeepEqual(repo.saved, want) {
+		t.Fatalf("unexpected scores %+v", repo.saved)
+	}
+}
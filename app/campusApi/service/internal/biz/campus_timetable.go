@@ -0,0 +1,188 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusTimetableProviderMock      = "mock"
+	CampusTimetableProviderZhengFang = "zhengfang"
+)
+
+type CampusTimetableProvider interface {
+	Name() string
+	Fetch(ctx context.Context, input *CampusTimetableFetchInput) (*CampusTimetableFetchResult, error)
+}
+
+// 教务系统验证码会话需要跨实例交接，按用户和验证码 token 存取，取出即删除
+type CampusTimetableSessionStore interface {
+	PutTimetableSession(ctx context.Context, userID, token string, raw []byte, ttl time.Duration) error
+	TakeTimetableSession(ctx context.Context, userID, token string) ([]byte, error)
+}
+
+type CampusTimetableFetchInput struct {
+	UserID       string
+	StudentNo    string
+	Password     string
+	Term         string
+	CaptchaToken string
+	Captcha      string
+}
+
+type CampusTimetableFetchResult struct {
+	Courses []*CampusTimetableCourse
+	Captcha *CampusTimetableCaptcha
+}
+
+type CampusTimetableCaptcha struct {
+	Token       string
+	ImageBase64 string
+	ContentType string
+	ExpiresAt   time.Time
+}
+
+type CampusTimetableProviderRegistry struct {
+	mu        sync.RWMutex
+	providers map[string]CampusTimetableProvider
+	active    string
+}
+
+func NewCampusTimetableProvider(sessions CampusTimetableSessionStore, logger log.Logger) (CampusTimetableProvider, error) {
+	registry := &CampusTimetableProviderRegistry{
+		providers: map[string]CampusTimetableProvider{},
+		active:    strings.ToLower(firstNonEmpty(strings.TrimSpace(os.Getenv("LEHU_CAMPUS_TIMETABLE_PROVIDER")), CampusTimetableProviderMock)),
+	}
+	registry.Register(NewMockCampusTimetableProvider())
+	if provider := NewZhengFangTimetableProviderFromEnv(sessions, logger); provider != nil {
+		registry.Register(provider)
+	}
+	if err := registry.validate(); err != nil {
+		return nil, err
+	}
+	return registry, nil
+}
+
+// 配置的课表来源必须已注册，避免启动后才在导入时报错
+func (r *CampusTimetableProviderRegistry) validate() error {
+	r.mu.RLock()
+	_, ok := r.providers[r.active]
+	r.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("timetable provider %q not registered, available: %s", r.active, strings.Join(r.Names(), ","))
+	}
+	return nil
+}
+
+func (r *CampusTimetableProviderRegistry) Register(provider CampusTimetableProvider) {
+	if provider == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.providers == nil {
+		r.providers = map[string]CampusTimetableProvider{}
+	}
+	r.providers[strings.ToLower(provider.Name())] = provider
+}
+
+func (r *CampusTimetableProviderRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *CampusTimetableProviderRegistry) Name() string {
+	return r.active
+}
+
+func (r *CampusTimetableProviderRegistry) Fetch(ctx context.Context, input *CampusTimetableFetchInput) (*CampusTimetableFetchResult, error) {
+	r.mu.RLock()
+	provider := r.providers[r.active]
+	r.mu.RUnlock()
+	if provider == nil {
+		return nil, apperror.DependencyUnavailable(fmt.Errorf("timetable provider %q not registered", r.active), "课表导入暂未开放")
+	}
+	return provider.Fetch(ctx, input)
+}
+
+type MockCampusTimetableProvider struct{}
+
+func NewMockCampusTimetableProvider() CampusTimetableProvider {
+	return &MockCampusTimetableProvider{}
+}
+
+func (p *MockCampusTimetableProvider) Name() string {
+	return CampusTimetableProviderMock
+}
+
+func (p *MockCampusTimetableProvider) Fetch(ctx context.Context, input *CampusTimetableFetchInput) (*CampusTimetableFetchResult, error) {
+	_ = ctx
+	if strings.TrimSpace(input.Password) == "" {
+		return nil, apperror.InvalidArgument("教务系统密码不能为空")
+	}
+	seed := shortHash(input.StudentNo+input.Term, 8)
+	return &CampusTimetableFetchResult{Courses: []*CampusTimetableCourse{
+		{
+			CourseName:     "高等数学 A",
+			Teacher:        "李老师",
+			Classroom:      "教学楼 A203",
+			Weekday:        1,
+			StartSection:   1,
+			EndSection:     2,
+			StartWeek:      1,
+			EndWeek:        16,
+			WeekParity:     0,
+			SourceCourseID: "mock-math-" + seed,
+		},
+		{
+			CourseName:     "大学英语",
+			Teacher:        "陈老师",
+			Classroom:      "教学楼 B105",
+			Weekday:        2,
+			StartSection:   3,
+			EndSection:     4,
+			StartWeek:      1,
+			EndWeek:        16,
+			WeekParity:     0,
+			SourceCourseID: "mock-english-" + seed,
+		},
+		{
+			CourseName:     "程序设计基础",
+			Teacher:        "王老师",
+			Classroom:      "实训楼 C301",
+			Weekday:        3,
+			StartSection:   5,
+			EndSection:     6,
+			StartWeek:      2,
+			EndWeek:        15,
+			WeekParity:     0,
+			SourceCourseID: "mock-code-" + seed,
+		},
+		{
+			CourseName:     "体育",
+			Teacher:        "周老师",
+			Classroom:      "运动场",
+			Weekday:        5,
+			StartSection:   7,
+			EndSection:     8,
+			StartWeek:      1,
+			EndWeek:        12,
+			WeekParity:     0,
+			SourceCourseID: "mock-pe-" + seed,
+		},
+	}}, nil
+}
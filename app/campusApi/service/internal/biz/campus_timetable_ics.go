@@ -0,0 +1,527 @@
+package biz
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	CampusTimetableSubscriptionActive  int32 = 1
+	CampusTimetableSubscriptionRevoked int32 = 0
+
+	campusOpsSettingTimetableSectionsPrefix = "timetable_sections:"
+	campusOpsSettingTimetableTermsPrefix    = "timetable_terms:"
+
+	campusTimetableMaxSection    = 16
+	campusTimetableMaxTermStarts = 12
+)
+
+var defaultCampusTimetableSections = []*CampusTimetableSectionTime{
+	{Section: 1, Start: "08:30", End: "09:15"},
+	{Section: 2, Start: "09:20", End: "10:05"},
+	{Section: 3, Start: "10:25", End: "11:10"},
+	{Section: 4, Start: "11:15", End: "12:00"},
+	{Section: 5, Start: "14:00", End: "14:45"},
+	{Section: 6, Start: "14:50", End: "15:35"},
+	{Section: 7, Start: "15:55", End: "16:40"},
+	{Section: 8, Start: "16:45", End: "17:30"},
+	{Section: 9, Start: "19:00", End: "19:45"},
+	{Section: 10, Start: "19:50", End: "20:35"},
+	{Section: 11, Start: "20:40", End: "21:25"},
+	{Section: 12, Start: "21:30", End: "22:15"},
+}
+
+type CampusTimetableSectionTime struct {
+	Section int32
+	Start   string
+	End     string
+}
+
+type CampusTimetableCalendarConfig struct {
+	CampusCode string
+	Sections   []*CampusTimetableSectionTime
+	TermStarts map[string]string
+}
+
+type CampusTimetableSubscription struct {
+	ID        int64
+	UserID    string
+	Nonce     string
+	Status    int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CampusTimetableSubscriptionOutput struct {
+	Active    bool
+	URL       string
+	WebcalURL string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CampusTimetableICS struct {
+	Term     string
+	Filename string
+	Content  string
+}
+
+type ExportCampusTimetableICSInput struct {
+	UserID string
+	Term   string
+}
+
+type GetCampusTimetableCalendarInput struct {
+	UserID     string
+	CampusCode string
+}
+
+type UpdateCampusTimetableCalendarInput struct {
+	UserID     string
+	CampusCode string
+	Sections   []*CampusTimetableSectionTime
+	TermStarts map[string]string
+}
+
+func (uc *CampusUsecase) ExportTimetableICS(ctx context.Context, input *ExportCampusTimetableICSInput) (*CampusTimetableICS, error) {
+	if strings.TrimSpace(input.UserID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	return uc.renderUserTimetableICS(ctx, input.UserID, input.Term)
+}
+
+func (uc *CampusUsecase) TimetableFeed(ctx context.Context, token, term string) (*CampusTimetableICS, error) {
+	userID, nonce, ok := uc.parseTimetableFeedToken(token)
+	if !ok {
+		return nil, apperror.NotFound("订阅链接无效")
+	}
+	exists, sub, err := uc.repo.GetTimetableSubscription(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询课表订阅失败")
+	}
+	if !exists || sub.Status != CampusTimetableSubscriptionActive || !hmac.Equal([]byte(sub.Nonce), []byte(nonce)) {
+		return nil, apperror.NotFound("订阅链接已失效")
+	}
+	return uc.renderUserTimetableICS(ctx, userID, term)
+}
+
+func (uc *CampusUsecase) GetTimetableSubscription(ctx context.Context, userID string) (*CampusTimetableSubscriptionOutput, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	exists, sub, err := uc.repo.GetTimetableSubscription(ctx, userID)
+	if err != nil {
+		return nil, apperror.Internal(err, "查询课表订阅失败")
+	}
+	if !exists || sub.Status != CampusTimetableSubscriptionActive {
+		return &CampusTimetableSubscriptionOutput{Active: false}, nil
+	}
+	return uc.timetableSubscriptionOutput(sub), nil
+}
+
+func (uc *CampusUsecase) RotateTimetableSubscription(ctx context.Context, userID string) (*CampusTimetableSubscriptionOutput, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, apperror.Unauthorized("请先登录")
+	}
+	raw := make([]byte, 18)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, apperror.Internal(err, "生成订阅链接失败")
+	}
+	now := time.Now()
+	sub := &CampusTimetableSubscription{
+		ID:        uc.idGen.NextID(),
+		UserID:    userID,
+		Nonce:     base64.RawURLEncoding.EncodeToString(raw),
+		Status:    CampusTimetableSubscriptionActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.repo.SaveTimetableSubscription(ctx, sub); err != nil {
+		return nil, apperror.Internal(err, "保存课表订阅失败")
+	}
+	return uc.timetableSubscriptionOutput(sub), nil
+}
+
+func (uc *CampusUsecase) RevokeTimetableSubscription(ctx context.Context, userID string) error {
+	if strings.TrimSpace(userID) == "" {
+		return apperror.Unauthorized("请先登录")
+	}
+	if err := uc.repo.RevokeTimetableSubscription(ctx, userID); err != nil {
+		return apperror.Internal(err, "取消课表订阅失败")
+	}
+	return nil
+}
+
+func (uc *CampusUsecase) AdminGetTimetableCalendar(ctx context.Context, input *GetCampusTimetableCalendarInput) (*CampusTimetableCalendarConfig, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	campusCode := firstNonEmpty(uc.operatorCampusScope(ctx, input.UserID, input.CampusCode), defaultCampusCode())
+	return uc.timetableCalendarConfig(ctx, campusCode), nil
+}
+
+func (uc *CampusUsecase) AdminUpdateTimetableCalendar(ctx context.Context, input *UpdateCampusTimetableCalendarInput) (*CampusTimetableCalendarConfig, error) {
+	if !uc.isCampusOperator(ctx, input.UserID) {
+		return nil, apperror.Forbidden("没有后台权限")
+	}
+	campusCode := firstNonEmpty(uc.operatorCampusScope(ctx, input.UserID, input.CampusCode), defaultCampusCode())
+	if !campusCodeAllowed(campusCode) {
+		return nil, apperror.InvalidArgument("校区暂未开放")
+	}
+	sections, err := normalizeCampusTimetableSections(input.Sections)
+	if err != nil {
+		return nil, err
+	}
+	termStarts, err := normalizeCampusTermStarts(input.TermStarts)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingTimetableSectionsPrefix+campusCode, formatCampusTimetableSections(sections), input.UserID); err != nil {
+		return nil, apperror.Internal(err, "保存节次时间失败")
+	}
+	if err := uc.repo.SetOpsSetting(ctx, campusOpsSettingTimetableTermsPrefix+campusCode, formatCampusTermStarts(termStarts), input.UserID); err != nil {
+		return nil, apperror.Internal(err, "保存开学日期失败")
+	}
+	return uc.timetableCalendarConfig(ctx, campusCode), nil
+}
+
+func (uc *CampusUsecase) renderUserTimetableICS(ctx context.Context, userID, term string) (*CampusTimetableICS, error) {
+	term = normalizeCampusTerm(term)
+	campusCode := firstNonEmpty(uc.userCampusCode(ctx, userID), defaultCampusCode())
+	termStart, ok := uc.campusTermStart(ctx, campusCode, term)
+	if !ok {
+		return nil, apperror.NotFound("本学期开学日期尚未配置")
+	}
+	courses, err := uc.repo.ListTimetableCourses(ctx, userID, term)
+	if err != nil {
+		return nil, apperror.Internal(err, "获取课表失败")
+	}
+	config := uc.timetableCalendarConfig(ctx, campusCode)
+	return &CampusTimetableICS{
+		Term:     term,
+		Filename: "timetable-" + term + ".ics",
+		Content:  renderCampusTimetableICS(term, courses, config.Sections, termStart, time.Now()),
+	}, nil
+}
+
+func (uc *CampusUsecase) timetableCalendarConfig(ctx context.Context, campusCode string) *CampusTimetableCalendarConfig {
+	sections := parseCampusTimetableSections(uc.stringOpsSetting(ctx, campusOpsSettingTimetableSectionsPrefix+campusCode, "", ""))
+	termStarts := parseCampusTermStarts(uc.stringOpsSetting(ctx, campusOpsSettingTimetableTermsPrefix+campusCode, "", ""))
+	return &CampusTimetableCalendarConfig{
+		CampusCode: campusCode,
+		Sections:   mergeCampusTimetableSections(sections),
+		TermStarts: termStarts,
+	}
+}
+
+func (uc *CampusUsecase) campusTermStart(ctx context.Context, campusCode, term string) (time.Time, bool) {
+	value, ok := uc.timetableCalendarConfig(ctx, campusCode).TermStarts[term]
+	if !ok {
+		return time.Time{}, false
+	}
+	start, err := time.ParseInLocation("2006-01-02", value, campusLocalNow().Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	return campusWeekMonday(start), true
+}
+
+func (uc *CampusUsecase) timetableSubscriptionOutput(sub *CampusTimetableSubscription) *CampusTimetableSubscriptionOutput {
+	token := sub.UserID + "." + sub.Nonce + "." + uc.signTimetableFeed(sub.UserID, sub.Nonce)
+	base := strings.TrimSuffix(strings.TrimRight(strings.TrimSpace(os.Getenv("LEHU_PUBLIC_API_BASE_URL")), "/"), "/v1")
+	path := "/v1/campus/timetable/feed/" + token + ".ics"
+	out := &CampusTimetableSubscriptionOutput{Active: true, URL: base + path, CreatedAt: sub.CreatedAt, UpdatedAt: sub.UpdatedAt}
+	if strings.HasPrefix(base, "https://") {
+		out.WebcalURL = "webcal://" + strings.TrimPrefix(base, "https://") + path
+	} else if strings.HasPrefix(base, "http://") {
+		out.WebcalURL = "webcal://" + strings.TrimPrefix(base, "http://") + path
+	}
+	return out
+}
+
+func (uc *CampusUsecase) signTimetableFeed(userID, nonce string) string {
+	mac := hmac.New(sha256.New, []byte(uc.authSecret))
+	mac.Write([]byte("timetable-feed:" + userID + "." + nonce))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func (uc *CampusUsecase) parseTimetableFeedToken(token string) (string, string, bool) {
+	parts := strings.Split(strings.TrimSuffix(strings.TrimSpace(token), ".ics"), ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	if _, err := strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return "", "", false
+	}
+	if !hmac.Equal([]byte(uc.signTimetableFeed(parts[0], parts[1])), []byte(parts[2])) {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func renderCampusTimetableICS(term string, courses []*CampusTimetableCourse, sections []*CampusTimetableSectionTime, termStart, now time.Time) string {
+	sectionTimes := map[int32]*CampusTimetableSectionTime{}
+	for _, section := range sections {
+		sectionTimes[section.Section] = section
+	}
+	var b strings.Builder
+	writeICSLine(&b, "BEGIN:VCALENDAR")
+	writeICSLine(&b, "VERSION:2.0")
+	writeICSLine(&b, "PRODID:-//Lehu Campus//Timetable//CN")
+	writeICSLine(&b, "CALSCALE:GREGORIAN")
+	writeICSLine(&b, "METHOD:PUBLISH")
+	writeICSLine(&b, "X-WR-CALNAME:"+escapeICSText("课表 "+term))
+	writeICSLine(&b, "X-WR-TIMEZONE:Asia/Shanghai")
+	writeICSLine(&b, "REFRESH-INTERVAL;VALUE=DURATION:PT12H")
+	writeICSLine(&b, "X-PUBLISHED-TTL:PT12H")
+	writeICSLine(&b, "BEGIN:VTIMEZONE")
+	writeICSLine(&b, "TZID:Asia/Shanghai")
+	writeICSLine(&b, "BEGIN:STANDARD")
+	writeICSLine(&b, "DTSTART:19700101T000000")
+	writeICSLine(&b, "TZOFFSETFROM:+0800")
+	writeICSLine(&b, "TZOFFSETTO:+0800")
+	writeICSLine(&b, "TZNAME:CST")
+	writeICSLine(&b, "END:STANDARD")
+	writeICSLine(&b, "END:VTIMEZONE")
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, course := range courses {
+		if course == nil || course.Weekday < 1 || course.Weekday > 7 {
+			continue
+		}
+		startTime, endTime := sectionTimes[course.StartSection], sectionTimes[course.EndSection]
+		if startTime == nil || endTime == nil {
+			continue
+		}
+		firstWeek, count, interval := campusCourseRecurrence(course)
+		if count <= 0 {
+			continue
+		}
+		day := termStart.AddDate(0, 0, int(firstWeek-1)*7+int(course.Weekday-1))
+		uid := firstNonEmpty(course.SourceCourseID, strconv.FormatInt(course.ID, 10))
+		writeICSLine(&b, "BEGIN:VEVENT")
+		writeICSLine(&b, "UID:"+escapeICSText(fmt.Sprintf("%s-%s-%d@lehu-campus", term, uid, course.ID)))
+		writeICSLine(&b, "DTSTAMP:"+stamp)
+		writeICSLine(&b, "DTSTART;TZID=Asia/Shanghai:"+day.Format("20060102")+"T"+strings.ReplaceAll(startTime.Start, ":", "")+"00")
+		writeICSLine(&b, "DTEND;TZID=Asia/Shanghai:"+day.Format("20060102")+"T"+strings.ReplaceAll(endTime.End, ":", "")+"00")
+		writeICSLine(&b, fmt.Sprintf("RRULE:FREQ=WEEKLY;INTERVAL=%d;COUNT=%d", interval, count))
+		writeICSLine(&b, "SUMMARY:"+escapeICSText(course.CourseName))
+		if course.Classroom != "" {
+			writeICSLine(&b, "LOCATION:"+escapeICSText(course.Classroom))
+		}
+		writeICSLine(&b, "DESCRIPTION:"+escapeICSText(campusCourseDescription(course)))
+		writeICSLine(&b, "END:VEVENT")
+	}
+	writeICSLine(&b, "END:VCALENDAR")
+	return b.String()
+}
+
+func campusCourseRecurrence(course *CampusTimetableCourse) (int32, int, int) {
+	first := course.StartWeek
+	if first < 1 {
+		first = 1
+	}
+	interval := 1
+	if course.WeekParity == 1 || course.WeekParity == 2 {
+		interval = 2
+		wantOdd := course.WeekParity == 1
+		if (first%2 == 1) != wantOdd {
+			first++
+		}
+	}
+	if course.EndWeek < first {
+		return first, 0, interval
+	}
+	return first, int(course.EndWeek-first)/interval + 1, interval
+}
+
+func campusCourseDescription(course *CampusTimetableCourse) string {
+	parts := make([]string, 0, 3)
+	if course.Teacher != "" {
+		parts = append(parts, "教师："+course.Teacher)
+	}
+	parity := ""
+	switch course.WeekParity {
+	case 1:
+		parity = "（单周）"
+	case 2:
+		parity = "（双周）"
+	}
+	parts = append(parts, fmt.Sprintf("第 %d-%d 周%s", course.StartWeek, course.EndWeek, parity))
+	parts = append(parts, fmt.Sprintf("第 %d-%d 节", course.StartSection, course.EndSection))
+	return strings.Join(parts, "\n")
+}
+
+func writeICSLine(b *strings.Builder, line string) {
+	const limit = 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !isUTF8Boundary(line, cut) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
+
+func isUTF8Boundary(value string, index int) bool {
+	return index >= len(value) || value[index]&0xC0 != 0x80
+}
+
+func escapeICSText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(value)
+}
+
+func campusWeekMonday(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()).AddDate(0, 0, -offset)
+}
+
+func parseCampusTimetableSections(value string) []*CampusTimetableSectionTime {
+	out := make([]*CampusTimetableSectionTime, 0, 12)
+	for _, item := range strings.Split(value, ",") {
+		key, times, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			continue
+		}
+		start, end, ok := strings.Cut(times, "-")
+		section, err := strconv.Atoi(strings.TrimSpace(key))
+		if !ok || err != nil {
+			continue
+		}
+		out = append(out, &CampusTimetableSectionTime{Section: int32(section), Start: strings.TrimSpace(start), End: strings.TrimSpace(end)})
+	}
+	sections, err := normalizeCampusTimetableSections(out)
+	if err != nil {
+		return nil
+	}
+	return sections
+}
+
+func normalizeCampusTimetableSections(sections []*CampusTimetableSectionTime) ([]*CampusTimetableSectionTime, error) {
+	seen := map[int32]bool{}
+	out := make([]*CampusTimetableSectionTime, 0, len(sections))
+	for _, section := range sections {
+		if section == nil {
+			continue
+		}
+		if section.Section < 1 || section.Section > campusTimetableMaxSection {
+			return nil, apperror.InvalidArgument("节次超出范围")
+		}
+		start, errStart := time.Parse("15:04", strings.TrimSpace(section.Start))
+		end, errEnd := time.Parse("15:04", strings.TrimSpace(section.End))
+		if errStart != nil || errEnd != nil || !end.After(start) {
+			return nil, apperror.InvalidArgument(fmt.Sprintf("第 %d 节时间格式应为 08:30-09:15", section.Section))
+		}
+		if seen[section.Section] {
+			continue
+		}
+		seen[section.Section] = true
+		out = append(out, &CampusTimetableSectionTime{Section: section.Section, Start: start.Format("15:04"), End: end.Format("15:04")})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Section < out[j].Section })
+	return out, nil
+}
+
+func mergeCampusTimetableSections(configured []*CampusTimetableSectionTime) []*CampusTimetableSectionTime {
+	merged := map[int32]*CampusTimetableSectionTime{}
+	for _, section := range defaultCampusTimetableSections {
+		merged[section.Section] = section
+	}
+	for _, section := range configured {
+		merged[section.Section] = section
+	}
+	out := make([]*CampusTimetableSectionTime, 0, len(merged))
+	for _, section := range merged {
+		out = append(out, section)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Section < out[j].Section })
+	return out
+}
+
+func formatCampusTimetableSections(sections []*CampusTimetableSectionTime) string {
+	parts := make([]string, 0, len(sections))
+	for _, section := range sections {
+		parts = append(parts, fmt.Sprintf("%d=%s-%s", section.Section, section.Start, section.End))
+	}
+	return strings.Join(parts, ",")
+}
+
+func parseCampusTermStarts(value string) map[string]string {
+	raw := map[string]string{}
+	for _, item := range strings.Split(value, ",") {
+		term, date, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if ok {
+			raw[strings.TrimSpace(term)] = strings.TrimSpace(date)
+		}
+	}
+	out, err := normalizeCampusTermStarts(raw)
+	if err != nil {
+		return map[string]string{}
+	}
+	return out
+}
+
+func normalizeCampusTermStarts(values map[string]string) (map[string]string, error) {
+	out := map[string]string{}
+	for term, date := range values {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		if !campusTermValid(term) {
+			return nil, apperror.InvalidArgument("学期格式应为 2025-2026-1")
+		}
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(date)); err != nil {
+			return nil, apperror.InvalidArgument("开学日期格式应为 2025-09-01")
+		}
+		out[term] = strings.TrimSpace(date)
+	}
+	if len(out) > campusTimetableMaxTermStarts {
+		terms := make([]string, 0, len(out))
+		for term := range out {
+			terms = append(terms, term)
+		}
+		sort.Strings(terms)
+		for _, term := range terms[:len(terms)-campusTimetableMaxTermStarts] {
+			delete(out, term)
+		}
+	}
+	return out, nil
+}
+
+func campusTermValid(term string) bool {
+	parts := strings.Split(term, "-")
+	if len(parts) != 3 {
+		return false
+	}
+	startYear, errStart := strconv.Atoi(parts[0])
+	endYear, errEnd := strconv.Atoi(parts[1])
+	return errStart == nil && errEnd == nil && endYear == startYear+1 && (parts[2] == "1" || parts[2] == "2" || parts[2] == "3")
+}
+
+func formatCampusTermStarts(values map[string]string) string {
+	terms := make([]string, 0, len(values))
+	for term := range values {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		parts = append(parts, term+"="+values[term])
+	}
+	return strings.Join(parts, ",")
+}
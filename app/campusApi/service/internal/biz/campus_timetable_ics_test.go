@@ -0,0 +1,86 @@
+package biz
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRenderCampusTimetableICSHonorsWeeksAndParity(t *testing.T) {
+	loc := time.FixedZone("Asia/Shanghai", 8*60*60)
+	termStart := time.Date(2025, 9, 1, 0, 0, 0, 0, loc)
+	courses := []*CampusTimetableCourse{
+		{ID: 1, CourseName: "高等数学, A", Classroom: "A203", Weekday: 1, StartSection: 1, EndSection: 2, StartWeek: 1, EndWeek: 16, SourceCourseID: "math"},
+		{ID: 2, CourseName: "大学物理", Weekday: 3, StartSection: 3, EndSection: 4, StartWeek: 2, EndWeek: 15, WeekParity: 1, SourceCourseID: "physics"},
+		{ID: 3, CourseName: "夜课", Weekday: 2, StartSection: 13, EndSection: 14, StartWeek: 1, EndWeek: 4},
+	}
+	out := renderCampusTimetableICS("2025-2026-1", courses, mergeCampusTimetableSections(nil), termStart, time.Unix(0, 0))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART;TZID=Asia/Shanghai:20250901T083000\r\n",
+		"DTEND;TZID=Asia/Shanghai:20250901T100500\r\n",
+		"RRULE:FREQ=WEEKLY;INTERVAL=1;COUNT=16\r\n",
+		"SUMMARY:高等数学\\, A\r\n",
+		"DTSTART;TZID=Asia/Shanghai:20250917T102500\r\n",
+		"RRULE:FREQ=WEEKLY;INTERVAL=2;COUNT=7\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("ics missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 2 {
+		t.Fatalf("course with unmapped sections should be skipped:\n%s", out)
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line not folded: %q", line)
+		}
+	}
+}
+
+func TestCampusCourseRecurrenceEvenWeeks(t *testing.T) {
+	first, count, interval := campusCourseRecurrence(&CampusTimetableCourse{StartWeek: 1, EndWeek: 16, WeekParity: 2})
+	if first != 2 || count != 8 || interval != 2 {
+		t.Fatalf("recurrence = %d %d %d, want 2 8 2", first, count, interval)
+	}
+	if _, count, _ := campusCourseRecurrence(&CampusTimetableCourse{StartWeek: 5, EndWeek: 5, WeekParity: 2}); count != 0 {
+		t.Fatalf("odd single week with even parity should be empty, got %d", count)
+	}
+}
+
+func TestTimetableFeedTokenSignature(t *testing.T) {
+	uc := &CampusUsecase{authSecret: "secret"}
+	sub := &CampusTimetableSubscription{UserID: "2060000000000000001", Nonce: "abc_DEF-123"}
+	out := uc.timetableSubscriptionOutput(sub)
+	token := out.URL[strings.LastIndex(out.URL, "/")+1:]
+
+	userID, nonce, ok := uc.parseTimetableFeedToken(token)
+	if !ok || userID != sub.UserID || nonce != sub.Nonce {
+		t.Fatalf("parse token = %q %q %v", userID, nonce, ok)
+	}
+	if _, _, ok := (&CampusUsecase{authSecret: "other"}).parseTimetableFeedToken(token); ok {
+		t.Fatalf("token signed with another secret should be rejected")
+	}
+	if _, _, ok := uc.parseTimetableFeedToken(strings.Replace(token, "abc", "abd", 1)); ok {
+		t.Fatalf("tampered nonce should be rejected")
+	}
+}
+
+func TestParseCampusTimetableSettings(t *testing.T) {
+	sections := parseCampusTimetableSections("2=09:30-10:15,1=08:00-08:45,bad")
+	if len(sections) != 2 || sections[0].Section != 1 || sections[0].Start != "08:00" {
+		t.Fatalf("sections = %+v", sections)
+	}
+	if parseCampusTimetableSections("1=09:00-08:00") != nil {
+		t.Fatalf("section ending before start should be dropped")
+	}
+	terms := parseCampusTermStarts(formatCampusTermStarts(map[string]string{"2025-2026-1": "2025-09-01"}))
+	if terms["2025-2026-1"] != "2025-09-01" {
+		t.Fatalf("terms = %v", terms)
+	}
+	if _, err := normalizeCampusTermStarts(map[string]string{"2025": "2025-09-01"}); err == nil {
+		t.Fatalf("invalid term should be rejected")
+	}
+}
@@ -0,0 +1,516 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"lehu-video/pkg/apperror"
+)
+
+const (
+	zhengFangLoginPath     = "/xtgl/login_slogin.html"
+	zhengFangPublicKeyPath = "/xtgl/login_getPublicKey.html"
+	zhengFangCaptchaPath   = "/kaptcha"
+	zhengFangSchedulePath  = "/kbcx/xskbcx_cxXsKb.html?gnmkdm=N2151"
+
+	zhengFangCaptchaAuto   = "auto"
+	zhengFangCaptchaAlways = "always"
+	zhengFangCaptchaNever  = "never"
+)
+
+var (
+	zhengFangInputTagPattern = regexp.MustCompile(`(?is)<input\b[^>]*>`)
+	zhengFangAttrPattern     = regexp.MustCompile(`(?is)\b(id|name|value)\s*=\s*["']([^"']*)["']`)
+	zhengFangTipsPattern     = regexp.MustCompile(`(?is)<p\b[^>]*id\s*=\s*["']tips["'][^>]*>(.*?)</p>`)
+	zhengFangTagPattern      = regexp.MustCompile(`(?s)<[^>]*>`)
+	zhengFangNumberPattern   = regexp.MustCompile(`\d+`)
+)
+
+type ZhengFangTimetableProvider struct {
+	baseURL     string
+	timeout     time.Duration
+	captchaMode string
+	sessionTTL  time.Duration
+	sessions    CampusTimetableSessionStore
+	log         *log.Helper
+}
+
+type zhengFangSession struct {
+	client    *http.Client
+	csrfToken string
+}
+
+// 验证码会话落 Redis 时只保存教务系统 cookie 和 csrf token，取回时重建 http client
+type zhengFangStoredSession struct {
+	UserID    string            `json:"user_id"`
+	CSRFToken string            `json:"csrf_token"`
+	Cookies   map[string]string `json:"cookies"`
+}
+
+type zhengFangText string
+
+func (t *zhengFangText) UnmarshalJSON(raw []byte) error {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		*t = zhengFangText(text)
+		return nil
+	}
+	var number json.Number
+	if err := json.Unmarshal(raw, &number); err != nil {
+		return err
+	}
+	*t = zhengFangText(number.String())
+	return nil
+}
+
+type zhengFangScheduleResponse struct {
+	KbList []struct {
+		Kcmc  zhengFangText `json:"kcmc"`
+		Xm    zhengFangText `json:"xm"`
+		Cdmc  zhengFangText `json:"cdmc"`
+		Xqj   zhengFangText `json:"xqj"`
+		Jcs   zhengFangText `json:"jcs"`
+		Zcd   zhengFangText `json:"zcd"`
+		KchID zhengFangText `json:"kch_id"`
+		JxbID zhengFangText `json:"jxb_id"`
+	} `json:"kbList"`
+}
+
+type zhengFangWeekRange struct {
+	Start  int32
+	End    int32
+	Parity int32
+}
+
+func NewZhengFangTimetableProviderFromEnv(sessions CampusTimetableSessionStore, logger log.Logger) *ZhengFangTimetableProvider {
+	baseURL := strings.TrimSpace(os.Getenv("LEHU_CAMPUS_TIMETABLE_ZF_BASE_URL"))
+	if baseURL == "" {
+		return nil
+	}
+	provider := NewZhengFangTimetableProvider(baseURL, sessions, logger)
+	provider.timeout = envDuration("LEHU_CAMPUS_TIMETABLE_ZF_TIMEOUT", provider.timeout)
+	provider.captchaMode = normalizeZhengFangCaptchaMode(os.Getenv("LEHU_CAMPUS_TIMETABLE_ZF_CAPTCHA"))
+	provider.sessionTTL = envDuration("LEHU_CAMPUS_TIMETABLE_CAPTCHA_TTL", provider.sessionTTL)
+	return provider
+}
+
+func NewZhengFangTimetableProvider(baseURL string, sessions CampusTimetableSessionStore, logger log.Logger) *ZhengFangTimetableProvider {
+	return &ZhengFangTimetableProvider{
+		baseURL:     strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		timeout:     10 * time.Second,
+		captchaMode: zhengFangCaptchaAuto,
+		sessionTTL:  5 * time.Minute,
+		sessions:    sessions,
+		log:         log.NewHelper(logger),
+	}
+}
+
+func (p *ZhengFangTimetableProvider) Name() string {
+	return CampusTimetableProviderZhengFang
+}
+
+func (p *ZhengFangTimetableProvider) Fetch(ctx context.Context, input *CampusTimetableFetchInput) (*CampusTimetableFetchResult, error) {
+	xnm, xqm, err := zhengFangTermParams(input.Term)
+	if err != nil {
+		return nil, err
+	}
+	var session *zhengFangSession
+	if token := strings.TrimSpace(input.CaptchaToken); token != "" {
+		if strings.TrimSpace(input.Captcha) == "" {
+			return nil, apperror.InvalidArgument("请输入验证码")
+		}
+		session, err = p.takeSession(ctx, input.UserID, token)
+		if err != nil {
+			return nil, err
+		}
+		if session == nil {
+			return nil, apperror.InvalidArgument("验证码已过期，请重新获取")
+		}
+	} else {
+		var needCaptcha bool
+		session, needCaptcha, err = p.openSession(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if needCaptcha {
+			captcha, err := p.issueCaptcha(ctx, input.UserID, session)
+			if err != nil {
+				return nil, err
+			}
+			return &CampusTimetableFetchResult{Captcha: captcha}, nil
+		}
+	}
+	if err := p.login(ctx, session, input.StudentNo, input.Password, input.Captcha); err != nil {
+		return nil, err
+	}
+	courses, err := p.fetchSchedule(ctx, session, xnm, xqm)
+	if err != nil {
+		return nil, err
+	}
+	return &CampusTimetableFetchResult{Courses: courses}, nil
+}
+
+func (p *ZhengFangTimetableProvider) newSession() *zhengFangSession {
+	jar, _ := cookiejar.New(nil)
+	return &zhengFangSession{client: &http.Client{
+		Timeout: p.timeout,
+		Jar:     jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+func (p *ZhengFangTimetableProvider) openSession(ctx context.Context) (*zhengFangSession, bool, error) {
+	session := p.newSession()
+	_, page, err := p.do(ctx, session, http.MethodGet, zhengFangLoginPath, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	session.csrfToken = parseZhengFangCSRFToken(string(page))
+	switch p.captchaMode {
+	case zhengFangCaptchaAlways:
+		return session, true, nil
+	case zhengFangCaptchaNever:
+		return session, false, nil
+	default:
+		return session, zhengFangPageNeedsCaptcha(string(page)), nil
+	}
+}
+
+func (p *ZhengFangTimetableProvider) issueCaptcha(ctx context.Context, userID string, session *zhengFangSession) (*CampusTimetableCaptcha, error) {
+	resp, raw, err := p.do(ctx, session, http.MethodGet, zhengFangCaptchaPath+"?time="+strconv.FormatInt(time.Now().UnixMilli(), 10), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, apperror.DependencyUnavailable(fmt.Errorf("zhengfang captcha empty"), "教务系统验证码获取失败")
+	}
+	token, err := generateOpsActionToken()
+	if err != nil {
+		return nil, apperror.Internal(err, "生成验证码会话失败")
+	}
+	expiresAt, err := p.putSession(ctx, userID, token, session)
+	if err != nil {
+		return nil, err
+	}
+	return &CampusTimetableCaptcha{
+		Token:       token,
+		ImageBase64: base64.StdEncoding.EncodeToString(raw),
+		ContentType: firstNonEmpty(resp.Header.Get("Content-Type"), "image/jpeg"),
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+func (p *ZhengFangTimetableProvider) login(ctx context.Context, session *zhengFangSession, studentNo, password, captcha string) error {
+	_, raw, err := p.do(ctx, session, http.MethodGet, zhengFangPublicKeyPath+"?time="+strconv.FormatInt(time.Now().UnixMilli(), 10), nil)
+	if err != nil {
+		return err
+	}
+	encrypted, err := encryptZhengFangPassword(raw, password)
+	if err != nil {
+		return apperror.DependencyUnavailable(err, "教务系统登录失败")
+	}
+	form := url.Values{}
+	form.Set("csrftoken", session.csrfToken)
+	form.Set("language", "zh_CN")
+	form.Set("yhm", studentNo)
+	form.Set("mm", encrypted)
+	if captcha = strings.TrimSpace(captcha); captcha != "" {
+		form.Set("yzm", captcha)
+	}
+	resp, page, err := p.do(ctx, session, http.MethodPost, zhengFangLoginPath+"?time="+strconv.FormatInt(time.Now().UnixMilli(), 10), form)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 && !strings.Contains(resp.Header.Get("Location"), "login_slogin") {
+		return nil
+	}
+	tips := parseZhengFangLoginTips(string(page))
+	if strings.Contains(tips, "验证码") {
+		return apperror.InvalidArgument("验证码错误，请重新获取")
+	}
+	return apperror.InvalidArgument(firstNonEmpty(tips, "教务系统账号或密码错误"))
+}
+
+func (p *ZhengFangTimetableProvider) fetchSchedule(ctx context.Context, session *zhengFangSession, xnm, xqm string) ([]*CampusTimetableCourse, error) {
+	form := url.Values{}
+	form.Set("xnm", xnm)
+	form.Set("xqm", xqm)
+	form.Set("kzlx", "ck")
+	_, raw, err := p.do(ctx, session, http.MethodPost, zhengFangSchedulePath, form)
+	if err != nil {
+		return nil, err
+	}
+	courses, err := parseZhengFangSchedule(raw)
+	if err != nil {
+		p.log.WithContext(ctx).Warnf("parse zhengfang schedule failed: body=%s err=%v", trimLimit(string(raw), 200), err)
+		return nil, apperror.DependencyUnavailable(err, "教务系统课表解析失败")
+	}
+	return courses, nil
+}
+
+func (p *ZhengFangTimetableProvider) do(ctx context.Context, session *zhengFangSession, method, path string, form url.Values) (*http.Response, []byte, error) {
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, body)
+	if err != nil {
+		return nil, nil, apperror.Internal(err, "教务系统请求失败")
+	}
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded;charset=UTF-8")
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0 (compatible; lehu-campus)")
+	resp, err := session.client.Do(req)
+	if err != nil {
+		return nil, nil, apperror.DependencyUnavailable(err, "教务系统暂时无法访问")
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 2<<20))
+	if resp.StatusCode >= 400 {
+		return nil, nil, apperror.DependencyUnavailable(fmt.Errorf("zhengfang status=%d path=%s", resp.StatusCode, path), "教务系统暂时无法访问")
+	}
+	return resp, raw, nil
+}
+
+func (p *ZhengFangTimetableProvider) putSession(ctx context.Context, userID, token string, session *zhengFangSession) (time.Time, error) {
+	if p.sessions == nil {
+		return time.Time{}, apperror.DependencyUnavailable(fmt.Errorf("zhengfang session store not configured"), "教务系统验证码暂不可用")
+	}
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return time.Time{}, apperror.Internal(err, "教务系统地址配置错误")
+	}
+	stored := zhengFangStoredSession{UserID: userID, CSRFToken: session.csrfToken, Cookies: map[string]string{}}
+	for _, cookie := range session.client.Jar.Cookies(base) {
+		stored.Cookies[cookie.Name] = cookie.Value
+	}
+	raw, err := json.Marshal(stored)
+	if err != nil {
+		return time.Time{}, apperror.Internal(err, "保存验证码会话失败")
+	}
+	expiresAt := time.Now().Add(p.sessionTTL)
+	if err := p.sessions.PutTimetableSession(ctx, userID, token, raw, p.sessionTTL); err != nil {
+		return time.Time{}, apperror.DependencyUnavailable(err, "保存验证码会话失败")
+	}
+	return expiresAt, nil
+}
+
+// 会话按用户隔离，拿到别人的 token 也不能复用对方的教务系统登录会话
+func (p *ZhengFangTimetableProvider) takeSession(ctx context.Context, userID, token string) (*zhengFangSession, error) {
+	if p.sessions == nil {
+		return nil, nil
+	}
+	raw, err := p.sessions.TakeTimetableSession(ctx, userID, token)
+	if err != nil {
+		return nil, apperror.DependencyUnavailable(err, "读取验证码会话失败")
+	}
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var stored zhengFangStoredSession
+	if err := json.Unmarshal(raw, &stored); err != nil || stored.UserID != userID {
+		return nil, nil
+	}
+	base, err := url.Parse(p.baseURL)
+	if err != nil {
+		return nil, apperror.Internal(err, "教务系统地址配置错误")
+	}
+	session := p.newSession()
+	session.csrfToken = stored.CSRFToken
+	cookies := make([]*http.Cookie, 0, len(stored.Cookies))
+	for name, value := range stored.Cookies {
+		cookies = append(cookies, &http.Cookie{Name: name, Value: value, Path: "/"})
+	}
+	session.client.Jar.SetCookies(base, cookies)
+	return session, nil
+}
+
+func normalizeZhengFangCaptchaMode(value string) string {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case zhengFangCaptchaAlways:
+		return zhengFangCaptchaAlways
+	case zhengFangCaptchaNever:
+		return zhengFangCaptchaNever
+	default:
+		return zhengFangCaptchaAuto
+	}
+}
+
+func zhengFangTermParams(term string) (string, string, error) {
+	parts := strings.Split(strings.TrimSpace(term), "-")
+	if len(parts) != 3 {
+		return "", "", apperror.InvalidArgument("学期格式应为 2025-2026-1")
+	}
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		return "", "", apperror.InvalidArgument("学期格式应为 2025-2026-1")
+	}
+	switch parts[2] {
+	case "1":
+		return parts[0], "3", nil
+	case "2":
+		return parts[0], "12", nil
+	case "3":
+		return parts[0], "16", nil
+	default:
+		return "", "", apperror.InvalidArgument("学期格式应为 2025-2026-1")
+	}
+}
+
+func encryptZhengFangPassword(raw []byte, password string) (string, error) {
+	var key struct {
+		Modulus  string `json:"modulus"`
+		Exponent string `json:"exponent"`
+	}
+	if err := json.Unmarshal(raw, &key); err != nil {
+		return "", fmt.Errorf("decode zhengfang public key: %w", err)
+	}
+	modulus, err := base64.StdEncoding.DecodeString(key.Modulus)
+	if err != nil || len(modulus) == 0 {
+		return "", fmt.Errorf("decode zhengfang modulus: %v", err)
+	}
+	exponent, err := base64.StdEncoding.DecodeString(key.Exponent)
+	if err != nil || len(exponent) == 0 {
+		return "", fmt.Errorf("decode zhengfang exponent: %v", err)
+	}
+	pub := &rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: int(new(big.Int).SetBytes(exponent).Int64())}
+	encrypted, err := rsa.EncryptPKCS1v15(rand.Reader, pub, []byte(password))
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(encrypted), nil
+}
+
+func parseZhengFangCSRFToken(page string) string {
+	for _, tag := range zhengFangInputTagPattern.FindAllString(page, -1) {
+		attrs := map[string]string{}
+		for _, match := range zhengFangAttrPattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(match[1])] = match[2]
+		}
+		if attrs["id"] == "csrftoken" || attrs["name"] == "csrftoken" {
+			return html.UnescapeString(attrs["value"])
+		}
+	}
+	return ""
+}
+
+func zhengFangPageNeedsCaptcha(page string) bool {
+	for _, tag := range zhengFangInputTagPattern.FindAllString(page, -1) {
+		for _, match := range zhengFangAttrPattern.FindAllStringSubmatch(tag, -1) {
+			if strings.EqualFold(match[1], "name") && match[2] == "yzm" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parseZhengFangLoginTips(page string) string {
+	match := zhengFangTipsPattern.FindStringSubmatch(page)
+	if len(match) < 2 {
+		return ""
+	}
+	return strings.TrimSpace(html.UnescapeString(zhengFangTagPattern.ReplaceAllString(match[1], "")))
+}
+
+func parseZhengFangSchedule(raw []byte) ([]*CampusTimetableCourse, error) {
+	trimmed := strings.TrimSpace(string(raw))
+	if strings.HasPrefix(trimmed, "<") {
+		return nil, fmt.Errorf("zhengfang session expired or schedule returned html")
+	}
+	var resp zhengFangScheduleResponse
+	if err := json.Unmarshal([]byte(trimmed), &resp); err != nil {
+		return nil, err
+	}
+	out := make([]*CampusTimetableCourse, 0, len(resp.KbList))
+	for _, item := range resp.KbList {
+		name := strings.TrimSpace(string(item.Kcmc))
+		weekday, _ := strconv.Atoi(strings.TrimSpace(string(item.Xqj)))
+		startSection, endSection := parseZhengFangSections(string(item.Jcs))
+		if name == "" || weekday < 1 || weekday > 7 || startSection == 0 {
+			continue
+		}
+		baseID := firstNonEmpty(strings.TrimSpace(string(item.JxbID)), strings.TrimSpace(string(item.KchID)), shortHash(name, 12))
+		for i, weeks := range parseZhengFangWeeks(string(item.Zcd)) {
+			out = append(out, &CampusTimetableCourse{
+				CourseName:     trimLimit(name, 120),
+				Teacher:        trimLimit(strings.TrimSpace(string(item.Xm)), 80),
+				Classroom:      trimLimit(strings.TrimSpace(string(item.Cdmc)), 120),
+				Weekday:        int32(weekday),
+				StartSection:   startSection,
+				EndSection:     endSection,
+				StartWeek:      weeks.Start,
+				EndWeek:        weeks.End,
+				WeekParity:     weeks.Parity,
+				SourceCourseID: trimLimit(fmt.Sprintf("zf-%s-%d-%d-%d", baseID, weekday, startSection, i), 128),
+			})
+		}
+	}
+	return out, nil
+}
+
+func parseZhengFangSections(value string) (int32, int32) {
+	numbers := zhengFangNumberPattern.FindAllString(value, -1)
+	if len(numbers) == 0 {
+		return 0, 0
+	}
+	start, _ := strconv.Atoi(numbers[0])
+	end := start
+	if len(numbers) > 1 {
+		end, _ = strconv.Atoi(numbers[len(numbers)-1])
+	}
+	if start <= 0 || end < start {
+		return 0, 0
+	}
+	return int32(start), int32(end)
+}
+
+func parseZhengFangWeeks(value string) []zhengFangWeekRange {
+	value = strings.NewReplacer("，", ",", "（", "(", "）", ")").Replace(value)
+	out := make([]zhengFangWeekRange, 0, 2)
+	for _, segment := range strings.Split(value, ",") {
+		segment = strings.TrimSpace(segment)
+		numbers := zhengFangNumberPattern.FindAllString(segment, -1)
+		if len(numbers) == 0 {
+			continue
+		}
+		start, _ := strconv.Atoi(numbers[0])
+		end := start
+		if len(numbers) > 1 {
+			end, _ = strconv.Atoi(numbers[1])
+		}
+		if start <= 0 || end < start {
+			continue
+		}
+		week := zhengFangWeekRange{Start: int32(start), End: int32(end)}
+		switch {
+		case strings.Contains(segment, "单"):
+			week.Parity = 1
+		case strings.Contains(segment, "双"):
+			week.Parity = 2
+		}
+		out = append(out, week)
+	}
+	if len(out) == 0 {
+		out = append(out, zhengFangWeekRange{Start: 1, End: 20})
+	}
+	return out
+}
@@ -0,0 +1,220 @@
+package biz
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+)
+
+const zhengFangTestLoginPage = `<html><body><form>
+<input type="hidden" id="csrftoken" name="csrftoken" value="csrf-123"/>
+<input type="text" name="yhm"/><input type="password" name="mm"/>
+%s
+</form><p id="tips" class="bg_danger">%s</p></body></html>`
+
+func newZhengFangTestServer(t *testing.T, withCaptcha bool) *httptest.Server {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	captchaInput := ""
+	if withCaptcha {
+		captchaInput = `<input type="text" name="yzm" id="yzm"/>`
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(zhengFangLoginPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: "session-1", Path: "/"})
+			_, _ = w.Write([]byte(sprintfPage(captchaInput, "")))
+			return
+		}
+		_ = r.ParseForm()
+		cipher, _ := base64.StdEncoding.DecodeString(r.PostForm.Get("mm"))
+		password, _ := rsa.DecryptPKCS1v15(nil, key, cipher)
+		cookie, _ := r.Cookie("JSESSIONID")
+		switch {
+		case cookie == nil || r.PostForm.Get("csrftoken") != "csrf-123":
+			_, _ = w.Write([]byte(sprintfPage(captchaInput, "会话失效")))
+		case withCaptcha && r.PostForm.Get("yzm") != "abcd":
+			_, _ = w.Write([]byte(sprintfPage(captchaInput, "验证码输入错误！")))
+		case r.PostForm.Get("yhm") != "20250001" || string(password) != "secret-pass":
+			_, _ = w.Write([]byte(sprintfPage(captchaInput, "用户名或密码不正确，请重新输入！")))
+		default:
+			http.Redirect(w, r, "/xtgl/index_initMenu.html", http.StatusFound)
+		}
+	})
+	mux.HandleFunc(zhengFangPublicKeyPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"modulus":  base64.StdEncoding.EncodeToString(key.N.Bytes()),
+			"exponent": base64.StdEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	})
+	mux.HandleFunc(zhengFangCaptchaPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte{0xff, 0xd8, 0xff, 0xd9})
+	})
+	mux.HandleFunc("/kbcx/xskbcx_cxXsKb.html", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("xnm") != "2025" || r.PostForm.Get("xqm") != "3" {
+			t.Errorf("unexpected term params: %v", r.PostForm)
+		}
+		_, _ = w.Write([]byte(`{"kbList":[
+			{"kcmc":"高等数学A","xm":"李老师","cdmc":"A203","xqj":"1","jcs":"1-2","zcd":"1-8周,10-16周(双)","jxb_id":"JXB01"},
+			{"kcmc":"体育","xm":"周老师","cdmc":"运动场","xqj":5,"jcs":"7-8","zcd":"3周","kch_id":"PE01"},
+			{"kcmc":"","xqj":"2","jcs":"1-2","zcd":"1-16周"}
+		]}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+type zhengFangTestSessionStore struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+func newZhengFangTestSessionStore() *zhengFangTestSessionStore {
+	return &zhengFangTestSessionStore{items: map[string][]byte{}}
+}
+
+func (s *zhengFangTestSessionStore) PutTimetableSession(ctx context.Context, userID, token string, raw []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.items[userID+":"+token] = raw
+	return nil
+}
+
+func (s *zhengFangTestSessionStore) TakeTimetableSession(ctx context.Context, userID, token string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	raw := s.items[userID+":"+token]
+	delete(s.items, userID+":"+token)
+	return raw, nil
+}
+
+func sprintfPage(captchaInput, tips string) string {
+	return fmt.Sprintf(zhengFangTestLoginPage, captchaInput, tips)
+}
+
+func TestZhengFangTimetableProviderFetchesSchedule(t *testing.T) {
+	server := newZhengFangTestServer(t, false)
+	provider := NewZhengFangTimetableProvider(server.URL, newZhengFangTestSessionStore(), log.DefaultLogger)
+
+	result, err := provider.Fetch(context.Background(), &CampusTimetableFetchInput{
+		StudentNo: "20250001",
+		Password:  "secret-pass",
+		Term:      "2025-2026-1",
+	})
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if result.Captcha != nil {
+		t.Fatalf("unexpected captcha challenge")
+	}
+	if len(result.Courses) != 3 {
+		t.Fatalf("courses = %d, want 3", len(result.Courses))
+	}
+	math := result.Courses[0]
+	if math.CourseName != "高等数学A" || math.Weekday != 1 || math.StartSection != 1 || math.EndSection != 2 || math.StartWeek != 1 || math.EndWeek != 8 || math.WeekParity != 0 {
+		t.Fatalf("unexpected first course: %+v", math)
+	}
+	if even := result.Courses[1]; even.StartWeek != 10 || even.EndWeek != 16 || even.WeekParity != 2 || even.SourceCourseID == math.SourceCourseID {
+		t.Fatalf("unexpected second segment: %+v", even)
+	}
+	if pe := result.Courses[2]; pe.Weekday != 5 || pe.StartWeek != 3 || pe.EndWeek != 3 || pe.Teacher != "周老师" {
+		t.Fatalf("unexpected pe course: %+v", pe)
+	}
+}
+
+func TestZhengFangTimetableProviderCaptchaHandoff(t *testing.T) {
+	server := newZhengFangTestServer(t, true)
+	provider := NewZhengFangTimetableProvider(server.URL, newZhengFangTestSessionStore(), log.DefaultLogger)
+	input := &CampusTimetableFetchInput{UserID: "10001", StudentNo: "20250001", Password: "secret-pass", Term: "2025-2026-1"}
+
+	first, err := provider.Fetch(context.Background(), input)
+	if err != nil {
+		t.Fatalf("first fetch: %v", err)
+	}
+	if first.Captcha == nil || first.Captcha.Token == "" || first.Captcha.ImageBase64 == "" {
+		t.Fatalf("expected captcha challenge, got %+v", first)
+	}
+
+	input.CaptchaToken = first.Captcha.Token
+	input.Captcha = "abcd"
+	other := *input
+	other.UserID = "10002"
+	if _, err := provider.Fetch(context.Background(), &other); err == nil {
+		t.Fatalf("captcha token should not be usable by another user")
+	}
+	second, err := provider.Fetch(context.Background(), input)
+	if err != nil {
+		t.Fatalf("captcha fetch: %v", err)
+	}
+	if len(second.Courses) != 3 {
+		t.Fatalf("courses = %d, want 3", len(second.Courses))
+	}
+
+	if _, err := provider.Fetch(context.Background(), input); err == nil {
+		t.Fatalf("captcha token should be single use")
+	}
+}
+
+func TestZhengFangTimetableProviderReportsLoginTips(t *testing.T) {
+	server := newZhengFangTestServer(t, false)
+	provider := NewZhengFangTimetableProvider(server.URL, newZhengFangTestSessionStore(), log.DefaultLogger)
+
+	_, err := provider.Fetch(context.Background(), &CampusTimetableFetchInput{
+		StudentNo: "20250001",
+		Password:  "wrong",
+		Term:      "2025-2026-1",
+	})
+	if err == nil || err.Error() == "" {
+		t.Fatalf("expected login error")
+	}
+	if got := parseZhengFangLoginTips(sprintfPage("", "用户名或密码不正确，请重新输入！")); got != "用户名或密码不正确，请重新输入！" {
+		t.Fatalf("tips = %q", got)
+	}
+}
+
+func TestZhengFangTermParams(t *testing.T) {
+	cases := map[string][2]string{
+		"2025-2026-1": {"2025", "3"},
+		"2025-2026-2": {"2025", "12"},
+		"2025-2026-3": {"2025", "16"},
+	}
+	for term, want := range cases {
+		xnm, xqm, err := zhengFangTermParams(term)
+		if err != nil || xnm != want[0] || xqm != want[1] {
+			t.Fatalf("zhengFangTermParams(%q) = %s %s %v", term, xnm, xqm, err)
+		}
+	}
+	if _, _, err := zhengFangTermParams("2025"); err == nil {
+		t.Fatalf("expected invalid term error")
+	}
+}
+
+func TestCampusTimetableProviderRejectsUnregisteredActive(t *testing.T) {
+	t.Setenv("LEHU_CAMPUS_TIMETABLE_PROVIDER", CampusTimetableProviderZhengFang)
+	t.Setenv("LEHU_CAMPUS_TIMETABLE_ZF_BASE_URL", "")
+	if _, err := NewCampusTimetableProvider(newZhengFangTestSessionStore(), log.DefaultLogger); err == nil {
+		t.Fatalf("expected error when active provider is not registered")
+	}
+	t.Setenv("LEHU_CAMPUS_TIMETABLE_PROVIDER", "")
+	provider, err := NewCampusTimetableProvider(newZhengFangTestSessionStore(), log.DefaultLogger)
+	if err != nil || provider.Name() != CampusTimetableProviderMock {
+		t.Fatalf("default provider = %v, %v", provider, err)
+	}
+}
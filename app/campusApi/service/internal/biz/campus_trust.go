@@ -0,0 +1,179 @@
+package biz
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	CampusTrustLevelTrusted = "trusted"
+	CampusTrustLevelNormal  = "normal"
+	CampusTrustLevelLow     = "low"
+
+	campusTrustBaseScore = 50
+)
+
+// 信用分只用于审核分流，不对用户展示
+type CampusUserTrustScore struct {
+	Score     int32
+	Level     string
+	Breakdown []*CampusUserTrustFactor
+}
+
+type CampusUserTrustFactor struct {
+	Code   string
+	Label  string
+	Value  int64
+	Points int32
+}
+
+type CampusUserTrustSignals struct {
+	RejectedPosts    int64
+	RemovedPosts     int64
+	RejectedComments int64
+	RemovedComments  int64
+	UpheldReports    int64
+	AcceptedAnswers  int64
+}
+
+func campusTrustThresholds() (int32, int32) {
+	trusted := int32(envInt64("CAMPUS_TRUST_SKIP_AI_SCORE", 80))
+	low := int32(envInt64("CAMPUS_TRUST_MANUAL_SCORE", 30))
+	return trusted, low
+}
+
+func campusTrustPoints(count int64, each, limit int32) int32 {
+	points := int32(count) * each
+	if count > int64(limit/each) {
+		points = limit
+	}
+	return points
+}
+
+func computeCampusUserTrust(profile *CampusProfile, signals *CampusUserTrustSignals, now time.Time) *CampusUserTrustScore {
+	if signals == nil {
+		signals = &CampusUserTrustSignals{}
+	}
+	out := &CampusUserTrustScore{}
+	add := func(code, label string, value int64, points int32) {
+		if points == 0 {
+			return
+		}
+		out.Breakdown = append(out.Breakdown, &CampusUserTrustFactor{Code: code, Label: label, Value: value, Points: points})
+	}
+	if profile != nil && !profile.CreatedAt.IsZero() {
+		days := int64(now.Sub(profile.CreatedAt).Hours() / 24)
+		switch {
+		case days < 7:
+			add("account_age", "注册不满 7 天", days, -10)
+		case days >= 180:
+			add("account_age", "注册超过半年", days, 10)
+		case days >= 30:
+			add("account_age", "注册超过 30 天", days, 5)
+		}
+	}
+	if profile != nil && profile.AuthStatus == 1 {
+		add("verified", "已完成校园认证", 1, 15)
+	}
+	add("accepted_answers", "回答被采纳", signals.AcceptedAnswers, campusTrustPoints(signals.AcceptedAnswers, 3, 15))
+	rejected := signals.RejectedPosts + signals.RejectedComments
+	add("rejected", "内容被驳回", rejected, campusTrustPoints(rejected, -8, -40))
+	removed := signals.RemovedPosts + signals.RemovedComments
+	add("removed", "内容被运营下架", removed, campusTrustPoints(removed, -10, -40))
+	add("upheld_reports", "被举报且成立", signals.UpheldReports, campusTrustPoints(signals.UpheldReports, -6, -30))
+
+	score := int32(campusTrustBaseScore)
+	for _, factor := range out.Breakdown {
+		score += factor.Points
+	}
+	if score < 0 {
+		score = 0
+	}
+	if score > 100 {
+		score = 100
+	}
+	out.Score = score
+	trusted, low := campusTrustThresholds()
+	switch {
+	case score >= trusted:
+		out.Level = CampusTrustLevelTrusted
+	case score < low:
+		out.Level = CampusTrustLevelLow
+	default:
+		out.Level = CampusTrustLevelNormal
+	}
+	return out
+}
+
+func (uc *CampusUsecase) userTrustScore(ctx context.Context, userID string) *CampusUserTrustScore {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil
+	}
+	_, profile, err := uc.repo.GetProfileByUserID(ctx, userID)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load campus profile for trust score failed: user_id=%s err=%v", userID, err)
+		return nil
+	}
+	signals, err := uc.repo.ListUserTrustSignals(ctx, []string{userID})
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load user trust signals failed: user_id=%s err=%v", userID, err)
+		return nil
+	}
+	return computeCampusUserTrust(profile, signals[userID], time.Now())
+}
+
+// 低信用作者一律人工审核；高信用作者在规则判定低风险时跳过 AI 初审
+func (uc *CampusUsecase) applyCampusTrustRouting(ctx context.Context, plan *campusPostAuditPlan, authorID string, mode string) {
+	if mode == CampusPostAuditModeManual {
+		return
+	}
+	trust := uc.userTrustScore(ctx, authorID)
+	if trust == nil {
+		return
+	}
+	plan.Trust = trust
+	evidence := fmt.Sprintf("trust_score:%d", trust.Score)
+	switch {
+	case trust.Level == CampusTrustLevelLow:
+		_, low := campusTrustThresholds()
+		plan.Status = CampusAuditStatusPending
+		plan.AuditReason = "等待人工审核"
+		plan.EnqueueAI = false
+		plan.AlertReason = fmt.Sprintf("作者信用分 %d，低于 %d，需人工审核", trust.Score, low)
+		if plan.AlertRisk == "" || plan.AlertRisk == "low" {
+			plan.AlertRisk = "medium"
+		}
+		plan.AlertEvidence = append(append([]string{}, plan.AlertEvidence...), evidence)
+	case trust.Level == CampusTrustLevelTrusted && plan.AIMode && plan.Rule.RiskLevel == "low":
+		plan.Status = CampusAuditStatusVisible
+		plan.AuditReason = ""
+		plan.EnqueueAI = false
+		plan.TrustedSkip = true
+	}
+}
+
+func (uc *CampusUsecase) attachUserTrust(ctx context.Context, users []*CampusAdminUser) {
+	userIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		if user != nil && user.User != nil {
+			userIDs = append(userIDs, user.User.ID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+	signals, err := uc.repo.ListUserTrustSignals(ctx, userIDs)
+	if err != nil {
+		uc.log.WithContext(ctx).Warnf("load admin user trust signals failed: err=%v", err)
+		return
+	}
+	now := time.Now()
+	for _, user := range users {
+		if user != nil && user.User != nil {
+			user.Trust = computeCampusUserTrust(user.Profile, signals[user.User.ID], now)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package biz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeCampusUserTrust(t *testing.T) {
+	now := time.Now()
+	veteran := &CampusProfile{AuthStatus: 1, CreatedAt: now.AddDate(-1, 0, 0)}
+	trust := computeCampusUserTrust(veteran, &CampusUserTrustSignals{AcceptedAnswers: 2}, now)
+	if trust.Score != 81 || trust.Level != CampusTrustLevelTrusted {
+		t.Fatalf("unexpected veteran trust %+v", trust)
+	}
+	if len(trust.Breakdown) != 3 {
+		t.Fatalf("expected three factors, got %d", len(trust.Breakdown))
+	}
+
+	newcomer := &CampusProfile{CreatedAt: now.Add(-24 * time.Hour)}
+	trust = computeCampusUserTrust(newcomer, &CampusUserTrustSignals{RejectedPosts: 1, RemovedComments: 1, UpheldReports: 1}, now)
+	if trust.Score != 16 || trust.Level != CampusTrustLevelLow {
+		t.Fatalf("unexpected newcomer trust %+v", trust)
+	}
+
+	trust = computeCampusUserTrust(nil, &CampusUserTrustSignals{RejectedPosts: 20, RemovedPosts: 20, UpheldReports: 20}, now)
+	if trust.Score != 0 {
+		t.Fatalf("penalties should clamp at zero, got %d", trust.Score)
+	}
+	for _, factor := range trust.Breakdown {
+		if factor.Code == "rejected" && factor.Points != -40 {
+			t.Fatalf("rejected penalty should cap at -40, got %d", factor.Points)
+		}
+	}
+}
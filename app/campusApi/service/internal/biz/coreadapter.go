@@ -11,4 +11,11 @@ type CoreAdapter interface {
 	BatchGetUserBaseInfo(ctx context.Context, userIDs []string) ([]*UserBaseInfo, error)
 	UpdateUserInfo(ctx context.Context, userID, name, nickName, avatar, backgroundImage, signature string, gender int32) error
 	SearchUsers(ctx context.Context, keyword string, page, pageSize int32) (int64, []*UserBaseInfo, error)
+	FollowUser(ctx context.Context, userID, targetUserID string) (bool, error)
+	UnfollowUser(ctx context.Context, userID, targetUserID string) (bool, error)
+	ListFollowers(ctx context.Context, userID string, page, pageSize int32) (int64, []*UserBaseInfo, error)
+	ListFollowing(ctx context.Context, userID string, page, pageSize int32) (int64, []*UserBaseInfo, error)
+	ListFollowerIDs(ctx context.Context, userID, afterUserID string, limit int32) ([]string, error)
+	ListFollowingIDs(ctx context.Context, userID string, limit int32) ([]string, error)
+	CheckFollowing(ctx context.Context, userID string, targetUserIDs []string) (map[string]bool, error)
 }
@@ -9,6 +9,8 @@ import (
 type FileInfo struct {
 	ObjectName string `json:"object_name"`
 	Hash       string `json:"hash"`
+	PHash      string `json:"phash"`
+	DHash      string `json:"dhash"`
 }
 
 type PreSignUploadPublicReq struct {
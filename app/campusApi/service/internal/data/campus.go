@@ -17,12 +17,13 @@ import (
 )
 
 type campusRepo struct {
-	data *Data
-	log  *log.Helper
+	data   *Data
+	search biz.CampusSearchIndex
+	log    *log.Helper
 }
 
-func NewCampusRepo(data *Data, logger log.Logger) biz.CampusRepo {
-	return &campusRepo{data: data, log: log.NewHelper(logger)}
+func NewCampusRepo(data *Data, search biz.CampusSearchIndex, logger log.Logger) biz.CampusRepo {
+	return &campusRepo{data: data, search: search, log: log.NewHelper(logger)}
 }
 
 func campusPostOrder(sort string, collectedByUser bool) string {
@@ -39,7 +40,7 @@ func campusPostOrder(sort string, collectedByUser bool) string {
 	case biz.CampusPostSortHot:
 		hotScore := "(" + interactionScore + " / POW(" + ageHours + " + 2, 1.15))"
 		return "campus_forum_post.is_pinned DESC, campus_forum_post.is_featured DESC, campus_forum_post.sort_weight DESC, " + hotScore + " DESC, campus_forum_post.created_at DESC, campus_forum_post.id DESC"
-	case biz.CampusPostSortNew:
+	case biz.CampusPostSortNew, biz.CampusPostSortFollowing:
 		return "campus_forum_post.created_at DESC, campus_forum_post.id DESC"
 	}
 	return "campus_forum_post.created_at DESC, campus_forum_post.id DESC"
@@ -82,6 +83,7 @@ type campusProfileModel struct {
 	AccountID    int64     `gorm:"column:account_id"`
 	OpenID       string    `gorm:"column:open_id"`
 	UnionID      string    `gorm:"column:union_id"`
+	CampusCode   string    `gorm:"column:campus_code"`
 	SchoolName   string    `gorm:"column:school_name"`
 	StudentNo    string    `gorm:"column:student_no"`
 	RealName     string    `gorm:"column:real_name"`
@@ -117,8 +119,41 @@ type campusTimetableCourseModel struct {
 
 func (campusTimetableCourseModel) TableName() string { return "campus_timetable_course" }
 
+type campusTimetableSubscriptionModel struct {
+	ID        int64     `gorm:"column:id"`
+	UserID    int64     `gorm:"column:user_id"`
+	Nonce     string    `gorm:"column:nonce"`
+	Status    int32     `gorm:"column:status"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+func (campusTimetableSubscriptionModel) TableName() string { return "campus_timetable_subscription" }
+
+type campusCalendarEventModel struct {
+	ID          int64     `gorm:"column:id"`
+	CampusCode  string    `gorm:"column:campus_code"`
+	Term        string    `gorm:"column:term"`
+	EventType   string    `gorm:"column:event_type"`
+	Title       string    `gorm:"column:title"`
+	Description string    `gorm:"column:description"`
+	Location    string    `gorm:"column:location"`
+	StartAt     time.Time `gorm:"column:start_at"`
+	EndAt       time.Time `gorm:"column:end_at"`
+	AllDay      bool      `gorm:"column:all_day"`
+	Status      int32     `gorm:"column:status"`
+	IsDeleted   bool      `gorm:"column:is_deleted"`
+	CreatedBy   int64     `gorm:"column:created_by"`
+	UpdatedBy   int64     `gorm:"column:updated_by"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at"`
+}
+
+func (campusCalendarEventModel) TableName() string { return "campus_calendar_event" }
+
 type campusForumCategoryModel struct {
 	ID          int64     `gorm:"column:id"`
+	CampusCode  string    `gorm:"column:campus_code"`
 	Code        string    `gorm:"column:code"`
 	Name        string    `gorm:"column:name"`
 	Description string    `gorm:"column:description"`
@@ -132,6 +167,8 @@ func (campusForumCategoryModel) TableName() string { return "campus_forum_catego
 
 type campusForumPostModel struct {
 	ID             int64           `gorm:"column:id"`
+	CampusCode     string          `gorm:"column:campus_code"`
+	IsCrossCampus  bool            `gorm:"column:is_cross_campus"`
 	CategoryCode   string          `gorm:"column:category_code"`
 	AuthorID       int64           `gorm:"column:author_id"`
 	Title          string          `gorm:"column:title"`
@@ -144,12 +181,15 @@ type campusForumPostModel struct {
 	IsOfficial     bool            `gorm:"column:is_official"`
 	IsFeatured     bool            `gorm:"column:is_featured"`
 	IsPinned       bool            `gorm:"column:is_pinned"`
+	IsAnonymous    bool            `gorm:"column:is_anonymous"`
 	SortWeight     int32           `gorm:"column:sort_weight"`
 	Status         int32           `gorm:"column:status"`
 	AuditReason    string          `gorm:"column:audit_reason"`
 	LikeCount      int64           `gorm:"column:like_count"`
 	CommentCount   int64           `gorm:"column:comment_count"`
 	CollectedCount int64           `gorm:"column:collected_count"`
+	EditCount      int32           `gorm:"column:edit_count"`
+	EditedAt       *time.Time      `gorm:"column:edited_at"`
 	IsDeleted      bool            `gorm:"column:is_deleted"`
 	CreatedAt      time.Time       `gorm:"column:created_at"`
 	UpdatedAt      time.Time       `gorm:"column:updated_at"`
@@ -291,6 +331,7 @@ type campusAIReplyTaskModel struct {
 	TriggerCommentID int64      `gorm:"column:trigger_comment_id"`
 	AskerID          int64      `gorm:"column:asker_id"`
 	BotUserID        int64      `gorm:"column:bot_user_id"`
+	Source           string     `gorm:"column:source"`
 	Prompt           string     `gorm:"column:prompt"`
 	Status           string     `gorm:"column:status"`
 	RetryCount       int32      `gorm:"column:retry_count"`
@@ -577,6 +618,7 @@ type campusUserRow struct {
 	Name             string       `gorm:"column:name"`
 	Nickname         string       `gorm:"column:nickname"`
 	Avatar           string       `gorm:"column:avatar"`
+	CampusCode       string       `gorm:"column:campus_code"`
 	SchoolName       string       `gorm:"column:school_name"`
 	StudentNo        string       `gorm:"column:student_no"`
 	RealName         string       `gorm:"column:real_name"`
@@ -656,6 +698,7 @@ func (r *campusRepo) SaveProfile(ctx context.Context, profile *biz.CampusProfile
 		AccountID:    parseID(profile.AccountID),
 		OpenID:       profile.OpenID,
 		UnionID:      profile.UnionID,
+		CampusCode:   profile.CampusCode,
 		SchoolName:   profile.SchoolName,
 		StudentNo:    profile.StudentNo,
 		RealName:     profile.RealName,
@@ -681,6 +724,7 @@ func (r *campusRepo) UpdateProfile(ctx context.Context, profile *biz.CampusProfi
 	return r.data.db.WithContext(ctx).Model(&campusProfileModel{}).
 		Where("user_id = ?", parseID(profile.UserID)).
 		Updates(map[string]interface{}{
+			"campus_code":   profile.CampusCode,
 			"school_name":   profile.SchoolName,
 			"student_no":    nullString(profile.StudentNo),
 			"real_name":     nullString(profile.RealName),
@@ -747,14 +791,193 @@ func (r *campusRepo) ListTimetableCourses(ctx context.Context, userID, term stri
 	return out, nil
 }
 
-func (r *campusRepo) ListCategories(ctx context.Context) ([]*biz.CampusForumCategory, error) {
+func (r *campusRepo) GetTimetableSubscription(ctx context.Context, userID string) (bool, *biz.CampusTimetableSubscription, error) {
+	var row campusTimetableSubscriptionModel
+	err := r.data.db.WithContext(ctx).Where("user_id = ?", parseID(userID)).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, &biz.CampusTimetableSubscription{
+		ID:        row.ID,
+		UserID:    fmt.Sprintf("%d", row.UserID),
+		Nonce:     row.Nonce,
+		Status:    row.Status,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}, nil
+}
+
+func (r *campusRepo) SaveTimetableSubscription(ctx context.Context, sub *biz.CampusTimetableSubscription) error {
+	if sub == nil {
+		return nil
+	}
+	row := campusTimetableSubscriptionModel{
+		ID:        sub.ID,
+		UserID:    parseID(sub.UserID),
+		Nonce:     sub.Nonce,
+		Status:    sub.Status,
+		CreatedAt: sub.CreatedAt,
+		UpdatedAt: sub.UpdatedAt,
+	}
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"nonce":      row.Nonce,
+			"status":     row.Status,
+			"updated_at": row.UpdatedAt,
+		}),
+	}).Create(&row).Error
+}
+
+func (r *campusRepo) RevokeTimetableSubscription(ctx context.Context, userID string) error {
+	return r.data.db.WithContext(ctx).Model(&campusTimetableSubscriptionModel{}).
+		Where("user_id = ?", parseID(userID)).
+		Updates(map[string]interface{}{
+			"status":     biz.CampusTimetableSubscriptionRevoked,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *campusRepo) CreateCalendarEvent(ctx context.Context, event *biz.CampusCalendarEvent) error {
+	now := time.Now()
+	event.CreatedAt = now
+	event.UpdatedAt = now
+	row := fromBizCalendarEvent(event)
+	return r.data.db.WithContext(ctx).Create(row).Error
+}
+
+func (r *campusRepo) UpdateCalendarEvent(ctx context.Context, event *biz.CampusCalendarEvent) error {
+	event.UpdatedAt = time.Now()
+	return r.data.db.WithContext(ctx).Model(&campusCalendarEventModel{}).
+		Where("id = ? AND is_deleted = ?", event.ID, false).
+		Updates(map[string]interface{}{
+			"campus_code": event.CampusCode,
+			"term":        event.Term,
+			"event_type":  event.EventType,
+			"title":       event.Title,
+			"description": event.Description,
+			"location":    event.Location,
+			"start_at":    event.StartAt,
+			"end_at":      event.EndAt,
+			"all_day":     event.AllDay,
+			"status":      event.Status,
+			"updated_by":  parseID(event.UpdatedBy),
+			"updated_at":  event.UpdatedAt,
+		}).Error
+}
+
+func (r *campusRepo) DeleteCalendarEvent(ctx context.Context, eventID int64, operatorID string) error {
+	return r.data.db.WithContext(ctx).Model(&campusCalendarEventModel{}).
+		Where("id = ?", eventID).
+		Updates(map[string]interface{}{
+			"is_deleted": true,
+			"updated_by": parseID(operatorID),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *campusRepo) GetCalendarEvent(ctx context.Context, eventID int64) (bool, *biz.CampusCalendarEvent, error) {
+	var row campusCalendarEventModel
+	err := r.data.db.WithContext(ctx).Where("id = ? AND is_deleted = ?", eventID, false).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, toBizCalendarEvent(&row), nil
+}
+
+func (r *campusRepo) ListCalendarEvents(ctx context.Context, query biz.ListCampusCalendarEventQuery) ([]*biz.CampusCalendarEvent, int64, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	db := r.data.db.WithContext(ctx).Model(&campusCalendarEventModel{}).Where("is_deleted = ?", false)
+	if query.CampusCode != "" {
+		db = db.Where("campus_code IN ?", []string{"", query.CampusCode})
+	}
+	if query.Term != "" {
+		db = db.Where("term = ?", query.Term)
+	}
+	if query.EventType != "" {
+		db = db.Where("event_type = ?", query.EventType)
+	}
+	if query.From != nil {
+		db = db.Where("end_at >= ?", *query.From)
+	}
+	if query.To != nil {
+		db = db.Where("start_at <= ?", *query.To)
+	}
+	if query.OnlyPublished {
+		db = db.Where("status = ?", biz.CampusCalendarStatusPublished)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusCalendarEventModel
+	if err := db.Order("start_at ASC, id ASC").Offset(query.Offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]*biz.CampusCalendarEvent, 0, len(rows))
+	for i := range rows {
+		out = append(out, toBizCalendarEvent(&rows[i]))
+	}
+	return out, total, nil
+}
+
+func fromBizCalendarEvent(event *biz.CampusCalendarEvent) *campusCalendarEventModel {
+	return &campusCalendarEventModel{
+		ID:          event.ID,
+		CampusCode:  event.CampusCode,
+		Term:        event.Term,
+		EventType:   event.EventType,
+		Title:       event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		StartAt:     event.StartAt,
+		EndAt:       event.EndAt,
+		AllDay:      event.AllDay,
+		Status:      event.Status,
+		CreatedBy:   parseID(event.CreatedBy),
+		UpdatedBy:   parseID(event.UpdatedBy),
+		CreatedAt:   event.CreatedAt,
+		UpdatedAt:   event.UpdatedAt,
+	}
+}
+
+func toBizCalendarEvent(row *campusCalendarEventModel) *biz.CampusCalendarEvent {
+	return &biz.CampusCalendarEvent{
+		ID:          row.ID,
+		CampusCode:  row.CampusCode,
+		Term:        row.Term,
+		EventType:   row.EventType,
+		Title:       row.Title,
+		Description: row.Description,
+		Location:    row.Location,
+		StartAt:     row.StartAt,
+		EndAt:       row.EndAt,
+		AllDay:      row.AllDay,
+		Status:      row.Status,
+		CreatedBy:   fmt.Sprintf("%d", row.CreatedBy),
+		UpdatedBy:   fmt.Sprintf("%d", row.UpdatedBy),
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
+
+func (r *campusRepo) ListCategories(ctx context.Context, campusCode string) ([]*biz.CampusForumCategory, error) {
 	var cached []*biz.CampusForumCategory
-	if r.getCacheJSON(ctx, campusCategoriesCacheKey(), &cached) {
+	if r.getCacheJSON(ctx, campusCategoriesCacheKey(campusCode), &cached) {
 		return cached, nil
 	}
 	var rows []campusForumCategoryModel
 	if err := r.data.db.WithContext(ctx).
-		Where("is_deleted = ?", false).
+		Where("is_deleted = ? AND campus_code IN ?", false, []string{"", campusCode}).
 		Order("sort_order ASC, id ASC").
 		Find(&rows).Error; err != nil {
 		return nil, err
@@ -763,15 +986,18 @@ func (r *campusRepo) ListCategories(ctx context.Context) ([]*biz.CampusForumCate
 	for i := range rows {
 		out = append(out, toBizCategory(&rows[i]))
 	}
-	r.setCacheJSON(ctx, campusCategoriesCacheKey(), out, campusCategoriesCacheTTL())
+	r.setCacheJSON(ctx, campusCategoriesCacheKey(campusCode), out, campusCategoriesCacheTTL())
 	return out, nil
 }
 
-func (r *campusRepo) GetCategoryByCode(ctx context.Context, code string) (bool, *biz.CampusForumCategory, error) {
+// campusCode 为空时不限校区；同一编码既有共享版块又有校区版块时优先取校区版块
+func (r *campusRepo) GetCategoryByCode(ctx context.Context, campusCode, code string) (bool, *biz.CampusForumCategory, error) {
 	var row campusForumCategoryModel
-	err := r.data.db.WithContext(ctx).
-		Where("code = ? AND is_deleted = ?", code, false).
-		First(&row).Error
+	db := r.data.db.WithContext(ctx).Where("code = ? AND is_deleted = ?", code, false)
+	if campusCode != "" {
+		db = db.Where("campus_code IN ?", []string{"", campusCode})
+	}
+	err := db.Order("campus_code DESC, id ASC").First(&row).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return false, nil, nil
 	}
@@ -782,35 +1008,60 @@ func (r *campusRepo) GetCategoryByCode(ctx context.Context, code string) (bool,
 }
 
 func (r *campusRepo) CreatePost(ctx context.Context, post *biz.CampusForumPost) error {
-	images, _ := json.Marshal(post.Images)
-	extra, _ := json.Marshal(post.Extra)
-	row := campusForumPostModel{
-		ID:           post.ID,
-		CategoryCode: post.CategoryCode,
-		AuthorID:     parseID(post.AuthorID),
-		Title:        post.Title,
-		Content:      post.Content,
-		Images:       images,
-		MediaType:    post.MediaType,
-		PostType:     post.PostType,
-		Extra:        extra,
-		CoverURL:     post.CoverURL,
-		IsOfficial:   post.IsOfficial,
-		IsFeatured:   post.IsFeatured,
-		IsPinned:     post.IsPinned,
-		SortWeight:   post.SortWeight,
-		Status:       post.Status,
-		AuditReason:  post.AuditReason,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-	}
-	if err := r.data.db.WithContext(ctx).Create(&row).Error; err != nil {
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fromBizPost(post)).Error; err != nil {
+			return err
+		}
+		if err := createPostPollWithTx(tx, post.Poll); err != nil {
+			return err
+		}
+		if err := createQuestionWithTx(tx, post.Question); err != nil {
+			return err
+		}
+		if err := createClubEventWithTx(tx, post.ClubEvent); err != nil {
+			return err
+		}
+		if err := createPostTagsWithTx(tx, post); err != nil {
+			return err
+		}
+		return createLostItemWithTx(tx, post.LostItem)
+	})
+	if err != nil {
 		return err
 	}
 	r.invalidatePostReadCaches(ctx, post.ID, true)
+	r.syncPostSearch(ctx, post.ID, false)
 	return nil
 }
 
+func fromBizPost(post *biz.CampusForumPost) *campusForumPostModel {
+	images, _ := json.Marshal(post.Images)
+	extra, _ := json.Marshal(post.Extra)
+	return &campusForumPostModel{
+		ID:            post.ID,
+		CampusCode:    post.CampusCode,
+		IsCrossCampus: post.IsCrossCampus,
+		CategoryCode:  post.CategoryCode,
+		AuthorID:      parseID(post.AuthorID),
+		Title:         post.Title,
+		Content:       post.Content,
+		Images:        images,
+		MediaType:     post.MediaType,
+		PostType:      post.PostType,
+		Extra:         extra,
+		CoverURL:      post.CoverURL,
+		IsOfficial:    post.IsOfficial,
+		IsFeatured:    post.IsFeatured,
+		IsPinned:      post.IsPinned,
+		IsAnonymous:   post.IsAnonymous,
+		SortWeight:    post.SortWeight,
+		Status:        post.Status,
+		AuditReason:   post.AuditReason,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+}
+
 func (r *campusRepo) ListPosts(ctx context.Context, query biz.ListCampusPostQuery) ([]*biz.CampusForumPost, int64, error) {
 	cacheable := r.shouldCachePostList(query)
 	cacheKey := ""
@@ -832,6 +1083,13 @@ func (r *campusRepo) ListPosts(ctx context.Context, query biz.ListCampusPostQuer
 	if len(query.Statuses) > 0 {
 		db = db.Where("campus_forum_post.status IN ?", query.Statuses)
 	}
+	if query.CampusCode != "" {
+		if query.OnlyOwnCampus {
+			db = db.Where("campus_forum_post.campus_code = ?", query.CampusCode)
+		} else {
+			db = db.Where("(campus_forum_post.campus_code = ? OR campus_forum_post.is_cross_campus = ?)", query.CampusCode, true)
+		}
+	}
 	if query.CategoryCode != "" {
 		db = db.Where("campus_forum_post.category_code = ?", query.CategoryCode)
 	}
@@ -841,12 +1099,25 @@ func (r *campusRepo) ListPosts(ctx context.Context, query biz.ListCampusPostQuer
 	if query.AuthorID != "" {
 		db = db.Where("campus_forum_post.author_id = ?", parseID(query.AuthorID))
 	}
+	if len(query.AuthorIDs) > 0 {
+		db = db.Where("campus_forum_post.author_id IN ?", parseIDs(query.AuthorIDs))
+	}
+	if len(query.ExcludeAuthorIDs) > 0 {
+		db = db.Where("campus_forum_post.author_id NOT IN ?", parseIDs(query.ExcludeAuthorIDs))
+	}
+	if query.ExcludeAnonymous {
+		db = db.Where("campus_forum_post.is_anonymous = ?", false)
+	}
+	if query.TagID > 0 {
+		db = db.Where("EXISTS (SELECT 1 FROM campus_post_tag pt WHERE pt.post_id = campus_forum_post.id AND pt.tag_id = ?)", query.TagID)
+	}
 	if query.CollectedByUserID != "" {
 		db = db.Joins("JOIN campus_forum_post_collection c ON c.post_id = campus_forum_post.id AND c.user_id = ? AND c.is_deleted = ?", parseID(query.CollectedByUserID), false)
 	}
 	if query.OnlyReported {
 		db = db.Where("EXISTS (SELECT 1 FROM campus_forum_report r WHERE r.target_type = ? AND r.target_id = campus_forum_post.id AND r.status = ?)", "post", biz.CampusAuditStatusPending)
 	}
+	db = moderationClaimScope(db, "'post'", "campus_forum_post.id", query.ClaimFilter, query.ClaimOperatorID, time.Now())
 	if query.Keyword != "" {
 		keyword := "%" + query.Keyword + "%"
 		if postID, err := strconv.ParseInt(strings.TrimSpace(query.Keyword), 10, 64); err == nil && postID > 0 {
@@ -868,6 +1139,10 @@ func (r *campusRepo) ListPosts(ctx context.Context, query biz.ListCampusPostQuer
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
+	if query.After != nil && query.KeysetPageable() {
+		after := query.After.SortAt()
+		db = db.Where("(campus_forum_post.created_at < ? OR (campus_forum_post.created_at = ? AND campus_forum_post.id < ?))", after, after, query.After.ID)
+	}
 	order := campusPostOrder(query.Sort, query.CollectedByUserID != "")
 	var rows []campusForumPostModel
 	if err := db.Order(order).Offset(query.Offset).Limit(query.Limit).Find(&rows).Error; err != nil {
@@ -893,6 +1168,17 @@ func (r *campusRepo) ListPosts(ctx context.Context, query biz.ListCampusPostQuer
 	return posts, total, nil
 }
 
+func (r *campusRepo) ListPostCampusCodes(ctx context.Context) ([]string, error) {
+	var codes []string
+	if err := r.data.db.WithContext(ctx).Model(&campusForumPostModel{}).
+		Where("status = ? AND is_deleted = ? AND campus_code <> ''", biz.CampusAuditStatusVisible, false).
+		Distinct().
+		Pluck("campus_code", &codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
 func (r *campusRepo) ListTopImagePostsByDate(ctx context.Context, start, end time.Time, limit int) ([]*biz.CampusForumPost, error) {
 	if limit <= 0 {
 		limit = 30
@@ -947,7 +1233,7 @@ func (r *campusRepo) GetPublicUserPostStats(ctx context.Context, userID string)
 			COALESCE(SUM(collected_count), 0) AS collected_count,
 			COALESCE(SUM(CASE WHEN is_official THEN 1 ELSE 0 END), 0) AS official_post_cnt
 		`).
-		Where("author_id = ? AND is_deleted = ? AND status = ?", parseID(userID), false, biz.CampusAuditStatusVisible).
+		Where("author_id = ? AND is_deleted = ? AND status = ? AND is_anonymous = ?", parseID(userID), false, biz.CampusAuditStatusVisible, false).
 		Scan(&row).Error
 	if err != nil {
 		return nil, err
@@ -961,6 +1247,27 @@ func (r *campusRepo) GetPublicUserPostStats(ctx context.Context, userID string)
 	return stats, nil
 }
 
+func (r *campusRepo) GetAnonymousPostAuthors(ctx context.Context, postIDs []int64) (map[int64]string, error) {
+	authors := make(map[int64]string, len(postIDs))
+	if len(postIDs) == 0 {
+		return authors, nil
+	}
+	var rows []struct {
+		ID       int64 `gorm:"column:id"`
+		AuthorID int64 `gorm:"column:author_id"`
+	}
+	if err := r.data.db.WithContext(ctx).Model(&campusForumPostModel{}).
+		Select("id, author_id").
+		Where("id IN ? AND is_anonymous = ?", postIDs, true).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		authors[row.ID] = fmt.Sprintf("%d", row.AuthorID)
+	}
+	return authors, nil
+}
+
 func (r *campusRepo) ListPostsByIDs(ctx context.Context, postIDs []int64, statuses []int32) ([]*biz.CampusForumPost, error) {
 	if len(postIDs) == 0 {
 		return []*biz.CampusForumPost{}, nil
@@ -1041,7 +1348,7 @@ func (r *campusRepo) DeletePost(ctx context.Context, postID int64) error {
 			Updates(map[string]interface{}{
 				"is_deleted":   true,
 				"status":       biz.CampusAuditStatusDeleted,
-				"audit_reason": "用户删除",
+				"audit_reason": biz.CampusAuditReasonUserDeleted,
 				"updated_at":   time.Now(),
 			}).Error; err != nil {
 			return err
@@ -1049,15 +1356,17 @@ func (r *campusRepo) DeletePost(ctx context.Context, postID int64) error {
 		return tx.Model(&campusForumCommentModel{}).
 			Where("post_id = ? AND is_deleted = ?", postID, false).
 			Updates(map[string]interface{}{
-				"is_deleted": true,
-				"status":     biz.CampusAuditStatusDeleted,
-				"updated_at": time.Now(),
+				"is_deleted":   true,
+				"status":       biz.CampusAuditStatusDeleted,
+				"audit_reason": biz.CampusAuditReasonPostDeleted,
+				"updated_at":   time.Now(),
 			}).Error
 	})
 	if err != nil {
 		return err
 	}
 	r.invalidatePostReadCaches(ctx, postID, true)
+	r.syncPostSearch(ctx, postID, false)
 	return nil
 }
 
@@ -1073,6 +1382,7 @@ func (r *campusRepo) UpdatePostStatus(ctx context.Context, postID int64, status
 		return err
 	}
 	r.invalidatePostReadCaches(ctx, postID, true)
+	r.syncPostSearch(ctx, postID, true)
 	return nil
 }
 
@@ -1082,26 +1392,30 @@ func (r *campusRepo) UpdatePostByAdmin(ctx context.Context, post *biz.CampusForu
 	if err := r.data.db.WithContext(ctx).Model(&campusForumPostModel{}).
 		Where("id = ?", post.ID).
 		Updates(map[string]interface{}{
-			"category_code": post.CategoryCode,
-			"title":         post.Title,
-			"content":       post.Content,
-			"images":        images,
-			"media_type":    post.MediaType,
-			"post_type":     post.PostType,
-			"extra":         extra,
-			"cover_url":     post.CoverURL,
-			"status":        post.Status,
-			"audit_reason":  post.AuditReason,
-			"is_official":   post.IsOfficial,
-			"is_featured":   post.IsFeatured,
-			"is_pinned":     post.IsPinned,
-			"sort_weight":   post.SortWeight,
-			"is_deleted":    post.Status == biz.CampusAuditStatusDeleted,
-			"updated_at":    time.Now(),
+			"category_code":   post.CategoryCode,
+			"is_cross_campus": post.IsCrossCampus,
+			"title":           post.Title,
+			"content":         post.Content,
+			"images":          images,
+			"media_type":      post.MediaType,
+			"post_type":       post.PostType,
+			"extra":           extra,
+			"cover_url":       post.CoverURL,
+			"status":          post.Status,
+			"audit_reason":    post.AuditReason,
+			"is_official":     post.IsOfficial,
+			"is_featured":     post.IsFeatured,
+			"is_pinned":       post.IsPinned,
+			"sort_weight":     post.SortWeight,
+			"edit_count":      post.EditCount,
+			"edited_at":       post.EditedAt,
+			"is_deleted":      post.Status == biz.CampusAuditStatusDeleted,
+			"updated_at":      time.Now(),
 		}).Error; err != nil {
 		return err
 	}
 	r.invalidatePostReadCaches(ctx, post.ID, true)
+	r.syncPostSearch(ctx, post.ID, true)
 	return nil
 }
 
@@ -1153,6 +1467,7 @@ func (r *campusRepo) CreateCommentWithOutbox(ctx context.Context, comment *biz.C
 	}
 	r.invalidatePostDetailCache(ctx, comment.PostID)
 	r.deleteCacheKeys(ctx, campusAdminSummaryCacheKey())
+	r.syncCommentSearch(ctx, comment.ID)
 	return nil
 }
 
@@ -1161,6 +1476,9 @@ func (r *campusRepo) ListComments(ctx context.Context, query biz.ListCampusComme
 	if !query.IncludeDeleted {
 		db = db.Where("is_deleted = ?", false)
 	}
+	if query.CampusCode != "" {
+		db = db.Where("post_id IN (?)", r.data.db.Model(&campusForumPostModel{}).Select("id").Where("campus_code = ?", query.CampusCode))
+	}
 	if query.PostID > 0 {
 		db = db.Where("post_id = ?", query.PostID)
 	}
@@ -1173,6 +1491,12 @@ func (r *campusRepo) ListComments(ctx context.Context, query biz.ListCampusComme
 	if len(query.Statuses) > 0 {
 		db = db.Where("status IN ?", query.Statuses)
 	}
+	if len(query.ExcludeIDs) > 0 {
+		db = db.Where("id NOT IN ?", query.ExcludeIDs)
+	}
+	if len(query.ExcludeAuthorIDs) > 0 {
+		db = db.Where("author_id NOT IN ?", parseIDs(query.ExcludeAuthorIDs))
+	}
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -1182,6 +1506,14 @@ func (r *campusRepo) ListComments(ctx context.Context, query biz.ListCampusComme
 	if query.AuthorID != "" {
 		order = "created_at DESC, id DESC"
 	}
+	if query.After != nil {
+		after := query.After.SortAt()
+		if query.AuthorID != "" {
+			db = db.Where("(created_at < ? OR (created_at = ? AND id < ?))", after, after, query.After.ID)
+		} else {
+			db = db.Where("(created_at > ? OR (created_at = ? AND id > ?))", after, after, query.After.ID)
+		}
+	}
 	if err := db.Order(order).Offset(query.Offset).Limit(query.Limit).Find(&rows).Error; err != nil {
 		return nil, 0, err
 	}
@@ -1396,7 +1728,7 @@ func (r *campusRepo) DeleteComment(ctx context.Context, commentID int64) error {
 			Updates(map[string]interface{}{
 				"is_deleted":   true,
 				"status":       biz.CampusAuditStatusDeleted,
-				"audit_reason": "用户删除",
+				"audit_reason": biz.CampusAuditReasonUserDeleted,
 				"updated_at":   time.Now(),
 			}).Error; err != nil {
 			return err
@@ -1407,7 +1739,7 @@ func (r *campusRepo) DeleteComment(ctx context.Context, commentID int64) error {
 				Updates(map[string]interface{}{
 					"is_deleted":   true,
 					"status":       biz.CampusAuditStatusDeleted,
-					"audit_reason": "父评论已撤回",
+					"audit_reason": biz.CampusAuditReasonParentCommentDeleted,
 					"updated_at":   time.Now(),
 				}).Error; err != nil {
 				return err
@@ -1432,6 +1764,7 @@ func (r *campusRepo) DeleteComment(ctx context.Context, commentID int64) error {
 	}
 	r.invalidatePostDetailCache(ctx, postID)
 	r.deleteCacheKeys(ctx, campusAdminSummaryCacheKey())
+	r.syncCommentSearch(ctx, commentID)
 	return nil
 }
 
@@ -1483,6 +1816,7 @@ func (r *campusRepo) UpdateCommentStatus(ctx context.Context, commentID int64, s
 		r.invalidatePostDetailCache(ctx, postID)
 		r.deleteCacheKeys(ctx, campusAdminSummaryCacheKey())
 	}
+	r.syncCommentSearch(ctx, commentID)
 	return nil
 }
 
@@ -1662,6 +1996,72 @@ func (r *campusRepo) RemovePostLike(ctx context.Context, userID string, postID i
 	return nil
 }
 
+type campusInteractionSignalRow struct {
+	PostID       int64     `gorm:"column:post_id"`
+	CategoryCode string    `gorm:"column:category_code"`
+	PostType     string    `gorm:"column:post_type"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (r *campusRepo) ListUserInteractionSignals(ctx context.Context, userID string, since time.Time, limit int) ([]*biz.CampusInteractionSignal, error) {
+	uid := parseID(userID)
+	if uid <= 0 {
+		return []*biz.CampusInteractionSignal{}, nil
+	}
+	if limit <= 0 {
+		limit = 200
+	}
+	sources := []struct {
+		kind  string
+		table string
+		where string
+	}{
+		{biz.CampusInteractionLike, "campus_forum_post_like s", "s.user_id = ? AND s.is_deleted = 0 AND s.created_at >= ?"},
+		{biz.CampusInteractionCollect, "campus_forum_post_collection s", "s.user_id = ? AND s.is_deleted = 0 AND s.created_at >= ?"},
+		{biz.CampusInteractionComment, "campus_forum_comment s", "s.author_id = ? AND s.is_deleted = 0 AND s.created_at >= ?"},
+	}
+	out := make([]*biz.CampusInteractionSignal, 0, limit)
+	for _, source := range sources {
+		var rows []campusInteractionSignalRow
+		if err := r.data.db.WithContext(ctx).
+			Table(source.table).
+			Select("p.id AS post_id, p.category_code, p.post_type, s.created_at").
+			Joins("JOIN campus_forum_post p ON p.id = s.post_id").
+			Where(source.where, uid, since).
+			Order("s.created_at DESC").
+			Limit(limit).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		out = appendInteractionSignals(out, rows, source.kind)
+	}
+	var views []campusInteractionSignalRow
+	if err := r.data.db.WithContext(ctx).
+		Table("campus_event s").
+		Select("p.id AS post_id, p.category_code, p.post_type, s.created_at").
+		Joins("JOIN campus_forum_post p ON p.id = s.target_id").
+		Where("s.user_id = ? AND s.event_type = ? AND s.target_type IN ? AND s.created_at >= ?", uid, "post_detail_visit", []string{"post", ""}, since).
+		Order("s.created_at DESC").
+		Limit(limit).
+		Scan(&views).Error; err != nil {
+		return nil, err
+	}
+	return appendInteractionSignals(out, views, biz.CampusInteractionView), nil
+}
+
+func appendInteractionSignals(out []*biz.CampusInteractionSignal, rows []campusInteractionSignalRow, kind string) []*biz.CampusInteractionSignal {
+	for i := range rows {
+		out = append(out, &biz.CampusInteractionSignal{
+			PostID:       rows[i].PostID,
+			CategoryCode: rows[i].CategoryCode,
+			PostType:     rows[i].PostType,
+			Kind:         kind,
+			CreatedAt:    rows[i].CreatedAt,
+		})
+	}
+	return out
+}
+
 func (r *campusRepo) GetPostCollectionStatus(ctx context.Context, userID string, postIDs []int64) (map[int64]bool, error) {
 	result := make(map[int64]bool, len(postIDs))
 	if userID == "" || len(postIDs) == 0 {
@@ -1815,17 +2215,26 @@ func (r *campusRepo) GetReportByTargetAndReporter(ctx context.Context, targetTyp
 	return true, report, nil
 }
 
-func (r *campusRepo) ListReports(ctx context.Context, status int32, offset, limit int) ([]*biz.CampusForumReport, int64, error) {
+func (r *campusRepo) reportScope(ctx context.Context, query *biz.CampusReportQuery) *gorm.DB {
 	db := r.data.db.WithContext(ctx).Model(&campusForumReportModel{})
-	if status >= 0 {
-		db = db.Where("status = ?", status)
+	if query.Status >= 0 {
+		db = db.Where("status = ?", query.Status)
+	}
+	if campusCode := query.CampusCode; campusCode != "" {
+		db = db.Where(`((target_type = 'post' AND target_id IN (SELECT p.id FROM campus_forum_post p WHERE p.campus_code = ?))
+			OR (target_type = 'comment' AND target_id IN (SELECT c.id FROM campus_forum_comment c JOIN campus_forum_post p ON p.id = c.post_id WHERE p.campus_code = ?)))`, campusCode, campusCode)
 	}
+	return moderationClaimScope(db, "campus_forum_report.target_type", "campus_forum_report.target_id", query.ClaimFilter, query.ClaimOperatorID, time.Now())
+}
+
+func (r *campusRepo) ListReports(ctx context.Context, query *biz.CampusReportQuery) ([]*biz.CampusForumReport, int64, error) {
+	db := r.reportScope(ctx, query)
 	var total int64
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 	var rows []campusForumReportModel
-	if err := db.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+	if err := db.Order("created_at DESC, id DESC").Offset(query.Offset).Limit(query.Limit).Find(&rows).Error; err != nil {
 		return nil, 0, err
 	}
 	reports := make([]*biz.CampusForumReport, 0, len(rows))
@@ -2235,11 +2644,16 @@ func (r *campusRepo) AttachAIReplyTaskDetails(ctx context.Context, tasks []*biz.
 	}
 	commentIDs := make([]int64, 0, len(tasks)*2)
 	triggerIDs := make([]int64, 0, len(tasks))
+	questionPostIDs := make([]int64, 0)
 	seenComment := map[int64]struct{}{}
 	for _, task := range tasks {
 		if task == nil {
 			continue
 		}
+		// 问题自动回答没有触发评论，RAG 日志按帖子关联
+		if task.TriggerCommentID == 0 && task.Source == biz.CampusAIReplySourceQuestion {
+			questionPostIDs = append(questionPostIDs, task.PostID)
+		}
 		if task.TriggerCommentID > 0 {
 			triggerIDs = append(triggerIDs, task.TriggerCommentID)
 			if _, ok := seenComment[task.TriggerCommentID]; !ok {
@@ -2289,12 +2703,35 @@ func (r *campusRepo) AttachAIReplyTaskDetails(ctx context.Context, tasks []*biz.
 			}
 		}
 	}
+	questionRAGMap := map[int64]*biz.CampusRAGQueryLog{}
+	if len(questionPostIDs) > 0 {
+		var rows []campusRAGQueryLogModel
+		if err := r.data.db.WithContext(ctx).
+			Where("post_id IN ? AND trigger_comment_id = ?", questionPostIDs, 0).
+			Order("created_at DESC, id DESC").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		for i := range rows {
+			item := toBizRAGQueryLog(&rows[i])
+			if item == nil {
+				continue
+			}
+			if _, ok := questionRAGMap[item.PostID]; !ok {
+				questionRAGMap[item.PostID] = item
+			}
+		}
+	}
 	for _, task := range tasks {
 		if task == nil {
 			continue
 		}
-		task.TriggerComment = commentMap[task.TriggerCommentID]
 		task.AnswerComment = commentMap[task.AnswerCommentID]
+		if task.TriggerCommentID == 0 {
+			task.RAGLog = questionRAGMap[task.PostID]
+			continue
+		}
+		task.TriggerComment = commentMap[task.TriggerCommentID]
 		task.RAGLog = ragMap[task.TriggerCommentID]
 	}
 	return nil
@@ -3159,7 +3596,7 @@ func (r *campusRepo) GetOpsAlertSummary(ctx context.Context, todayStart time.Tim
 	return out, nil
 }
 
-func (r *campusRepo) GetOpsSLASnapshot(ctx context.Context, reportBefore, auditBefore, feishuBefore time.Time, sampleLimit int) (*biz.CampusOpsSLASnapshot, error) {
+func (r *campusRepo) GetOpsSLASnapshot(ctx context.Context, reportBefore, auditBefore, feishuBefore, appealBefore time.Time, sampleLimit int) (*biz.CampusOpsSLASnapshot, error) {
 	if sampleLimit <= 0 {
 		sampleLimit = 3
 	}
@@ -3240,6 +3677,12 @@ func (r *campusRepo) GetOpsSLASnapshot(ctx context.Context, reportBefore, auditB
 	for i := range alertRows {
 		out.FeishuDegradedAlerts = append(out.FeishuDegradedAlerts, toBizOpsAlert(&alertRows[i]))
 	}
+
+	appeals, appealCount, err := r.listOverdueAppeals(ctx, appealBefore, sampleLimit)
+	if err != nil {
+		return nil, err
+	}
+	out.OverdueAppeals, out.OverdueAppealCount = appeals, appealCount
 	return out, nil
 }
 
@@ -3379,6 +3822,7 @@ func (r *campusRepo) GetOpsMetricSeries(ctx context.Context, now time.Time, sla
 	appendSeries("campus_sla_overdue_items", map[string]string{"kind": "report"}, float64(sla.OverdueReportCount))
 	appendSeries("campus_sla_overdue_items", map[string]string{"kind": "audit"}, float64(sla.OverduePostCount+sla.OverdueCommentCount))
 	appendSeries("campus_sla_overdue_items", map[string]string{"kind": "feishu"}, float64(sla.FeishuDegradedCount))
+	appendSeries("campus_sla_overdue_items", map[string]string{"kind": "appeal"}, float64(sla.OverdueAppealCount))
 	return series, nil
 }
 
@@ -3425,7 +3869,7 @@ func (r *campusRepo) UseOpsActionToken(ctx context.Context, tokenHash string, no
 	return used, out, err
 }
 
-func (r *campusRepo) ListNotifications(ctx context.Context, userID, group string, offset, limit int) ([]*biz.CampusNotification, int64, error) {
+func (r *campusRepo) ListNotifications(ctx context.Context, userID, group string, after *biz.CampusListCursor, offset, limit int) ([]*biz.CampusNotification, int64, error) {
 	db := r.data.db.WithContext(ctx).Model(&campusNotificationModel{}).
 		Where("recipient_id = ? AND is_deleted = ?", parseID(userID), false)
 	switch group {
@@ -3440,6 +3884,10 @@ func (r *campusRepo) ListNotifications(ctx context.Context, userID, group string
 	if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
+	if after != nil {
+		at := after.SortAt()
+		db = db.Where("(created_at < ? OR (created_at = ? AND id < ?))", at, at, after.ID)
+	}
 	var rows []campusNotificationModel
 	if err := db.Order("created_at DESC, id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
 		return nil, 0, err
@@ -3983,6 +4431,7 @@ func (r *campusRepo) ReconcileCampusStats(ctx context.Context) (*biz.CampusStats
 func (r *campusRepo) ListCampusUsers(ctx context.Context, keyword, role string, authStatus int32, offset, limit int) ([]*biz.CampusAdminUser, int64, error) {
 	db := r.data.db.WithContext(ctx).Table("user u").
 		Select(`u.id AS user_id, u.account_id, u.mobile, u.email, u.name, u.nickname, u.avatar,
+			COALESCE(p.campus_code, '') AS campus_code,
 			COALESCE(p.school_name, '') AS school_name,
 			COALESCE(p.student_no, '') AS student_no,
 			COALESCE(p.real_name, '') AS real_name,
@@ -4154,6 +4603,7 @@ func toBizProfile(row *campusProfileModel) *biz.CampusProfile {
 		AccountID:    fmt.Sprintf("%d", row.AccountID),
 		OpenID:       row.OpenID,
 		UnionID:      row.UnionID,
+		CampusCode:   row.CampusCode,
 		SchoolName:   row.SchoolName,
 		StudentNo:    row.StudentNo,
 		RealName:     row.RealName,
@@ -4170,6 +4620,7 @@ func toBizProfile(row *campusProfileModel) *biz.CampusProfile {
 func toBizCategory(row *campusForumCategoryModel) *biz.CampusForumCategory {
 	return &biz.CampusForumCategory{
 		ID:          row.ID,
+		CampusCode:  row.CampusCode,
 		Code:        row.Code,
 		Name:        row.Name,
 		Description: row.Description,
@@ -4209,6 +4660,8 @@ func toBizPost(row *campusForumPostModel) *biz.CampusForumPost {
 	}
 	return &biz.CampusForumPost{
 		ID:             row.ID,
+		CampusCode:     row.CampusCode,
+		IsCrossCampus:  row.IsCrossCampus,
 		CategoryCode:   row.CategoryCode,
 		AuthorID:       fmt.Sprintf("%d", row.AuthorID),
 		Title:          row.Title,
@@ -4221,12 +4674,15 @@ func toBizPost(row *campusForumPostModel) *biz.CampusForumPost {
 		IsOfficial:     row.IsOfficial,
 		IsFeatured:     row.IsFeatured,
 		IsPinned:       row.IsPinned,
+		IsAnonymous:    row.IsAnonymous,
 		SortWeight:     row.SortWeight,
 		Status:         row.Status,
 		AuditReason:    row.AuditReason,
 		LikeCount:      row.LikeCount,
 		CommentCount:   row.CommentCount,
 		CollectedCount: row.CollectedCount,
+		EditCount:      row.EditCount,
+		EditedAt:       row.EditedAt,
 		CreatedAt:      row.CreatedAt,
 		UpdatedAt:      row.UpdatedAt,
 	}
@@ -4820,6 +5276,10 @@ func toAIReplyTaskModel(in *biz.CampusAIReplyTask) campusAIReplyTaskModel {
 	if status == "" {
 		status = biz.CampusAIReplyTaskStatusPending
 	}
+	source := in.Source
+	if source == "" {
+		source = biz.CampusAIReplySourceMention
+	}
 	return campusAIReplyTaskModel{
 		ID:               in.ID,
 		PostID:           in.PostID,
@@ -4827,6 +5287,7 @@ func toAIReplyTaskModel(in *biz.CampusAIReplyTask) campusAIReplyTaskModel {
 		TriggerCommentID: in.TriggerCommentID,
 		AskerID:          parseID(in.AskerID),
 		BotUserID:        parseID(in.BotUserID),
+		Source:           source,
 		Prompt:           in.Prompt,
 		Status:           status,
 		RetryCount:       in.RetryCount,
@@ -4851,6 +5312,7 @@ func toBizAIReplyTask(row *campusAIReplyTaskModel) *biz.CampusAIReplyTask {
 		TriggerCommentID: row.TriggerCommentID,
 		AskerID:          fmt.Sprintf("%d", row.AskerID),
 		BotUserID:        fmt.Sprintf("%d", row.BotUserID),
+		Source:           row.Source,
 		Prompt:           row.Prompt,
 		Status:           row.Status,
 		RetryCount:       row.RetryCount,
@@ -5040,6 +5502,7 @@ func toBizAdminUser(row *campusUserRow) *biz.CampusAdminUser {
 		Profile: &biz.CampusProfile{
 			UserID:       userID,
 			AccountID:    accountID,
+			CampusCode:   row.CampusCode,
 			SchoolName:   row.SchoolName,
 			StudentNo:    row.StudentNo,
 			RealName:     row.RealName,
@@ -5154,3 +5617,13 @@ func parseID(value string) int64 {
 	id, _ := strconv.ParseInt(value, 10, 64)
 	return id
 }
+
+func parseIDs(values []string) []int64 {
+	ids := make([]int64, 0, len(values))
+	for _, value := range values {
+		if id := parseID(value); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,171 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusModerationAppealModel struct {
+	ID             int64      `gorm:"column:id"`
+	TargetType     string     `gorm:"column:target_type"`
+	TargetID       int64      `gorm:"column:target_id"`
+	PostID         int64      `gorm:"column:post_id"`
+	UserID         int64      `gorm:"column:user_id"`
+	CampusCode     string     `gorm:"column:campus_code"`
+	Reason         string     `gorm:"column:reason"`
+	Status         string     `gorm:"column:status"`
+	OriginalStatus int32      `gorm:"column:original_status"`
+	OriginalReason string     `gorm:"column:original_reason"`
+	TargetExcerpt  string     `gorm:"column:target_excerpt"`
+	ReviewerID     int64      `gorm:"column:reviewer_id"`
+	ReviewNote     string     `gorm:"column:review_note"`
+	ReviewedAt     *time.Time `gorm:"column:reviewed_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusModerationAppealModel) TableName() string { return "campus_moderation_appeal" }
+
+func campusModerationAppealFromModel(row campusModerationAppealModel) *biz.CampusModerationAppeal {
+	appeal := &biz.CampusModerationAppeal{
+		ID:             row.ID,
+		TargetType:     row.TargetType,
+		TargetID:       row.TargetID,
+		PostID:         row.PostID,
+		UserID:         fmt.Sprintf("%d", row.UserID),
+		CampusCode:     row.CampusCode,
+		Reason:         row.Reason,
+		Status:         row.Status,
+		OriginalStatus: row.OriginalStatus,
+		OriginalReason: row.OriginalReason,
+		TargetExcerpt:  row.TargetExcerpt,
+		ReviewNote:     row.ReviewNote,
+		ReviewedAt:     row.ReviewedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+	}
+	if row.ReviewerID > 0 {
+		appeal.ReviewerID = fmt.Sprintf("%d", row.ReviewerID)
+	}
+	return appeal
+}
+
+func (r *campusRepo) CreateModerationAppeal(ctx context.Context, appeal *biz.CampusModerationAppeal) (bool, error) {
+	now := time.Now()
+	row := campusModerationAppealModel{
+		ID:             appeal.ID,
+		TargetType:     appeal.TargetType,
+		TargetID:       appeal.TargetID,
+		PostID:         appeal.PostID,
+		UserID:         parseID(appeal.UserID),
+		CampusCode:     appeal.CampusCode,
+		Reason:         trimLimitData(appeal.Reason, 500),
+		Status:         appeal.Status,
+		OriginalStatus: appeal.OriginalStatus,
+		OriginalReason: trimLimitData(appeal.OriginalReason, 255),
+		TargetExcerpt:  trimLimitData(appeal.TargetExcerpt, 255),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	result := r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "target_type"}, {Name: "target_id"}},
+		DoNothing: true,
+	}).Create(&row)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	appeal.CreatedAt, appeal.UpdatedAt = now, now
+	return result.RowsAffected > 0, nil
+}
+
+func (r *campusRepo) GetModerationAppeal(ctx context.Context, id int64) (bool, *biz.CampusModerationAppeal, error) {
+	var row campusModerationAppealModel
+	err := r.data.db.WithContext(ctx).Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, campusModerationAppealFromModel(row), nil
+}
+
+func (r *campusRepo) ListModerationAppeals(ctx context.Context, query *biz.CampusAppealQuery) ([]*biz.CampusModerationAppeal, int64, error) {
+	if query == nil {
+		query = &biz.CampusAppealQuery{}
+	}
+	if query.Limit <= 0 {
+		query.Limit = 20
+	}
+	db := r.data.db.WithContext(ctx).Model(&campusModerationAppealModel{})
+	if query.UserID != "" {
+		db = db.Where("user_id = ?", parseID(query.UserID))
+	}
+	if query.CampusCode != "" {
+		db = db.Where("campus_code = ?", query.CampusCode)
+	}
+	if query.Status != "" {
+		db = db.Where("status = ?", query.Status)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	order := "created_at DESC, id DESC"
+	if query.Status == biz.CampusAppealStatusPending {
+		order = "created_at ASC, id ASC"
+	}
+	var rows []campusModerationAppealModel
+	if err := db.Order(order).Offset(query.Offset).Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]*biz.CampusModerationAppeal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, campusModerationAppealFromModel(row))
+	}
+	return out, total, nil
+}
+
+func (r *campusRepo) UpdateModerationAppealStatus(ctx context.Context, id int64, fromStatus, toStatus, reviewerID, note string) (bool, error) {
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":      toStatus,
+		"reviewer_id": parseID(reviewerID),
+		"review_note": trimLimitData(note, 255),
+		"reviewed_at": &now,
+		"updated_at":  now,
+	}
+	if toStatus == biz.CampusAppealStatusPending {
+		updates["reviewed_at"] = nil
+	}
+	result := r.data.db.WithContext(ctx).Model(&campusModerationAppealModel{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(updates)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *campusRepo) listOverdueAppeals(ctx context.Context, before time.Time, sampleLimit int) ([]*biz.CampusModerationAppeal, int64, error) {
+	query := func() *gorm.DB {
+		return r.data.db.WithContext(ctx).Model(&campusModerationAppealModel{}).
+			Where("status = ? AND created_at <= ?", biz.CampusAppealStatusPending, before)
+	}
+	var total int64
+	if err := query().Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusModerationAppealModel
+	if err := query().Order("created_at ASC, id ASC").Limit(sampleLimit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	out := make([]*biz.CampusModerationAppeal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, campusModerationAppealFromModel(row))
+	}
+	return out, total, nil
+}
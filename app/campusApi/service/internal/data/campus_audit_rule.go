@@ -0,0 +1,129 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusAuditRuleModel struct {
+	ID        int64      `gorm:"column:id"`
+	Name      string     `gorm:"column:name"`
+	Category  string     `gorm:"column:category"`
+	RuleType  string     `gorm:"column:rule_type"`
+	Patterns  string     `gorm:"column:patterns"`
+	Score     int32      `gorm:"column:score"`
+	Enabled   bool       `gorm:"column:enabled"`
+	HitCount  int64      `gorm:"column:hit_count"`
+	LastHitAt *time.Time `gorm:"column:last_hit_at"`
+	CreatedBy int64      `gorm:"column:created_by"`
+	UpdatedBy int64      `gorm:"column:updated_by"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+	UpdatedAt time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusAuditRuleModel) TableName() string { return "campus_audit_rule" }
+
+func campusAuditRuleFromModel(row campusAuditRuleModel) *biz.CampusAuditRule {
+	patterns := make([]string, 0)
+	for _, item := range strings.Split(row.Patterns, "\n") {
+		if item = strings.TrimSpace(item); item != "" {
+			patterns = append(patterns, item)
+		}
+	}
+	return &biz.CampusAuditRule{
+		ID:        row.ID,
+		Name:      row.Name,
+		Category:  row.Category,
+		RuleType:  row.RuleType,
+		Patterns:  patterns,
+		Score:     row.Score,
+		Enabled:   row.Enabled,
+		HitCount:  row.HitCount,
+		LastHitAt: row.LastHitAt,
+		CreatedBy: fmt.Sprintf("%d", row.CreatedBy),
+		UpdatedBy: fmt.Sprintf("%d", row.UpdatedBy),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
+
+func (r *campusRepo) ListAuditRules(ctx context.Context) ([]*biz.CampusAuditRule, error) {
+	var rows []campusAuditRuleModel
+	if err := r.data.db.WithContext(ctx).Model(&campusAuditRuleModel{}).
+		Order("category ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusAuditRule, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, campusAuditRuleFromModel(row))
+	}
+	return out, nil
+}
+
+func (r *campusRepo) GetAuditRule(ctx context.Context, id int64) (bool, *biz.CampusAuditRule, error) {
+	var row campusAuditRuleModel
+	err := r.data.db.WithContext(ctx).Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, campusAuditRuleFromModel(row), nil
+}
+
+func (r *campusRepo) CreateAuditRule(ctx context.Context, rule *biz.CampusAuditRule) error {
+	now := time.Now()
+	return r.data.db.WithContext(ctx).Create(&campusAuditRuleModel{
+		ID:        rule.ID,
+		Name:      trimLimitData(rule.Name, 64),
+		Category:  rule.Category,
+		RuleType:  rule.RuleType,
+		Patterns:  strings.Join(rule.Patterns, "\n"),
+		Score:     rule.Score,
+		Enabled:   rule.Enabled,
+		CreatedBy: parseID(rule.CreatedBy),
+		UpdatedBy: parseID(rule.UpdatedBy),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}).Error
+}
+
+func (r *campusRepo) UpdateAuditRule(ctx context.Context, rule *biz.CampusAuditRule) error {
+	return r.data.db.WithContext(ctx).Model(&campusAuditRuleModel{}).
+		Where("id = ?", rule.ID).
+		Updates(map[string]interface{}{
+			"name":       trimLimitData(rule.Name, 64),
+			"category":   rule.Category,
+			"rule_type":  rule.RuleType,
+			"patterns":   strings.Join(rule.Patterns, "\n"),
+			"score":      rule.Score,
+			"enabled":    rule.Enabled,
+			"updated_by": parseID(rule.UpdatedBy),
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func (r *campusRepo) DeleteAuditRule(ctx context.Context, id int64) (bool, error) {
+	result := r.data.db.WithContext(ctx).Where("id = ?", id).Delete(&campusAuditRuleModel{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *campusRepo) IncrAuditRuleHits(ctx context.Context, ids []int64, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.data.db.WithContext(ctx).Model(&campusAuditRuleModel{}).
+		Where("id IN ?", ids).
+		UpdateColumns(map[string]interface{}{
+			"hit_count":   gorm.Expr("hit_count + 1"),
+			"last_hit_at": at,
+		}).Error
+}
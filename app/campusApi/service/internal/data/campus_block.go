@@ -0,0 +1,94 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusUserBlockModel struct {
+	UserID       int64     `gorm:"column:user_id;primaryKey"`
+	TargetUserID int64     `gorm:"column:target_user_id;primaryKey"`
+	Kind         string    `gorm:"column:kind"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (campusUserBlockModel) TableName() string { return "campus_user_block" }
+
+func (r *campusRepo) SetUserBlock(ctx context.Context, block *biz.CampusUserBlock) error {
+	now := time.Now()
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "target_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "updated_at"}),
+	}).Create(&campusUserBlockModel{
+		UserID:       parseID(block.UserID),
+		TargetUserID: parseID(block.TargetUserID),
+		Kind:         block.Kind,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}).Error
+}
+
+func (r *campusRepo) DeleteUserBlock(ctx context.Context, userID, targetUserID, kind string) error {
+	return r.data.db.WithContext(ctx).
+		Where("user_id = ? AND target_user_id = ? AND kind = ?", parseID(userID), parseID(targetUserID), kind).
+		Delete(&campusUserBlockModel{}).Error
+}
+
+func (r *campusRepo) ListUserBlocks(ctx context.Context, userID, kind string, offset, limit int) ([]*biz.CampusUserBlock, int64, error) {
+	db := r.data.db.WithContext(ctx).Model(&campusUserBlockModel{}).Where("user_id = ? AND kind = ?", parseID(userID), kind)
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusUserBlockModel
+	if err := db.Order("updated_at DESC, target_user_id DESC").Offset(offset).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	blocks := make([]*biz.CampusUserBlock, 0, len(rows))
+	for i := range rows {
+		blocks = append(blocks, &biz.CampusUserBlock{
+			UserID:       fmt.Sprintf("%d", rows[i].UserID),
+			TargetUserID: fmt.Sprintf("%d", rows[i].TargetUserID),
+			Kind:         rows[i].Kind,
+			CreatedAt:    rows[i].UpdatedAt,
+		})
+	}
+	return blocks, total, nil
+}
+
+func (r *campusRepo) ListBlockedUserIDs(ctx context.Context, userID string, limit int) ([]string, error) {
+	var ids []int64
+	if err := r.data.db.WithContext(ctx).Model(&campusUserBlockModel{}).
+		Where("user_id = ?", parseID(userID)).
+		Order("updated_at DESC").
+		Limit(limit).
+		Pluck("target_user_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, fmt.Sprintf("%d", id))
+	}
+	return result, nil
+}
+
+func (r *campusRepo) GetUserBlockKind(ctx context.Context, userID, targetUserID string) (string, error) {
+	var row campusUserBlockModel
+	err := r.data.db.WithContext(ctx).
+		Where("user_id = ? AND target_user_id = ?", parseID(userID), parseID(targetUserID)).
+		Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return row.Kind, nil
+}
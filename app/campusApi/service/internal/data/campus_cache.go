@@ -140,7 +140,7 @@ func (r *campusRepo) shouldCachePostList(query biz.ListCampusPostQuery) bool {
 	if !r.cacheEnabled() {
 		return false
 	}
-	if query.IncludeDeleted || query.Keyword != "" || query.AuthorID != "" || query.CollectedByUserID != "" || query.OnlyReported {
+	if query.IncludeDeleted || query.ExcludeAnonymous || query.Keyword != "" || query.AuthorID != "" || len(query.AuthorIDs) > 0 || len(query.ExcludeAuthorIDs) > 0 || query.CollectedByUserID != "" || query.OnlyReported || query.ClaimFilter != "" || query.After != nil {
 		return false
 	}
 	if query.OnlyOfficial != nil || query.OnlyFeatured != nil || query.OnlyPinned != nil {
@@ -158,24 +158,41 @@ func (r *campusRepo) shouldCachePostList(query biz.ListCampusPostQuery) bool {
 
 func (r *campusRepo) postListCacheKey(ctx context.Context, query biz.ListCampusPostQuery) string {
 	version := r.postFeedCacheVersion(ctx)
-	payload := fmt.Sprintf("v=%d|category=%s|post_type=%s|sort=%s|offset=%d|limit=%d",
+	payload := fmt.Sprintf("v=%d|campus=%s|own=%t|category=%s|post_type=%s|sort=%s|offset=%d|limit=%d",
 		version,
+		strings.TrimSpace(query.CampusCode),
+		query.OnlyOwnCampus,
 		strings.TrimSpace(query.CategoryCode),
 		strings.TrimSpace(query.PostType),
 		strings.TrimSpace(query.Sort),
 		query.Offset,
 		query.Limit,
 	)
+	if query.TagID > 0 {
+		payload += fmt.Sprintf("|tag=%d", query.TagID)
+	}
 	sum := sha1.Sum([]byte(payload))
-	return campusCachePrefix + ":postfeed:" + hex.EncodeToString(sum[:])
+	return campusCachePrefix + ":postfeed:" + campusCacheScope(query.CampusCode) + ":" + hex.EncodeToString(sum[:])
 }
 
 func campusPostDetailCacheKey(postID int64) string {
 	return fmt.Sprintf("%s:post:%d", campusCachePrefix, postID)
 }
 
-func campusCategoriesCacheKey() string {
-	return campusCachePrefix + ":categories"
+func campusCategoriesCacheKey(campusCode string) string {
+	return campusCachePrefix + ":categories:" + campusCacheScope(campusCode)
+}
+
+func campusCacheScope(campusCode string) string {
+	campusCode = strings.TrimSpace(campusCode)
+	if campusCode == "" {
+		return "all"
+	}
+	return campusCode
+}
+
+func campusUserSanctionsCacheKey(userID int64) string {
+	return fmt.Sprintf("%s:sanctions:%d", campusCachePrefix, userID)
 }
 
 func campusAdminSummaryCacheKey() string {
@@ -219,6 +236,10 @@ func campusPostDetailCacheTTL() time.Duration {
 	return campusCacheTTL("LEHU_CACHE_POST_DETAIL_TTL", 30*time.Second)
 }
 
+func campusUserSanctionsCacheTTL() time.Duration {
+	return campusCacheTTL("LEHU_CACHE_USER_SANCTIONS_TTL", 5*time.Minute)
+}
+
 func campusAdminSummaryCacheTTL() time.Duration {
 	return campusCacheTTL("LEHU_CACHE_ADMIN_SUMMARY_TTL", 60*time.Second)
 }
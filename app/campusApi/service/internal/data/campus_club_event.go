@@ -0,0 +1,308 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusClubEventModel struct {
+	PostID         int64      `gorm:"column:post_id;primaryKey"`
+	CampusCode     string     `gorm:"column:campus_code"`
+	OrganizerID    int64      `gorm:"column:organizer_id"`
+	Capacity       int32      `gorm:"column:capacity"`
+	StartsAt       time.Time  `gorm:"column:starts_at"`
+	SignupDeadline time.Time  `gorm:"column:signup_deadline"`
+	Place          string     `gorm:"column:place"`
+	CheckinCode    string     `gorm:"column:checkin_code"`
+	ReminderSentAt *time.Time `gorm:"column:reminder_sent_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusClubEventModel) TableName() string { return "campus_club_event" }
+
+type campusClubRegistrationModel struct {
+	PostID      int64      `gorm:"column:post_id;primaryKey"`
+	UserID      int64      `gorm:"column:user_id;primaryKey"`
+	Status      string     `gorm:"column:status"`
+	CheckedInAt *time.Time `gorm:"column:checked_in_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusClubRegistrationModel) TableName() string { return "campus_club_registration" }
+
+func createClubEventWithTx(tx *gorm.DB, event *biz.CampusClubEvent) error {
+	if event == nil {
+		return nil
+	}
+	now := time.Now()
+	return tx.Create(&campusClubEventModel{
+		PostID:         event.PostID,
+		CampusCode:     event.CampusCode,
+		OrganizerID:    parseID(event.OrganizerID),
+		Capacity:       event.Capacity,
+		StartsAt:       event.StartsAt,
+		SignupDeadline: event.SignupDeadline,
+		Place:          event.Place,
+		CheckinCode:    event.CheckinCode,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}).Error
+}
+
+func (r *campusRepo) GetClubEvents(ctx context.Context, postIDs []int64) (map[int64]*biz.CampusClubEvent, error) {
+	events := make(map[int64]*biz.CampusClubEvent, len(postIDs))
+	if len(postIDs) == 0 {
+		return events, nil
+	}
+	var rows []campusClubEventModel
+	if err := r.data.db.WithContext(ctx).Where("post_id IN ?", postIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return events, nil
+	}
+	ids := make([]int64, 0, len(rows))
+	for i := range rows {
+		ids = append(ids, rows[i].PostID)
+		events[rows[i].PostID] = toBizClubEvent(&rows[i])
+	}
+	var counts []struct {
+		PostID    int64  `gorm:"column:post_id"`
+		Status    string `gorm:"column:status"`
+		Total     int64  `gorm:"column:total"`
+		CheckedIn int64  `gorm:"column:checked_in"`
+	}
+	if err := r.data.db.WithContext(ctx).Model(&campusClubRegistrationModel{}).
+		Select("post_id, status, COUNT(*) AS total, COUNT(checked_in_at) AS checked_in").
+		Where("post_id IN ?", ids).
+		Group("post_id, status").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range counts {
+		event := events[item.PostID]
+		switch item.Status {
+		case biz.CampusClubRegistrationRegistered:
+			event.RegisteredCount = item.Total
+			event.CheckedInCount = item.CheckedIn
+		case biz.CampusClubRegistrationWaitlisted:
+			event.WaitlistCount = item.Total
+		}
+	}
+	return events, nil
+}
+
+func (r *campusRepo) GetClubRegistrations(ctx context.Context, userID string, postIDs []int64) (map[int64]*biz.CampusClubRegistration, error) {
+	registrations := make(map[int64]*biz.CampusClubRegistration, len(postIDs))
+	if len(postIDs) == 0 {
+		return registrations, nil
+	}
+	var rows []campusClubRegistrationModel
+	if err := r.data.db.WithContext(ctx).
+		Where("post_id IN ? AND user_id = ?", postIDs, parseID(userID)).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		registrations[rows[i].PostID] = toBizClubRegistration(&rows[i])
+	}
+	return registrations, nil
+}
+
+func (r *campusRepo) RegisterClubEvent(ctx context.Context, postID int64, userID string, now time.Time) (*biz.CampusClubRegistration, error) {
+	uid := parseID(userID)
+	var out *biz.CampusClubRegistration
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var event campusClubEventModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("post_id = ?", postID).Take(&event).Error; err != nil {
+			return err
+		}
+		var existing campusClubRegistrationModel
+		err := tx.Where("post_id = ? AND user_id = ?", postID, uid).Take(&existing).Error
+		if err == nil && existing.Status != biz.CampusClubRegistrationCancelled {
+			out = toBizClubRegistration(&existing)
+			return nil
+		}
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		status := biz.CampusClubRegistrationRegistered
+		if event.Capacity > 0 {
+			var registered int64
+			if err := tx.Model(&campusClubRegistrationModel{}).
+				Where("post_id = ? AND status = ?", postID, biz.CampusClubRegistrationRegistered).
+				Count(&registered).Error; err != nil {
+				return err
+			}
+			if registered >= int64(event.Capacity) {
+				status = biz.CampusClubRegistrationWaitlisted
+			}
+		}
+		row := campusClubRegistrationModel{PostID: postID, UserID: uid, Status: status, CreatedAt: now, UpdatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}, {Name: "user_id"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{"status": status, "checked_in_at": nil, "created_at": now, "updated_at": now}),
+		}).Create(&row).Error; err != nil {
+			return err
+		}
+		out = toBizClubRegistration(&row)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *campusRepo) CancelClubRegistration(ctx context.Context, postID int64, userID string, now time.Time) (string, error) {
+	promoted := ""
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("post_id = ?", postID).Take(&campusClubEventModel{}).Error; err != nil {
+			return err
+		}
+		var existing campusClubRegistrationModel
+		err := tx.Where("post_id = ? AND user_id = ?", postID, parseID(userID)).Take(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if existing.Status == biz.CampusClubRegistrationCancelled {
+			return nil
+		}
+		if err := tx.Model(&campusClubRegistrationModel{}).
+			Where("post_id = ? AND user_id = ?", postID, existing.UserID).
+			Updates(map[string]interface{}{"status": biz.CampusClubRegistrationCancelled, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		if existing.Status != biz.CampusClubRegistrationRegistered {
+			return nil
+		}
+		var next campusClubRegistrationModel
+		err = tx.Where("post_id = ? AND status = ?", postID, biz.CampusClubRegistrationWaitlisted).
+			Order("created_at ASC, user_id ASC").
+			Take(&next).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.Model(&campusClubRegistrationModel{}).
+			Where("post_id = ? AND user_id = ?", postID, next.UserID).
+			Updates(map[string]interface{}{"status": biz.CampusClubRegistrationRegistered, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		promoted = fmt.Sprintf("%d", next.UserID)
+		return nil
+	})
+	return promoted, err
+}
+
+func (r *campusRepo) CheckInClubEvent(ctx context.Context, postID int64, userID string, now time.Time) error {
+	return r.data.db.WithContext(ctx).Model(&campusClubRegistrationModel{}).
+		Where("post_id = ? AND user_id = ? AND status = ? AND checked_in_at IS NULL", postID, parseID(userID), biz.CampusClubRegistrationRegistered).
+		Updates(map[string]interface{}{"checked_in_at": now, "updated_at": now}).Error
+}
+
+func (r *campusRepo) ListClubRegistrations(ctx context.Context, postID int64, status string, offset, limit int) ([]*biz.CampusClubRegistration, int64, error) {
+	db := r.data.db.WithContext(ctx).Model(&campusClubRegistrationModel{}).Where("post_id = ?", postID)
+	if status != "" {
+		db = db.Where("status = ?", status)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusClubRegistrationModel
+	if err := db.Order("FIELD(status, 'registered', 'waitlisted', 'cancelled'), created_at ASC, user_id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	registrations := make([]*biz.CampusClubRegistration, 0, len(rows))
+	for i := range rows {
+		registrations = append(registrations, toBizClubRegistration(&rows[i]))
+	}
+	return registrations, total, nil
+}
+
+func (r *campusRepo) ListDueClubReminders(ctx context.Context, now, before time.Time, limit int) ([]*biz.CampusClubEvent, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []campusClubEventModel
+	if err := r.data.db.WithContext(ctx).Model(&campusClubEventModel{}).
+		Joins("JOIN campus_forum_post p ON p.id = campus_club_event.post_id AND p.is_deleted = ? AND p.status = ?", false, biz.CampusAuditStatusVisible).
+		Where("campus_club_event.reminder_sent_at IS NULL AND campus_club_event.starts_at > ? AND campus_club_event.starts_at <= ?", now, before).
+		Select("campus_club_event.*").
+		Order("campus_club_event.starts_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	events := make([]*biz.CampusClubEvent, 0, len(rows))
+	for i := range rows {
+		events = append(events, toBizClubEvent(&rows[i]))
+	}
+	return events, nil
+}
+
+func (r *campusRepo) MarkClubReminderSent(ctx context.Context, postID int64, now time.Time, outboxes []*biz.CampusNotificationOutbox) (bool, error) {
+	marked := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&campusClubEventModel{}).
+			Where("post_id = ? AND reminder_sent_at IS NULL", postID).
+			Updates(map[string]interface{}{"reminder_sent_at": now, "updated_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		marked = true
+		for _, outbox := range outboxes {
+			if err := createNotificationOutboxWithTx(tx, outbox); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return marked, err
+}
+
+func toBizClubEvent(row *campusClubEventModel) *biz.CampusClubEvent {
+	return &biz.CampusClubEvent{
+		PostID:         row.PostID,
+		CampusCode:     row.CampusCode,
+		OrganizerID:    fmt.Sprintf("%d", row.OrganizerID),
+		Capacity:       row.Capacity,
+		StartsAt:       row.StartsAt,
+		SignupDeadline: row.SignupDeadline,
+		Place:          row.Place,
+		CheckinCode:    row.CheckinCode,
+		ReminderSentAt: row.ReminderSentAt,
+		CreatedAt:      row.CreatedAt,
+	}
+}
+
+func toBizClubRegistration(row *campusClubRegistrationModel) *biz.CampusClubRegistration {
+	return &biz.CampusClubRegistration{
+		PostID:      row.PostID,
+		UserID:      fmt.Sprintf("%d", row.UserID),
+		Status:      row.Status,
+		CheckedInAt: row.CheckedInAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
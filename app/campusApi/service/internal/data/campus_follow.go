@@ -0,0 +1,86 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusPostFanoutModel struct {
+	PostID        int64      `gorm:"column:post_id;primaryKey"`
+	AuthorID      int64      `gorm:"column:author_id"`
+	LastUserID    int64      `gorm:"column:last_user_id"`
+	NotifiedCount int64      `gorm:"column:notified_count"`
+	DoneAt        *time.Time `gorm:"column:done_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusPostFanoutModel) TableName() string { return "campus_post_fanout" }
+
+func (r *campusRepo) ListDueFollowFanouts(ctx context.Context, since time.Time, limit int) ([]*biz.CampusPostFanout, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []campusPostFanoutModel
+	if err := r.data.db.WithContext(ctx).Table("campus_forum_post p").
+		Joins("LEFT JOIN campus_post_fanout f ON f.post_id = p.id").
+		Where("p.is_deleted = ? AND p.status = ? AND p.is_anonymous = ? AND p.created_at >= ? AND f.done_at IS NULL", false, biz.CampusAuditStatusVisible, false, since).
+		Select("p.id AS post_id, p.author_id, COALESCE(f.last_user_id, 0) AS last_user_id, COALESCE(f.notified_count, 0) AS notified_count").
+		Order("p.created_at ASC, p.id ASC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	fanouts := make([]*biz.CampusPostFanout, 0, len(rows))
+	for i := range rows {
+		fanouts = append(fanouts, toBizPostFanout(&rows[i]))
+	}
+	return fanouts, nil
+}
+
+func (r *campusRepo) SaveFollowFanoutBatch(ctx context.Context, fanout *biz.CampusPostFanout, outboxes []*biz.CampusNotificationOutbox) error {
+	now := time.Now()
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		rows := make([]campusNotificationOutboxModel, 0, len(outboxes))
+		for _, outbox := range outboxes {
+			if outbox != nil {
+				rows = append(rows, toNotificationOutboxModel(outbox))
+			}
+		}
+		if len(rows) > 0 {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "dedupe_key"}},
+				DoNothing: true,
+			}).CreateInBatches(rows, 100).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"last_user_id", "notified_count", "done_at", "updated_at"}),
+		}).Create(&campusPostFanoutModel{
+			PostID:        fanout.PostID,
+			AuthorID:      parseID(fanout.AuthorID),
+			LastUserID:    parseID(fanout.LastUserID),
+			NotifiedCount: fanout.NotifiedCount,
+			DoneAt:        fanout.DoneAt,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}).Error
+	})
+}
+
+func toBizPostFanout(row *campusPostFanoutModel) *biz.CampusPostFanout {
+	return &biz.CampusPostFanout{
+		PostID:        row.PostID,
+		AuthorID:      fmt.Sprintf("%d", row.AuthorID),
+		LastUserID:    fmt.Sprintf("%d", row.LastUserID),
+		NotifiedCount: row.NotifiedCount,
+		DoneAt:        row.DoneAt,
+	}
+}
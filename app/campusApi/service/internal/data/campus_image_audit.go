@@ -0,0 +1,208 @@
+package data
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusImageAuditTaskModel struct {
+	ID          int64      `gorm:"column:id"`
+	TargetType  string     `gorm:"column:target_type"`
+	TargetID    int64      `gorm:"column:target_id"`
+	ImageURL    string     `gorm:"column:image_url"`
+	URLHash     string     `gorm:"column:url_hash"`
+	Status      string     `gorm:"column:status"`
+	Provider    string     `gorm:"column:provider"`
+	Decision    string     `gorm:"column:decision"`
+	Label       string     `gorm:"column:label"`
+	Reason      string     `gorm:"column:reason"`
+	RawResult   string     `gorm:"column:raw_result"`
+	RetryCount  int32      `gorm:"column:retry_count"`
+	NextRetryAt *time.Time `gorm:"column:next_retry_at"`
+	LockedUntil *time.Time `gorm:"column:locked_until"`
+	LastError   string     `gorm:"column:last_error"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+	ProcessedAt *time.Time `gorm:"column:processed_at"`
+}
+
+func (campusImageAuditTaskModel) TableName() string { return "campus_image_audit_task" }
+
+func campusImageURLHash(imageURL string) string {
+	sum := sha1.Sum([]byte(strings.TrimSpace(imageURL)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *campusRepo) CreateImageAuditTasks(ctx context.Context, tasks []*biz.CampusImageAuditTask) error {
+	rows := make([]campusImageAuditTaskModel, 0, len(tasks))
+	now := time.Now()
+	for _, task := range tasks {
+		if task == nil || strings.TrimSpace(task.ImageURL) == "" {
+			continue
+		}
+		rows = append(rows, campusImageAuditTaskModel{
+			ID:         task.ID,
+			TargetType: task.TargetType,
+			TargetID:   task.TargetID,
+			ImageURL:   trimLimitData(strings.TrimSpace(task.ImageURL), 1024),
+			URLHash:    campusImageURLHash(task.ImageURL),
+			Status:     biz.CampusAIContentAuditTaskStatusPending,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "target_type"}, {Name: "target_id"}, {Name: "url_hash"}},
+		DoNothing: true,
+	}).Create(&rows).Error
+}
+
+func (r *campusRepo) ClaimImageAuditTasks(ctx context.Context, limit int, lockFor time.Duration) ([]*biz.CampusImageAuditTask, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if lockFor <= 0 {
+		lockFor = time.Minute
+	}
+	now := time.Now()
+	lockedUntil := now.Add(lockFor)
+	var rows []campusImageAuditTaskModel
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("((status = ? AND (next_retry_at IS NULL OR next_retry_at <= ?)) OR (status = ? AND (locked_until IS NULL OR locked_until < ?)))",
+				biz.CampusAIContentAuditTaskStatusPending, now, biz.CampusAIContentAuditTaskStatusProcessing, now).
+			Order("created_at ASC, id ASC").
+			Limit(limit).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		ids := make([]int64, 0, len(rows))
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return tx.Model(&campusImageAuditTaskModel{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":       biz.CampusAIContentAuditTaskStatusProcessing,
+				"locked_until": lockedUntil,
+				"updated_at":   now,
+			}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusImageAuditTask, 0, len(rows))
+	for i := range rows {
+		rows[i].Status = biz.CampusAIContentAuditTaskStatusProcessing
+		rows[i].LockedUntil = &lockedUntil
+		out = append(out, toBizImageAuditTask(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *campusRepo) MarkImageAuditTaskDone(ctx context.Context, id int64, provider, decision, label, reason, rawResult string) error {
+	now := time.Now()
+	return r.data.db.WithContext(ctx).Model(&campusImageAuditTaskModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        biz.CampusAIContentAuditTaskStatusDone,
+			"provider":      trimLimitData(provider, 64),
+			"decision":      trimLimitData(decision, 24),
+			"label":         trimLimitData(label, 64),
+			"reason":        trimLimitData(reason, 255),
+			"raw_result":    trimLimitData(rawResult, 4000),
+			"locked_until":  nil,
+			"next_retry_at": nil,
+			"last_error":    "",
+			"processed_at":  now,
+			"updated_at":    now,
+		}).Error
+}
+
+func (r *campusRepo) MarkImageAuditTaskRetry(ctx context.Context, id int64, retryCount int32, nextRetryAt *time.Time, lastError string, final bool) error {
+	status := biz.CampusAIContentAuditTaskStatusPending
+	if final {
+		status = biz.CampusAIContentAuditTaskStatusFailed
+	}
+	return r.data.db.WithContext(ctx).Model(&campusImageAuditTaskModel{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":        status,
+			"retry_count":   retryCount,
+			"next_retry_at": nextRetryAt,
+			"locked_until":  nil,
+			"last_error":    trimLimitData(lastError, 600),
+			"updated_at":    time.Now(),
+		}).Error
+}
+
+func (r *campusRepo) GetRecentImageAuditByURL(ctx context.Context, imageURL string, since time.Time) (bool, *biz.CampusImageAuditTask, error) {
+	var row campusImageAuditTaskModel
+	err := r.data.db.WithContext(ctx).Model(&campusImageAuditTaskModel{}).
+		Where("url_hash = ? AND status = ? AND decision IN ? AND label <> ? AND processed_at >= ?",
+			campusImageURLHash(imageURL), biz.CampusAIContentAuditTaskStatusDone,
+			[]string{biz.CampusAIContentAuditDecisionPass, biz.CampusAIContentAuditDecisionReview, biz.CampusAIContentAuditDecisionReject}, "skipped", since).
+		Order("processed_at DESC, id DESC").
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, toBizImageAuditTask(&row), nil
+}
+
+func (r *campusRepo) ListImageAuditTasks(ctx context.Context, targetType string, targetID int64) ([]*biz.CampusImageAuditTask, error) {
+	var rows []campusImageAuditTaskModel
+	if err := r.data.db.WithContext(ctx).Model(&campusImageAuditTaskModel{}).
+		Where("target_type = ? AND target_id = ?", targetType, targetID).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusImageAuditTask, 0, len(rows))
+	for i := range rows {
+		out = append(out, toBizImageAuditTask(&rows[i]))
+	}
+	return out, nil
+}
+
+func toBizImageAuditTask(row *campusImageAuditTaskModel) *biz.CampusImageAuditTask {
+	if row == nil {
+		return nil
+	}
+	return &biz.CampusImageAuditTask{
+		ID:          row.ID,
+		TargetType:  row.TargetType,
+		TargetID:    row.TargetID,
+		ImageURL:    row.ImageURL,
+		Status:      row.Status,
+		Provider:    row.Provider,
+		Decision:    row.Decision,
+		Label:       row.Label,
+		Reason:      row.Reason,
+		RawResult:   row.RawResult,
+		RetryCount:  row.RetryCount,
+		NextRetryAt: row.NextRetryAt,
+		LockedUntil: row.LockedUntil,
+		LastError:   row.LastError,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		ProcessedAt: row.ProcessedAt,
+	}
+}
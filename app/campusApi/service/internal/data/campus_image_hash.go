@@ -0,0 +1,154 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusImageFingerprintModel struct {
+	URLHash   string    `gorm:"column:url_hash;primaryKey"`
+	ImageURL  string    `gorm:"column:image_url"`
+	FileID    int64     `gorm:"column:file_id"`
+	PHash     uint64    `gorm:"column:phash"`
+	DHash     uint64    `gorm:"column:dhash"`
+	Source    string    `gorm:"column:source"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (campusImageFingerprintModel) TableName() string { return "campus_image_fingerprint" }
+
+type campusImageHashBlockModel struct {
+	ID           int64     `gorm:"column:id"`
+	PHash        uint64    `gorm:"column:phash"`
+	DHash        uint64    `gorm:"column:dhash"`
+	ImageURL     string    `gorm:"column:image_url"`
+	SourcePostID int64     `gorm:"column:source_post_id"`
+	Reason       string    `gorm:"column:reason"`
+	CreatedBy    int64     `gorm:"column:created_by"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+}
+
+func (campusImageHashBlockModel) TableName() string { return "campus_image_hash_block" }
+
+func (r *campusRepo) SaveImageFingerprint(ctx context.Context, fingerprint *biz.CampusImageFingerprint) error {
+	if fingerprint == nil || strings.TrimSpace(fingerprint.ImageURL) == "" {
+		return nil
+	}
+	row := campusImageFingerprintModel{
+		URLHash:   campusImageURLHash(fingerprint.ImageURL),
+		ImageURL:  trimLimitData(strings.TrimSpace(fingerprint.ImageURL), 1024),
+		FileID:    fingerprint.FileID,
+		PHash:     fingerprint.PHash,
+		DHash:     fingerprint.DHash,
+		Source:    fingerprint.Source,
+		CreatedAt: time.Now(),
+	}
+	return r.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "url_hash"}},
+		DoNothing: true,
+	}).Create(&row).Error
+}
+
+func (r *campusRepo) GetImageFingerprint(ctx context.Context, imageURL string) (bool, *biz.CampusImageFingerprint, error) {
+	var row campusImageFingerprintModel
+	err := r.data.db.WithContext(ctx).Where("url_hash = ?", campusImageURLHash(imageURL)).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, &biz.CampusImageFingerprint{
+		ImageURL:  row.ImageURL,
+		FileID:    row.FileID,
+		PHash:     row.PHash,
+		DHash:     row.DHash,
+		Source:    row.Source,
+		CreatedAt: row.CreatedAt,
+	}, nil
+}
+
+func (r *campusRepo) FindRejectedImageDuplicate(ctx context.Context, query biz.CampusImageDuplicateQuery) (bool, *biz.CampusImageDuplicate, error) {
+	var rows []struct {
+		PostID   int64  `gorm:"column:post_id"`
+		ImageURL string `gorm:"column:image_url"`
+		Status   int32  `gorm:"column:status"`
+		Distance int    `gorm:"column:distance"`
+	}
+	db := r.data.db.WithContext(ctx)
+	// 先按 idx_campus_post_status_created 取出窗口内最近的驳回/下架帖子，再逐个比对它们的图片指纹
+	candidates := db.Model(&campusForumPostModel{}).
+		Select("id, status").
+		Where("status IN ? AND created_at >= ? AND id <> ?", []int32{biz.CampusAuditStatusRejected, biz.CampusAuditStatusDeleted}, query.Since, query.ExcludePostID).
+		Where("(status = ? OR audit_reason NOT IN ?)", biz.CampusAuditStatusRejected, biz.CampusUserDeletionReasons()).
+		Order("created_at DESC").
+		Limit(query.ScanLimit)
+	err := db.Table("(?) AS p", candidates).
+		Select("t.target_id AS post_id, t.image_url, p.status, BIT_COUNT(f.phash ^ ?) AS distance", query.PHash).
+		Joins("JOIN campus_image_audit_task t ON t.target_type = ? AND t.target_id = p.id", biz.CampusImageAuditTargetPost).
+		Joins("JOIN campus_image_fingerprint f ON f.url_hash = t.url_hash").
+		Where("f.phash <> 0 AND BIT_COUNT(f.phash ^ ?) <= ?", query.PHash, query.MaxDistance).
+		Order("distance ASC, t.created_at DESC").
+		Limit(1).
+		Scan(&rows).Error
+	if err != nil {
+		return false, nil, err
+	}
+	if len(rows) == 0 {
+		return false, nil, nil
+	}
+	return true, &biz.CampusImageDuplicate{
+		PostID:   rows[0].PostID,
+		ImageURL: rows[0].ImageURL,
+		Status:   rows[0].Status,
+		Distance: rows[0].Distance,
+	}, nil
+}
+
+func (r *campusRepo) ListImageHashBlocks(ctx context.Context) ([]*biz.CampusImageHashBlock, error) {
+	var rows []campusImageHashBlockModel
+	if err := r.data.db.WithContext(ctx).Model(&campusImageHashBlockModel{}).
+		Order("created_at DESC, id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusImageHashBlock, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, &biz.CampusImageHashBlock{
+			ID:           row.ID,
+			PHash:        row.PHash,
+			DHash:        row.DHash,
+			ImageURL:     row.ImageURL,
+			SourcePostID: row.SourcePostID,
+			Reason:       row.Reason,
+			CreatedBy:    fmt.Sprintf("%d", row.CreatedBy),
+			CreatedAt:    row.CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *campusRepo) CreateImageHashBlock(ctx context.Context, block *biz.CampusImageHashBlock) error {
+	return r.data.db.WithContext(ctx).Create(&campusImageHashBlockModel{
+		ID:           block.ID,
+		PHash:        block.PHash,
+		DHash:        block.DHash,
+		ImageURL:     trimLimitData(block.ImageURL, 1024),
+		SourcePostID: block.SourcePostID,
+		Reason:       trimLimitData(block.Reason, 255),
+		CreatedBy:    parseID(block.CreatedBy),
+		CreatedAt:    time.Now(),
+	}).Error
+}
+
+func (r *campusRepo) DeleteImageHashBlock(ctx context.Context, id int64) (bool, error) {
+	result := r.data.db.WithContext(ctx).Where("id = ?", id).Delete(&campusImageHashBlockModel{})
+	return result.RowsAffected > 0, result.Error
+}
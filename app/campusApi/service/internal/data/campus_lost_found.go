@@ -0,0 +1,223 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusLostItemModel struct {
+	PostID     int64      `gorm:"column:post_id;primaryKey"`
+	CampusCode string     `gorm:"column:campus_code"`
+	AuthorID   int64      `gorm:"column:author_id"`
+	Kind       string     `gorm:"column:kind"`
+	Category   string     `gorm:"column:category"`
+	Place      string     `gorm:"column:place"`
+	EventStart *time.Time `gorm:"column:event_start"`
+	EventEnd   *time.Time `gorm:"column:event_end"`
+	Status     string     `gorm:"column:status"`
+	ResolvedAt *time.Time `gorm:"column:resolved_at"`
+	ResolvedBy int64      `gorm:"column:resolved_by"`
+	ScannedAt  *time.Time `gorm:"column:scanned_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusLostItemModel) TableName() string { return "campus_lost_item" }
+
+type campusLostMatchModel struct {
+	ID          int64     `gorm:"column:id;primaryKey"`
+	LostPostID  int64     `gorm:"column:lost_post_id"`
+	FoundPostID int64     `gorm:"column:found_post_id"`
+	Score       float64   `gorm:"column:score"`
+	Reasons     string    `gorm:"column:reasons"`
+	CreatedAt   time.Time `gorm:"column:created_at"`
+}
+
+func (campusLostMatchModel) TableName() string { return "campus_lost_match" }
+
+func createLostItemWithTx(tx *gorm.DB, item *biz.CampusLostItem) error {
+	if item == nil {
+		return nil
+	}
+	now := time.Now()
+	return tx.Create(&campusLostItemModel{
+		PostID:     item.PostID,
+		CampusCode: item.CampusCode,
+		AuthorID:   parseID(item.AuthorID),
+		Kind:       item.Kind,
+		Category:   item.Category,
+		Place:      item.Place,
+		EventStart: item.EventStart,
+		EventEnd:   item.EventEnd,
+		Status:     biz.CampusLostStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}).Error
+}
+
+func (r *campusRepo) ListLostItemsByPostIDs(ctx context.Context, postIDs []int64) (map[int64]*biz.CampusLostItem, error) {
+	items := make(map[int64]*biz.CampusLostItem, len(postIDs))
+	if len(postIDs) == 0 {
+		return items, nil
+	}
+	var rows []campusLostItemModel
+	if err := r.data.db.WithContext(ctx).Where("post_id IN ?", postIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		items[rows[i].PostID] = toBizLostItem(&rows[i])
+	}
+	return items, nil
+}
+
+func (r *campusRepo) ListUnscannedLostItems(ctx context.Context, since time.Time, limit int) ([]*biz.CampusLostItem, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []campusLostItemModel
+	if err := r.data.db.WithContext(ctx).Model(&campusLostItemModel{}).
+		Joins("JOIN campus_forum_post p ON p.id = campus_lost_item.post_id AND p.is_deleted = ? AND p.status = ?", false, biz.CampusAuditStatusVisible).
+		Where("campus_lost_item.scanned_at IS NULL AND campus_lost_item.status = ? AND campus_lost_item.created_at >= ?", biz.CampusLostStatusOpen, since).
+		Select("campus_lost_item.*").
+		Order("campus_lost_item.created_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toBizLostItems(rows), nil
+}
+
+func (r *campusRepo) ListOpenLostItems(ctx context.Context, campusCode, kind string, since time.Time, limit int) ([]*biz.CampusLostItem, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+	var rows []campusLostItemModel
+	if err := r.data.db.WithContext(ctx).
+		Where("campus_code = ? AND kind = ? AND status = ? AND created_at >= ?", campusCode, kind, biz.CampusLostStatusOpen, since).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toBizLostItems(rows), nil
+}
+
+func (r *campusRepo) CreateLostMatch(ctx context.Context, match *biz.CampusLostMatch, outboxes []*biz.CampusNotificationOutbox) (bool, error) {
+	created := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&campusLostMatchModel{
+			ID:          match.ID,
+			LostPostID:  match.LostPostID,
+			FoundPostID: match.FoundPostID,
+			Score:       match.Score,
+			Reasons:     strings.Join(match.Reasons, ","),
+			CreatedAt:   time.Now(),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		created = true
+		for _, outbox := range outboxes {
+			if err := createNotificationOutboxWithTx(tx, outbox); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return created, err
+}
+
+func (r *campusRepo) ListLostMatches(ctx context.Context, postID int64) ([]*biz.CampusLostMatch, error) {
+	var rows []campusLostMatchModel
+	if err := r.data.db.WithContext(ctx).
+		Where("lost_post_id = ? OR found_post_id = ?", postID, postID).
+		Order("score DESC, created_at DESC").
+		Limit(20).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	matches := make([]*biz.CampusLostMatch, 0, len(rows))
+	for _, row := range rows {
+		reasons := []string{}
+		if row.Reasons != "" {
+			reasons = strings.Split(row.Reasons, ",")
+		}
+		matches = append(matches, &biz.CampusLostMatch{
+			ID:          row.ID,
+			LostPostID:  row.LostPostID,
+			FoundPostID: row.FoundPostID,
+			Score:       row.Score,
+			Reasons:     reasons,
+			CreatedAt:   row.CreatedAt,
+		})
+	}
+	return matches, nil
+}
+
+func (r *campusRepo) MarkLostItemScanned(ctx context.Context, postID int64, now time.Time) error {
+	return r.data.db.WithContext(ctx).Model(&campusLostItemModel{}).
+		Where("post_id = ?", postID).
+		Updates(map[string]interface{}{
+			"scanned_at": now,
+			"updated_at": now,
+		}).Error
+}
+
+func (r *campusRepo) ResolveLostItems(ctx context.Context, postIDs []int64, userID string, now time.Time) error {
+	if len(postIDs) == 0 {
+		return nil
+	}
+	if err := r.data.db.WithContext(ctx).Model(&campusLostItemModel{}).
+		Where("post_id IN ? AND status = ?", postIDs, biz.CampusLostStatusOpen).
+		Updates(map[string]interface{}{
+			"status":      biz.CampusLostStatusReturned,
+			"resolved_at": now,
+			"resolved_by": parseID(userID),
+			"updated_at":  now,
+		}).Error; err != nil {
+		return err
+	}
+	for _, postID := range postIDs {
+		r.invalidatePostDetailCache(ctx, postID)
+	}
+	return nil
+}
+
+func toBizLostItems(rows []campusLostItemModel) []*biz.CampusLostItem {
+	items := make([]*biz.CampusLostItem, 0, len(rows))
+	for i := range rows {
+		items = append(items, toBizLostItem(&rows[i]))
+	}
+	return items
+}
+
+func toBizLostItem(row *campusLostItemModel) *biz.CampusLostItem {
+	resolvedBy := ""
+	if row.ResolvedBy > 0 {
+		resolvedBy = fmt.Sprintf("%d", row.ResolvedBy)
+	}
+	return &biz.CampusLostItem{
+		PostID:     row.PostID,
+		CampusCode: row.CampusCode,
+		AuthorID:   fmt.Sprintf("%d", row.AuthorID),
+		Kind:       row.Kind,
+		Category:   row.Category,
+		Place:      row.Place,
+		EventStart: row.EventStart,
+		EventEnd:   row.EventEnd,
+		Status:     row.Status,
+		ResolvedAt: row.ResolvedAt,
+		ResolvedBy: resolvedBy,
+		ScannedAt:  row.ScannedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
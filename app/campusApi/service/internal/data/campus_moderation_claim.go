@@ -0,0 +1,213 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusModerationClaimModel struct {
+	TargetType string    `gorm:"column:target_type;primaryKey"`
+	TargetID   int64     `gorm:"column:target_id;primaryKey"`
+	OperatorID int64     `gorm:"column:operator_id"`
+	AssignedBy int64     `gorm:"column:assigned_by"`
+	ClaimedAt  time.Time `gorm:"column:claimed_at"`
+	ExpiresAt  time.Time `gorm:"column:expires_at"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (campusModerationClaimModel) TableName() string { return "campus_moderation_claim" }
+
+type campusModerationOperatorStatsRow struct {
+	UserID         int64     `gorm:"column:user_id"`
+	Reviewed       int64     `gorm:"column:reviewed"`
+	Approved       int64     `gorm:"column:approved"`
+	Rejected       int64     `gorm:"column:rejected"`
+	Deleted        int64     `gorm:"column:deleted"`
+	ReportsHandled int64     `gorm:"column:reports_handled"`
+	AppealsHandled int64     `gorm:"column:appeals_handled"`
+	LastActionAt   time.Time `gorm:"column:last_action_at"`
+}
+
+type campusModerationActiveClaimsRow struct {
+	OperatorID int64 `gorm:"column:operator_id"`
+	Claims     int64 `gorm:"column:claims"`
+}
+
+func campusModerationClaimFromModel(row campusModerationClaimModel) *biz.CampusModerationClaim {
+	claim := &biz.CampusModerationClaim{
+		TargetType: row.TargetType,
+		TargetID:   row.TargetID,
+		OperatorID: fmt.Sprintf("%d", row.OperatorID),
+		ClaimedAt:  row.ClaimedAt,
+		ExpiresAt:  row.ExpiresAt,
+	}
+	if row.AssignedBy > 0 {
+		claim.AssignedBy = fmt.Sprintf("%d", row.AssignedBy)
+	}
+	return claim
+}
+
+// 审核队列的认领过滤：mine 只看自己认领的，available 排除别人认领中的
+func moderationClaimScope(db *gorm.DB, targetTypeColumn, targetIDColumn, filter, operatorID string, now time.Time) *gorm.DB {
+	exists := fmt.Sprintf("SELECT 1 FROM campus_moderation_claim mc WHERE mc.target_type = %s AND mc.target_id = %s AND mc.expires_at > ?", targetTypeColumn, targetIDColumn)
+	switch filter {
+	case biz.CampusClaimFilterMine:
+		return db.Where("EXISTS ("+exists+" AND mc.operator_id = ?)", now, parseID(operatorID))
+	case biz.CampusClaimFilterAvailable:
+		return db.Where("NOT EXISTS ("+exists+" AND mc.operator_id <> ?)", now, parseID(operatorID))
+	default:
+		return db
+	}
+}
+
+// 先尝试插入；已有记录时只有自己续期或原认领已过期才能接手，force 用于管理员指派
+func (r *campusRepo) ClaimModerationItem(ctx context.Context, claim *biz.CampusModerationClaim, force bool) (bool, *biz.CampusModerationClaim, error) {
+	now := time.Now()
+	row := campusModerationClaimModel{
+		TargetType: claim.TargetType,
+		TargetID:   claim.TargetID,
+		OperatorID: parseID(claim.OperatorID),
+		AssignedBy: parseID(claim.AssignedBy),
+		ClaimedAt:  claim.ClaimedAt,
+		ExpiresAt:  claim.ExpiresAt,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	db := r.data.db.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	acquired := result.RowsAffected > 0
+	if !acquired {
+		update := db.Model(&campusModerationClaimModel{}).Where("target_type = ? AND target_id = ?", claim.TargetType, claim.TargetID)
+		if !force {
+			update = update.Where("(operator_id = ? OR expires_at <= ?)", row.OperatorID, now)
+		}
+		result = update.Updates(map[string]interface{}{
+			"operator_id": row.OperatorID,
+			"assigned_by": row.AssignedBy,
+			"claimed_at":  row.ClaimedAt,
+			"expires_at":  row.ExpiresAt,
+			"updated_at":  now,
+		})
+		if result.Error != nil {
+			return false, nil, result.Error
+		}
+		acquired = result.RowsAffected > 0
+	}
+	var current campusModerationClaimModel
+	err := db.Where("target_type = ? AND target_id = ?", claim.TargetType, claim.TargetID).First(&current).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return acquired, nil, nil
+	}
+	if err != nil {
+		return acquired, nil, err
+	}
+	return acquired, campusModerationClaimFromModel(current), nil
+}
+
+func (r *campusRepo) GetActiveModerationClaim(ctx context.Context, targetType string, targetID int64, now time.Time) (bool, *biz.CampusModerationClaim, error) {
+	var row campusModerationClaimModel
+	err := r.data.db.WithContext(ctx).
+		Where("target_type = ? AND target_id = ? AND expires_at > ?", targetType, targetID, now).
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, campusModerationClaimFromModel(row), nil
+}
+
+func (r *campusRepo) ListActiveModerationClaims(ctx context.Context, targetType string, targetIDs []int64, now time.Time) (map[int64]*biz.CampusModerationClaim, error) {
+	out := map[int64]*biz.CampusModerationClaim{}
+	if len(targetIDs) == 0 {
+		return out, nil
+	}
+	var rows []campusModerationClaimModel
+	if err := r.data.db.WithContext(ctx).
+		Where("target_type = ? AND target_id IN ? AND expires_at > ?", targetType, targetIDs, now).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.TargetID] = campusModerationClaimFromModel(row)
+	}
+	return out, nil
+}
+
+// operatorID 为空时不校验持有人，审核完成或管理员强制释放时使用
+func (r *campusRepo) ReleaseModerationClaim(ctx context.Context, targetType string, targetID int64, operatorID string) (bool, error) {
+	db := r.data.db.WithContext(ctx).Where("target_type = ? AND target_id = ?", targetType, targetID)
+	if operatorID != "" {
+		db = db.Where("operator_id = ?", parseID(operatorID))
+	}
+	result := db.Delete(&campusModerationClaimModel{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *campusRepo) DeleteExpiredModerationClaims(ctx context.Context, now time.Time) (int64, error) {
+	result := r.data.db.WithContext(ctx).Where("expires_at <= ?", now).Delete(&campusModerationClaimModel{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *campusRepo) ListModerationOperatorStats(ctx context.Context, since, now time.Time) ([]*biz.CampusModerationOperatorStats, error) {
+	var rows []campusModerationOperatorStatsRow
+	if err := r.data.db.WithContext(ctx).Model(&campusAuditLogModel{}).
+		Select(`user_id,
+			SUM(provider = 'manual' AND target_type IN ('post', 'comment') AND result <> 'dismiss_reports') AS reviewed,
+			SUM(provider = 'manual' AND target_type IN ('post', 'comment') AND result IN ('approve', 'pass', 'visible')) AS approved,
+			SUM(provider = 'manual' AND target_type IN ('post', 'comment') AND result = 'reject') AS rejected,
+			SUM(provider = 'manual' AND target_type IN ('post', 'comment') AND result = 'delete') AS deleted,
+			SUM(provider = 'manual' AND (target_type = 'report' OR result = 'dismiss_reports')) AS reports_handled,
+			SUM(provider = 'appeal') AS appeals_handled,
+			MAX(created_at) AS last_action_at`).
+		Where("user_id > 0 AND provider IN ? AND created_at >= ?", []string{"manual", "appeal"}, since).
+		Group("user_id").
+		Order("reviewed DESC, user_id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	var claimRows []campusModerationActiveClaimsRow
+	if err := r.data.db.WithContext(ctx).Model(&campusModerationClaimModel{}).
+		Select("operator_id, COUNT(*) AS claims").
+		Where("expires_at > ?", now).
+		Group("operator_id").
+		Scan(&claimRows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusModerationOperatorStats, 0, len(rows)+len(claimRows))
+	byOperator := make(map[int64]*biz.CampusModerationOperatorStats, len(rows))
+	for _, row := range rows {
+		item := &biz.CampusModerationOperatorStats{
+			OperatorID:     fmt.Sprintf("%d", row.UserID),
+			Reviewed:       row.Reviewed,
+			Approved:       row.Approved,
+			Rejected:       row.Rejected,
+			Deleted:        row.Deleted,
+			ReportsHandled: row.ReportsHandled,
+			AppealsHandled: row.AppealsHandled,
+			LastActionAt:   row.LastActionAt,
+		}
+		out = append(out, item)
+		byOperator[row.UserID] = item
+	}
+	for _, row := range claimRows {
+		item := byOperator[row.OperatorID]
+		if item == nil {
+			item = &biz.CampusModerationOperatorStats{OperatorID: fmt.Sprintf("%d", row.OperatorID)}
+			out = append(out, item)
+		}
+		item.ActiveClaims = row.Claims
+	}
+	return out, nil
+}
@@ -0,0 +1,308 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusPostPollModel struct {
+	PostID     int64      `gorm:"column:post_id;primaryKey"`
+	ChoiceMode string     `gorm:"column:choice_mode"`
+	MaxChoices int32      `gorm:"column:max_choices"`
+	ClosesAt   *time.Time `gorm:"column:closes_at"`
+	Anonymous  bool       `gorm:"column:anonymous"`
+	Status     string     `gorm:"column:status"`
+	ClosedAt   *time.Time `gorm:"column:closed_at"`
+	CreatedAt  time.Time  `gorm:"column:created_at"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusPostPollModel) TableName() string { return "campus_post_poll" }
+
+type campusPostPollOptionModel struct {
+	PostID   int64  `gorm:"column:post_id;primaryKey"`
+	OptionID int32  `gorm:"column:option_id;primaryKey"`
+	Text     string `gorm:"column:text"`
+}
+
+func (campusPostPollOptionModel) TableName() string { return "campus_post_poll_option" }
+
+type campusPostPollVoterModel struct {
+	PostID    int64     `gorm:"column:post_id;primaryKey"`
+	UserID    int64     `gorm:"column:user_id;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (campusPostPollVoterModel) TableName() string { return "campus_post_poll_voter" }
+
+type campusPostPollVoteModel struct {
+	PostID    int64     `gorm:"column:post_id;primaryKey"`
+	UserID    int64     `gorm:"column:user_id;primaryKey"`
+	OptionID  int32     `gorm:"column:option_id;primaryKey"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (campusPostPollVoteModel) TableName() string { return "campus_post_poll_vote" }
+
+type campusPollResultCache struct {
+	Counts map[int32]int64 `json:"counts"`
+	Voters int64           `json:"voters"`
+}
+
+func createPostPollWithTx(tx *gorm.DB, poll *biz.CampusPoll) error {
+	if poll == nil {
+		return nil
+	}
+	now := time.Now()
+	row := campusPostPollModel{
+		PostID:     poll.PostID,
+		ChoiceMode: poll.ChoiceMode,
+		MaxChoices: poll.MaxChoices,
+		ClosesAt:   poll.ClosesAt,
+		Anonymous:  poll.Anonymous,
+		Status:     biz.CampusPollStatusOpen,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := tx.Create(&row).Error; err != nil {
+		return err
+	}
+	options := make([]campusPostPollOptionModel, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		options = append(options, campusPostPollOptionModel{PostID: poll.PostID, OptionID: option.ID, Text: option.Text})
+	}
+	return tx.Create(&options).Error
+}
+
+func (r *campusRepo) GetPostPolls(ctx context.Context, postIDs []int64) (map[int64]*biz.CampusPoll, error) {
+	polls := make(map[int64]*biz.CampusPoll, len(postIDs))
+	if len(postIDs) == 0 {
+		return polls, nil
+	}
+	var rows []campusPostPollModel
+	if err := r.data.db.WithContext(ctx).Where("post_id IN ?", postIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return polls, nil
+	}
+	ids := make([]int64, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		ids = append(ids, row.PostID)
+		polls[row.PostID] = &biz.CampusPoll{
+			PostID:     row.PostID,
+			ChoiceMode: row.ChoiceMode,
+			MaxChoices: row.MaxChoices,
+			ClosesAt:   row.ClosesAt,
+			Anonymous:  row.Anonymous,
+			Status:     row.Status,
+			ClosedAt:   row.ClosedAt,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	var options []campusPostPollOptionModel
+	if err := r.data.db.WithContext(ctx).Where("post_id IN ?", ids).Order("post_id ASC, option_id ASC").Find(&options).Error; err != nil {
+		return nil, err
+	}
+	for _, option := range options {
+		if poll := polls[option.PostID]; poll != nil {
+			poll.Options = append(poll.Options, &biz.CampusPollOption{ID: option.OptionID, Text: option.Text})
+		}
+	}
+	results, err := r.pollResults(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for postID, poll := range polls {
+		result := results[postID]
+		if result == nil {
+			continue
+		}
+		poll.VoterCount = result.Voters
+		for _, option := range poll.Options {
+			option.VoteCount = result.Counts[option.ID]
+		}
+	}
+	return polls, nil
+}
+
+func (r *campusRepo) pollResults(ctx context.Context, postIDs []int64) (map[int64]*campusPollResultCache, error) {
+	results := make(map[int64]*campusPollResultCache, len(postIDs))
+	missing := make([]int64, 0, len(postIDs))
+	for _, postID := range postIDs {
+		var cached campusPollResultCache
+		if r.getCacheJSON(ctx, campusPollResultCacheKey(postID), &cached) {
+			results[postID] = &cached
+			continue
+		}
+		missing = append(missing, postID)
+	}
+	if len(missing) == 0 {
+		return results, nil
+	}
+	for _, postID := range missing {
+		results[postID] = &campusPollResultCache{Counts: map[int32]int64{}}
+	}
+	var counts []struct {
+		PostID   int64 `gorm:"column:post_id"`
+		OptionID int32 `gorm:"column:option_id"`
+		Total    int64 `gorm:"column:total"`
+	}
+	if err := r.data.db.WithContext(ctx).Model(&campusPostPollVoteModel{}).
+		Select("post_id, option_id, COUNT(*) AS total").
+		Where("post_id IN ?", missing).
+		Group("post_id, option_id").
+		Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range counts {
+		results[item.PostID].Counts[item.OptionID] = item.Total
+	}
+	var voters []struct {
+		PostID int64 `gorm:"column:post_id"`
+		Total  int64 `gorm:"column:total"`
+	}
+	if err := r.data.db.WithContext(ctx).Model(&campusPostPollVoterModel{}).
+		Select("post_id, COUNT(*) AS total").
+		Where("post_id IN ?", missing).
+		Group("post_id").
+		Scan(&voters).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range voters {
+		results[item.PostID].Voters = item.Total
+	}
+	for _, postID := range missing {
+		r.setCacheJSON(ctx, campusPollResultCacheKey(postID), results[postID], campusPollResultCacheTTL())
+	}
+	return results, nil
+}
+
+func (r *campusRepo) ListPollChoices(ctx context.Context, userID string, postIDs []int64) (map[int64][]int32, error) {
+	choices := make(map[int64][]int32, len(postIDs))
+	if len(postIDs) == 0 {
+		return choices, nil
+	}
+	var rows []campusPostPollVoteModel
+	if err := r.data.db.WithContext(ctx).
+		Where("post_id IN ? AND user_id = ?", postIDs, parseID(userID)).
+		Order("option_id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		choices[row.PostID] = append(choices[row.PostID], row.OptionID)
+	}
+	return choices, nil
+}
+
+func (r *campusRepo) ListPollVoters(ctx context.Context, postID int64, perOption int) (map[int32][]string, error) {
+	if perOption <= 0 {
+		perOption = 10
+	}
+	var rows []campusPostPollVoteModel
+	if err := r.data.db.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Order("created_at DESC").
+		Limit(perOption * 20).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	voters := map[int32][]string{}
+	for _, row := range rows {
+		if len(voters[row.OptionID]) >= perOption {
+			continue
+		}
+		voters[row.OptionID] = append(voters[row.OptionID], fmt.Sprintf("%d", row.UserID))
+	}
+	return voters, nil
+}
+
+func (r *campusRepo) VotePoll(ctx context.Context, postID int64, userID string, optionIDs []int32) (bool, error) {
+	uid := parseID(userID)
+	now := time.Now()
+	voted := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&campusPostPollVoterModel{PostID: postID, UserID: uid, CreatedAt: now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		rows := make([]campusPostPollVoteModel, 0, len(optionIDs))
+		for _, optionID := range optionIDs {
+			rows = append(rows, campusPostPollVoteModel{PostID: postID, UserID: uid, OptionID: optionID, CreatedAt: now})
+		}
+		if err := tx.Create(&rows).Error; err != nil {
+			return err
+		}
+		voted = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if voted {
+		r.deleteCacheKeys(ctx, campusPollResultCacheKey(postID))
+	}
+	return voted, nil
+}
+
+func (r *campusRepo) ListDuePollPostIDs(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	var postIDs []int64
+	if err := r.data.db.WithContext(ctx).Model(&campusPostPollModel{}).
+		Where("status = ? AND closes_at <= ?", biz.CampusPollStatusOpen, now).
+		Order("closes_at ASC").
+		Limit(limit).
+		Pluck("post_id", &postIDs).Error; err != nil {
+		return nil, err
+	}
+	return postIDs, nil
+}
+
+func (r *campusRepo) ClosePoll(ctx context.Context, postID int64, now time.Time, outbox *biz.CampusNotificationOutbox) (bool, error) {
+	closed := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&campusPostPollModel{}).
+			Where("post_id = ? AND status = ?", postID, biz.CampusPollStatusOpen).
+			Updates(map[string]interface{}{
+				"status":     biz.CampusPollStatusClosed,
+				"closed_at":  now,
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		closed = true
+		return createNotificationOutboxWithTx(tx, outbox)
+	})
+	if err != nil {
+		return false, err
+	}
+	if closed {
+		r.invalidatePostDetailCache(ctx, postID)
+	}
+	return closed, nil
+}
+
+func campusPollResultCacheKey(postID int64) string {
+	return fmt.Sprintf("%s:poll:%d", campusCachePrefix, postID)
+}
+
+func campusPollResultCacheTTL() time.Duration {
+	return campusCacheTTL("LEHU_CACHE_POLL_RESULT_TTL", 5*time.Minute)
+}
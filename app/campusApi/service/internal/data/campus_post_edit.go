@@ -0,0 +1,128 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusPostRevisionModel struct {
+	ID           int64           `gorm:"column:id"`
+	PostID       int64           `gorm:"column:post_id"`
+	Revision     int32           `gorm:"column:revision"`
+	EditorID     int64           `gorm:"column:editor_id"`
+	EditorRole   string          `gorm:"column:editor_role"`
+	Title        string          `gorm:"column:title"`
+	Content      string          `gorm:"column:content"`
+	Images       json.RawMessage `gorm:"column:images"`
+	MediaType    string          `gorm:"column:media_type"`
+	Extra        json.RawMessage `gorm:"column:extra"`
+	CoverURL     string          `gorm:"column:cover_url"`
+	StatusBefore int32           `gorm:"column:status_before"`
+	CreatedAt    time.Time       `gorm:"column:created_at"`
+}
+
+func (campusPostRevisionModel) TableName() string { return "campus_forum_post_revision" }
+
+func (r *campusRepo) UpdatePostContent(ctx context.Context, post *biz.CampusForumPost, revision *biz.CampusPostRevision) error {
+	images, _ := json.Marshal(post.Images)
+	extra, _ := json.Marshal(post.Extra)
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if revision != nil {
+			row := fromBizPostRevision(revision)
+			if err := tx.Create(row).Error; err != nil {
+				return err
+			}
+		}
+		if err := replacePostTagsWithTx(tx, post); err != nil {
+			return err
+		}
+		return tx.Model(&campusForumPostModel{}).
+			Where("id = ? AND is_deleted = ?", post.ID, false).
+			Updates(map[string]interface{}{
+				"title":        post.Title,
+				"content":      post.Content,
+				"images":       images,
+				"media_type":   post.MediaType,
+				"extra":        extra,
+				"cover_url":    post.CoverURL,
+				"status":       post.Status,
+				"audit_reason": post.AuditReason,
+				"edit_count":   post.EditCount,
+				"edited_at":    post.EditedAt,
+				"updated_at":   time.Now(),
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	r.invalidatePostReadCaches(ctx, post.ID, true)
+	r.syncPostSearch(ctx, post.ID, false)
+	return nil
+}
+
+func (r *campusRepo) CreatePostRevision(ctx context.Context, revision *biz.CampusPostRevision) error {
+	return r.data.db.WithContext(ctx).Create(fromBizPostRevision(revision)).Error
+}
+
+func (r *campusRepo) ListPostRevisions(ctx context.Context, postID int64) ([]*biz.CampusPostRevision, error) {
+	var rows []campusPostRevisionModel
+	if err := r.data.db.WithContext(ctx).
+		Where("post_id = ?", postID).
+		Order("revision DESC, id DESC").
+		Limit(100).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	revisions := make([]*biz.CampusPostRevision, 0, len(rows))
+	for i := range rows {
+		revisions = append(revisions, toBizPostRevision(&rows[i]))
+	}
+	return revisions, nil
+}
+
+func fromBizPostRevision(revision *biz.CampusPostRevision) *campusPostRevisionModel {
+	images, _ := json.Marshal(revision.Images)
+	extra, _ := json.Marshal(revision.Extra)
+	return &campusPostRevisionModel{
+		ID:           revision.ID,
+		PostID:       revision.PostID,
+		Revision:     revision.Revision,
+		EditorID:     parseID(revision.EditorID),
+		EditorRole:   revision.EditorRole,
+		Title:        revision.Title,
+		Content:      revision.Content,
+		Images:       images,
+		MediaType:    revision.MediaType,
+		Extra:        extra,
+		CoverURL:     revision.CoverURL,
+		StatusBefore: revision.StatusBefore,
+		CreatedAt:    time.Now(),
+	}
+}
+
+func toBizPostRevision(row *campusPostRevisionModel) *biz.CampusPostRevision {
+	images := make([]string, 0)
+	_ = json.Unmarshal(row.Images, &images)
+	extra := make(map[string]string)
+	_ = json.Unmarshal(row.Extra, &extra)
+	return &biz.CampusPostRevision{
+		ID:           row.ID,
+		PostID:       row.PostID,
+		Revision:     row.Revision,
+		EditorID:     fmt.Sprintf("%d", row.EditorID),
+		EditorRole:   row.EditorRole,
+		Title:        row.Title,
+		Content:      row.Content,
+		Images:       images,
+		MediaType:    row.MediaType,
+		Extra:        extra,
+		CoverURL:     row.CoverURL,
+		StatusBefore: row.StatusBefore,
+		CreatedAt:    row.CreatedAt,
+	}
+}
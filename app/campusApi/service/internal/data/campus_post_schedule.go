@@ -0,0 +1,251 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusPostScheduleModel struct {
+	PostID      int64      `gorm:"column:post_id;primaryKey"`
+	CampusCode  string     `gorm:"column:campus_code"`
+	State       string     `gorm:"column:state"`
+	PublishAt   *time.Time `gorm:"column:publish_at"`
+	IsPinned    bool       `gorm:"column:is_pinned"`
+	IsFeatured  bool       `gorm:"column:is_featured"`
+	SortWeight  int32      `gorm:"column:sort_weight"`
+	CreatedBy   int64      `gorm:"column:created_by"`
+	UpdatedBy   int64      `gorm:"column:updated_by"`
+	LastError   string     `gorm:"column:last_error"`
+	PublishedAt *time.Time `gorm:"column:published_at"`
+	CreatedAt   time.Time  `gorm:"column:created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusPostScheduleModel) TableName() string { return "campus_post_schedule" }
+
+func (r *campusRepo) CreateScheduledPost(ctx context.Context, post *biz.CampusForumPost, schedule *biz.CampusPostSchedule) error {
+	now := time.Now()
+	row := fromBizPostSchedule(schedule)
+	row.CreatedAt, row.UpdatedAt = now, now
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(fromBizPost(post)).Error; err != nil {
+			return err
+		}
+		if err := createPostPollWithTx(tx, post.Poll); err != nil {
+			return err
+		}
+		if err := createQuestionWithTx(tx, post.Question); err != nil {
+			return err
+		}
+		if err := createClubEventWithTx(tx, post.ClubEvent); err != nil {
+			return err
+		}
+		if err := createLostItemWithTx(tx, post.LostItem); err != nil {
+			return err
+		}
+		if err := createPostTagsWithTx(tx, post); err != nil {
+			return err
+		}
+		return tx.Create(row).Error
+	})
+	if err != nil {
+		return err
+	}
+	r.invalidatePostReadCaches(ctx, post.ID, false)
+	return nil
+}
+
+func (r *campusRepo) GetPostSchedule(ctx context.Context, postID int64) (bool, *biz.CampusPostSchedule, error) {
+	var row campusPostScheduleModel
+	err := r.data.db.WithContext(ctx).Where("post_id = ?", postID).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, toBizPostSchedule(&row), nil
+}
+
+func (r *campusRepo) ListPostSchedules(ctx context.Context, campusCode string, states []string, offset, limit int) ([]*biz.CampusPostSchedule, int64, error) {
+	db := r.data.db.WithContext(ctx).Model(&campusPostScheduleModel{}).
+		Joins("JOIN campus_forum_post p ON p.id = campus_post_schedule.post_id AND p.is_deleted = ?", false)
+	if campusCode != "" {
+		db = db.Where("campus_post_schedule.campus_code = ?", campusCode)
+	}
+	if len(states) > 0 {
+		db = db.Where("campus_post_schedule.state IN ?", states)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusPostScheduleModel
+	if err := db.Select("campus_post_schedule.*").
+		Order("campus_post_schedule.publish_at IS NULL ASC, campus_post_schedule.publish_at ASC, campus_post_schedule.updated_at DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	schedules := make([]*biz.CampusPostSchedule, 0, len(rows))
+	for i := range rows {
+		schedules = append(schedules, toBizPostSchedule(&rows[i]))
+	}
+	return schedules, total, nil
+}
+
+func (r *campusRepo) UpdatePostSchedule(ctx context.Context, schedule *biz.CampusPostSchedule, postStatus int32) error {
+	now := time.Now()
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&campusPostScheduleModel{}).
+			Where("post_id = ? AND state <> ?", schedule.PostID, biz.CampusPostSchedulePublished).
+			Updates(map[string]interface{}{
+				"state":       schedule.State,
+				"publish_at":  schedule.PublishAt,
+				"is_pinned":   schedule.IsPinned,
+				"is_featured": schedule.IsFeatured,
+				"sort_weight": schedule.SortWeight,
+				"updated_by":  parseID(schedule.UpdatedBy),
+				"last_error":  schedule.LastError,
+				"updated_at":  now,
+			}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&campusForumPostModel{}).
+			Where("id = ? AND status IN ?", schedule.PostID, []int32{biz.CampusAuditStatusDraft, biz.CampusAuditStatusScheduled}).
+			Updates(map[string]interface{}{
+				"status":     postStatus,
+				"updated_at": now,
+			}).Error
+	})
+	if err != nil {
+		return err
+	}
+	r.invalidatePostReadCaches(ctx, schedule.PostID, false)
+	return nil
+}
+
+func (r *campusRepo) ListDuePostSchedules(ctx context.Context, now time.Time, limit int) ([]*biz.CampusPostSchedule, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []campusPostScheduleModel
+	if err := r.data.db.WithContext(ctx).
+		Where("state = ? AND publish_at <= ?", biz.CampusPostScheduleScheduled, now).
+		Order("publish_at ASC, post_id ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	schedules := make([]*biz.CampusPostSchedule, 0, len(rows))
+	for i := range rows {
+		schedules = append(schedules, toBizPostSchedule(&rows[i]))
+	}
+	return schedules, nil
+}
+
+func (r *campusRepo) PublishScheduledPost(ctx context.Context, postID int64, now time.Time) (bool, error) {
+	published := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var row campusPostScheduleModel
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("post_id = ? AND state = ? AND publish_at <= ?", postID, biz.CampusPostScheduleScheduled, now).
+			Take(&row).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&campusForumPostModel{}).
+			Where("id = ? AND status = ? AND is_deleted = ?", postID, biz.CampusAuditStatusScheduled, false).
+			Updates(map[string]interface{}{
+				"status":       biz.CampusAuditStatusVisible,
+				"audit_reason": "",
+				"is_pinned":    row.IsPinned,
+				"is_featured":  row.IsFeatured,
+				"sort_weight":  row.SortWeight,
+				"created_at":   now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		updates := map[string]interface{}{
+			"state":        biz.CampusPostSchedulePublished,
+			"last_error":   "",
+			"published_at": now,
+			"updated_at":   now,
+		}
+		if result.RowsAffected == 0 {
+			updates = map[string]interface{}{
+				"state":      biz.CampusPostScheduleDraft,
+				"publish_at": nil,
+				"last_error": "帖子已删除或状态已变更，定时发布已取消",
+				"updated_at": now,
+			}
+		}
+		if err := tx.Model(&campusPostScheduleModel{}).Where("post_id = ?", postID).Updates(updates).Error; err != nil {
+			return err
+		}
+		published = result.RowsAffected > 0
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	if published {
+		r.invalidatePostReadCaches(ctx, postID, true)
+		r.syncPostSearch(ctx, postID, false)
+	}
+	return published, nil
+}
+
+func (r *campusRepo) MarkPostScheduleError(ctx context.Context, postID int64, message string) error {
+	return r.data.db.WithContext(ctx).Model(&campusPostScheduleModel{}).
+		Where("post_id = ? AND state = ?", postID, biz.CampusPostScheduleScheduled).
+		Updates(map[string]interface{}{
+			"last_error": message,
+			"updated_at": time.Now(),
+		}).Error
+}
+
+func fromBizPostSchedule(schedule *biz.CampusPostSchedule) *campusPostScheduleModel {
+	return &campusPostScheduleModel{
+		PostID:     schedule.PostID,
+		CampusCode: schedule.CampusCode,
+		State:      schedule.State,
+		PublishAt:  schedule.PublishAt,
+		IsPinned:   schedule.IsPinned,
+		IsFeatured: schedule.IsFeatured,
+		SortWeight: schedule.SortWeight,
+		CreatedBy:  parseID(schedule.CreatedBy),
+		UpdatedBy:  parseID(schedule.UpdatedBy),
+		LastError:  schedule.LastError,
+	}
+}
+
+func toBizPostSchedule(row *campusPostScheduleModel) *biz.CampusPostSchedule {
+	return &biz.CampusPostSchedule{
+		PostID:      row.PostID,
+		CampusCode:  row.CampusCode,
+		State:       row.State,
+		PublishAt:   row.PublishAt,
+		IsPinned:    row.IsPinned,
+		IsFeatured:  row.IsFeatured,
+		SortWeight:  row.SortWeight,
+		CreatedBy:   fmt.Sprintf("%d", row.CreatedBy),
+		UpdatedBy:   fmt.Sprintf("%d", row.UpdatedBy),
+		LastError:   row.LastError,
+		PublishedAt: row.PublishedAt,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+	}
+}
@@ -0,0 +1,180 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusQuestionModel struct {
+	PostID            int64      `gorm:"column:post_id;primaryKey"`
+	CampusCode        string     `gorm:"column:campus_code"`
+	AuthorID          int64      `gorm:"column:author_id"`
+	AcceptedCommentID int64      `gorm:"column:accepted_comment_id"`
+	AcceptedAt        *time.Time `gorm:"column:accepted_at"`
+	AnsweredAt        *time.Time `gorm:"column:answered_at"`
+	AITaskID          int64      `gorm:"column:ai_task_id"`
+	AIQueuedAt        *time.Time `gorm:"column:ai_queued_at"`
+	CreatedAt         time.Time  `gorm:"column:created_at"`
+	UpdatedAt         time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusQuestionModel) TableName() string { return "campus_question" }
+
+func createQuestionWithTx(tx *gorm.DB, question *biz.CampusQuestion) error {
+	if question == nil {
+		return nil
+	}
+	now := time.Now()
+	return tx.Create(&campusQuestionModel{
+		PostID:     question.PostID,
+		CampusCode: question.CampusCode,
+		AuthorID:   parseID(question.AuthorID),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}).Error
+}
+
+func (r *campusRepo) ListQuestionsByPostIDs(ctx context.Context, postIDs []int64) (map[int64]*biz.CampusQuestion, error) {
+	questions := make(map[int64]*biz.CampusQuestion, len(postIDs))
+	if len(postIDs) == 0 {
+		return questions, nil
+	}
+	var rows []campusQuestionModel
+	if err := r.data.db.WithContext(ctx).Where("post_id IN ?", postIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		questions[rows[i].PostID] = toBizQuestion(&rows[i])
+	}
+	return questions, nil
+}
+
+func (r *campusRepo) AcceptAnswer(ctx context.Context, postID, commentID int64, now time.Time, outbox *biz.CampusNotificationOutbox) error {
+	updates := map[string]interface{}{
+		"accepted_comment_id": commentID,
+		"accepted_at":         nil,
+		"updated_at":          now,
+	}
+	if commentID > 0 {
+		updates["accepted_at"] = now
+		updates["answered_at"] = gorm.Expr("COALESCE(answered_at, ?)", now)
+	}
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&campusQuestionModel{}).Where("post_id = ?", postID).Updates(updates).Error; err != nil {
+			return err
+		}
+		return createNotificationOutboxWithTx(tx, outbox)
+	})
+	if err != nil {
+		return err
+	}
+	r.invalidatePostDetailCache(ctx, postID)
+	return nil
+}
+
+func (r *campusRepo) MarkQuestionAnswered(ctx context.Context, postID int64, now time.Time) error {
+	return r.data.db.WithContext(ctx).Model(&campusQuestionModel{}).
+		Where("post_id = ? AND answered_at IS NULL", postID).
+		Updates(map[string]interface{}{
+			"answered_at": now,
+			"updated_at":  now,
+		}).Error
+}
+
+func (r *campusRepo) ListQuestions(ctx context.Context, campusCode, state string, offset, limit int) ([]*biz.CampusQuestion, int64, error) {
+	db := r.data.db.WithContext(ctx).Model(&campusQuestionModel{}).
+		Joins("JOIN campus_forum_post p ON p.id = campus_question.post_id AND p.is_deleted = ? AND p.status = ?", false, biz.CampusAuditStatusVisible).
+		Where("campus_question.accepted_comment_id = ?", 0)
+	if campusCode != "" {
+		db = db.Where("campus_question.campus_code = ?", campusCode)
+	}
+	if state == biz.CampusQuestionStateUnanswered {
+		db = db.Where("campus_question.answered_at IS NULL")
+	} else {
+		db = db.Where("campus_question.answered_at IS NOT NULL")
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusQuestionModel
+	if err := db.Select("campus_question.*").
+		Order("p.created_at ASC, campus_question.post_id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	questions := make([]*biz.CampusQuestion, 0, len(rows))
+	for i := range rows {
+		questions = append(questions, toBizQuestion(&rows[i]))
+	}
+	return questions, total, nil
+}
+
+func (r *campusRepo) ListAIDueQuestions(ctx context.Context, before time.Time, limit int) ([]*biz.CampusQuestion, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	var rows []campusQuestionModel
+	if err := r.data.db.WithContext(ctx).Model(&campusQuestionModel{}).
+		Joins("JOIN campus_forum_post p ON p.id = campus_question.post_id AND p.is_deleted = ? AND p.status = ?", false, biz.CampusAuditStatusVisible).
+		Where("campus_question.accepted_comment_id = ? AND campus_question.answered_at IS NULL AND campus_question.ai_task_id = ?", 0, 0).
+		Where("p.created_at <= ?", before).
+		Select("campus_question.*").
+		Order("p.created_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	questions := make([]*biz.CampusQuestion, 0, len(rows))
+	for i := range rows {
+		questions = append(questions, toBizQuestion(&rows[i]))
+	}
+	return questions, nil
+}
+
+func (r *campusRepo) QueueQuestionAIReply(ctx context.Context, postID int64, task *biz.CampusAIReplyTask) (bool, error) {
+	queued := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&campusQuestionModel{}).
+			Where("post_id = ? AND ai_task_id = ? AND answered_at IS NULL AND accepted_comment_id = ?", postID, 0, 0).
+			Updates(map[string]interface{}{
+				"ai_task_id":   task.ID,
+				"ai_queued_at": now,
+				"updated_at":   now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		row := toAIReplyTaskModel(task)
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+		queued = true
+		return nil
+	})
+	return queued, err
+}
+
+func toBizQuestion(row *campusQuestionModel) *biz.CampusQuestion {
+	return &biz.CampusQuestion{
+		PostID:            row.PostID,
+		CampusCode:        row.CampusCode,
+		AuthorID:          fmt.Sprintf("%d", row.AuthorID),
+		AcceptedCommentID: row.AcceptedCommentID,
+		AcceptedAt:        row.AcceptedAt,
+		AnsweredAt:        row.AnsweredAt,
+		AITaskID:          row.AITaskID,
+		AIQueuedAt:        row.AIQueuedAt,
+		CreatedAt:         row.CreatedAt,
+	}
+}
@@ -0,0 +1,129 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusReportGroupRow struct {
+	TargetType      string    `gorm:"column:target_type"`
+	TargetID        int64     `gorm:"column:target_id"`
+	ReportCount     int64     `gorm:"column:report_count"`
+	PendingCount    int64     `gorm:"column:pending_count"`
+	FirstReportedAt time.Time `gorm:"column:first_reported_at"`
+	LastReportedAt  time.Time `gorm:"column:last_reported_at"`
+}
+
+type campusReporterStatsRow struct {
+	ReporterID int64 `gorm:"column:reporter_id"`
+	Upheld     int64 `gorm:"column:upheld"`
+	Dismissed  int64 `gorm:"column:dismissed"`
+}
+
+func (r *campusRepo) ListReportGroups(ctx context.Context, query *biz.CampusReportQuery) ([]*biz.CampusReportGroup, int64, error) {
+	var total int64
+	sub := r.reportScope(ctx, query).Select("target_type, target_id").Group("target_type, target_id")
+	if err := r.data.db.WithContext(ctx).Table("(?) AS report_group", sub).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusReportGroupRow
+	if err := r.reportScope(ctx, query).
+		Select("target_type, target_id, COUNT(*) AS report_count, SUM(status = ?) AS pending_count, MIN(created_at) AS first_reported_at, MAX(created_at) AS last_reported_at", biz.CampusAuditStatusPending).
+		Group("target_type, target_id").
+		Order("pending_count DESC, last_reported_at DESC").
+		Offset(query.Offset).
+		Limit(query.Limit).
+		Scan(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	groups := make([]*biz.CampusReportGroup, 0, len(rows))
+	if len(rows) == 0 {
+		return groups, total, nil
+	}
+	byTarget := make(map[string]*biz.CampusReportGroup, len(rows))
+	postIDs := make([]int64, 0, len(rows))
+	commentIDs := make([]int64, 0, len(rows))
+	for _, row := range rows {
+		group := &biz.CampusReportGroup{
+			TargetType:      row.TargetType,
+			TargetID:        row.TargetID,
+			ReportCount:     row.ReportCount,
+			PendingCount:    row.PendingCount,
+			FirstReportedAt: row.FirstReportedAt,
+			LastReportedAt:  row.LastReportedAt,
+		}
+		groups = append(groups, group)
+		byTarget[fmt.Sprintf("%s:%d", row.TargetType, row.TargetID)] = group
+		if row.TargetType == "comment" {
+			commentIDs = append(commentIDs, row.TargetID)
+		} else {
+			postIDs = append(postIDs, row.TargetID)
+		}
+	}
+	db := r.data.db.WithContext(ctx).Model(&campusForumReportModel{})
+	if query.Status >= 0 {
+		db = db.Where("status = ?", query.Status)
+	}
+	var reportRows []campusForumReportModel
+	if err := db.Where("(target_type = 'post' AND target_id IN ?) OR (target_type = 'comment' AND target_id IN ?)", append(postIDs, 0), append(commentIDs, 0)).
+		Order("created_at ASC, id ASC").
+		Find(&reportRows).Error; err != nil {
+		return nil, 0, err
+	}
+	reports := make([]*biz.CampusForumReport, 0, len(reportRows))
+	for i := range reportRows {
+		reports = append(reports, toBizReport(&reportRows[i]))
+	}
+	if err := r.fillReports(ctx, reports); err != nil {
+		return nil, 0, err
+	}
+	for _, report := range reports {
+		if group := byTarget[fmt.Sprintf("%s:%d", report.TargetType, report.TargetID)]; group != nil {
+			group.Reports = append(group.Reports, report)
+		}
+	}
+	return groups, total, nil
+}
+
+func (r *campusRepo) ListReporterStats(ctx context.Context, reporterIDs []string) (map[string]*biz.CampusReporterStats, error) {
+	out := map[string]*biz.CampusReporterStats{}
+	ids := make([]int64, 0, len(reporterIDs))
+	for _, reporterID := range reporterIDs {
+		if id := parseID(reporterID); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var rows []campusReporterStatsRow
+	if err := r.data.db.WithContext(ctx).Model(&campusForumReportModel{}).
+		Select("reporter_id, SUM(status = ?) AS upheld, SUM(status = ?) AS dismissed", biz.CampusReportStatusUpheld, biz.CampusReportStatusDismissed).
+		Where("reporter_id IN ? AND status IN ?", ids, []int32{biz.CampusReportStatusUpheld, biz.CampusReportStatusDismissed}).
+		Group("reporter_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[fmt.Sprintf("%d", row.ReporterID)] = &biz.CampusReporterStats{Upheld: row.Upheld, Dismissed: row.Dismissed}
+	}
+	var profiles []campusProfileModel
+	if err := r.data.db.WithContext(ctx).
+		Select("user_id, auth_status, created_at").
+		Where("user_id IN ?", ids).
+		Find(&profiles).Error; err != nil {
+		return nil, err
+	}
+	for _, profile := range profiles {
+		key := fmt.Sprintf("%d", profile.UserID)
+		if out[key] == nil {
+			out[key] = &biz.CampusReporterStats{}
+		}
+		out[key].Verified = profile.AuthStatus == biz.CampusAuthStatusVerified
+		out[key].JoinedAt = profile.CreatedAt
+	}
+	return out, nil
+}
@@ -0,0 +1,205 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusUserSanctionModel struct {
+	ID           int64      `gorm:"column:id"`
+	UserID       int64      `gorm:"column:user_id"`
+	SanctionType string     `gorm:"column:sanction_type"`
+	Reason       string     `gorm:"column:reason"`
+	AppealNote   string     `gorm:"column:appeal_note"`
+	Status       string     `gorm:"column:status"`
+	StartsAt     time.Time  `gorm:"column:starts_at"`
+	ExpiresAt    *time.Time `gorm:"column:expires_at"`
+	CreatedBy    int64      `gorm:"column:created_by"`
+	RevokedBy    int64      `gorm:"column:revoked_by"`
+	RevokedAt    *time.Time `gorm:"column:revoked_at"`
+	RevokeReason string     `gorm:"column:revoke_reason"`
+	CreatedAt    time.Time  `gorm:"column:created_at"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at"`
+}
+
+func (campusUserSanctionModel) TableName() string { return "campus_user_sanction" }
+
+func campusUserSanctionFromModel(row campusUserSanctionModel) *biz.CampusUserSanction {
+	sanction := &biz.CampusUserSanction{
+		ID:           row.ID,
+		UserID:       fmt.Sprintf("%d", row.UserID),
+		Type:         row.SanctionType,
+		Reason:       row.Reason,
+		AppealNote:   row.AppealNote,
+		Status:       row.Status,
+		StartsAt:     row.StartsAt,
+		ExpiresAt:    row.ExpiresAt,
+		CreatedBy:    fmt.Sprintf("%d", row.CreatedBy),
+		RevokedAt:    row.RevokedAt,
+		RevokeReason: row.RevokeReason,
+		CreatedAt:    row.CreatedAt,
+	}
+	if row.RevokedBy > 0 {
+		sanction.RevokedBy = fmt.Sprintf("%d", row.RevokedBy)
+	}
+	return sanction
+}
+
+func (r *campusRepo) CreateUserSanction(ctx context.Context, sanction *biz.CampusUserSanction) error {
+	now := time.Now()
+	defer r.deleteCacheKeys(ctx, campusUserSanctionsCacheKey(parseID(sanction.UserID)))
+	return r.data.db.WithContext(ctx).Create(&campusUserSanctionModel{
+		ID:           sanction.ID,
+		UserID:       parseID(sanction.UserID),
+		SanctionType: sanction.Type,
+		Reason:       trimLimitData(sanction.Reason, 255),
+		AppealNote:   trimLimitData(sanction.AppealNote, 255),
+		Status:       sanction.Status,
+		StartsAt:     sanction.StartsAt,
+		ExpiresAt:    sanction.ExpiresAt,
+		CreatedBy:    parseID(sanction.CreatedBy),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}).Error
+}
+
+func (r *campusRepo) GetUserSanction(ctx context.Context, id int64) (bool, *biz.CampusUserSanction, error) {
+	var row campusUserSanctionModel
+	err := r.data.db.WithContext(ctx).Where("id = ?", id).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, campusUserSanctionFromModel(row), nil
+}
+
+func (r *campusRepo) ListUserSanctions(ctx context.Context, userID string, limit int) ([]*biz.CampusUserSanction, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	var rows []campusUserSanctionModel
+	if err := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).
+		Where("user_id = ?", parseID(userID)).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusUserSanction, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, campusUserSanctionFromModel(row))
+	}
+	return out, nil
+}
+
+func (r *campusRepo) ListActiveUserSanctions(ctx context.Context, userIDs []string) (map[string][]*biz.CampusUserSanction, error) {
+	out := map[string][]*biz.CampusUserSanction{}
+	ids := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if id := parseID(userID); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	var rows []campusUserSanctionModel
+	if err := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).
+		Where("user_id IN ? AND status = ?", ids, biz.CampusSanctionStatusActive).
+		Where("(expires_at IS NULL OR expires_at > ?)", time.Now()).
+		Order("created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		sanction := campusUserSanctionFromModel(row)
+		out[sanction.UserID] = append(out[sanction.UserID], sanction)
+	}
+	return out, nil
+}
+
+// 用户当前生效的处罚，发帖评论时每次都会查，走 Redis 缓存；处罚创建、解除、到期时删除缓存
+func (r *campusRepo) GetActiveUserSanctions(ctx context.Context, userID string) ([]*biz.CampusUserSanction, error) {
+	id := parseID(userID)
+	if id <= 0 {
+		return nil, nil
+	}
+	key := campusUserSanctionsCacheKey(id)
+	var cached []*biz.CampusUserSanction
+	if r.getCacheJSON(ctx, key, &cached) {
+		return cached, nil
+	}
+	active, err := r.ListActiveUserSanctions(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	sanctions := active[fmt.Sprintf("%d", id)]
+	if sanctions == nil {
+		sanctions = []*biz.CampusUserSanction{}
+	}
+	r.setCacheJSON(ctx, key, sanctions, campusUserSanctionsCacheTTL())
+	return sanctions, nil
+}
+
+func (r *campusRepo) invalidateUserSanctionsCache(ctx context.Context, sanctionID int64) {
+	if !r.cacheEnabled() {
+		return
+	}
+	var userIDs []int64
+	if err := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).Where("id = ?", sanctionID).Pluck("user_id", &userIDs).Error; err != nil {
+		r.log.WithContext(ctx).Warnf("load sanction user failed: id=%d err=%v", sanctionID, err)
+		return
+	}
+	for _, userID := range userIDs {
+		r.deleteCacheKeys(ctx, campusUserSanctionsCacheKey(userID))
+	}
+}
+
+func (r *campusRepo) RevokeUserSanction(ctx context.Context, id int64, revokedBy, reason string) (bool, error) {
+	now := time.Now()
+	defer r.invalidateUserSanctionsCache(ctx, id)
+	result := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).
+		Where("id = ? AND status = ?", id, biz.CampusSanctionStatusActive).
+		Updates(map[string]interface{}{
+			"status":        biz.CampusSanctionStatusRevoked,
+			"revoked_by":    parseID(revokedBy),
+			"revoked_at":    now,
+			"revoke_reason": trimLimitData(reason, 255),
+			"updated_at":    now,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *campusRepo) ListDueUserSanctions(ctx context.Context, now time.Time, limit int) ([]*biz.CampusUserSanction, error) {
+	var rows []campusUserSanctionModel
+	if err := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at <= ?", biz.CampusSanctionStatusActive, now).
+		Order("expires_at ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]*biz.CampusUserSanction, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, campusUserSanctionFromModel(row))
+	}
+	return out, nil
+}
+
+func (r *campusRepo) ExpireUserSanction(ctx context.Context, id int64) (bool, error) {
+	defer r.invalidateUserSanctionsCache(ctx, id)
+	result := r.data.db.WithContext(ctx).Model(&campusUserSanctionModel{}).
+		Where("id = ? AND status = ?", id, biz.CampusSanctionStatusActive).
+		Updates(map[string]interface{}{
+			"status":     biz.CampusSanctionStatusExpired,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected > 0, result.Error
+}
@@ -0,0 +1,379 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+const campusSearchMatchExpr = "MATCH(title, content) AGAINST (? IN NATURAL LANGUAGE MODE)"
+
+type campusSearchDocumentModel struct {
+	DocType       string    `gorm:"column:doc_type;primaryKey"`
+	DocID         int64     `gorm:"column:doc_id;primaryKey"`
+	PostID        int64     `gorm:"column:post_id"`
+	CampusCode    string    `gorm:"column:campus_code"`
+	IsCrossCampus bool      `gorm:"column:is_cross_campus"`
+	CategoryCode  string    `gorm:"column:category_code"`
+	PostType      string    `gorm:"column:post_type"`
+	AuthorID      int64     `gorm:"column:author_id"`
+	Title         string    `gorm:"column:title"`
+	Content       string    `gorm:"column:content"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+	IndexedAt     time.Time `gorm:"column:indexed_at"`
+}
+
+func (campusSearchDocumentModel) TableName() string { return "campus_search_document" }
+
+type campusSearchHitRow struct {
+	campusSearchDocumentModel
+	Score float64 `gorm:"column:score"`
+}
+
+type campusMySQLSearchIndex struct {
+	data *Data
+}
+
+func NewCampusSearchIndex(data *Data, logger log.Logger) (biz.CampusSearchIndex, func(), error) {
+	backend, err := biz.CampusSearchBackendFromEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	helper := log.NewHelper(logger)
+	if backend == biz.CampusSearchBackendBleve {
+		path := campusBleveSearchPath()
+		idx, err := newCampusBleveSearchIndex(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		helper.Infof("campus search backend: %s path=%s", backend, path)
+		return idx, func() {
+			if err := idx.Close(); err != nil {
+				helper.Warnf("close bleve search index error: %v", err)
+			}
+		}, nil
+	}
+	helper.Infof("campus search backend: %s", backend)
+	return &campusMySQLSearchIndex{data: data}, func() {}, nil
+}
+
+func (idx *campusMySQLSearchIndex) Backend() string {
+	return biz.CampusSearchBackendMySQL
+}
+
+func (idx *campusMySQLSearchIndex) Upsert(ctx context.Context, docs []*biz.CampusSearchDocument) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]campusSearchDocumentModel, 0, len(docs))
+	for _, doc := range docs {
+		if doc == nil || doc.DocID <= 0 {
+			continue
+		}
+		rows = append(rows, campusSearchDocumentModel{
+			DocType:       doc.DocType,
+			DocID:         doc.DocID,
+			PostID:        doc.PostID,
+			CampusCode:    doc.CampusCode,
+			IsCrossCampus: doc.IsCrossCampus,
+			CategoryCode:  doc.CategoryCode,
+			PostType:      doc.PostType,
+			AuthorID:      parseID(doc.AuthorID),
+			Title:         doc.Title,
+			Content:       doc.Content,
+			CreatedAt:     doc.CreatedAt,
+			IndexedAt:     now,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	return idx.data.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "doc_type"}, {Name: "doc_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"post_id", "campus_code", "is_cross_campus", "category_code", "post_type",
+			"author_id", "title", "content", "created_at", "indexed_at",
+		}),
+	}).Create(&rows).Error
+}
+
+func (idx *campusMySQLSearchIndex) Delete(ctx context.Context, docType string, docIDs []int64) error {
+	if len(docIDs) == 0 {
+		return nil
+	}
+	return idx.data.db.WithContext(ctx).
+		Where("doc_type = ? AND doc_id IN ?", docType, docIDs).
+		Delete(&campusSearchDocumentModel{}).Error
+}
+
+func (idx *campusMySQLSearchIndex) DeleteByPost(ctx context.Context, postID int64) error {
+	return idx.data.db.WithContext(ctx).
+		Where("doc_type = ? AND post_id = ?", biz.CampusSearchDocComment, postID).
+		Delete(&campusSearchDocumentModel{}).Error
+}
+
+func (idx *campusMySQLSearchIndex) Prune(ctx context.Context, indexedBefore time.Time) (int64, error) {
+	result := idx.data.db.WithContext(ctx).
+		Where("indexed_at < ?", indexedBefore).
+		Delete(&campusSearchDocumentModel{})
+	return result.RowsAffected, result.Error
+}
+
+func (idx *campusMySQLSearchIndex) Count(ctx context.Context) (int64, error) {
+	var total int64
+	err := idx.data.db.WithContext(ctx).Model(&campusSearchDocumentModel{}).Count(&total).Error
+	return total, err
+}
+
+func (idx *campusMySQLSearchIndex) Search(ctx context.Context, query *biz.CampusSearchQuery) ([]*biz.CampusSearchHit, int64, error) {
+	filter := func(db *gorm.DB) *gorm.DB {
+		db = db.Where(campusSearchMatchExpr, query.Keyword)
+		if len(query.DocTypes) > 0 {
+			db = db.Where("doc_type IN ?", query.DocTypes)
+		}
+		if query.CampusCode != "" {
+			db = db.Where("(campus_code = ? OR is_cross_campus = ?)", query.CampusCode, true)
+		}
+		if query.CategoryCode != "" {
+			db = db.Where("category_code = ?", query.CategoryCode)
+		}
+		if query.PostType != "" {
+			db = db.Where("post_type = ?", query.PostType)
+		}
+		if len(query.ExcludeAuthorIDs) > 0 {
+			db = db.Where("author_id NOT IN ?", parseIDs(query.ExcludeAuthorIDs))
+		}
+		return db
+	}
+	var total int64
+	if err := filter(idx.data.db.WithContext(ctx).Model(&campusSearchDocumentModel{})).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if total == 0 {
+		return []*biz.CampusSearchHit{}, 0, nil
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	var rows []campusSearchHitRow
+	if err := filter(idx.data.db.WithContext(ctx).Model(&campusSearchDocumentModel{})).
+		Select("*, MATCH(title) AGAINST (? IN NATURAL LANGUAGE MODE) * 2 + "+campusSearchMatchExpr+" AS score", query.Keyword, query.Keyword).
+		Order("score DESC, created_at DESC").
+		Offset(query.Offset).
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	hits := make([]*biz.CampusSearchHit, 0, len(rows))
+	for i := range rows {
+		hits = append(hits, &biz.CampusSearchHit{Document: toBizSearchDocument(&rows[i].campusSearchDocumentModel), Score: rows[i].Score})
+	}
+	return hits, total, nil
+}
+
+func (r *campusRepo) ListSearchDocuments(ctx context.Context, docType string, afterID int64, limit int) ([]*biz.CampusSearchDocument, error) {
+	if limit <= 0 {
+		limit = 200
+	}
+	switch docType {
+	case biz.CampusSearchDocPost:
+		var rows []campusForumPostModel
+		if err := r.data.db.WithContext(ctx).
+			Where("id > ? AND status = ? AND is_deleted = ?", afterID, biz.CampusAuditStatusVisible, false).
+			Order("id ASC").
+			Limit(limit).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		out := make([]*biz.CampusSearchDocument, 0, len(rows))
+		for i := range rows {
+			out = append(out, postSearchDocument(&rows[i]))
+		}
+		return out, nil
+	case biz.CampusSearchDocComment:
+		var rows []campusForumCommentModel
+		if err := r.data.db.WithContext(ctx).
+			Where("id > ? AND status = ? AND is_deleted = ?", afterID, biz.CampusAuditStatusVisible, false).
+			Order("id ASC").
+			Limit(limit).
+			Find(&rows).Error; err != nil {
+			return nil, err
+		}
+		return r.commentSearchDocuments(ctx, rows)
+	default:
+		return nil, fmt.Errorf("unknown search doc type: %s", docType)
+	}
+}
+
+func (r *campusRepo) commentSearchDocuments(ctx context.Context, rows []campusForumCommentModel) ([]*biz.CampusSearchDocument, error) {
+	postIDs := make([]int64, 0, len(rows))
+	for i := range rows {
+		postIDs = append(postIDs, rows[i].PostID)
+	}
+	posts := map[int64]*campusForumPostModel{}
+	if len(postIDs) > 0 {
+		var postRows []campusForumPostModel
+		if err := r.data.db.WithContext(ctx).
+			Where("id IN ? AND status = ? AND is_deleted = ?", postIDs, biz.CampusAuditStatusVisible, false).
+			Find(&postRows).Error; err != nil {
+			return nil, err
+		}
+		for i := range postRows {
+			posts[postRows[i].ID] = &postRows[i]
+		}
+	}
+	out := make([]*biz.CampusSearchDocument, 0, len(rows))
+	for i := range rows {
+		post := posts[rows[i].PostID]
+		if post == nil {
+			continue
+		}
+		out = append(out, &biz.CampusSearchDocument{
+			DocType:       biz.CampusSearchDocComment,
+			DocID:         rows[i].ID,
+			PostID:        post.ID,
+			CampusCode:    post.CampusCode,
+			IsCrossCampus: post.IsCrossCampus,
+			CategoryCode:  post.CategoryCode,
+			PostType:      post.PostType,
+			AuthorID:      fmt.Sprintf("%d", rows[i].AuthorID),
+			Content:       rows[i].Content,
+			CreatedAt:     rows[i].CreatedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *campusRepo) syncPostSearch(ctx context.Context, postID int64, withComments bool) {
+	if r.search == nil || postID <= 0 {
+		return
+	}
+	var row campusForumPostModel
+	err := r.data.db.WithContext(ctx).Where("id = ?", postID).First(&row).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		r.log.WithContext(ctx).Warnf("load post for search index failed: post_id=%d err=%v", postID, err)
+		return
+	}
+	if err != nil || row.IsDeleted || row.Status != biz.CampusAuditStatusVisible {
+		if err := r.search.Delete(ctx, biz.CampusSearchDocPost, []int64{postID}); err != nil {
+			r.log.WithContext(ctx).Warnf("delete post search doc failed: post_id=%d err=%v", postID, err)
+		}
+		if err := r.search.DeleteByPost(ctx, postID); err != nil {
+			r.log.WithContext(ctx).Warnf("delete comment search docs failed: post_id=%d err=%v", postID, err)
+		}
+		return
+	}
+	if err := r.search.Upsert(ctx, []*biz.CampusSearchDocument{postSearchDocument(&row)}); err != nil {
+		r.log.WithContext(ctx).Warnf("upsert post search doc failed: post_id=%d err=%v", postID, err)
+	}
+	if !withComments {
+		return
+	}
+	var afterID int64
+	for {
+		var comments []campusForumCommentModel
+		if err := r.data.db.WithContext(ctx).
+			Where("post_id = ? AND id > ? AND status = ? AND is_deleted = ?", postID, afterID, biz.CampusAuditStatusVisible, false).
+			Order("id ASC").
+			Limit(200).
+			Find(&comments).Error; err != nil {
+			r.log.WithContext(ctx).Warnf("load comments for search index failed: post_id=%d err=%v", postID, err)
+			return
+		}
+		if len(comments) == 0 {
+			return
+		}
+		docs, err := r.commentSearchDocuments(ctx, comments)
+		if err == nil {
+			err = r.search.Upsert(ctx, docs)
+		}
+		if err != nil {
+			r.log.WithContext(ctx).Warnf("upsert comment search docs failed: post_id=%d err=%v", postID, err)
+			return
+		}
+		afterID = comments[len(comments)-1].ID
+	}
+}
+
+func (r *campusRepo) syncCommentSearch(ctx context.Context, commentID int64) {
+	if r.search == nil || commentID <= 0 {
+		return
+	}
+	var rows []campusForumCommentModel
+	if err := r.data.db.WithContext(ctx).
+		Where("id = ? OR parent_id = ?", commentID, commentID).
+		Find(&rows).Error; err != nil {
+		r.log.WithContext(ctx).Warnf("load comment for search index failed: comment_id=%d err=%v", commentID, err)
+		return
+	}
+	visible := make([]campusForumCommentModel, 0, len(rows))
+	hidden := make([]int64, 0, len(rows))
+	for i := range rows {
+		if rows[i].IsDeleted || rows[i].Status != biz.CampusAuditStatusVisible {
+			hidden = append(hidden, rows[i].ID)
+			continue
+		}
+		visible = append(visible, rows[i])
+	}
+	docs, err := r.commentSearchDocuments(ctx, visible)
+	if err != nil {
+		r.log.WithContext(ctx).Warnf("build comment search docs failed: comment_id=%d err=%v", commentID, err)
+		return
+	}
+	indexed := make(map[int64]bool, len(docs))
+	for _, doc := range docs {
+		indexed[doc.DocID] = true
+	}
+	for i := range visible {
+		if !indexed[visible[i].ID] {
+			hidden = append(hidden, visible[i].ID)
+		}
+	}
+	if err := r.search.Upsert(ctx, docs); err != nil {
+		r.log.WithContext(ctx).Warnf("upsert comment search docs failed: comment_id=%d err=%v", commentID, err)
+	}
+	if err := r.search.Delete(ctx, biz.CampusSearchDocComment, hidden); err != nil {
+		r.log.WithContext(ctx).Warnf("delete comment search docs failed: comment_id=%d err=%v", commentID, err)
+	}
+}
+
+func postSearchDocument(row *campusForumPostModel) *biz.CampusSearchDocument {
+	return &biz.CampusSearchDocument{
+		DocType:       biz.CampusSearchDocPost,
+		DocID:         row.ID,
+		PostID:        row.ID,
+		CampusCode:    row.CampusCode,
+		IsCrossCampus: row.IsCrossCampus,
+		CategoryCode:  row.CategoryCode,
+		PostType:      row.PostType,
+		AuthorID:      fmt.Sprintf("%d", row.AuthorID),
+		Title:         row.Title,
+		Content:       row.Content,
+		CreatedAt:     row.CreatedAt,
+	}
+}
+
+func toBizSearchDocument(row *campusSearchDocumentModel) *biz.CampusSearchDocument {
+	return &biz.CampusSearchDocument{
+		DocType:       row.DocType,
+		DocID:         row.DocID,
+		PostID:        row.PostID,
+		CampusCode:    row.CampusCode,
+		IsCrossCampus: row.IsCrossCampus,
+		CategoryCode:  row.CategoryCode,
+		PostType:      row.PostType,
+		AuthorID:      fmt.Sprintf("%d", row.AuthorID),
+		Title:         row.Title,
+		Content:       row.Content,
+		CreatedAt:     row.CreatedAt,
+		IndexedAt:     row.IndexedAt,
+	}
+}
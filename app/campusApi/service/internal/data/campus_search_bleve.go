@@ -0,0 +1,270 @@
+package data
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/keyword"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+const (
+	campusBleveDefaultPath = "data/campus_search.bleve"
+	// Delete/Prune 每批取出的文档数
+	campusBleveScanBatch = 1000
+)
+
+// 进程内的 Bleve 磁盘索引，标题和正文用 cjk 二元分词；每个实例各自维护一份，
+// 启动时索引为空会由 WarmCampusSearchIndex 从 MySQL 全量重建
+type campusBleveSearchIndex struct {
+	index bleve.Index
+}
+
+type campusBleveDocument struct {
+	DocType       string    `json:"doc_type"`
+	PostID        float64   `json:"post_id"`
+	CampusCode    string    `json:"campus_code"`
+	IsCrossCampus bool      `json:"is_cross_campus"`
+	CategoryCode  string    `json:"category_code"`
+	PostType      string    `json:"post_type"`
+	AuthorID      string    `json:"author_id"`
+	Title         string    `json:"title"`
+	Content       string    `json:"content"`
+	CreatedAt     time.Time `json:"created_at"`
+	IndexedAt     time.Time `json:"indexed_at"`
+	Source        string    `json:"source"`
+}
+
+func campusBleveSearchPath() string {
+	return firstNonEmptyData(os.Getenv("LEHU_CAMPUS_SEARCH_BLEVE_PATH"), campusBleveDefaultPath)
+}
+
+// path 为空时使用纯内存索引
+func newCampusBleveSearchIndex(path string) (*campusBleveSearchIndex, error) {
+	if path == "" {
+		index, err := bleve.NewMemOnly(campusBleveMapping())
+		if err != nil {
+			return nil, err
+		}
+		return &campusBleveSearchIndex{index: index}, nil
+	}
+	index, err := bleve.Open(path)
+	if errors.Is(err, bleve.ErrorIndexPathDoesNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		index, err = bleve.New(path, campusBleveMapping())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open bleve search index %s: %w", path, err)
+	}
+	return &campusBleveSearchIndex{index: index}, nil
+}
+
+func campusBleveMapping() mapping.IndexMapping {
+	keywordField := bleve.NewTextFieldMapping()
+	keywordField.Analyzer = keyword.Name
+	keywordField.IncludeInAll = false
+	textField := bleve.NewTextFieldMapping()
+	textField.Analyzer = cjk.AnalyzerName
+	textField.IncludeInAll = false
+	numericField := bleve.NewNumericFieldMapping()
+	numericField.IncludeInAll = false
+	boolField := bleve.NewBooleanFieldMapping()
+	boolField.IncludeInAll = false
+	dateField := bleve.NewDateTimeFieldMapping()
+	dateField.IncludeInAll = false
+	sourceField := bleve.NewTextFieldMapping()
+	sourceField.Index = false
+	sourceField.IncludeInAll = false
+
+	doc := bleve.NewDocumentStaticMapping()
+	for _, name := range []string{"doc_type", "campus_code", "category_code", "post_type", "author_id"} {
+		doc.AddFieldMappingsAt(name, keywordField)
+	}
+	doc.AddFieldMappingsAt("title", textField)
+	doc.AddFieldMappingsAt("content", textField)
+	doc.AddFieldMappingsAt("post_id", numericField)
+	doc.AddFieldMappingsAt("is_cross_campus", boolField)
+	doc.AddFieldMappingsAt("created_at", dateField)
+	doc.AddFieldMappingsAt("indexed_at", dateField)
+	doc.AddFieldMappingsAt("source", sourceField)
+
+	indexMapping := bleve.NewIndexMapping()
+	indexMapping.DefaultMapping = doc
+	indexMapping.DefaultAnalyzer = cjk.AnalyzerName
+	return indexMapping
+}
+
+func campusBleveDocID(docType string, docID int64) string {
+	return fmt.Sprintf("%s:%d", docType, docID)
+}
+
+func (idx *campusBleveSearchIndex) Backend() string {
+	return biz.CampusSearchBackendBleve
+}
+
+func (idx *campusBleveSearchIndex) Close() error {
+	return idx.index.Close()
+}
+
+func (idx *campusBleveSearchIndex) Upsert(ctx context.Context, docs []*biz.CampusSearchDocument) error {
+	now := time.Now()
+	batch := idx.index.NewBatch()
+	for _, doc := range docs {
+		if doc == nil || doc.DocID <= 0 {
+			continue
+		}
+		copied := *doc
+		copied.IndexedAt = now
+		source, err := json.Marshal(&copied)
+		if err != nil {
+			return err
+		}
+		if err := batch.Index(campusBleveDocID(doc.DocType, doc.DocID), campusBleveDocument{
+			DocType:       doc.DocType,
+			PostID:        float64(doc.PostID),
+			CampusCode:    doc.CampusCode,
+			IsCrossCampus: doc.IsCrossCampus,
+			CategoryCode:  doc.CategoryCode,
+			PostType:      doc.PostType,
+			AuthorID:      doc.AuthorID,
+			Title:         doc.Title,
+			Content:       doc.Content,
+			CreatedAt:     doc.CreatedAt,
+			IndexedAt:     now,
+			Source:        string(source),
+		}); err != nil {
+			return err
+		}
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	return idx.index.Batch(batch)
+}
+
+func (idx *campusBleveSearchIndex) Delete(ctx context.Context, docType string, docIDs []int64) error {
+	if len(docIDs) == 0 {
+		return nil
+	}
+	batch := idx.index.NewBatch()
+	for _, id := range docIDs {
+		batch.Delete(campusBleveDocID(docType, id))
+	}
+	return idx.index.Batch(batch)
+}
+
+func (idx *campusBleveSearchIndex) DeleteByPost(ctx context.Context, postID int64) error {
+	value := float64(postID)
+	inclusive := true
+	byPost := bleve.NewNumericRangeInclusiveQuery(&value, &value, &inclusive, &inclusive)
+	byPost.SetField("post_id")
+	_, err := idx.deleteMatching(ctx, bleve.NewConjunctionQuery(campusBleveTerm("doc_type", biz.CampusSearchDocComment), byPost))
+	return err
+}
+
+func (idx *campusBleveSearchIndex) Prune(ctx context.Context, indexedBefore time.Time) (int64, error) {
+	exclusive := false
+	before := bleve.NewDateRangeInclusiveQuery(time.Time{}, indexedBefore, nil, &exclusive)
+	before.SetField("indexed_at")
+	return idx.deleteMatching(ctx, before)
+}
+
+// 每次删掉第一页命中，直到查不出文档
+func (idx *campusBleveSearchIndex) deleteMatching(ctx context.Context, q query.Query) (int64, error) {
+	var deleted int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+		result, err := idx.index.SearchInContext(ctx, bleve.NewSearchRequestOptions(q, campusBleveScanBatch, 0, false))
+		if err != nil {
+			return deleted, err
+		}
+		if len(result.Hits) == 0 {
+			return deleted, nil
+		}
+		batch := idx.index.NewBatch()
+		for _, hit := range result.Hits {
+			batch.Delete(hit.ID)
+		}
+		if err := idx.index.Batch(batch); err != nil {
+			return deleted, err
+		}
+		deleted += int64(len(result.Hits))
+	}
+}
+
+func (idx *campusBleveSearchIndex) Count(ctx context.Context) (int64, error) {
+	count, err := idx.index.DocCount()
+	return int64(count), err
+}
+
+func (idx *campusBleveSearchIndex) Search(ctx context.Context, q *biz.CampusSearchQuery) ([]*biz.CampusSearchHit, int64, error) {
+	title := bleve.NewMatchQuery(q.Keyword)
+	title.SetField("title")
+	title.SetBoost(2)
+	content := bleve.NewMatchQuery(q.Keyword)
+	content.SetField("content")
+	conjuncts := []query.Query{bleve.NewDisjunctionQuery(title, content)}
+	if len(q.DocTypes) > 0 {
+		docTypes := make([]query.Query, 0, len(q.DocTypes))
+		for _, docType := range q.DocTypes {
+			docTypes = append(docTypes, campusBleveTerm("doc_type", docType))
+		}
+		conjuncts = append(conjuncts, bleve.NewDisjunctionQuery(docTypes...))
+	}
+	if q.CampusCode != "" {
+		crossCampus := bleve.NewBoolFieldQuery(true)
+		crossCampus.SetField("is_cross_campus")
+		conjuncts = append(conjuncts, bleve.NewDisjunctionQuery(campusBleveTerm("campus_code", q.CampusCode), crossCampus))
+	}
+	if q.CategoryCode != "" {
+		conjuncts = append(conjuncts, campusBleveTerm("category_code", q.CategoryCode))
+	}
+	if q.PostType != "" {
+		conjuncts = append(conjuncts, campusBleveTerm("post_type", q.PostType))
+	}
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	search := bleve.NewBooleanQuery()
+	search.AddMust(conjuncts...)
+	for _, authorID := range q.ExcludeAuthorIDs {
+		search.AddMustNot(campusBleveTerm("author_id", authorID))
+	}
+	request := bleve.NewSearchRequestOptions(search, limit, q.Offset, false)
+	request.Fields = []string{"source"}
+	request.SortBy([]string{"-_score", "-created_at"})
+	result, err := idx.index.SearchInContext(ctx, request)
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]*biz.CampusSearchHit, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		source, _ := hit.Fields["source"].(string)
+		var doc biz.CampusSearchDocument
+		if err := json.Unmarshal([]byte(source), &doc); err != nil {
+			return nil, 0, fmt.Errorf("decode bleve search document %s: %w", hit.ID, err)
+		}
+		hits = append(hits, &biz.CampusSearchHit{Document: &doc, Score: hit.Score})
+	}
+	return hits, int64(result.Total), nil
+}
+
+func campusBleveTerm(field, value string) *query.TermQuery {
+	term := bleve.NewTermQuery(value)
+	term.SetField(field)
+	return term
+}
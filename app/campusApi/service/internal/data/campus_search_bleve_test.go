@@ -0,0 +1,54 @@
+package data
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+func TestCampusBleveSearchIndex(t *testing.T) {
+	idx, err := newCampusBleveSearchIndex("")
+	if err != nil {
+		t.Fatalf("newCampusBleveSearchIndex: %v", err)
+	}
+	defer idx.Close()
+	ctx := context.Background()
+	now := time.Now()
+	if err := idx.Upsert(ctx, []*biz.CampusSearchDocument{
+		{DocType: biz.CampusSearchDocPost, DocID: 1, PostID: 1, CampusCode: "a", AuthorID: "7", Title: "二手自行车转让", Content: "九成新", CreatedAt: now},
+		{DocType: biz.CampusSearchDocPost, DocID: 2, PostID: 2, CampusCode: "b", Title: "求购自行车", Content: "预算两百", CreatedAt: now},
+		{DocType: biz.CampusSearchDocPost, DocID: 3, PostID: 3, CampusCode: "b", IsCrossCampus: true, Title: "全市骑行活动", Content: "自行车爱好者集合", CreatedAt: now},
+		{DocType: biz.CampusSearchDocComment, DocID: 10, PostID: 1, CampusCode: "a", Content: "自行车还在吗", CreatedAt: now},
+	}); err != nil {
+		t.Fatalf("Upsert: %v", err)
+	}
+
+	hits, total, err := idx.Search(ctx, &biz.CampusSearchQuery{Keyword: "自行车", CampusCode: "a", DocTypes: []string{biz.CampusSearchDocPost}, Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if total != 2 || len(hits) != 2 || hits[0].Document.DocID != 1 || hits[0].Document.Title != "二手自行车转让" {
+		t.Fatalf("unexpected campus hits total=%d hits=%+v", total, hits)
+	}
+	if hits[1].Document.DocID != 3 {
+		t.Fatalf("cross campus post should match, got %+v", hits[1].Document)
+	}
+
+	hits, total, err = idx.Search(ctx, &biz.CampusSearchQuery{Keyword: "自行车", CampusCode: "a", DocTypes: []string{biz.CampusSearchDocPost}, ExcludeAuthorIDs: []string{"7"}, Limit: 10})
+	if err != nil || total != 1 || len(hits) != 1 || hits[0].Document.DocID != 3 {
+		t.Fatalf("hidden author should be excluded, total=%d hits=%+v err=%v", total, hits, err)
+	}
+
+	if err := idx.DeleteByPost(ctx, 1); err != nil {
+		t.Fatalf("DeleteByPost: %v", err)
+	}
+	if count, _ := idx.Count(ctx); count != 3 {
+		t.Fatalf("comment should be removed with its post, count=%d", count)
+	}
+	pruned, err := idx.Prune(ctx, time.Now().Add(time.Second))
+	if err != nil || pruned != 3 {
+		t.Fatalf("Prune = %d, %v", pruned, err)
+	}
+}
@@ -0,0 +1,409 @@
+package data
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusTagModel struct {
+	ID           int64     `gorm:"column:id;primaryKey"`
+	Name         string    `gorm:"column:name"`
+	DisplayName  string    `gorm:"column:display_name"`
+	Status       int32     `gorm:"column:status"`
+	MergedIntoID int64     `gorm:"column:merged_into_id"`
+	IsFeatured   bool      `gorm:"column:is_featured"`
+	PostCount    int64     `gorm:"column:post_count"`
+	TrendScore   float64   `gorm:"column:trend_score"`
+	CreatedAt    time.Time `gorm:"column:created_at"`
+	UpdatedAt    time.Time `gorm:"column:updated_at"`
+}
+
+func (campusTagModel) TableName() string { return "campus_tag" }
+
+type campusPostTagModel struct {
+	PostID    int64     `gorm:"column:post_id;primaryKey"`
+	TagID     int64     `gorm:"column:tag_id;primaryKey"`
+	Source    string    `gorm:"column:source"`
+	Position  int32     `gorm:"column:position"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (campusPostTagModel) TableName() string { return "campus_post_tag" }
+
+type campusTagStatModel struct {
+	CampusCode string    `gorm:"column:campus_code;primaryKey"`
+	TagID      int64     `gorm:"column:tag_id;primaryKey"`
+	PostCount  int64     `gorm:"column:post_count"`
+	TrendScore float64   `gorm:"column:trend_score"`
+	UpdatedAt  time.Time `gorm:"column:updated_at"`
+}
+
+func (campusTagStatModel) TableName() string { return "campus_tag_stat" }
+
+func createPostTagsWithTx(tx *gorm.DB, post *biz.CampusForumPost) error {
+	if post == nil || len(post.Tags) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]campusPostTagModel, 0, len(post.Tags))
+	tagIDs := make([]int64, 0, len(post.Tags))
+	for i, tag := range post.Tags {
+		rows = append(rows, campusPostTagModel{PostID: post.ID, TagID: tag.ID, Source: tag.Source, Position: int32(i), CreatedAt: now})
+		tagIDs = append(tagIDs, tag.ID)
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error; err != nil {
+		return err
+	}
+	if post.Status != biz.CampusAuditStatusVisible {
+		return nil
+	}
+	if err := tx.Model(&campusTagModel{}).Where("id IN ?", tagIDs).
+		Updates(map[string]interface{}{"post_count": gorm.Expr("post_count + 1"), "updated_at": now}).Error; err != nil {
+		return err
+	}
+	stats := make([]campusTagStatModel, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		stats = append(stats, campusTagStatModel{CampusCode: post.CampusCode, TagID: tagID, PostCount: 1, UpdatedAt: now})
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "campus_code"}, {Name: "tag_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{"post_count": gorm.Expr("post_count + 1"), "updated_at": now}),
+	}).Create(&stats).Error
+}
+
+func (r *campusRepo) EnsureTags(ctx context.Context, tags []*biz.CampusTag) (map[string]*biz.CampusTag, error) {
+	result := make(map[string]*biz.CampusTag, len(tags))
+	if len(tags) == 0 {
+		return result, nil
+	}
+	now := time.Now()
+	rows := make([]campusTagModel, 0, len(tags))
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		rows = append(rows, campusTagModel{ID: tag.ID, Name: tag.Name, DisplayName: tag.DisplayName, CreatedAt: now, UpdatedAt: now})
+		names = append(names, tag.Name)
+	}
+	db := r.data.db.WithContext(ctx)
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Create(&rows).Error; err != nil {
+		return nil, err
+	}
+	var stored []campusTagModel
+	if err := db.Where("name IN ?", names).Find(&stored).Error; err != nil {
+		return nil, err
+	}
+	for i := range stored {
+		result[stored[i].Name] = toBizTag(&stored[i])
+	}
+	return result, nil
+}
+
+func (r *campusRepo) GetTagByName(ctx context.Context, name string) (bool, *biz.CampusTag, error) {
+	var row campusTagModel
+	err := r.data.db.WithContext(ctx).Where("name = ?", name).Take(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	return true, toBizTag(&row), nil
+}
+
+func (r *campusRepo) GetTagsByIDs(ctx context.Context, ids []int64) (map[int64]*biz.CampusTag, error) {
+	tags := make(map[int64]*biz.CampusTag, len(ids))
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	var rows []campusTagModel
+	if err := r.data.db.WithContext(ctx).Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		tags[rows[i].ID] = toBizTag(&rows[i])
+	}
+	return tags, nil
+}
+
+func (r *campusRepo) GetPostTags(ctx context.Context, postIDs []int64) (map[int64][]*biz.CampusTag, error) {
+	tags := make(map[int64][]*biz.CampusTag, len(postIDs))
+	if len(postIDs) == 0 {
+		return tags, nil
+	}
+	type postTagRow struct {
+		campusTagModel
+		PostID int64  `gorm:"column:post_id"`
+		Source string `gorm:"column:source"`
+	}
+	var rows []postTagRow
+	if err := r.data.db.WithContext(ctx).Table("campus_post_tag pt").
+		Joins("JOIN campus_tag t ON t.id = pt.tag_id").
+		Where("pt.post_id IN ? AND t.status = ?", postIDs, biz.CampusTagStatusNormal).
+		Select("t.*, pt.post_id, pt.source").
+		Order("pt.post_id ASC, pt.position ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		tag := toBizTag(&rows[i].campusTagModel)
+		tag.Source = rows[i].Source
+		tags[rows[i].PostID] = append(tags[rows[i].PostID], tag)
+	}
+	return tags, nil
+}
+
+func replacePostTagsWithTx(tx *gorm.DB, post *biz.CampusForumPost) error {
+	if err := tx.Where("post_id = ?", post.ID).Delete(&campusPostTagModel{}).Error; err != nil {
+		return err
+	}
+	if len(post.Tags) == 0 {
+		return nil
+	}
+	now := time.Now()
+	rows := make([]campusPostTagModel, 0, len(post.Tags))
+	for i, tag := range post.Tags {
+		rows = append(rows, campusPostTagModel{PostID: post.ID, TagID: tag.ID, Source: tag.Source, Position: int32(i), CreatedAt: now})
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+}
+
+func (r *campusRepo) ReplacePostTags(ctx context.Context, postID int64, tags []*biz.CampusTag) error {
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return replacePostTagsWithTx(tx, &biz.CampusForumPost{ID: postID, Tags: tags})
+	})
+	if err != nil {
+		return err
+	}
+	r.invalidatePostReadCaches(ctx, postID, true)
+	return nil
+}
+
+func (r *campusRepo) ListTags(ctx context.Context, query biz.ListCampusTagQuery) ([]*biz.CampusTag, int64, error) {
+	db := r.data.db.WithContext(ctx).Model(&campusTagModel{})
+	if query.Keyword != "" {
+		db = db.Where("name LIKE ?", "%"+query.Keyword+"%")
+	}
+	if query.Status != nil {
+		db = db.Where("status = ?", *query.Status)
+	}
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []campusTagModel
+	if err := db.Order("is_featured DESC, post_count DESC, id DESC").Offset(query.Offset).Limit(query.Limit).Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+	tags := make([]*biz.CampusTag, 0, len(rows))
+	for i := range rows {
+		tags = append(tags, toBizTag(&rows[i]))
+	}
+	return tags, total, nil
+}
+
+// 热度和帖子数按学校取 campus_tag_stat，运营推荐的话题即使本校还没有帖子也展示
+func (r *campusRepo) ListTrendingTags(ctx context.Context, campusCode string, limit int) ([]*biz.CampusTag, error) {
+	var rows []campusTagModel
+	if err := r.data.db.WithContext(ctx).Table("campus_tag t").
+		Joins("LEFT JOIN campus_tag_stat s ON s.tag_id = t.id AND s.campus_code = ?", campusCode).
+		Where("t.status = ? AND (t.is_featured = ? OR s.trend_score > 0)", biz.CampusTagStatusNormal, true).
+		Select("t.id, t.name, t.display_name, t.status, t.merged_into_id, t.is_featured, COALESCE(s.post_count, 0) AS post_count, COALESCE(s.trend_score, 0) AS trend_score, t.created_at, t.updated_at").
+		Order("t.is_featured DESC, COALESCE(s.trend_score, 0) DESC, COALESCE(s.post_count, 0) DESC, t.id DESC").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	tags := make([]*biz.CampusTag, 0, len(rows))
+	for i := range rows {
+		tags = append(tags, toBizTag(&rows[i]))
+	}
+	return tags, nil
+}
+
+func (r *campusRepo) GetTagCampusStats(ctx context.Context, campusCode string, tagIDs []int64) (map[int64]*biz.CampusTagStat, error) {
+	stats := make(map[int64]*biz.CampusTagStat, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return stats, nil
+	}
+	var rows []campusTagStatModel
+	if err := r.data.db.WithContext(ctx).Where("campus_code = ? AND tag_id IN ?", campusCode, tagIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		stats[row.TagID] = &biz.CampusTagStat{CampusCode: row.CampusCode, TagID: row.TagID, PostCount: row.PostCount, TrendScore: row.TrendScore}
+	}
+	return stats, nil
+}
+
+func (r *campusRepo) ListTagActivity(ctx context.Context, since time.Time) ([]*biz.CampusTagActivity, error) {
+	var rows []struct {
+		CampusCode   string `gorm:"column:campus_code"`
+		TagID        int64  `gorm:"column:tag_id"`
+		PostCount    int64  `gorm:"column:post_count"`
+		LikeCount    int64  `gorm:"column:like_count"`
+		CommentCount int64  `gorm:"column:comment_count"`
+	}
+	if err := r.data.db.WithContext(ctx).Table("campus_post_tag pt").
+		Joins("JOIN campus_forum_post p ON p.id = pt.post_id").
+		Joins("JOIN campus_tag t ON t.id = pt.tag_id").
+		Where("t.status = ? AND p.status = ? AND p.is_deleted = ? AND p.created_at >= ?", biz.CampusTagStatusNormal, biz.CampusAuditStatusVisible, false, since).
+		Select("p.campus_code, pt.tag_id, COUNT(*) AS post_count, COALESCE(SUM(p.like_count), 0) AS like_count, COALESCE(SUM(p.comment_count), 0) AS comment_count").
+		Group("p.campus_code, pt.tag_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	activities := make([]*biz.CampusTagActivity, 0, len(rows))
+	for _, row := range rows {
+		activities = append(activities, &biz.CampusTagActivity{
+			CampusCode:   row.CampusCode,
+			TagID:        row.TagID,
+			PostCount:    row.PostCount,
+			LikeCount:    row.LikeCount,
+			CommentCount: row.CommentCount,
+		})
+	}
+	return activities, nil
+}
+
+// 分校热度写进 campus_tag_stat，campus_tag.trend_score 存各校之和给后台列表看
+func (r *campusRepo) SaveTagTrendScores(ctx context.Context, scores []*biz.CampusTagStat) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Model(&campusTagStatModel{}).Where("trend_score <> 0").Updates(map[string]interface{}{"trend_score": 0, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&campusTagModel{}).Where("trend_score <> 0").Update("trend_score", 0).Error; err != nil {
+			return err
+		}
+		totals := make(map[int64]float64, len(scores))
+		for _, score := range scores {
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "campus_code"}, {Name: "tag_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"trend_score", "updated_at"}),
+			}).Create(&campusTagStatModel{CampusCode: score.CampusCode, TagID: score.TagID, TrendScore: score.TrendScore, UpdatedAt: now}).Error; err != nil {
+				return err
+			}
+			totals[score.TagID] += score.TrendScore
+		}
+		for tagID, total := range totals {
+			if err := tx.Model(&campusTagModel{}).Where("id = ?", tagID).Update("trend_score", total).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *campusRepo) ReconcileTagPostCounts(ctx context.Context) error {
+	return r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`UPDATE campus_tag t SET post_count = (
+			SELECT COUNT(*) FROM campus_post_tag pt
+			JOIN campus_forum_post p ON p.id = pt.post_id
+			WHERE pt.tag_id = t.id AND p.status = ? AND p.is_deleted = ?
+		) WHERE t.status <> ?`, biz.CampusAuditStatusVisible, false, biz.CampusTagStatusMerged).Error; err != nil {
+			return err
+		}
+		return reconcileTagCampusStatsWithTx(tx, 0)
+	})
+}
+
+// tagID 为 0 时对账全部话题
+func reconcileTagCampusStatsWithTx(tx *gorm.DB, tagID int64) error {
+	now := time.Now()
+	reset := tx.Model(&campusTagStatModel{}).Where("post_count <> 0")
+	if tagID > 0 {
+		reset = reset.Where("tag_id = ?", tagID)
+	}
+	if err := reset.Updates(map[string]interface{}{"post_count": 0, "updated_at": now}).Error; err != nil {
+		return err
+	}
+	filter, args := "", []interface{}{now, biz.CampusAuditStatusVisible, false, biz.CampusTagStatusMerged}
+	if tagID > 0 {
+		filter, args = " AND pt.tag_id = ?", append(args, tagID)
+	}
+	return tx.Exec(`INSERT INTO campus_tag_stat (campus_code, tag_id, post_count, trend_score, updated_at)
+		SELECT p.campus_code, pt.tag_id, COUNT(*), 0, ? FROM campus_post_tag pt
+		JOIN campus_forum_post p ON p.id = pt.post_id
+		JOIN campus_tag t ON t.id = pt.tag_id
+		WHERE p.status = ? AND p.is_deleted = ? AND t.status <> ?`+filter+`
+		GROUP BY p.campus_code, pt.tag_id
+		ON DUPLICATE KEY UPDATE post_count = VALUES(post_count), updated_at = VALUES(updated_at)`, args...).Error
+}
+
+func (r *campusRepo) MergeTag(ctx context.Context, sourceID, targetID int64) error {
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`INSERT IGNORE INTO campus_post_tag (post_id, tag_id, source, position, created_at)
+			SELECT post_id, ?, source, position, created_at FROM campus_post_tag WHERE tag_id = ?`, targetID, sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", sourceID).Delete(&campusPostTagModel{}).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		if err := tx.Model(&campusTagModel{}).Where("merged_into_id = ?", sourceID).
+			Updates(map[string]interface{}{"merged_into_id": targetID, "updated_at": now}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&campusTagModel{}).Where("id = ?", sourceID).Updates(map[string]interface{}{
+			"status":         biz.CampusTagStatusMerged,
+			"merged_into_id": targetID,
+			"is_featured":    false,
+			"post_count":     0,
+			"trend_score":    0,
+			"updated_at":     now,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(`UPDATE campus_tag SET post_count = (
+			SELECT COUNT(*) FROM campus_post_tag pt
+			JOIN campus_forum_post p ON p.id = pt.post_id
+			WHERE pt.tag_id = ? AND p.status = ? AND p.is_deleted = ?
+		), updated_at = ? WHERE id = ?`, targetID, biz.CampusAuditStatusVisible, false, now, targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("tag_id = ?", sourceID).Delete(&campusTagStatModel{}).Error; err != nil {
+			return err
+		}
+		return reconcileTagCampusStatsWithTx(tx, targetID)
+	})
+	if err != nil {
+		return err
+	}
+	r.bumpPostFeedCacheVersion(ctx)
+	return nil
+}
+
+func (r *campusRepo) UpdateTagFlags(ctx context.Context, tag *biz.CampusTag) error {
+	err := r.data.db.WithContext(ctx).Model(&campusTagModel{}).Where("id = ?", tag.ID).Updates(map[string]interface{}{
+		"status":      tag.Status,
+		"is_featured": tag.IsFeatured,
+		"updated_at":  time.Now(),
+	}).Error
+	if err != nil {
+		return err
+	}
+	r.bumpPostFeedCacheVersion(ctx)
+	return nil
+}
+
+func toBizTag(row *campusTagModel) *biz.CampusTag {
+	return &biz.CampusTag{
+		ID:           row.ID,
+		Name:         row.Name,
+		DisplayName:  row.DisplayName,
+		Status:       row.Status,
+		MergedIntoID: row.MergedIntoID,
+		IsFeatured:   row.IsFeatured,
+		PostCount:    row.PostCount,
+		TrendScore:   row.TrendScore,
+		CreatedAt:    row.CreatedAt,
+		UpdatedAt:    row.UpdatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package data
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+const campusTimetableSessionPrefix = "campus:timetable:session:v1"
+
+type campusTimetableSessionStore struct {
+	data *Data
+}
+
+func NewCampusTimetableSessionStore(data *Data) biz.CampusTimetableSessionStore {
+	return &campusTimetableSessionStore{data: data}
+}
+
+func campusTimetableSessionKey(userID, token string) string {
+	return fmt.Sprintf("%s:%s:%s", campusTimetableSessionPrefix, userID, token)
+}
+
+func (s *campusTimetableSessionStore) PutTimetableSession(ctx context.Context, userID, token string, raw []byte, ttl time.Duration) error {
+	if s.data == nil || s.data.rds == nil {
+		return fmt.Errorf("redis is not initialized")
+	}
+	return s.data.rds.Set(ctx, campusTimetableSessionKey(userID, token), raw, ttl).Err()
+}
+
+// GETDEL 保证验证码会话只能被取用一次
+func (s *campusTimetableSessionStore) TakeTimetableSession(ctx context.Context, userID, token string) ([]byte, error) {
+	if s.data == nil || s.data.rds == nil {
+		return nil, fmt.Errorf("redis is not initialized")
+	}
+	raw, err := s.data.rds.GetDel(ctx, campusTimetableSessionKey(userID, token)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	return raw, err
+}
@@ -0,0 +1,95 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"lehu-video/app/campusApi/service/internal/biz"
+)
+
+type campusTrustStatusRow struct {
+	UserID   int64 `gorm:"column:user_id"`
+	Rejected int64 `gorm:"column:rejected"`
+	Removed  int64 `gorm:"column:removed"`
+}
+
+type campusTrustCountRow struct {
+	UserID int64 `gorm:"column:user_id"`
+	Total  int64 `gorm:"column:total"`
+}
+
+func (r *campusRepo) ListUserTrustSignals(ctx context.Context, userIDs []string) (map[string]*biz.CampusUserTrustSignals, error) {
+	out := map[string]*biz.CampusUserTrustSignals{}
+	ids := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if id := parseID(userID); id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+	signal := func(userID int64) *biz.CampusUserTrustSignals {
+		key := fmt.Sprintf("%d", userID)
+		if out[key] == nil {
+			out[key] = &biz.CampusUserTrustSignals{}
+		}
+		return out[key]
+	}
+	db := r.data.db.WithContext(ctx)
+
+	var statusRows []campusTrustStatusRow
+	if err := db.Table("campus_forum_post").
+		Select("author_id AS user_id, SUM(status = ?) AS rejected, SUM(status = ? AND audit_reason NOT IN ?) AS removed", biz.CampusAuditStatusRejected, biz.CampusAuditStatusDeleted, biz.CampusUserDeletionReasons()).
+		Where("author_id IN ? AND status IN ?", ids, []int32{biz.CampusAuditStatusRejected, biz.CampusAuditStatusDeleted}).
+		Group("author_id").
+		Scan(&statusRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range statusRows {
+		signal(row.UserID).RejectedPosts, signal(row.UserID).RemovedPosts = row.Rejected, row.Removed
+	}
+
+	statusRows = nil
+	if err := db.Table("campus_forum_comment").
+		Select("author_id AS user_id, SUM(status = ?) AS rejected, SUM(status = ? AND audit_reason NOT IN ?) AS removed", biz.CampusAuditStatusRejected, biz.CampusAuditStatusDeleted, biz.CampusUserDeletionReasons()).
+		Where("author_id IN ? AND status IN ?", ids, []int32{biz.CampusAuditStatusRejected, biz.CampusAuditStatusDeleted}).
+		Group("author_id").
+		Scan(&statusRows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range statusRows {
+		signal(row.UserID).RejectedComments, signal(row.UserID).RemovedComments = row.Rejected, row.Removed
+	}
+
+	// 同一条内容被多人举报只算一次
+	var rows []campusTrustCountRow
+	if err := db.Raw(`SELECT user_id, COUNT(*) AS total FROM (
+		SELECT DISTINCT p.author_id AS user_id, r.target_type, r.target_id FROM campus_forum_report r
+		JOIN campus_forum_post p ON r.target_type = 'post' AND p.id = r.target_id
+		WHERE r.status = 1 AND p.author_id IN ?
+		UNION
+		SELECT DISTINCT c.author_id AS user_id, r.target_type, r.target_id FROM campus_forum_report r
+		JOIN campus_forum_comment c ON r.target_type = 'comment' AND c.id = r.target_id
+		WHERE r.status = 1 AND c.author_id IN ?
+	) upheld GROUP BY user_id`, ids, ids).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		signal(row.UserID).UpheldReports = row.Total
+	}
+
+	rows = nil
+	if err := db.Table("campus_question q").
+		Select("c.author_id AS user_id, COUNT(*) AS total").
+		Joins("JOIN campus_forum_comment c ON c.id = q.accepted_comment_id").
+		Where("q.accepted_comment_id > 0 AND c.author_id IN ? AND c.author_id <> q.author_id", ids).
+		Group("c.author_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		signal(row.UserID).AcceptedAnswers = row.Total
+	}
+	return out, nil
+}
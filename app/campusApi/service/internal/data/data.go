@@ -31,6 +31,8 @@ var ProviderSet = wire.NewSet(
 	NewBaseAdapter,
 	NewFileServiceClient,
 	NewCampusCoreAdapter,
+	NewCampusSearchIndex,
+	NewCampusTimetableSessionStore,
 	NewCampusRepo,
 )
 
@@ -111,6 +111,8 @@ func (r *baseAdapterImpl) GetFileInfoById(ctx context.Context, fileId string) (*
 	ret := &biz.FileInfo{
 		ObjectName: resp.ObjectName,
 		Hash:       resp.Hash,
+		PHash:      resp.Phash,
+		DHash:      resp.Dhash,
 	}
 	return ret, nil
 }
@@ -0,0 +1,117 @@
+package data
+
+import (
+	"context"
+
+	core "lehu-video/api/campusUser/service/v1"
+	"lehu-video/app/campusApi/service/internal/biz"
+	"lehu-video/app/campusApi/service/internal/pkg/utils/respcheck"
+)
+
+func (r *CoreAdapterImpl) FollowUser(ctx context.Context, userID, targetUserID string) (bool, error) {
+	resp, err := r.user.FollowUser(ctx, &core.FollowUserReq{
+		UserId:       userID,
+		TargetUserId: targetUserID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return false, err
+	}
+	return resp.Changed, nil
+}
+
+func (r *CoreAdapterImpl) UnfollowUser(ctx context.Context, userID, targetUserID string) (bool, error) {
+	resp, err := r.user.UnfollowUser(ctx, &core.UnfollowUserReq{
+		UserId:       userID,
+		TargetUserId: targetUserID,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return false, err
+	}
+	return resp.Changed, nil
+}
+
+func (r *CoreAdapterImpl) ListFollowers(ctx context.Context, userID string, page, pageSize int32) (int64, []*biz.UserBaseInfo, error) {
+	resp, err := r.user.ListFollowers(ctx, &core.ListFollowReq{UserId: userID, Page: page, PageSize: pageSize})
+	return followUsersFromResp(resp, err)
+}
+
+func (r *CoreAdapterImpl) ListFollowing(ctx context.Context, userID string, page, pageSize int32) (int64, []*biz.UserBaseInfo, error) {
+	resp, err := r.user.ListFollowing(ctx, &core.ListFollowReq{UserId: userID, Page: page, PageSize: pageSize})
+	return followUsersFromResp(resp, err)
+}
+
+func (r *CoreAdapterImpl) ListFollowerIDs(ctx context.Context, userID, afterUserID string, limit int32) ([]string, error) {
+	if afterUserID == "" {
+		afterUserID = "0"
+	}
+	resp, err := r.user.ListFollowers(ctx, &core.ListFollowReq{
+		UserId:      userID,
+		PageSize:    limit,
+		AfterUserId: afterUserID,
+		IdsOnly:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return nil, err
+	}
+	return resp.UserIds, nil
+}
+
+func (r *CoreAdapterImpl) ListFollowingIDs(ctx context.Context, userID string, limit int32) ([]string, error) {
+	resp, err := r.user.ListFollowing(ctx, &core.ListFollowReq{
+		UserId:   userID,
+		Page:     1,
+		PageSize: limit,
+		IdsOnly:  true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return nil, err
+	}
+	return resp.UserIds, nil
+}
+
+func (r *CoreAdapterImpl) CheckFollowing(ctx context.Context, userID string, targetUserIDs []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(targetUserIDs))
+	if userID == "" || len(targetUserIDs) == 0 {
+		return result, nil
+	}
+	resp, err := r.user.CheckFollowing(ctx, &core.CheckFollowingReq{
+		UserId:        userID,
+		TargetUserIds: targetUserIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return nil, err
+	}
+	for _, id := range resp.FollowingUserIds {
+		result[id] = true
+	}
+	return result, nil
+}
+
+func followUsersFromResp(resp *core.ListFollowResp, err error) (int64, []*biz.UserBaseInfo, error) {
+	if err != nil {
+		return 0, nil, err
+	}
+	if err := respcheck.ValidateResponseMeta(resp.Meta); err != nil {
+		return 0, nil, err
+	}
+	users := make([]*biz.UserBaseInfo, 0, len(resp.Users))
+	for _, user := range resp.Users {
+		users = append(users, convertToBizUserBaseInfo(user))
+	}
+	return resp.Total, users, nil
+}
@@ -77,11 +77,22 @@ func (s *CampusTaskServer) Stop(ctx context.Context) error {
 func (s *CampusTaskServer) run(ctx context.Context) {
 	defer close(s.done)
 	s.runExclusive(ctx, "recommend_pool", s.safeRefreshRecommendPool)
+	s.runExclusive(ctx, "search_warmup", s.safeWarmSearchIndex)
 	s.runExclusive(ctx, "notification_outbox", s.safeProcessNotificationOutbox)
 	s.runExclusive(ctx, "ops_alerts", s.safeProcessOpsAlerts)
 	s.runExclusive(ctx, "ops_sla_alerts", s.safeProcessOpsSLAAlerts)
 	s.runExclusive(ctx, "ai_replies", s.safeProcessAIReplyTasks)
 	s.runExclusive(ctx, "ai_audit", s.safeProcessAIContentAuditTasks)
+	s.runExclusive(ctx, "image_audit", s.safeProcessImageAuditTasks)
+	s.runExclusive(ctx, "scheduled_posts", s.safeProcessScheduledPosts)
+	s.runExclusive(ctx, "poll_close", s.safeProcessDuePolls)
+	s.runExclusive(ctx, "lost_match", s.safeProcessLostMatches)
+	s.runExclusive(ctx, "question_ai", s.safeProcessUnansweredQuestions)
+	s.runExclusive(ctx, "club_reminders", s.safeProcessClubReminders)
+	s.runExclusive(ctx, "follow_fanout", s.safeProcessFollowFanout)
+	s.runExclusive(ctx, "tag_trending", s.safeRefreshTrendingTags)
+	s.runExclusive(ctx, "user_sanctions", s.safeExpireUserSanctions)
+	s.runExclusive(ctx, "moderation_claims", s.safeExpireModerationClaims)
 	s.runExclusive(ctx, "access_log_cleanup", s.safeCleanupAccessLogs)
 	s.runExclusive(ctx, "rag_eval_drafts", s.safeSeedRAGEvalDrafts)
 	var dailyReportTimer *time.Timer
@@ -97,6 +108,16 @@ func (s *CampusTaskServer) run(ctx context.Context) {
 	opsSLATicker := time.NewTicker(5 * time.Minute)
 	aiReplyTicker := time.NewTicker(5 * time.Second)
 	aiAuditTicker := time.NewTicker(5 * time.Second)
+	imageAuditTicker := time.NewTicker(10 * time.Second)
+	scheduledPostTicker := time.NewTicker(30 * time.Second)
+	pollTicker := time.NewTicker(1 * time.Minute)
+	lostMatchTicker := time.NewTicker(1 * time.Minute)
+	questionTicker := time.NewTicker(5 * time.Minute)
+	clubReminderTicker := time.NewTicker(1 * time.Minute)
+	followFanoutTicker := time.NewTicker(1 * time.Minute)
+	tagTrendingTicker := time.NewTicker(10 * time.Minute)
+	sanctionTicker := time.NewTicker(1 * time.Minute)
+	claimTicker := time.NewTicker(1 * time.Minute)
 	defer recommendTicker.Stop()
 	defer reconcileTicker.Stop()
 	defer flushTicker.Stop()
@@ -105,6 +126,16 @@ func (s *CampusTaskServer) run(ctx context.Context) {
 	defer opsSLATicker.Stop()
 	defer aiReplyTicker.Stop()
 	defer aiAuditTicker.Stop()
+	defer imageAuditTicker.Stop()
+	defer scheduledPostTicker.Stop()
+	defer pollTicker.Stop()
+	defer lostMatchTicker.Stop()
+	defer questionTicker.Stop()
+	defer clubReminderTicker.Stop()
+	defer followFanoutTicker.Stop()
+	defer tagTrendingTicker.Stop()
+	defer sanctionTicker.Stop()
+	defer claimTicker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
@@ -129,6 +160,26 @@ func (s *CampusTaskServer) run(ctx context.Context) {
 			s.runExclusive(ctx, "ai_replies", s.safeProcessAIReplyTasks)
 		case <-aiAuditTicker.C:
 			s.runExclusive(ctx, "ai_audit", s.safeProcessAIContentAuditTasks)
+		case <-imageAuditTicker.C:
+			s.runExclusive(ctx, "image_audit", s.safeProcessImageAuditTasks)
+		case <-scheduledPostTicker.C:
+			s.runExclusive(ctx, "scheduled_posts", s.safeProcessScheduledPosts)
+		case <-pollTicker.C:
+			s.runExclusive(ctx, "poll_close", s.safeProcessDuePolls)
+		case <-lostMatchTicker.C:
+			s.runExclusive(ctx, "lost_match", s.safeProcessLostMatches)
+		case <-questionTicker.C:
+			s.runExclusive(ctx, "question_ai", s.safeProcessUnansweredQuestions)
+		case <-clubReminderTicker.C:
+			s.runExclusive(ctx, "club_reminders", s.safeProcessClubReminders)
+		case <-followFanoutTicker.C:
+			s.runExclusive(ctx, "follow_fanout", s.safeProcessFollowFanout)
+		case <-tagTrendingTicker.C:
+			s.runExclusive(ctx, "tag_trending", s.safeRefreshTrendingTags)
+		case <-sanctionTicker.C:
+			s.runExclusive(ctx, "user_sanctions", s.safeExpireUserSanctions)
+		case <-claimTicker.C:
+			s.runExclusive(ctx, "moderation_claims", s.safeExpireModerationClaims)
 		case <-dailyReportTimerC(dailyReportTimer):
 			s.runExclusive(ctx, "daily_agent_report", s.safeRunDailyAgentReport)
 			if dailyReportTimer != nil {
@@ -167,6 +218,129 @@ func (s *CampusTaskServer) safeRefreshRecommendPool(ctx context.Context) {
 	}
 }
 
+func (s *CampusTaskServer) safeWarmSearchIndex(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, envDurationServer("CAMPUS_SEARCH_REINDEX_TIMEOUT", 30*time.Minute))
+	defer cancel()
+	if err := s.uc.WarmCampusSearchIndex(taskCtx); err != nil {
+		s.log.Warnf("预热校园搜索索引失败: %v", err)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessScheduledPosts(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	published, err := s.uc.ProcessDueScheduledPosts(taskCtx, 20)
+	if err != nil {
+		s.log.Warnf("发布定时帖子失败: %v", err)
+		return
+	}
+	if published > 0 {
+		s.log.Infof("发布定时帖子完成: published=%d", published)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessDuePolls(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	closed, err := s.uc.ProcessDuePolls(taskCtx, 50)
+	if err != nil {
+		s.log.Warnf("结束到期投票失败: %v", err)
+		return
+	}
+	if closed > 0 {
+		s.log.Infof("结束到期投票完成: closed=%d", closed)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessLostMatches(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	matched, err := s.uc.ProcessLostFoundMatches(taskCtx, 20)
+	if err != nil {
+		s.log.Warnf("失物招领匹配失败: %v", err)
+		return
+	}
+	if matched > 0 {
+		s.log.Infof("失物招领匹配完成: matched=%d", matched)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessUnansweredQuestions(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	queued, err := s.uc.ProcessUnansweredQuestions(taskCtx, 10)
+	if err != nil {
+		s.log.Warnf("排队e仔回答未解决问题失败: %v", err)
+		return
+	}
+	if queued > 0 {
+		s.log.Infof("排队e仔回答未解决问题完成: queued=%d", queued)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessClubReminders(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	sent, err := s.uc.ProcessClubEventReminders(taskCtx, 20)
+	if err != nil {
+		s.log.Warnf("发送活动开始提醒失败: %v", err)
+		return
+	}
+	if sent > 0 {
+		s.log.Infof("发送活动开始提醒完成: sent=%d", sent)
+	}
+}
+
+func (s *CampusTaskServer) safeProcessFollowFanout(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 50*time.Second)
+	defer cancel()
+	notified, err := s.uc.ProcessFollowerPostFanout(taskCtx, 20)
+	if err != nil {
+		s.log.Warnf("推送关注用户新帖失败: %v", err)
+		return
+	}
+	if notified > 0 {
+		s.log.Infof("推送关注用户新帖完成: notified=%d", notified)
+	}
+}
+
+func (s *CampusTaskServer) safeRefreshTrendingTags(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+	trending, err := s.uc.RefreshTrendingTags(taskCtx)
+	if err != nil {
+		s.log.Warnf("刷新热门话题失败: %v", err)
+		return
+	}
+	s.log.Infof("刷新热门话题完成: trending=%d", trending)
+}
+
+func (s *CampusTaskServer) safeExpireUserSanctions(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	expired, err := s.uc.ExpireUserSanctions(taskCtx, 50)
+	if err != nil {
+		s.log.Warnf("解除到期用户处罚失败: %v", err)
+		return
+	}
+	if expired > 0 {
+		s.log.Infof("解除到期用户处罚完成: expired=%d", expired)
+	}
+}
+
+func (s *CampusTaskServer) safeExpireModerationClaims(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	expired, err := s.uc.ExpireModerationClaims(taskCtx)
+	if err != nil {
+		s.log.Warnf("清理过期审核认领失败: %v", err)
+		return
+	}
+	if expired > 0 {
+		s.log.Infof("清理过期审核认领完成: expired=%d", expired)
+	}
+}
+
 func (s *CampusTaskServer) safeReconcile(ctx context.Context) {
 	taskCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
@@ -239,6 +413,14 @@ func (s *CampusTaskServer) safeProcessAIContentAuditTasks(ctx context.Context) {
 	}
 }
 
+func (s *CampusTaskServer) safeProcessImageAuditTasks(ctx context.Context) {
+	taskCtx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+	if err := s.uc.ProcessPendingImageAuditTasks(taskCtx, 5); err != nil {
+		s.log.Warnf("处理校园图片审核任务失败: %v", err)
+	}
+}
+
 func (s *CampusTaskServer) safeRunDailyAgentReport(ctx context.Context) {
 	taskCtx, cancel := context.WithTimeout(ctx, 45*time.Second)
 	defer cancel()
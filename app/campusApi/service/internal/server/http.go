@@ -35,9 +35,17 @@ func NewCampusWhiteListMatcher() selector.MatchFunc {
 		"/v1/campus/forum/posts":                                    {},
 		"/v1/campus/forum/posts/{id}":                               {},
 		"/v1/campus/forum/posts/{id}/comments":                      {},
+		"/v1/campus/forum/posts/{id}/poll":                          {},
+		"/v1/campus/search":                                         {},
+		"/v1/campus/tags/trending":                                  {},
+		"/v1/campus/tags/{name}":                                    {},
 		"/v1/campus/users/{id}":                                     {},
 		"/v1/campus/users/{id}/posts":                               {},
+		"/v1/campus/users/{id}/followers":                           {},
+		"/v1/campus/users/{id}/following":                           {},
 		"/v1/campus/analytics/track":                                {},
+		"/v1/campus/timetable/feed/{token}.ics":                     {},
+		"/v1/campus/calendar":                                       {},
 		"/v1/campus/internal/ops-metrics":                           {},
 		"/healthz":                                                  {},
 		"/readyz":                                                   {},
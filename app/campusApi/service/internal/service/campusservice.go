@@ -25,6 +25,7 @@ import (
 	"lehu-video/app/campusApi/service/internal/pkg/utils/claims"
 	"lehu-video/pkg/apperror"
 	sharedauth "lehu-video/pkg/auth"
+	"lehu-video/pkg/imagehash"
 )
 
 type CampusService struct {
@@ -50,22 +51,55 @@ func (s *CampusService) RegisterRoutes(srv *khttp.Server) {
 	r.GET("/v1/campus/profile", s.wrap(s.authRequired(s.handleGetProfile)))
 	r.PUT("/v1/campus/profile", s.wrap(s.authRequired(s.handleUpdateProfile)))
 	r.PUT("/v1/campus/me/avatar", s.wrap(s.authRequired(s.handleUpdateAvatar)))
+	r.GET("/v1/campus/me/blocks", s.wrap(s.authRequired(s.handleListUserBlocks)))
+	r.GET("/v1/campus/me/sanctions", s.wrap(s.authRequired(s.handleListMySanctions)))
+	r.GET("/v1/campus/me/appeals", s.wrap(s.authRequired(s.handleListMyAppeals)))
 	r.GET("/v1/campus/timetable", s.wrap(s.authRequired(s.handleListTimetable)))
 	r.POST("/v1/campus/timetable/import", s.wrap(s.authRequired(s.handleImportTimetable)))
+	r.GET("/v1/campus/timetable/ics", s.wrap(s.authRequired(s.handleExportTimetableICS)))
+	r.GET("/v1/campus/timetable/subscription", s.wrap(s.authRequired(s.handleGetTimetableSubscription)))
+	r.POST("/v1/campus/timetable/subscription", s.wrap(s.authRequired(s.handleRotateTimetableSubscription)))
+	r.DELETE("/v1/campus/timetable/subscription", s.wrap(s.authRequired(s.handleRevokeTimetableSubscription)))
+	r.GET("/v1/campus/timetable/feed/{token}.ics", s.wrap(s.handleTimetableFeed))
+	r.GET("/v1/campus/calendar", s.wrap(s.handleListCalendar))
 	r.POST("/v1/campus/analytics/track", s.wrap(s.handleTrackEvent))
 	r.POST("/v1/campus/upload/presign", s.wrap(s.authRequired(s.handleUploadPresign)))
 	r.POST("/v1/campus/upload/complete", s.wrap(s.authRequired(s.handleUploadComplete)))
 	r.POST("/v1/campus/upload/image", s.wrap(s.authRequired(s.handleUploadImage)))
 	r.GET("/v1/campus/forum/categories", s.wrap(s.handleListCategories))
 	r.GET("/v1/campus/forum/posts", s.wrap(s.handleListPosts))
+	r.GET("/v1/campus/search", s.wrap(s.handleSearch))
+	r.GET("/v1/campus/tags/trending", s.wrap(s.handleListTrendingTags))
+	r.GET("/v1/campus/tags/{name}", s.wrap(s.handleGetTag))
 	r.GET("/v1/campus/users/{id}", s.wrap(s.handleGetPublicUserProfile))
 	r.GET("/v1/campus/users/{id}/posts", s.wrap(s.handleListPublicUserPosts))
+	r.GET("/v1/campus/users/{id}/followers", s.wrap(s.handleListFollowers))
+	r.GET("/v1/campus/users/{id}/following", s.wrap(s.handleListFollowing))
+	r.POST("/v1/campus/users/{id}/follow", s.wrap(s.authRequired(s.handleFollowUser)))
+	r.DELETE("/v1/campus/users/{id}/follow", s.wrap(s.authRequired(s.handleUnfollowUser)))
+	r.POST("/v1/campus/users/{id}/block", s.wrap(s.authRequired(s.handleBlockUser)))
+	r.DELETE("/v1/campus/users/{id}/block", s.wrap(s.authRequired(s.handleUnblockUser)))
+	r.POST("/v1/campus/users/{id}/mute", s.wrap(s.authRequired(s.handleMuteUser)))
+	r.DELETE("/v1/campus/users/{id}/mute", s.wrap(s.authRequired(s.handleUnmuteUser)))
 	r.POST("/v1/campus/forum/posts", s.wrap(s.authRequired(s.handleCreatePost)))
 	r.GET("/v1/campus/forum/my-posts", s.wrap(s.authRequired(s.handleListMyPosts)))
 	r.GET("/v1/campus/forum/my-collections", s.wrap(s.authRequired(s.handleListMyCollections)))
 	r.GET("/v1/campus/forum/my-comments", s.wrap(s.authRequired(s.handleListMyComments)))
 	r.GET("/v1/campus/forum/posts/{id}", s.wrap(s.handleGetPost))
+	r.PUT("/v1/campus/forum/posts/{id}", s.wrap(s.authRequired(s.handleUpdatePost)))
 	r.DELETE("/v1/campus/forum/posts/{id}", s.wrap(s.authRequired(s.handleDeletePost)))
+	r.GET("/v1/campus/forum/posts/{id}/poll", s.wrap(s.handleGetPostPoll))
+	r.POST("/v1/campus/forum/posts/{id}/poll/vote", s.wrap(s.authRequired(s.handleVotePoll)))
+	r.POST("/v1/campus/forum/posts/{id}/poll/close", s.wrap(s.authRequired(s.handleClosePoll)))
+	r.POST("/v1/campus/forum/posts/{id}/accepted-answer", s.wrap(s.authRequired(s.handleAcceptAnswer)))
+	r.DELETE("/v1/campus/forum/posts/{id}/accepted-answer", s.wrap(s.authRequired(s.handleUnacceptAnswer)))
+	r.POST("/v1/campus/forum/posts/{id}/event/register", s.wrap(s.authRequired(s.handleRegisterClubEvent)))
+	r.POST("/v1/campus/forum/posts/{id}/event/cancel", s.wrap(s.authRequired(s.handleCancelClubRegistration)))
+	r.POST("/v1/campus/forum/posts/{id}/event/checkin", s.wrap(s.authRequired(s.handleCheckInClubEvent)))
+	r.GET("/v1/campus/forum/posts/{id}/event/attendees", s.wrap(s.authRequired(s.handleListClubRegistrations)))
+	r.GET("/v1/campus/forum/posts/{id}/event/attendees/export", s.wrap(s.authRequired(s.handleExportClubRegistrations)))
+	r.GET("/v1/campus/forum/posts/{id}/lost/matches", s.wrap(s.authRequired(s.handleListLostMatches)))
+	r.POST("/v1/campus/forum/posts/{id}/lost/resolve", s.wrap(s.authRequired(s.handleResolveLostItem)))
 	r.GET("/v1/campus/forum/posts/{id}/comments", s.wrap(s.handleListComments))
 	r.POST("/v1/campus/forum/posts/{id}/comments", s.wrap(s.authRequired(s.handleCreateComment)))
 	r.POST("/v1/campus/forum/posts/{id}/like", s.wrap(s.authRequired(s.handleLikePost)))
@@ -78,6 +112,8 @@ func (s *CampusService) RegisterRoutes(srv *khttp.Server) {
 	r.DELETE("/v1/campus/forum/comments/{id}/like", s.wrap(s.authRequired(s.handleUnlikeComment)))
 	r.DELETE("/v1/campus/forum/comments/{id}", s.wrap(s.authRequired(s.handleDeleteComment)))
 	r.POST("/v1/campus/forum/comments/{id}/report", s.wrap(s.authRequired(s.handleReportComment)))
+	r.POST("/v1/campus/forum/posts/{id}/appeal", s.wrap(s.authRequired(s.handleAppealPost)))
+	r.POST("/v1/campus/forum/comments/{id}/appeal", s.wrap(s.authRequired(s.handleAppealComment)))
 	r.POST("/v1/campus/feedback", s.wrap(s.authRequired(s.handleCreateFeedback)))
 	r.GET("/v1/campus/feishu/card/callback", s.wrap(s.handleFeishuCardCallback))
 	r.POST("/v1/campus/feishu/card/callback", s.wrap(s.handleFeishuCardCallback))
@@ -89,10 +125,22 @@ func (s *CampusService) RegisterRoutes(srv *khttp.Server) {
 	r.GET("/v1/campus/moderation/comments", s.wrap(s.authRequired(s.handleListModerationComments)))
 	r.POST("/v1/campus/moderation/posts/{id}/review", s.wrap(s.authRequired(s.handleReviewPost)))
 	r.POST("/v1/campus/moderation/comments/{id}/review", s.wrap(s.authRequired(s.handleReviewComment)))
+	r.POST("/v1/campus/moderation/claims/{type}/{id}", s.wrap(s.authRequired(s.handleClaimModerationItem)))
+	r.POST("/v1/campus/moderation/claims/{type}/{id}/release", s.wrap(s.authRequired(s.handleReleaseModerationItem)))
 	r.GET("/v1/campus/admin/summary", s.wrap(s.authRequired(s.handleAdminSummary)))
 	r.GET("/v1/campus/admin/settings/audit", s.wrap(s.authRequired(s.handleAdminGetAuditSettings)))
 	r.PUT("/v1/campus/admin/settings/audit", s.wrap(s.authRequired(s.handleAdminUpdateAuditSettings)))
+	r.GET("/v1/campus/admin/settings/anonymous", s.wrap(s.authRequired(s.handleAdminGetAnonymousSettings)))
+	r.PUT("/v1/campus/admin/settings/anonymous", s.wrap(s.authRequired(s.handleAdminUpdateAnonymousSettings)))
 	r.GET("/v1/campus/admin/settings/agent", s.wrap(s.authRequired(s.handleAdminGetAgentSettings)))
+	r.GET("/v1/campus/admin/timetable/calendar", s.wrap(s.authRequired(s.handleAdminGetTimetableCalendar)))
+	r.PUT("/v1/campus/admin/timetable/calendar", s.wrap(s.authRequired(s.handleAdminUpdateTimetableCalendar)))
+	r.GET("/v1/campus/admin/calendar", s.wrap(s.authRequired(s.handleAdminListCalendar)))
+	r.POST("/v1/campus/admin/calendar", s.wrap(s.authRequired(s.handleAdminCreateCalendarEvent)))
+	r.PUT("/v1/campus/admin/calendar/{id}", s.wrap(s.authRequired(s.handleAdminUpdateCalendarEvent)))
+	r.DELETE("/v1/campus/admin/calendar/{id}", s.wrap(s.authRequired(s.handleAdminDeleteCalendarEvent)))
+	r.GET("/v1/campus/admin/search/status", s.wrap(s.authRequired(s.handleAdminSearchStatus)))
+	r.POST("/v1/campus/admin/search/reindex", s.wrap(s.authRequired(s.handleAdminReindexSearch)))
 	r.PUT("/v1/campus/admin/settings/agent", s.wrap(s.authRequired(s.handleAdminUpdateAgentSettings)))
 	r.GET("/v1/campus/admin/ezai/persona", s.wrap(s.authRequired(s.handleAdminGetEzaiPersona)))
 	r.PUT("/v1/campus/admin/ezai/persona", s.wrap(s.authRequired(s.handleAdminUpdateEzaiPersona)))
@@ -108,8 +156,23 @@ func (s *CampusService) RegisterRoutes(srv *khttp.Server) {
 	r.GET("/v1/campus/admin/posts", s.wrap(s.authRequired(s.handleAdminListPosts)))
 	r.POST("/v1/campus/admin/posts", s.wrap(s.authRequired(s.handleAdminCreatePost)))
 	r.POST("/v1/campus/admin/posts/batch", s.wrap(s.authRequired(s.handleAdminBatchPosts)))
+	r.GET("/v1/campus/admin/posts/scheduled", s.wrap(s.authRequired(s.handleAdminListPostSchedules)))
+	r.GET("/v1/campus/admin/questions", s.wrap(s.authRequired(s.handleAdminListQuestions)))
 	r.PUT("/v1/campus/admin/posts/{id}", s.wrap(s.authRequired(s.handleAdminUpdatePost)))
 	r.DELETE("/v1/campus/admin/posts/{id}", s.wrap(s.authRequired(s.handleAdminDeletePost)))
+	r.GET("/v1/campus/admin/posts/{id}/revisions", s.wrap(s.authRequired(s.handleAdminListPostRevisions)))
+	r.GET("/v1/campus/admin/posts/{id}/image-audits", s.wrap(s.authRequired(s.handleAdminListPostImageAudits)))
+	r.GET("/v1/campus/admin/image-hash-blocks", s.wrap(s.authRequired(s.handleAdminListImageHashBlocks)))
+	r.POST("/v1/campus/admin/image-hash-blocks", s.wrap(s.authRequired(s.handleAdminCreateImageHashBlocks)))
+	r.DELETE("/v1/campus/admin/image-hash-blocks/{id}", s.wrap(s.authRequired(s.handleAdminDeleteImageHashBlock)))
+	r.GET("/v1/campus/admin/audit-rules", s.wrap(s.authRequired(s.handleAdminGetAuditRules)))
+	r.POST("/v1/campus/admin/audit-rules", s.wrap(s.authRequired(s.handleAdminSaveAuditRule)))
+	r.POST("/v1/campus/admin/audit-rules/dry-run", s.wrap(s.authRequired(s.handleAdminDryRunAuditRules)))
+	r.PUT("/v1/campus/admin/audit-rules/{id}", s.wrap(s.authRequired(s.handleAdminSaveAuditRule)))
+	r.DELETE("/v1/campus/admin/audit-rules/{id}", s.wrap(s.authRequired(s.handleAdminDeleteAuditRule)))
+	r.PUT("/v1/campus/admin/audit-rule-categories", s.wrap(s.authRequired(s.handleAdminUpdateAuditRuleCategories)))
+	r.PUT("/v1/campus/admin/posts/{id}/schedule", s.wrap(s.authRequired(s.handleAdminReschedulePost)))
+	r.POST("/v1/campus/admin/posts/{id}/schedule/cancel", s.wrap(s.authRequired(s.handleAdminCancelPostSchedule)))
 	r.GET("/v1/campus/admin/moments/candidates", s.wrap(s.authRequired(s.handleAdminListMomentsCandidates)))
 	r.POST("/v1/campus/admin/moments/packages", s.wrap(s.authRequired(s.handleAdminCreateMomentsPackage)))
 	r.GET("/v1/campus/admin/moments/packages/{id}/images/{slot}.png", s.wrap(s.authRequired(s.handleAdminGetMomentsImage)))
@@ -137,13 +200,28 @@ func (s *CampusService) RegisterRoutes(srv *khttp.Server) {
 	r.POST("/v1/campus/admin/knowledge/upload", s.wrap(s.authRequired(s.handleAdminUploadKnowledgeFile)))
 	r.GET("/v1/campus/admin/reports", s.wrap(s.authRequired(s.handleAdminListReports)))
 	r.POST("/v1/campus/admin/reports/{id}/review", s.wrap(s.authRequired(s.handleAdminReviewReport)))
+	r.GET("/v1/campus/admin/report-groups", s.wrap(s.authRequired(s.handleAdminListReportGroups)))
+	r.POST("/v1/campus/admin/report-groups/{type}/{id}/review", s.wrap(s.authRequired(s.handleAdminReviewReportGroup)))
+	r.POST("/v1/campus/admin/moderation/claims/{type}/{id}/assign", s.wrap(s.authRequired(s.handleAdminAssignModerationItem)))
+	r.GET("/v1/campus/admin/moderation/operator-stats", s.wrap(s.authRequired(s.handleAdminModerationOperatorStats)))
 	r.GET("/v1/campus/admin/feedback", s.wrap(s.authRequired(s.handleAdminListFeedback)))
 	r.POST("/v1/campus/admin/feedback/{id}/review", s.wrap(s.authRequired(s.handleAdminReviewFeedback)))
+	r.GET("/v1/campus/admin/appeals", s.wrap(s.authRequired(s.handleAdminListAppeals)))
+	r.POST("/v1/campus/admin/appeals/{id}/review", s.wrap(s.authRequired(s.handleAdminReviewAppeal)))
 	r.GET("/v1/campus/admin/security", s.wrap(s.authRequired(s.handleAdminSecurityOverview)))
 	r.POST("/v1/campus/admin/security/ip-blocks", s.wrap(s.authRequired(s.handleAdminBlockIP)))
 	r.DELETE("/v1/campus/admin/security/ip-blocks/{id}", s.wrap(s.authRequired(s.handleAdminUnblockIP)))
 	r.GET("/v1/campus/admin/users", s.wrap(s.authRequired(s.handleAdminListUsers)))
 	r.PUT("/v1/campus/admin/users/{id}/role", s.wrap(s.authRequired(s.handleAdminUpdateUserRole)))
+	r.GET("/v1/campus/admin/users/{id}/sanctions", s.wrap(s.authRequired(s.handleAdminListUserSanctions)))
+	r.POST("/v1/campus/admin/users/{id}/sanctions", s.wrap(s.authRequired(s.handleAdminCreateUserSanction)))
+	r.POST("/v1/campus/admin/sanctions/{id}/revoke", s.wrap(s.authRequired(s.handleAdminRevokeUserSanction)))
+	r.GET("/v1/campus/admin/tags", s.wrap(s.authRequired(s.handleAdminListTags)))
+	r.POST("/v1/campus/admin/tags/{id}/merge", s.wrap(s.authRequired(s.handleAdminMergeTag)))
+	r.POST("/v1/campus/admin/tags/{id}/ban", s.wrap(s.authRequired(s.handleAdminBanTag)))
+	r.DELETE("/v1/campus/admin/tags/{id}/ban", s.wrap(s.authRequired(s.handleAdminUnbanTag)))
+	r.POST("/v1/campus/admin/tags/{id}/feature", s.wrap(s.authRequired(s.handleAdminFeatureTag)))
+	r.DELETE("/v1/campus/admin/tags/{id}/feature", s.wrap(s.authRequired(s.handleAdminUnfeatureTag)))
 	r.POST("/v1/campus/admin/notifications", s.wrap(s.authRequired(s.handleAdminCreateNotification)))
 	r.GET("/v1/campus/internal/ops-metrics", s.wrap(s.handleOpsMetrics))
 	r.GET("/v1/campus/internal/copilot/tools/admin-summary", s.wrap(s.handleCopilotToolAdminSummary))
@@ -261,6 +339,7 @@ func (s *CampusService) handleWechatLogin(w http.ResponseWriter, r *http.Request
 }
 
 type profileRequest struct {
+	CampusCode   string `json:"campus_code"`
 	SchoolName   string `json:"school_name"`
 	StudentNo    string `json:"student_no"`
 	RealName     string `json:"real_name"`
@@ -283,10 +362,37 @@ type trackEventRequest struct {
 	Extra      map[string]string `json:"extra"`
 }
 
+type timetableCalendarRequest struct {
+	CampusCode string                        `json:"campus_code"`
+	Sections   []timetableSectionTimeRequest `json:"sections"`
+	TermStarts map[string]string             `json:"term_starts"`
+}
+
+type calendarEventRequest struct {
+	CampusCode  string `json:"campus_code"`
+	Term        string `json:"term"`
+	EventType   string `json:"event_type"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Location    string `json:"location"`
+	StartAt     string `json:"start_at"`
+	EndAt       string `json:"end_at"`
+	AllDay      bool   `json:"all_day"`
+	Hidden      bool   `json:"hidden"`
+}
+
+type timetableSectionTimeRequest struct {
+	Section int32  `json:"section"`
+	Start   string `json:"start"`
+	End     string `json:"end"`
+}
+
 type importTimetableRequest struct {
-	StudentNo string `json:"student_no"`
-	Password  string `json:"password"`
-	Term      string `json:"term"`
+	StudentNo    string `json:"student_no"`
+	Password     string `json:"password"`
+	Term         string `json:"term"`
+	CaptchaToken string `json:"captcha_token"`
+	Captcha      string `json:"captcha"`
 }
 
 func (s *CampusService) handleGetProfile(w http.ResponseWriter, r *http.Request) {
@@ -307,6 +413,7 @@ func (s *CampusService) handleUpdateProfile(w http.ResponseWriter, r *http.Reque
 	userID, _ := s.userIDFromRequest(r)
 	profile, err := s.uc.UpdateProfile(r.Context(), &biz.UpdateCampusProfileInput{
 		UserID:       userID,
+		CampusCode:   req.CampusCode,
 		SchoolName:   req.SchoolName,
 		StudentNo:    req.StudentNo,
 		RealName:     req.RealName,
@@ -386,26 +493,260 @@ func (s *CampusService) handleImportTimetable(w http.ResponseWriter, r *http.Req
 	}
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.ImportTimetable(r.Context(), &biz.ImportCampusTimetableInput{
-		UserID:    userID,
-		StudentNo: req.StudentNo,
-		Password:  req.Password,
-		Term:      req.Term,
+		UserID:       userID,
+		StudentNo:    req.StudentNo,
+		Password:     req.Password,
+		Term:         req.Term,
+		CaptchaToken: req.CaptchaToken,
+		Captcha:      req.Captcha,
 	})
 	if err != nil {
 		writeError(w, r, err)
 		return
 	}
+	if out.Captcha != nil {
+		writeJSON(w, r, map[string]interface{}{
+			"term":             out.Term,
+			"captcha_required": true,
+			"captcha": map[string]interface{}{
+				"token":        out.Captcha.Token,
+				"image_base64": out.Captcha.ImageBase64,
+				"content_type": out.Captcha.ContentType,
+				"expires_at":   formatTime(out.Captcha.ExpiresAt),
+			},
+		})
+		return
+	}
 	courses := make([]map[string]interface{}, 0, len(out.Courses))
 	for _, course := range out.Courses {
 		courses = append(courses, timetableCourseToMap(course))
 	}
 	writeJSON(w, r, map[string]interface{}{
-		"term":    out.Term,
-		"count":   out.Count,
-		"courses": courses,
+		"term":             out.Term,
+		"count":            out.Count,
+		"captcha_required": false,
+		"courses":          courses,
 	})
 }
 
+func (s *CampusService) handleExportTimetableICS(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	ics, err := s.uc.ExportTimetableICS(r.Context(), &biz.ExportCampusTimetableICSInput{
+		UserID: userID,
+		Term:   r.URL.Query().Get("term"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeICS(w, ics, true)
+}
+
+func (s *CampusService) handleTimetableFeed(w http.ResponseWriter, r *http.Request) {
+	ics, err := s.uc.TimetableFeed(r.Context(), mux.Vars(r)["token"], r.URL.Query().Get("term"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeICS(w, ics, false)
+}
+
+func (s *CampusService) handleGetTimetableSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.GetTimetableSubscription(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"subscription": timetableSubscriptionToMap(out)})
+}
+
+func (s *CampusService) handleRotateTimetableSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.RotateTimetableSubscription(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"subscription": timetableSubscriptionToMap(out)})
+}
+
+func (s *CampusService) handleRevokeTimetableSubscription(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.RevokeTimetableSubscription(r.Context(), userID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAdminGetTimetableCalendar(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	config, err := s.uc.AdminGetTimetableCalendar(r.Context(), &biz.GetCampusTimetableCalendarInput{
+		UserID:     userID,
+		CampusCode: r.URL.Query().Get("campus_code"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"calendar": timetableCalendarToMap(config)})
+}
+
+func (s *CampusService) handleAdminUpdateTimetableCalendar(w http.ResponseWriter, r *http.Request) {
+	var req timetableCalendarRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	sections := make([]*biz.CampusTimetableSectionTime, 0, len(req.Sections))
+	for _, item := range req.Sections {
+		sections = append(sections, &biz.CampusTimetableSectionTime{Section: item.Section, Start: item.Start, End: item.End})
+	}
+	userID, _ := s.userIDFromRequest(r)
+	config, err := s.uc.AdminUpdateTimetableCalendar(r.Context(), &biz.UpdateCampusTimetableCalendarInput{
+		UserID:     userID,
+		CampusCode: req.CampusCode,
+		Sections:   sections,
+		TermStarts: req.TermStarts,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"calendar": timetableCalendarToMap(config)})
+}
+
+func (s *CampusService) handleListCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	out, err := s.uc.ListCalendar(r.Context(), &biz.ListCampusCalendarInput{
+		CurrentUserID: userID,
+		CampusCode:    q.Get("campus_code"),
+		EventType:     q.Get("type"),
+		From:          parseOptionalRequestTime(q.Get("from")),
+		To:            parseOptionalRequestTime(q.Get("to")),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(out.Events))
+	for _, event := range out.Events {
+		items = append(items, calendarEventToMap(event))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"campus_code": out.CampusCode,
+		"term_week":   termWeekToMap(out.TermWeek),
+		"events":      items,
+	})
+}
+
+func (s *CampusService) handleAdminListCalendar(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListCalendar(r.Context(), &biz.ListCampusAdminCalendarInput{
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Term:       q.Get("term"),
+		EventType:  q.Get("type"),
+		From:       parseOptionalRequestTime(q.Get("from")),
+		To:         parseOptionalRequestTime(q.Get("to")),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(out.Events))
+	for _, event := range out.Events {
+		items = append(items, calendarEventToMap(event))
+	}
+	writeJSON(w, r, map[string]interface{}{"events": items, "page_stats": map[string]interface{}{"total": out.Total}})
+}
+
+func (s *CampusService) handleAdminCreateCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	var req calendarEventRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	event, err := s.uc.AdminCreateCalendarEvent(r.Context(), calendarEventInput(userID, 0, &req))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"event": calendarEventToMap(event)})
+}
+
+func (s *CampusService) handleAdminUpdateCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req calendarEventRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	event, err := s.uc.AdminUpdateCalendarEvent(r.Context(), calendarEventInput(userID, eventID, &req))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"event": calendarEventToMap(event)})
+}
+
+func (s *CampusService) handleAdminDeleteCalendarEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminDeleteCalendarEvent(r.Context(), &biz.DeleteCampusCalendarEventInput{UserID: userID, EventID: eventID}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func calendarEventInput(userID string, eventID int64, req *calendarEventRequest) *biz.SaveCampusCalendarEventInput {
+	return &biz.SaveCampusCalendarEventInput{
+		UserID:      userID,
+		EventID:     eventID,
+		CampusCode:  req.CampusCode,
+		Term:        req.Term,
+		EventType:   req.EventType,
+		Title:       req.Title,
+		Description: req.Description,
+		Location:    req.Location,
+		StartAt:     parseOptionalRequestTime(req.StartAt),
+		EndAt:       parseOptionalRequestTime(req.EndAt),
+		AllDay:      req.AllDay,
+		Hidden:      req.Hidden,
+	}
+}
+
+func (s *CampusService) handleAdminSearchStatus(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	status, err := s.uc.AdminSearchStatus(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"status": searchReindexStatusToMap(status)})
+}
+
+func (s *CampusService) handleAdminReindexSearch(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	status, err := s.uc.AdminReindexSearch(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"status": searchReindexStatusToMap(status)})
+}
+
 func (s *CampusService) handleUploadImage(w http.ResponseWriter, r *http.Request) {
 	if !legacyUploadEnabled() {
 		writeError(w, r, apperror.InvalidArgument("图片中转上传已关闭，请使用直传"))
@@ -618,7 +959,8 @@ func (s *CampusService) handleAdminUploadKnowledgeFile(w http.ResponseWriter, r
 }
 
 func (s *CampusService) handleListCategories(w http.ResponseWriter, r *http.Request) {
-	categories, err := s.uc.ListCategories(r.Context())
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	categories, err := s.uc.ListCategories(r.Context(), currentUserID, r.URL.Query().Get("campus_code"))
 	if err != nil {
 		writeError(w, r, err)
 		return
@@ -635,10 +977,13 @@ func (s *CampusService) handleListPosts(w http.ResponseWriter, r *http.Request)
 	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
 	out, err := s.uc.ListPosts(r.Context(), &biz.ListCampusPostsInput{
 		CurrentUserID: currentUserID,
+		CampusCode:    q.Get("campus_code"),
 		CategoryCode:  q.Get("category_code"),
 		PostType:      q.Get("post_type"),
+		Tag:           q.Get("tag"),
 		Sort:          q.Get("sort"),
 		Keyword:       q.Get("keyword"),
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -652,6 +997,71 @@ func (s *CampusService) handleListPosts(w http.ResponseWriter, r *http.Request)
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"posts": posts,
+		"page_stats": map[string]interface{}{
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
+		},
+	})
+}
+
+func (s *CampusService) handleListTrendingTags(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	tags, err := s.uc.ListTrendingTags(r.Context(), currentUserID, q.Get("campus_code"), queryInt(q.Get("limit"), 0))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		items = append(items, tagToMap(tag))
+	}
+	writeJSON(w, r, map[string]interface{}{"tags": items})
+}
+
+func (s *CampusService) handleGetTag(w http.ResponseWriter, r *http.Request) {
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	tag, err := s.uc.GetCampusTag(r.Context(), currentUserID, r.URL.Query().Get("campus_code"), mux.Vars(r)["name"])
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"tag": tagToMap(tag)})
+}
+
+func (s *CampusService) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	out, err := s.uc.SearchCampus(r.Context(), &biz.SearchCampusInput{
+		CurrentUserID: currentUserID,
+		CampusCode:    q.Get("campus_code"),
+		Keyword:       q.Get("keyword"),
+		Type:          q.Get("type"),
+		CategoryCode:  q.Get("category_code"),
+		Page:          int32(queryInt(q.Get("page"), 1)),
+		Size:          int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	results := make([]map[string]interface{}, 0, len(out.Results))
+	for _, result := range out.Results {
+		item := map[string]interface{}{
+			"type":       result.DocType,
+			"post":       postToMap(result.Post),
+			"score":      result.Score,
+			"highlight":  searchHighlightToMap(result.Highlight),
+			"created_at": formatTime(result.CreatedAt),
+		}
+		if result.CommentID > 0 {
+			item["comment_id"] = strconv.FormatInt(result.CommentID, 10)
+		}
+		results = append(results, item)
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"results": results,
+		"backend": out.Backend,
 		"page_stats": map[string]interface{}{
 			"total": out.Total,
 		},
@@ -663,7 +1073,8 @@ func (s *CampusService) handleGetPublicUserProfile(w http.ResponseWriter, r *htt
 	if !ok {
 		return
 	}
-	profile, err := s.uc.GetPublicCampusUserProfile(r.Context(), userID)
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	profile, err := s.uc.GetPublicCampusUserProfile(r.Context(), userID, currentUserID)
 	if err != nil {
 		writeError(w, r, err)
 		return
@@ -671,6 +1082,150 @@ func (s *CampusService) handleGetPublicUserProfile(w http.ResponseWriter, r *htt
 	writeJSON(w, r, map[string]interface{}{"user": publicUserProfileToMap(profile)})
 }
 
+func (s *CampusService) handleFollowUser(w http.ResponseWriter, r *http.Request) {
+	s.setFollow(w, r, true)
+}
+
+func (s *CampusService) handleUnfollowUser(w http.ResponseWriter, r *http.Request) {
+	s.setFollow(w, r, false)
+}
+
+func (s *CampusService) setFollow(w http.ResponseWriter, r *http.Request, follow bool) {
+	targetUserID, ok := pathStringID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	input := &biz.FollowCampusUserInput{UserID: userID, TargetUserID: targetUserID}
+	var state *biz.CampusFollowState
+	var err error
+	if follow {
+		state, err = s.uc.FollowUser(r.Context(), input)
+	} else {
+		state, err = s.uc.UnfollowUser(r.Context(), input)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"user_id":   state.TargetUserID,
+		"following": state.Following,
+		"changed":   state.Changed,
+	})
+}
+
+func (s *CampusService) handleBlockUser(w http.ResponseWriter, r *http.Request) {
+	s.setUserBlock(w, r, biz.CampusUserBlockKindBlock, true)
+}
+
+func (s *CampusService) handleUnblockUser(w http.ResponseWriter, r *http.Request) {
+	s.setUserBlock(w, r, biz.CampusUserBlockKindBlock, false)
+}
+
+func (s *CampusService) handleMuteUser(w http.ResponseWriter, r *http.Request) {
+	s.setUserBlock(w, r, biz.CampusUserBlockKindMute, true)
+}
+
+func (s *CampusService) handleUnmuteUser(w http.ResponseWriter, r *http.Request) {
+	s.setUserBlock(w, r, biz.CampusUserBlockKindMute, false)
+}
+
+func (s *CampusService) setUserBlock(w http.ResponseWriter, r *http.Request, kind string, enabled bool) {
+	targetUserID, ok := pathStringID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	input := &biz.SetCampusUserBlockInput{UserID: userID, TargetUserID: targetUserID, Kind: kind}
+	if !enabled {
+		if err := s.uc.UnblockUser(r.Context(), input); err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeJSON(w, r, map[string]interface{}{"user_id": targetUserID, "kind": kind, "enabled": false})
+		return
+	}
+	block, err := s.uc.BlockUser(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"user_id": block.TargetUserID, "kind": block.Kind, "enabled": true})
+}
+
+func (s *CampusService) handleListUserBlocks(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	q := r.URL.Query()
+	out, err := s.uc.ListUserBlocks(r.Context(), &biz.ListCampusUserBlocksInput{
+		UserID: userID,
+		Kind:   q.Get("kind"),
+		Cursor: q.Get("cursor"),
+		Page:   int32(queryInt(q.Get("page"), 1)),
+		Size:   int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	blocks := make([]map[string]interface{}, 0, len(out.Blocks))
+	for _, block := range out.Blocks {
+		blocks = append(blocks, map[string]interface{}{
+			"user_id":    block.TargetUserID,
+			"kind":       block.Kind,
+			"user":       authorToMap(block.Target),
+			"created_at": formatTime(block.CreatedAt),
+		})
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"blocks":     blocks,
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
+	})
+}
+
+func (s *CampusService) handleListFollowers(w http.ResponseWriter, r *http.Request) {
+	s.listFollowUsers(w, r, true)
+}
+
+func (s *CampusService) handleListFollowing(w http.ResponseWriter, r *http.Request) {
+	s.listFollowUsers(w, r, false)
+}
+
+func (s *CampusService) listFollowUsers(w http.ResponseWriter, r *http.Request, followers bool) {
+	userID, ok := pathStringID(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	input := &biz.ListCampusFollowInput{
+		UserID:        userID,
+		CurrentUserID: currentUserID,
+		Cursor:        q.Get("cursor"),
+		Page:          int32(queryInt(q.Get("page"), 1)),
+		Size:          int32(queryInt(q.Get("size"), 20)),
+	}
+	var out *biz.ListCampusFollowOutput
+	var err error
+	if followers {
+		out, err = s.uc.ListFollowers(r.Context(), input)
+	} else {
+		out, err = s.uc.ListFollowing(r.Context(), input)
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	users := make([]map[string]interface{}, 0, len(out.Users))
+	for _, user := range out.Users {
+		users = append(users, followUserToMap(user))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"users":      users,
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
+	})
+}
+
 func (s *CampusService) handleListPublicUserPosts(w http.ResponseWriter, r *http.Request) {
 	userID, ok := pathStringID(w, r)
 	if !ok {
@@ -683,6 +1238,7 @@ func (s *CampusService) handleListPublicUserPosts(w http.ResponseWriter, r *http
 		AuthorID:      userID,
 		PostType:      q.Get("post_type"),
 		Sort:          q.Get("sort"),
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -696,7 +1252,7 @@ func (s *CampusService) handleListPublicUserPosts(w http.ResponseWriter, r *http
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"posts":      posts,
-		"page_stats": map[string]interface{}{"total": out.Total},
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
 	})
 }
 
@@ -709,6 +1265,7 @@ func (s *CampusService) handleListMyPosts(w http.ResponseWriter, r *http.Request
 		PostType:      q.Get("post_type"),
 		Sort:          q.Get("sort"),
 		Keyword:       q.Get("keyword"),
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -723,7 +1280,8 @@ func (s *CampusService) handleListMyPosts(w http.ResponseWriter, r *http.Request
 	writeJSON(w, r, map[string]interface{}{
 		"posts": posts,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -737,6 +1295,7 @@ func (s *CampusService) handleListMyCollections(w http.ResponseWriter, r *http.R
 		PostType:      q.Get("post_type"),
 		Sort:          q.Get("sort"),
 		Keyword:       q.Get("keyword"),
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -751,12 +1310,15 @@ func (s *CampusService) handleListMyCollections(w http.ResponseWriter, r *http.R
 	writeJSON(w, r, map[string]interface{}{
 		"posts": posts,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
 
 type postRequest struct {
+	CampusCode   string            `json:"campus_code"`
+	CrossCampus  bool              `json:"cross_campus"`
 	CategoryCode string            `json:"category_code"`
 	Title        string            `json:"title"`
 	Content      string            `json:"content"`
@@ -769,6 +1331,60 @@ type postRequest struct {
 	IsFeatured   bool              `json:"is_featured"`
 	IsPinned     bool              `json:"is_pinned"`
 	SortWeight   int32             `json:"sort_weight"`
+	PublishMode  string            `json:"publish_mode"`
+	PublishAt    string            `json:"publish_at"`
+	Poll         *pollRequest      `json:"poll"`
+	LostItem     *lostItemRequest  `json:"lost_item"`
+	ClubEvent    *clubEventRequest `json:"event"`
+	Tags         []string          `json:"tags"`
+	Anonymous    bool              `json:"anonymous"`
+}
+
+// anonymous 不传时按匿名投票处理，与表默认值一致
+type pollRequest struct {
+	Options    []string `json:"options"`
+	ChoiceMode string   `json:"choice_mode"`
+	MaxChoices int32    `json:"max_choices"`
+	ClosesAt   string   `json:"closes_at"`
+	Anonymous  *bool    `json:"anonymous"`
+}
+
+type lostItemRequest struct {
+	Kind       string `json:"kind"`
+	Category   string `json:"category"`
+	Place      string `json:"place"`
+	EventStart string `json:"event_start"`
+	EventEnd   string `json:"event_end"`
+}
+
+type clubEventRequest struct {
+	Capacity       int32  `json:"capacity"`
+	StartsAt       string `json:"starts_at"`
+	SignupDeadline string `json:"signup_deadline"`
+	Place          string `json:"place"`
+}
+
+type clubCheckinRequest struct {
+	Code string `json:"code"`
+}
+
+type acceptAnswerRequest struct {
+	CommentID int64 `json:"comment_id"`
+}
+
+type lostResolveRequest struct {
+	MatchPostID int64 `json:"match_post_id"`
+}
+
+type pollVoteRequest struct {
+	OptionIDs []int32 `json:"option_ids"`
+}
+
+type postScheduleRequest struct {
+	PublishAt  string `json:"publish_at"`
+	IsPinned   bool   `json:"is_pinned"`
+	IsFeatured bool   `json:"is_featured"`
+	SortWeight int32  `json:"sort_weight"`
 }
 
 type batchPostsRequest struct {
@@ -876,6 +1492,8 @@ func (s *CampusService) handleCreatePost(w http.ResponseWriter, r *http.Request)
 	userID, _ := s.userIDFromRequest(r)
 	post, err := s.uc.CreatePost(r.Context(), &biz.CreateCampusPostInput{
 		UserID:       userID,
+		CampusCode:   req.CampusCode,
+		CrossCampus:  req.CrossCampus,
 		CategoryCode: req.CategoryCode,
 		Title:        req.Title,
 		Content:      req.Content,
@@ -888,6 +1506,11 @@ func (s *CampusService) handleCreatePost(w http.ResponseWriter, r *http.Request)
 		IsFeatured:   req.IsFeatured,
 		IsPinned:     req.IsPinned,
 		SortWeight:   req.SortWeight,
+		Poll:         req.Poll.toInput(),
+		LostItem:     req.LostItem.toInput(),
+		ClubEvent:    req.ClubEvent.toInput(),
+		Tags:         req.Tags,
+		Anonymous:    req.Anonymous,
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -910,6 +1533,244 @@ func (s *CampusService) handleGetPost(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, r, map[string]interface{}{"post": postToMap(post)})
 }
 
+func (s *CampusService) handleGetPostPoll(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	currentUserID, _ := optionalUserIDFromRequest(r, s.authSecret)
+	poll, err := s.uc.GetPostPoll(r.Context(), &biz.GetCampusPostInput{PostID: postID, CurrentUserID: currentUserID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"poll": pollToMap(poll)})
+}
+
+func (s *CampusService) handleVotePoll(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req pollVoteRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	poll, err := s.uc.VotePoll(r.Context(), &biz.VoteCampusPollInput{UserID: userID, PostID: postID, OptionIDs: req.OptionIDs})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"poll": pollToMap(poll)})
+}
+
+func (s *CampusService) handleClosePoll(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	poll, err := s.uc.ClosePoll(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"poll": pollToMap(poll)})
+}
+
+func (s *CampusService) handleRegisterClubEvent(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	event, err := s.uc.RegisterClubEvent(r.Context(), &biz.ClubEventActionInput{UserID: userID, PostID: postID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"event": clubEventToMap(event)})
+}
+
+func (s *CampusService) handleCancelClubRegistration(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	event, err := s.uc.CancelClubRegistration(r.Context(), &biz.ClubEventActionInput{UserID: userID, PostID: postID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"event": clubEventToMap(event)})
+}
+
+func (s *CampusService) handleCheckInClubEvent(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req clubCheckinRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	event, err := s.uc.CheckInClubEvent(r.Context(), &biz.ClubEventActionInput{UserID: userID, PostID: postID, Code: req.Code})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"event": clubEventToMap(event)})
+}
+
+func (s *CampusService) handleListClubRegistrations(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.ListClubRegistrations(r.Context(), &biz.ListCampusClubRegistrationsInput{
+		UserID: userID,
+		PostID: postID,
+		Status: q.Get("status"),
+		Page:   int32(queryInt(q.Get("page"), 1)),
+		Size:   int32(queryInt(q.Get("size"), 50)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(out.Registrations))
+	for _, registration := range out.Registrations {
+		items = append(items, clubRegistrationToMap(registration))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"attendees":  items,
+		"page_stats": map[string]interface{}{"total": out.Total},
+	})
+}
+
+func (s *CampusService) handleExportClubRegistrations(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	file, err := s.uc.ExportClubRegistrationsCSV(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, strings.ReplaceAll(file.Filename, `"`, "")))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(file.Content)
+}
+
+func (s *CampusService) handleAcceptAnswer(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req acceptAnswerRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	if req.CommentID <= 0 {
+		writeError(w, r, apperror.InvalidArgument("请选择要采纳的评论"))
+		return
+	}
+	s.acceptAnswer(w, r, postID, req.CommentID)
+}
+
+func (s *CampusService) handleUnacceptAnswer(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	s.acceptAnswer(w, r, postID, 0)
+}
+
+func (s *CampusService) acceptAnswer(w http.ResponseWriter, r *http.Request, postID, commentID int64) {
+	userID, _ := s.userIDFromRequest(r)
+	question, err := s.uc.AcceptAnswer(r.Context(), &biz.AcceptCampusAnswerInput{UserID: userID, PostID: postID, CommentID: commentID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"question": questionToMap(question)})
+}
+
+func (s *CampusService) handleListLostMatches(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	matches, err := s.uc.ListLostMatches(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(matches))
+	for _, match := range matches {
+		items = append(items, lostMatchToMap(match))
+	}
+	writeJSON(w, r, map[string]interface{}{"items": items})
+}
+
+func (s *CampusService) handleResolveLostItem(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req lostResolveRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if !decodeJSON(w, r, &req) {
+			return
+		}
+	}
+	userID, _ := s.userIDFromRequest(r)
+	item, err := s.uc.ResolveLostItem(r.Context(), &biz.ResolveCampusLostItemInput{UserID: userID, PostID: postID, MatchPostID: req.MatchPostID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"lost_item": lostItemToMap(item)})
+}
+
+func (s *CampusService) handleUpdatePost(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req postRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	post, err := s.uc.UpdatePost(r.Context(), &biz.UpdateCampusPostInput{
+		UserID:    userID,
+		PostID:    postID,
+		Title:     req.Title,
+		Content:   req.Content,
+		Images:    req.Images,
+		MediaType: req.MediaType,
+		Extra:     req.Extra,
+		CoverURL:  req.CoverURL,
+		Tags:      req.Tags,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"post": postToMap(post)})
+}
+
 func (s *CampusService) handleDeletePost(w http.ResponseWriter, r *http.Request) {
 	postID, ok := pathID(w, r)
 	if !ok {
@@ -933,6 +1794,7 @@ func (s *CampusService) handleListComments(w http.ResponseWriter, r *http.Reques
 	out, err := s.uc.ListComments(r.Context(), &biz.ListCampusCommentsInput{
 		PostID:        postID,
 		CurrentUserID: currentUserID,
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -947,7 +1809,8 @@ func (s *CampusService) handleListComments(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, r, map[string]interface{}{
 		"comments": comments,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -962,6 +1825,7 @@ func (s *CampusService) handleListCommentReplies(w http.ResponseWriter, r *http.
 	out, err := s.uc.ListCommentReplies(r.Context(), &biz.ListCampusCommentsInput{
 		CommentID:     commentID,
 		CurrentUserID: currentUserID,
+		Cursor:        q.Get("cursor"),
 		Page:          int32(queryInt(q.Get("page"), 1)),
 		Size:          int32(queryInt(q.Get("size"), 20)),
 	})
@@ -976,7 +1840,8 @@ func (s *CampusService) handleListCommentReplies(w http.ResponseWriter, r *http.
 	writeJSON(w, r, map[string]interface{}{
 		"comments": replies,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -986,6 +1851,7 @@ func (s *CampusService) handleListMyComments(w http.ResponseWriter, r *http.Requ
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.ListMyComments(r.Context(), &biz.ListCampusCommentsInput{
 		UserID: userID,
+		Cursor: q.Get("cursor"),
 		Page:   int32(queryInt(q.Get("page"), 1)),
 		Size:   int32(queryInt(q.Get("size"), 20)),
 	})
@@ -1000,7 +1866,8 @@ func (s *CampusService) handleListMyComments(w http.ResponseWriter, r *http.Requ
 	writeJSON(w, r, map[string]interface{}{
 		"comments": comments,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -1153,7 +2020,50 @@ func (s *CampusService) handleReportPost(w http.ResponseWriter, r *http.Request)
 		writeError(w, r, err)
 		return
 	}
-	writeJSON(w, r, map[string]interface{}{})
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAppealPost(w http.ResponseWriter, r *http.Request) {
+	s.appealContent(w, r, "post")
+}
+
+func (s *CampusService) handleAppealComment(w http.ResponseWriter, r *http.Request) {
+	s.appealContent(w, r, "comment")
+}
+
+func (s *CampusService) appealContent(w http.ResponseWriter, r *http.Request, targetType string) {
+	targetID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	appeal, err := s.uc.CreateAppeal(r.Context(), &biz.CreateCampusAppealInput{
+		UserID:     userID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     req.Reason,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"appeal": appealToMap(appeal)})
+}
+
+func (s *CampusService) handleListMyAppeals(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	appeals, err := s.uc.ListMyAppeals(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"appeals": appealsToMaps(appeals)})
 }
 
 func (s *CampusService) handleListNotifications(w http.ResponseWriter, r *http.Request) {
@@ -1162,6 +2072,7 @@ func (s *CampusService) handleListNotifications(w http.ResponseWriter, r *http.R
 	out, err := s.uc.ListNotifications(r.Context(), &biz.ListCampusNotificationsInput{
 		UserID: userID,
 		Type:   q.Get("type"),
+		Cursor: q.Get("cursor"),
 		Page:   int32(queryInt(q.Get("page"), 1)),
 		Size:   int32(queryInt(q.Get("size"), 20)),
 	})
@@ -1175,7 +2086,7 @@ func (s *CampusService) handleListNotifications(w http.ResponseWriter, r *http.R
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"notifications": items,
-		"page_stats":    map[string]interface{}{"total": out.Total},
+		"page_stats":    map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
 	})
 }
 
@@ -1263,10 +2174,13 @@ func (s *CampusService) handleListModerationPosts(w http.ResponseWriter, r *http
 	q := r.URL.Query()
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.ListModerationPosts(r.Context(), &biz.ListCampusModerationInput{
-		UserID: userID,
-		Status: int32(queryInt(q.Get("status"), int(biz.CampusAuditStatusPending))),
-		Page:   int32(queryInt(q.Get("page"), 1)),
-		Size:   int32(queryInt(q.Get("size"), 20)),
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     int32(queryInt(q.Get("status"), int(biz.CampusAuditStatusPending))),
+		Claim:      q.Get("claim"),
+		Cursor:     q.Get("cursor"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -1274,12 +2188,15 @@ func (s *CampusService) handleListModerationPosts(w http.ResponseWriter, r *http
 	}
 	posts := make([]map[string]interface{}, 0, len(out.Posts))
 	for _, post := range out.Posts {
-		posts = append(posts, postToMap(post))
+		item := postToMap(post)
+		item["claim"] = moderationClaimToMap(post.Claim)
+		posts = append(posts, item)
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"posts": posts,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -1288,10 +2205,12 @@ func (s *CampusService) handleListModerationComments(w http.ResponseWriter, r *h
 	q := r.URL.Query()
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.ListModerationComments(r.Context(), &biz.ListCampusModerationInput{
-		UserID: userID,
-		Status: int32(queryInt(q.Get("status"), int(biz.CampusAuditStatusPending))),
-		Page:   int32(queryInt(q.Get("page"), 1)),
-		Size:   int32(queryInt(q.Get("size"), 20)),
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     int32(queryInt(q.Get("status"), int(biz.CampusAuditStatusPending))),
+		Cursor:     q.Get("cursor"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -1304,7 +2223,8 @@ func (s *CampusService) handleListModerationComments(w http.ResponseWriter, r *h
 	writeJSON(w, r, map[string]interface{}{
 		"comments": comments,
 		"page_stats": map[string]interface{}{
-			"total": out.Total,
+			"total":       out.Total,
+			"next_cursor": out.NextCursor,
 		},
 	})
 }
@@ -1312,6 +2232,7 @@ func (s *CampusService) handleListModerationComments(w http.ResponseWriter, r *h
 type reviewRequest struct {
 	Action string `json:"action"`
 	Reason string `json:"reason"`
+	Force  bool   `json:"force"`
 }
 
 func (s *CampusService) handleReviewPost(w http.ResponseWriter, r *http.Request) {
@@ -1330,6 +2251,7 @@ func (s *CampusService) handleReviewPost(w http.ResponseWriter, r *http.Request)
 		TargetID:   postID,
 		Action:     req.Action,
 		Reason:     req.Reason,
+		Force:      req.Force,
 	}); err != nil {
 		writeError(w, r, err)
 		return
@@ -1353,6 +2275,7 @@ func (s *CampusService) handleReviewComment(w http.ResponseWriter, r *http.Reque
 		TargetID:   commentID,
 		Action:     req.Action,
 		Reason:     req.Reason,
+		Force:      req.Force,
 	}); err != nil {
 		writeError(w, r, err)
 		return
@@ -1750,6 +2673,10 @@ type auditSettingsRequest struct {
 	PostAuditMode string `json:"post_audit_mode"`
 }
 
+type anonymousSettingsRequest struct {
+	CategoryCodes []string `json:"category_codes"`
+}
+
 type agentSettingsRequest struct {
 	AgentEnabled                 bool    `json:"agent_enabled"`
 	AgentAuditEnabled            bool    `json:"agent_audit_enabled"`
@@ -1815,6 +2742,33 @@ func (s *CampusService) handleAdminUpdateAuditSettings(w http.ResponseWriter, r
 	writeJSON(w, r, map[string]interface{}{"settings": auditSettingsToMap(settings)})
 }
 
+func (s *CampusService) handleAdminGetAnonymousSettings(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	settings, err := s.uc.AdminGetAnonymousSettings(r.Context(), &biz.GetCampusAnonymousSettingsInput{UserID: userID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"settings": anonymousSettingsToMap(settings)})
+}
+
+func (s *CampusService) handleAdminUpdateAnonymousSettings(w http.ResponseWriter, r *http.Request) {
+	var req anonymousSettingsRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	settings, err := s.uc.AdminUpdateAnonymousSettings(r.Context(), &biz.UpdateCampusAnonymousSettingsInput{
+		UserID:        userID,
+		CategoryCodes: req.CategoryCodes,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"settings": anonymousSettingsToMap(settings)})
+}
+
 func (s *CampusService) handleAdminGetAgentSettings(w http.ResponseWriter, r *http.Request) {
 	userID, _ := s.userIDFromRequest(r)
 	settings, err := s.uc.AdminGetAgentSettings(r.Context(), &biz.GetCampusAgentSettingsInput{UserID: userID})
@@ -1941,12 +2895,14 @@ func (s *CampusService) handleAdminListPosts(w http.ResponseWriter, r *http.Requ
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.AdminListPosts(r.Context(), &biz.ListCampusAdminPostsInput{
 		UserID:       userID,
+		CampusCode:   q.Get("campus_code"),
 		CategoryCode: q.Get("category_code"),
 		PostType:     q.Get("post_type"),
 		OpsFilter:    q.Get("ops_filter"),
 		Keyword:      q.Get("keyword"),
 		Status:       int32(queryInt(q.Get("status"), -1)),
 		Sort:         q.Get("sort"),
+		Cursor:       q.Get("cursor"),
 		Page:         int32(queryInt(q.Get("page"), 1)),
 		Size:         int32(queryInt(q.Get("size"), 20)),
 	})
@@ -1960,7 +2916,7 @@ func (s *CampusService) handleAdminListPosts(w http.ResponseWriter, r *http.Requ
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"posts":      posts,
-		"page_stats": map[string]interface{}{"total": out.Total},
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
 	})
 }
 
@@ -1972,6 +2928,8 @@ func (s *CampusService) handleAdminCreatePost(w http.ResponseWriter, r *http.Req
 	userID, _ := s.userIDFromRequest(r)
 	post, err := s.uc.AdminCreatePost(r.Context(), &biz.CreateCampusPostInput{
 		UserID:       userID,
+		CampusCode:   req.CampusCode,
+		CrossCampus:  req.CrossCampus,
 		CategoryCode: req.CategoryCode,
 		Title:        req.Title,
 		Content:      req.Content,
@@ -1984,6 +2942,12 @@ func (s *CampusService) handleAdminCreatePost(w http.ResponseWriter, r *http.Req
 		IsFeatured:   req.IsFeatured,
 		IsPinned:     req.IsPinned,
 		SortWeight:   req.SortWeight,
+		PublishMode:  req.PublishMode,
+		PublishAt:    parseOptionalRequestTime(req.PublishAt),
+		Poll:         req.Poll.toInput(),
+		LostItem:     req.LostItem.toInput(),
+		ClubEvent:    req.ClubEvent.toInput(),
+		Tags:         req.Tags,
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -1992,6 +2956,99 @@ func (s *CampusService) handleAdminCreatePost(w http.ResponseWriter, r *http.Req
 	writeJSON(w, r, map[string]interface{}{"post": postToMap(post)})
 }
 
+func (s *CampusService) handleAdminListPostSchedules(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListPostSchedules(r.Context(), &biz.ListCampusPostSchedulesInput{
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		State:      q.Get("state"),
+		Cursor:     q.Get("cursor"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(out.Schedules))
+	for _, schedule := range out.Schedules {
+		items = append(items, postScheduleToMap(schedule))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"schedules":  items,
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
+	})
+}
+
+func (s *CampusService) handleAdminListQuestions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListQuestions(r.Context(), &biz.ListCampusQuestionsInput{
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		State:      q.Get("state"),
+		Cursor:     q.Get("cursor"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(out.Questions))
+	for _, question := range out.Questions {
+		item := questionToMap(question)
+		item["ai_task_id"] = strconv.FormatInt(question.AITaskID, 10)
+		item["ai_queued_at"] = formatOptionalTime(question.AIQueuedAt)
+		item["post"] = postToMap(question.Post)
+		items = append(items, item)
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"questions":  items,
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
+	})
+}
+
+func (s *CampusService) handleAdminReschedulePost(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req postScheduleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	schedule, err := s.uc.AdminReschedulePost(r.Context(), &biz.UpdateCampusPostScheduleInput{
+		UserID:     userID,
+		PostID:     postID,
+		PublishAt:  parseOptionalRequestTime(req.PublishAt),
+		IsPinned:   req.IsPinned,
+		IsFeatured: req.IsFeatured,
+		SortWeight: req.SortWeight,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"schedule": postScheduleToMap(schedule)})
+}
+
+func (s *CampusService) handleAdminCancelPostSchedule(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	schedule, err := s.uc.AdminCancelPostSchedule(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"schedule": postScheduleToMap(schedule)})
+}
+
 func (s *CampusService) handleAdminBatchPosts(w http.ResponseWriter, r *http.Request) {
 	var req batchPostsRequest
 	if !decodeJSON(w, r, &req) {
@@ -2025,6 +3082,8 @@ func (s *CampusService) handleAdminUpdatePost(w http.ResponseWriter, r *http.Req
 		postRequest
 		Status      int32  `json:"status"`
 		AuditReason string `json:"audit_reason"`
+		// 未传 cross_campus 时保留帖子原有的跨校区设置
+		CrossCampus *bool `json:"cross_campus"`
 	}
 	if !decodeJSON(w, r, &req) {
 		return
@@ -2046,6 +3105,7 @@ func (s *CampusService) handleAdminUpdatePost(w http.ResponseWriter, r *http.Req
 		IsOfficial:   req.IsOfficial,
 		IsFeatured:   req.IsFeatured,
 		IsPinned:     req.IsPinned,
+		CrossCampus:  req.CrossCampus,
 		SortWeight:   req.SortWeight,
 	})
 	if err != nil {
@@ -2068,6 +3128,269 @@ func (s *CampusService) handleAdminDeletePost(w http.ResponseWriter, r *http.Req
 	writeJSON(w, r, map[string]interface{}{})
 }
 
+func (s *CampusService) handleAdminListPostRevisions(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	revisions, err := s.uc.AdminListPostRevisions(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(revisions))
+	for _, revision := range revisions {
+		items = append(items, postRevisionToMap(revision))
+	}
+	writeJSON(w, r, map[string]interface{}{"revisions": items})
+}
+
+func (s *CampusService) handleAdminListPostImageAudits(w http.ResponseWriter, r *http.Request) {
+	postID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	tasks, err := s.uc.AdminListPostImageAudits(r.Context(), userID, postID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(tasks))
+	for _, task := range tasks {
+		items = append(items, imageAuditTaskToMap(task))
+	}
+	writeJSON(w, r, map[string]interface{}{"image_audits": items})
+}
+
+type imageHashBlockRequest struct {
+	PostID   json.RawMessage `json:"post_id"`
+	ImageURL string          `json:"image_url"`
+	PHash    string          `json:"phash"`
+	DHash    string          `json:"dhash"`
+	Reason   string          `json:"reason"`
+}
+
+func (s *CampusService) handleAdminListImageHashBlocks(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	blocks, err := s.uc.AdminListImageHashBlocks(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, imageHashBlockToMap(block))
+	}
+	writeJSON(w, r, map[string]interface{}{"blocks": items})
+}
+
+func (s *CampusService) handleAdminCreateImageHashBlocks(w http.ResponseWriter, r *http.Request) {
+	var req imageHashBlockRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	postIDs, err := parseRawInt64List([]json.RawMessage{req.PostID})
+	if err != nil {
+		writeError(w, r, apperror.InvalidArgument("帖子 ID 无效"))
+		return
+	}
+	var postID int64
+	if len(postIDs) > 0 {
+		postID = postIDs[0]
+	}
+	userID, _ := s.userIDFromRequest(r)
+	blocks, err := s.uc.AdminCreateImageHashBlocks(r.Context(), &biz.CreateCampusImageHashBlockInput{
+		UserID:   userID,
+		PostID:   postID,
+		ImageURL: req.ImageURL,
+		PHash:    req.PHash,
+		DHash:    req.DHash,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		items = append(items, imageHashBlockToMap(block))
+	}
+	writeJSON(w, r, map[string]interface{}{"blocks": items})
+}
+
+func (s *CampusService) handleAdminDeleteImageHashBlock(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminDeleteImageHashBlock(r.Context(), userID, id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+type auditRuleRequest struct {
+	ID       json.RawMessage `json:"id"`
+	Name     string          `json:"name"`
+	Category string          `json:"category"`
+	RuleType string          `json:"rule_type"`
+	Patterns string          `json:"patterns"`
+	Score    int32           `json:"score"`
+	Enabled  *bool           `json:"enabled"`
+}
+
+func (req *auditRuleRequest) toInput(userID string) (*biz.SaveCampusAuditRuleInput, error) {
+	ids, err := parseRawInt64List([]json.RawMessage{req.ID})
+	if err != nil {
+		return nil, apperror.InvalidArgument("规则 ID 无效")
+	}
+	input := &biz.SaveCampusAuditRuleInput{
+		UserID:   userID,
+		Name:     req.Name,
+		Category: req.Category,
+		RuleType: req.RuleType,
+		Patterns: req.Patterns,
+		Score:    req.Score,
+		Enabled:  req.Enabled == nil || *req.Enabled,
+	}
+	if len(ids) > 0 {
+		input.ID = ids[0]
+	}
+	return input, nil
+}
+
+func (s *CampusService) handleAdminGetAuditRules(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	config, err := s.uc.AdminGetAuditRules(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	rules := make([]map[string]interface{}, 0, len(config.Rules))
+	for _, rule := range config.Rules {
+		rules = append(rules, auditRuleToMap(rule))
+	}
+	writeJSON(w, r, map[string]interface{}{"rules": rules, "categories": config.Categories})
+}
+
+func (s *CampusService) handleAdminSaveAuditRule(w http.ResponseWriter, r *http.Request) {
+	var req auditRuleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	input, err := req.toInput(userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if mux.Vars(r)["id"] != "" {
+		id, ok := pathID(w, r)
+		if !ok {
+			return
+		}
+		input.ID = id
+	} else {
+		input.ID = 0
+	}
+	rule, err := s.uc.AdminSaveAuditRule(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"rule": auditRuleToMap(rule)})
+}
+
+func (s *CampusService) handleAdminDeleteAuditRule(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminDeleteAuditRule(r.Context(), userID, id); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAdminUpdateAuditRuleCategories(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Categories []*biz.CampusAuditRuleCategory `json:"categories"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	categories, err := s.uc.AdminUpdateAuditRuleCategories(r.Context(), &biz.UpdateCampusAuditRuleCategoriesInput{
+		UserID:     userID,
+		Categories: req.Categories,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"categories": categories})
+}
+
+func (s *CampusService) handleAdminDryRunAuditRules(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		CampusCode string            `json:"campus_code"`
+		Limit      int               `json:"limit"`
+		Draft      *auditRuleRequest `json:"draft"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	input := &biz.DryRunCampusAuditRulesInput{UserID: userID, CampusCode: req.CampusCode, Limit: req.Limit}
+	if req.Draft != nil {
+		draft, err := req.Draft.toInput(userID)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		input.Draft = draft
+	}
+	result, err := s.uc.AdminDryRunAuditRules(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	items := make([]map[string]interface{}, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, map[string]interface{}{
+			"post_id":    strconv.FormatInt(item.PostID, 10),
+			"title":      item.Title,
+			"status":     item.Status,
+			"decision":   item.Decision,
+			"risk_level": item.RiskLevel,
+			"reason":     item.Reason,
+			"hits":       auditRuleHitsToMaps(item.Hits),
+		})
+	}
+	ruleHits := make([]map[string]interface{}, 0, len(result.RuleHits))
+	for _, stat := range result.RuleHits {
+		ruleHits = append(ruleHits, map[string]interface{}{
+			"rule_id":   strconv.FormatInt(stat.RuleID, 10),
+			"rule_name": stat.RuleName,
+			"category":  stat.Category,
+			"count":     stat.Count,
+		})
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"scanned":   result.Scanned,
+		"flagged":   result.Flagged,
+		"rejected":  result.Rejected,
+		"items":     items,
+		"rule_hits": ruleHits,
+	})
+}
+
 func (s *CampusService) handleAdminListMomentsCandidates(w http.ResponseWriter, r *http.Request) {
 	userID, _ := s.userIDFromRequest(r)
 	posts, err := s.uc.AdminListMomentsCandidates(r.Context(), &biz.ListCampusMomentsCandidatesInput{
@@ -2140,11 +3463,13 @@ func (s *CampusService) handleAdminListComments(w http.ResponseWriter, r *http.R
 	q := r.URL.Query()
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.AdminListComments(r.Context(), &biz.ListCampusAdminCommentsInput{
-		UserID: userID,
-		Status: int32(queryInt(q.Get("status"), -1)),
-		PostID: int64(queryInt(q.Get("post_id"), 0)),
-		Page:   int32(queryInt(q.Get("page"), 1)),
-		Size:   int32(queryInt(q.Get("size"), 20)),
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     int32(queryInt(q.Get("status"), -1)),
+		PostID:     int64(queryInt(q.Get("post_id"), 0)),
+		Cursor:     q.Get("cursor"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -2156,7 +3481,7 @@ func (s *CampusService) handleAdminListComments(w http.ResponseWriter, r *http.R
 	}
 	writeJSON(w, r, map[string]interface{}{
 		"comments":   comments,
-		"page_stats": map[string]interface{}{"total": out.Total},
+		"page_stats": map[string]interface{}{"total": out.Total, "next_cursor": out.NextCursor},
 	})
 }
 
@@ -2563,10 +3888,12 @@ func (s *CampusService) handleAdminListReports(w http.ResponseWriter, r *http.Re
 	q := r.URL.Query()
 	userID, _ := s.userIDFromRequest(r)
 	out, err := s.uc.AdminListReports(r.Context(), &biz.ListCampusReportsInput{
-		UserID: userID,
-		Status: int32(queryInt(q.Get("status"), -1)),
-		Page:   int32(queryInt(q.Get("page"), 1)),
-		Size:   int32(queryInt(q.Get("size"), 20)),
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     int32(queryInt(q.Get("status"), -1)),
+		Claim:      q.Get("claim"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
 	})
 	if err != nil {
 		writeError(w, r, err)
@@ -2582,6 +3909,201 @@ func (s *CampusService) handleAdminListReports(w http.ResponseWriter, r *http.Re
 	})
 }
 
+func (s *CampusService) handleAdminListAppeals(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListAppeals(r.Context(), &biz.ListCampusAppealsInput{
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     q.Get("status"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"appeals":    appealsToMaps(out.Appeals),
+		"page_stats": map[string]interface{}{"total": out.Total},
+	})
+}
+
+func (s *CampusService) handleAdminReviewAppeal(w http.ResponseWriter, r *http.Request) {
+	appealID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Action string `json:"action"`
+		Note   string `json:"note"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	appeal, err := s.uc.AdminReviewAppeal(r.Context(), &biz.ReviewCampusAppealInput{
+		UserID:   userID,
+		AppealID: appealID,
+		Action:   req.Action,
+		Note:     req.Note,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"appeal": appealToMap(appeal)})
+}
+
+func (s *CampusService) handleAdminListReportGroups(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListReportGroups(r.Context(), &biz.ListCampusReportsInput{
+		UserID:     userID,
+		CampusCode: q.Get("campus_code"),
+		Status:     int32(queryInt(q.Get("status"), -1)),
+		Claim:      q.Get("claim"),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	groups := make([]map[string]interface{}, 0, len(out.Groups))
+	for _, group := range out.Groups {
+		groups = append(groups, reportGroupToMap(group))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"groups":              groups,
+		"auto_hide_threshold": out.Threshold,
+		"page_stats":          map[string]interface{}{"total": out.Total},
+	})
+}
+
+func (s *CampusService) handleAdminReviewReportGroup(w http.ResponseWriter, r *http.Request) {
+	targetID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req reviewRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminReviewReportGroup(r.Context(), &biz.ReviewCampusReportGroupInput{
+		UserID:     userID,
+		TargetType: mux.Vars(r)["type"],
+		TargetID:   targetID,
+		Action:     req.Action,
+		Reason:     req.Reason,
+		Force:      req.Force,
+	}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+type moderationClaimRequest struct {
+	Force      bool   `json:"force"`
+	AssigneeID string `json:"assignee_id"`
+}
+
+func (s *CampusService) handleClaimModerationItem(w http.ResponseWriter, r *http.Request) {
+	targetID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	claim, err := s.uc.ClaimModerationItem(r.Context(), &biz.ClaimCampusModerationInput{
+		UserID:     userID,
+		TargetType: mux.Vars(r)["type"],
+		TargetID:   targetID,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"claim": moderationClaimToMap(claim)})
+}
+
+func (s *CampusService) handleReleaseModerationItem(w http.ResponseWriter, r *http.Request) {
+	targetID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req moderationClaimRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.ReleaseModerationItem(r.Context(), &biz.ReleaseCampusModerationInput{
+		UserID:     userID,
+		TargetType: mux.Vars(r)["type"],
+		TargetID:   targetID,
+		Force:      req.Force,
+	}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAdminAssignModerationItem(w http.ResponseWriter, r *http.Request) {
+	targetID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req moderationClaimRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	claim, err := s.uc.AssignModerationItem(r.Context(), &biz.AssignCampusModerationInput{
+		UserID:     userID,
+		TargetType: mux.Vars(r)["type"],
+		TargetID:   targetID,
+		AssigneeID: req.AssigneeID,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"claim": moderationClaimToMap(claim)})
+}
+
+func (s *CampusService) handleAdminModerationOperatorStats(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminModerationOperatorStats(r.Context(), &biz.ListCampusModerationStatsInput{
+		UserID: userID,
+		Days:   int32(queryInt(r.URL.Query().Get("days"), 7)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	operators := make([]map[string]interface{}, 0, len(out.Operators))
+	for _, item := range out.Operators {
+		operators = append(operators, map[string]interface{}{
+			"operator_id":     item.OperatorID,
+			"operator":        authorToMap(item.Operator),
+			"reviewed":        item.Reviewed,
+			"approved":        item.Approved,
+			"rejected":        item.Rejected,
+			"deleted":         item.Deleted,
+			"reports_handled": item.ReportsHandled,
+			"appeals_handled": item.AppealsHandled,
+			"active_claims":   item.ActiveClaims,
+			"last_action_at":  formatTime(item.LastActionAt),
+		})
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"operators": operators,
+		"since":     formatTime(out.Since),
+	})
+}
+
 func (s *CampusService) handleAdminReviewReport(w http.ResponseWriter, r *http.Request) {
 	reportID, ok := pathID(w, r)
 	if !ok {
@@ -2597,6 +4119,7 @@ func (s *CampusService) handleAdminReviewReport(w http.ResponseWriter, r *http.R
 		ReportID: reportID,
 		Action:   req.Action,
 		Reason:   req.Reason,
+		Force:    req.Force,
 	}); err != nil {
 		writeError(w, r, err)
 		return
@@ -2667,73 +4190,229 @@ func (s *CampusService) handleAdminCreateNotification(w http.ResponseWriter, r *
 		writeError(w, r, err)
 		return
 	}
-	writeJSON(w, r, map[string]interface{}{"queued": true, "task_id": fmt.Sprintf("%d", taskID)})
+	writeJSON(w, r, map[string]interface{}{"queued": true, "task_id": fmt.Sprintf("%d", taskID)})
+}
+
+func (s *CampusService) handleAdminSecurityOverview(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	overview, err := s.uc.AdminSecurityOverview(r.Context(), &biz.ListCampusSecurityInput{UserID: userID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"security": securityOverviewToMap(overview)})
+}
+
+func (s *CampusService) handleAdminBlockIP(w http.ResponseWriter, r *http.Request) {
+	var req blockIPRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminBlockIP(r.Context(), &biz.BlockCampusIPInput{
+		UserID: userID,
+		IP:     req.IP,
+		Reason: req.Reason,
+	}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAdminUnblockIP(w http.ResponseWriter, r *http.Request) {
+	ip := strings.TrimSpace(mux.Vars(r)["id"])
+	if ip == "" {
+		writeError(w, r, apperror.InvalidArgument("IP 无效"))
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	if err := s.uc.AdminUnblockIP(r.Context(), &biz.BlockCampusIPInput{UserID: userID, IP: ip}); err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{})
+}
+
+func (s *CampusService) handleAdminListUsers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListUsers(r.Context(), &biz.ListCampusAdminUsersInput{
+		UserID:     userID,
+		Keyword:    q.Get("keyword"),
+		Role:       q.Get("role"),
+		AuthStatus: int32(queryInt(q.Get("auth_status"), -1)),
+		Page:       int32(queryInt(q.Get("page"), 1)),
+		Size:       int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	users := make([]map[string]interface{}, 0, len(out.Users))
+	for _, user := range out.Users {
+		users = append(users, adminUserToMap(user))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"users":      users,
+		"page_stats": map[string]interface{}{"total": out.Total},
+	})
+}
+
+func (s *CampusService) handleAdminListTags(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	userID, _ := s.userIDFromRequest(r)
+	out, err := s.uc.AdminListTags(r.Context(), &biz.ListCampusAdminTagsInput{
+		UserID:  userID,
+		Keyword: q.Get("keyword"),
+		Status:  int32(queryInt(q.Get("status"), -1)),
+		Page:    int32(queryInt(q.Get("page"), 1)),
+		Size:    int32(queryInt(q.Get("size"), 20)),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	tags := make([]map[string]interface{}, 0, len(out.Tags))
+	for _, tag := range out.Tags {
+		tags = append(tags, tagToMap(tag))
+	}
+	writeJSON(w, r, map[string]interface{}{
+		"tags":       tags,
+		"page_stats": map[string]interface{}{"total": out.Total},
+	})
+}
+
+func (s *CampusService) handleAdminMergeTag(w http.ResponseWriter, r *http.Request) {
+	tagID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		TargetID string `json:"target_id"`
+	}
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+	targetID, err := strconv.ParseInt(strings.TrimSpace(req.TargetID), 10, 64)
+	if err != nil || targetID <= 0 {
+		writeError(w, r, apperror.InvalidArgument("目标话题 ID 无效"))
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	tag, err := s.uc.AdminMergeTag(r.Context(), &biz.UpdateCampusTagInput{UserID: userID, TagID: tagID, TargetTagID: targetID})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"tag": tagToMap(tag)})
+}
+
+func (s *CampusService) handleAdminBanTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTagFlag(w, r, s.uc.AdminSetTagBanned, true)
+}
+
+func (s *CampusService) handleAdminUnbanTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTagFlag(w, r, s.uc.AdminSetTagBanned, false)
+}
+
+func (s *CampusService) handleAdminFeatureTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTagFlag(w, r, s.uc.AdminSetTagFeatured, true)
+}
+
+func (s *CampusService) handleAdminUnfeatureTag(w http.ResponseWriter, r *http.Request) {
+	s.updateTagFlag(w, r, s.uc.AdminSetTagFeatured, false)
+}
+
+func (s *CampusService) updateTagFlag(w http.ResponseWriter, r *http.Request, update func(context.Context, *biz.UpdateCampusTagInput) (*biz.CampusTag, error), enabled bool) {
+	tagID, ok := pathID(w, r)
+	if !ok {
+		return
+	}
+	userID, _ := s.userIDFromRequest(r)
+	tag, err := update(r.Context(), &biz.UpdateCampusTagInput{UserID: userID, TagID: tagID, Enabled: enabled})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"tag": tagToMap(tag)})
+}
+
+func (s *CampusService) handleListMySanctions(w http.ResponseWriter, r *http.Request) {
+	userID, _ := s.userIDFromRequest(r)
+	sanctions, err := s.uc.ListMySanctions(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, r, map[string]interface{}{"sanctions": sanctionsToMaps(sanctions)})
 }
 
-func (s *CampusService) handleAdminSecurityOverview(w http.ResponseWriter, r *http.Request) {
+func (s *CampusService) handleAdminListUserSanctions(w http.ResponseWriter, r *http.Request) {
+	targetUserID, ok := pathStringID(w, r)
+	if !ok {
+		return
+	}
 	userID, _ := s.userIDFromRequest(r)
-	overview, err := s.uc.AdminSecurityOverview(r.Context(), &biz.ListCampusSecurityInput{UserID: userID})
+	sanctions, err := s.uc.AdminListUserSanctions(r.Context(), userID, targetUserID)
 	if err != nil {
 		writeError(w, r, err)
 		return
 	}
-	writeJSON(w, r, map[string]interface{}{"security": securityOverviewToMap(overview)})
+	writeJSON(w, r, map[string]interface{}{"sanctions": sanctionsToMaps(sanctions)})
 }
 
-func (s *CampusService) handleAdminBlockIP(w http.ResponseWriter, r *http.Request) {
-	var req blockIPRequest
+func (s *CampusService) handleAdminCreateUserSanction(w http.ResponseWriter, r *http.Request) {
+	targetUserID, ok := pathStringID(w, r)
+	if !ok {
+		return
+	}
+	var req struct {
+		Type          string `json:"type"`
+		DurationHours int32  `json:"duration_hours"`
+		Reason        string `json:"reason"`
+		AppealNote    string `json:"appeal_note"`
+	}
 	if !decodeJSON(w, r, &req) {
 		return
 	}
 	userID, _ := s.userIDFromRequest(r)
-	if err := s.uc.AdminBlockIP(r.Context(), &biz.BlockCampusIPInput{
-		UserID: userID,
-		IP:     req.IP,
-		Reason: req.Reason,
-	}); err != nil {
+	sanction, err := s.uc.AdminCreateUserSanction(r.Context(), &biz.CreateCampusUserSanctionInput{
+		UserID:        userID,
+		TargetUserID:  targetUserID,
+		Type:          req.Type,
+		DurationHours: req.DurationHours,
+		Reason:        req.Reason,
+		AppealNote:    req.AppealNote,
+	})
+	if err != nil {
 		writeError(w, r, err)
 		return
 	}
-	writeJSON(w, r, map[string]interface{}{})
+	writeJSON(w, r, map[string]interface{}{"sanction": sanctionToMap(sanction)})
 }
 
-func (s *CampusService) handleAdminUnblockIP(w http.ResponseWriter, r *http.Request) {
-	ip := strings.TrimSpace(mux.Vars(r)["id"])
-	if ip == "" {
-		writeError(w, r, apperror.InvalidArgument("IP 无效"))
+func (s *CampusService) handleAdminRevokeUserSanction(w http.ResponseWriter, r *http.Request) {
+	id, ok := pathID(w, r)
+	if !ok {
 		return
 	}
-	userID, _ := s.userIDFromRequest(r)
-	if err := s.uc.AdminUnblockIP(r.Context(), &biz.BlockCampusIPInput{UserID: userID, IP: ip}); err != nil {
-		writeError(w, r, err)
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if !decodeJSON(w, r, &req) {
 		return
 	}
-	writeJSON(w, r, map[string]interface{}{})
-}
-
-func (s *CampusService) handleAdminListUsers(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
 	userID, _ := s.userIDFromRequest(r)
-	out, err := s.uc.AdminListUsers(r.Context(), &biz.ListCampusAdminUsersInput{
+	if err := s.uc.AdminRevokeUserSanction(r.Context(), &biz.RevokeCampusUserSanctionInput{
 		UserID:     userID,
-		Keyword:    q.Get("keyword"),
-		Role:       q.Get("role"),
-		AuthStatus: int32(queryInt(q.Get("auth_status"), -1)),
-		Page:       int32(queryInt(q.Get("page"), 1)),
-		Size:       int32(queryInt(q.Get("size"), 20)),
-	})
-	if err != nil {
+		SanctionID: id,
+		Reason:     req.Reason,
+	}); err != nil {
 		writeError(w, r, err)
 		return
 	}
-	users := make([]map[string]interface{}, 0, len(out.Users))
-	for _, user := range out.Users {
-		users = append(users, adminUserToMap(user))
-	}
-	writeJSON(w, r, map[string]interface{}{
-		"users":      users,
-		"page_stats": map[string]interface{}{"total": out.Total},
-	})
+	writeJSON(w, r, map[string]interface{}{})
 }
 
 func (s *CampusService) handleAdminUpdateUserRole(w http.ResponseWriter, r *http.Request) {
@@ -2765,15 +4444,37 @@ func (s *CampusService) authRequired(next http.HandlerFunc) http.HandlerFunc {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
-		_, err := s.userIDFromRequest(r)
+		userID, err := s.userIDFromRequest(r)
 		if err != nil {
 			writeError(w, r, err)
 			return
 		}
+		if !suspendedUserAllowed(r) {
+			if err := s.uc.CheckUserSuspended(r.Context(), userID); err != nil {
+				writeError(w, r, err)
+				return
+			}
+		}
 		next(w, r)
 	}
 }
 
+func suspendedUserAllowed(r *http.Request) bool {
+	path := r.URL.Path
+	switch {
+	case strings.HasPrefix(path, "/v1/campus/notifications"):
+		return true
+	case r.Method == http.MethodGet && (path == "/v1/campus/profile" || path == "/v1/campus/me/sanctions" || path == "/v1/campus/me/appeals"):
+		return true
+	case r.Method == http.MethodPost && strings.HasPrefix(path, "/v1/campus/forum/") && strings.HasSuffix(path, "/appeal"):
+		return true
+	case r.Method == http.MethodPost && path == "/v1/campus/feedback":
+		return true
+	default:
+		return false
+	}
+}
+
 func (s *CampusService) userIDFromRequest(r *http.Request) (string, error) {
 	if userID, err := claims.GetUserId(r.Context()); err == nil && userID != "" && userID != "0" {
 		return userID, nil
@@ -3019,6 +4720,18 @@ func writeError(w http.ResponseWriter, r *http.Request, err error) {
 	resp.ErrorEncoder(w, r, err)
 }
 
+func writeICS(w http.ResponseWriter, ics *biz.CampusTimetableICS, attachment bool) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	disposition := "inline"
+	if attachment {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`%s; filename="%s"`, disposition, strings.ReplaceAll(ics.Filename, `"`, "")))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(ics.Content))
+}
+
 func serveDownloadFile(w http.ResponseWriter, r *http.Request, file *biz.CampusMomentsPackageFile, attachment bool) {
 	if file == nil || strings.TrimSpace(file.Path) == "" {
 		writeError(w, r, apperror.NotFound("文件不存在"))
@@ -3167,11 +4880,13 @@ func campusStorageProvider() string {
 
 func categoryToMap(category *biz.CampusForumCategory) map[string]interface{} {
 	return map[string]interface{}{
-		"id":          strconv.FormatInt(category.ID, 10),
-		"code":        category.Code,
-		"name":        category.Name,
-		"description": category.Description,
-		"sort_order":  category.SortOrder,
+		"id":              strconv.FormatInt(category.ID, 10),
+		"campus_code":     category.CampusCode,
+		"code":            category.Code,
+		"name":            category.Name,
+		"description":     category.Description,
+		"sort_order":      category.SortOrder,
+		"allow_anonymous": category.AllowAnonymous,
 	}
 }
 
@@ -3182,6 +4897,7 @@ func profileToMap(profile *biz.CampusProfile) map[string]interface{} {
 	return map[string]interface{}{
 		"id":            strconv.FormatInt(profile.ID, 10),
 		"user_id":       profile.UserID,
+		"campus_code":   profile.CampusCode,
 		"school_name":   profile.SchoolName,
 		"student_no":    profile.StudentNo,
 		"real_name":     profile.RealName,
@@ -3209,6 +4925,71 @@ func userToMap(user *biz.UserBaseInfo) map[string]interface{} {
 	}
 }
 
+func timetableSubscriptionToMap(sub *biz.CampusTimetableSubscriptionOutput) map[string]interface{} {
+	if sub == nil || !sub.Active {
+		return map[string]interface{}{"active": false}
+	}
+	return map[string]interface{}{
+		"active":     true,
+		"url":        sub.URL,
+		"webcal_url": sub.WebcalURL,
+		"created_at": formatTime(sub.CreatedAt),
+		"updated_at": formatTime(sub.UpdatedAt),
+	}
+}
+
+func timetableCalendarToMap(config *biz.CampusTimetableCalendarConfig) map[string]interface{} {
+	if config == nil {
+		return nil
+	}
+	sections := make([]map[string]interface{}, 0, len(config.Sections))
+	for _, section := range config.Sections {
+		sections = append(sections, map[string]interface{}{
+			"section": section.Section,
+			"start":   section.Start,
+			"end":     section.End,
+		})
+	}
+	return map[string]interface{}{
+		"campus_code": config.CampusCode,
+		"sections":    sections,
+		"term_starts": config.TermStarts,
+	}
+}
+
+func calendarEventToMap(event *biz.CampusCalendarEvent) map[string]interface{} {
+	if event == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":          strconv.FormatInt(event.ID, 10),
+		"campus_code": event.CampusCode,
+		"term":        event.Term,
+		"event_type":  event.EventType,
+		"title":       event.Title,
+		"description": event.Description,
+		"location":    event.Location,
+		"start_at":    formatTime(event.StartAt),
+		"end_at":      formatTime(event.EndAt),
+		"all_day":     event.AllDay,
+		"hidden":      event.Status != biz.CampusCalendarStatusPublished,
+		"created_at":  formatTime(event.CreatedAt),
+		"updated_at":  formatTime(event.UpdatedAt),
+	}
+}
+
+func termWeekToMap(week *biz.CampusTermWeek) map[string]interface{} {
+	if week == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"term":       week.Term,
+		"week":       week.Week,
+		"term_start": week.TermStart.Format("2006-01-02"),
+		"in_term":    week.InTerm,
+	}
+}
+
 func timetableCourseToMap(course *biz.CampusTimetableCourse) map[string]interface{} {
 	if course == nil {
 		return nil
@@ -3236,8 +5017,10 @@ func postToMap(post *biz.CampusForumPost) map[string]interface{} {
 	if post == nil {
 		return nil
 	}
-	return map[string]interface{}{
+	item := map[string]interface{}{
 		"id":                   strconv.FormatInt(post.ID, 10),
+		"campus_code":          post.CampusCode,
+		"is_cross_campus":      post.IsCrossCampus,
 		"category_code":        post.CategoryCode,
 		"category_name":        post.CategoryName,
 		"author":               authorToMap(post.Author),
@@ -3251,6 +5034,7 @@ func postToMap(post *biz.CampusForumPost) map[string]interface{} {
 		"is_official":          post.IsOfficial,
 		"is_featured":          post.IsFeatured,
 		"is_pinned":            post.IsPinned,
+		"is_anonymous":         post.IsAnonymous,
 		"sort_weight":          post.SortWeight,
 		"status":               post.Status,
 		"audit_reason":         post.AuditReason,
@@ -3268,9 +5052,364 @@ func postToMap(post *biz.CampusForumPost) map[string]interface{} {
 		"collected_count":      post.CollectedCount,
 		"is_liked":             post.IsLiked,
 		"is_collected":         post.IsCollected,
+		"edit_count":           post.EditCount,
+		"edited_at":            formatOptionalTime(post.EditedAt),
+		"editable_until":       formatTime(biz.CampusPostEditableUntil(post)),
 		"created_at":           formatTime(post.CreatedAt),
 		"updated_at":           formatTime(post.UpdatedAt),
 	}
+	if post.SearchHighlight != nil {
+		item["highlight"] = searchHighlightToMap(post.SearchHighlight)
+	}
+	if post.Poll != nil {
+		item["poll"] = pollToMap(post.Poll)
+	}
+	if post.LostItem != nil {
+		item["lost_item"] = lostItemToMap(post.LostItem)
+	}
+	if post.Question != nil {
+		item["question"] = questionToMap(post.Question)
+	}
+	if post.ClubEvent != nil {
+		item["event"] = clubEventToMap(post.ClubEvent)
+	}
+	if len(post.Tags) > 0 {
+		tags := make([]map[string]interface{}, 0, len(post.Tags))
+		for _, tag := range post.Tags {
+			tags = append(tags, map[string]interface{}{
+				"id":           strconv.FormatInt(tag.ID, 10),
+				"name":         tag.Name,
+				"display_name": tag.DisplayName,
+			})
+		}
+		item["tags"] = tags
+	}
+	return item
+}
+
+func tagToMap(tag *biz.CampusTag) map[string]interface{} {
+	if tag == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":             strconv.FormatInt(tag.ID, 10),
+		"name":           tag.Name,
+		"display_name":   tag.DisplayName,
+		"status":         tag.Status,
+		"merged_into_id": strconv.FormatInt(tag.MergedIntoID, 10),
+		"is_featured":    tag.IsFeatured,
+		"post_count":     tag.PostCount,
+		"trend_score":    tag.TrendScore,
+		"created_at":     formatTime(tag.CreatedAt),
+		"updated_at":     formatTime(tag.UpdatedAt),
+	}
+}
+
+func (req *clubEventRequest) toInput() *biz.CampusClubEventInput {
+	if req == nil {
+		return nil
+	}
+	return &biz.CampusClubEventInput{
+		Capacity:       req.Capacity,
+		StartsAt:       parseOptionalRequestTime(req.StartsAt),
+		SignupDeadline: parseOptionalRequestTime(req.SignupDeadline),
+		Place:          req.Place,
+	}
+}
+
+func clubEventToMap(event *biz.CampusClubEvent) map[string]interface{} {
+	if event == nil {
+		return nil
+	}
+	now := time.Now()
+	item := map[string]interface{}{
+		"capacity":         event.Capacity,
+		"starts_at":        formatTime(event.StartsAt),
+		"signup_deadline":  formatTime(event.SignupDeadline),
+		"place":            event.Place,
+		"signup_closed":    event.SignupClosed(now),
+		"full":             event.Full(),
+		"registered_count": event.RegisteredCount,
+		"waitlist_count":   event.WaitlistCount,
+		"checked_in_count": event.CheckedInCount,
+		"my_registration":  clubRegistrationToMap(event.MyRegistration),
+	}
+	if event.CheckinCode != "" {
+		item["checkin_code"] = event.CheckinCode
+	}
+	return item
+}
+
+func clubRegistrationToMap(registration *biz.CampusClubRegistration) map[string]interface{} {
+	if registration == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"user_id":       registration.UserID,
+		"user":          authorToMap(registration.User),
+		"status":        registration.Status,
+		"checked_in":    registration.CheckedInAt != nil,
+		"checked_in_at": formatOptionalTime(registration.CheckedInAt),
+		"created_at":    formatTime(registration.CreatedAt),
+	}
+}
+
+func questionToMap(question *biz.CampusQuestion) map[string]interface{} {
+	if question == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"post_id":             strconv.FormatInt(question.PostID, 10),
+		"accepted":            question.Accepted(),
+		"accepted_comment_id": strconv.FormatInt(question.AcceptedCommentID, 10),
+		"accepted_at":         formatOptionalTime(question.AcceptedAt),
+		"answered":            question.AnsweredAt != nil,
+		"answered_at":         formatOptionalTime(question.AnsweredAt),
+	}
+}
+
+func (req *lostItemRequest) toInput() *biz.CampusLostItemInput {
+	if req == nil {
+		return nil
+	}
+	return &biz.CampusLostItemInput{
+		Kind:       req.Kind,
+		Category:   req.Category,
+		Place:      req.Place,
+		EventStart: parseOptionalRequestTime(req.EventStart),
+		EventEnd:   parseOptionalRequestTime(req.EventEnd),
+	}
+}
+
+func lostItemToMap(item *biz.CampusLostItem) map[string]interface{} {
+	if item == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"kind":        item.Kind,
+		"category":    item.Category,
+		"place":       item.Place,
+		"event_start": formatOptionalTime(item.EventStart),
+		"event_end":   formatOptionalTime(item.EventEnd),
+		"status":      item.Status,
+		"resolved":    item.Status == biz.CampusLostStatusReturned,
+		"resolved_at": formatOptionalTime(item.ResolvedAt),
+	}
+}
+
+func lostMatchToMap(match *biz.CampusLostMatch) map[string]interface{} {
+	return map[string]interface{}{
+		"id":            fmt.Sprintf("%d", match.ID),
+		"lost_post_id":  fmt.Sprintf("%d", match.LostPostID),
+		"found_post_id": fmt.Sprintf("%d", match.FoundPostID),
+		"score":         match.Score,
+		"reasons":       match.Reasons,
+		"created_at":    formatTime(match.CreatedAt),
+		"post":          postToMap(match.Counterpart),
+	}
+}
+
+func (req *pollRequest) toInput() *biz.CampusPollInput {
+	if req == nil {
+		return nil
+	}
+	return &biz.CampusPollInput{
+		Options:    req.Options,
+		ChoiceMode: req.ChoiceMode,
+		MaxChoices: req.MaxChoices,
+		ClosesAt:   parseOptionalRequestTime(req.ClosesAt),
+		Anonymous:  req.Anonymous == nil || *req.Anonymous,
+	}
+}
+
+func pollToMap(poll *biz.CampusPoll) map[string]interface{} {
+	if poll == nil {
+		return nil
+	}
+	options := make([]map[string]interface{}, 0, len(poll.Options))
+	for _, option := range poll.Options {
+		item := map[string]interface{}{
+			"id":         option.ID,
+			"text":       option.Text,
+			"vote_count": option.VoteCount,
+		}
+		if !poll.Anonymous {
+			voters := make([]map[string]interface{}, 0, len(option.Voters))
+			for _, voter := range option.Voters {
+				voters = append(voters, authorToMap(voter))
+			}
+			item["voters"] = voters
+		}
+		options = append(options, item)
+	}
+	myChoices := poll.MyChoices
+	if myChoices == nil {
+		myChoices = []int32{}
+	}
+	return map[string]interface{}{
+		"choice_mode": poll.ChoiceMode,
+		"max_choices": poll.MaxChoices,
+		"anonymous":   poll.Anonymous,
+		"status":      poll.Status,
+		"closed":      poll.IsClosed(time.Now()),
+		"closes_at":   formatOptionalTime(poll.ClosesAt),
+		"closed_at":   formatOptionalTime(poll.ClosedAt),
+		"voter_count": poll.VoterCount,
+		"has_voted":   poll.HasVoted(),
+		"my_choices":  myChoices,
+		"options":     options,
+	}
+}
+
+func imageAuditTaskToMap(task *biz.CampusImageAuditTask) map[string]interface{} {
+	if task == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":           strconv.FormatInt(task.ID, 10),
+		"image_url":    task.ImageURL,
+		"status":       task.Status,
+		"provider":     task.Provider,
+		"decision":     task.Decision,
+		"label":        task.Label,
+		"reason":       task.Reason,
+		"retry_count":  task.RetryCount,
+		"last_error":   task.LastError,
+		"raw_result":   task.RawResult,
+		"created_at":   formatTime(task.CreatedAt),
+		"processed_at": formatOptionalTime(task.ProcessedAt),
+	}
+}
+
+func auditRuleToMap(rule *biz.CampusAuditRule) map[string]interface{} {
+	if rule == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":          strconv.FormatInt(rule.ID, 10),
+		"name":        rule.Name,
+		"category":    rule.Category,
+		"rule_type":   rule.RuleType,
+		"patterns":    rule.Patterns,
+		"score":       rule.Score,
+		"enabled":     rule.Enabled,
+		"hit_count":   rule.HitCount,
+		"last_hit_at": formatOptionalTime(rule.LastHitAt),
+		"created_by":  rule.CreatedBy,
+		"updated_by":  rule.UpdatedBy,
+		"created_at":  formatTime(rule.CreatedAt),
+		"updated_at":  formatTime(rule.UpdatedAt),
+	}
+}
+
+func auditRuleHitsToMaps(hits []biz.CampusAuditRuleHit) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(hits))
+	for _, hit := range hits {
+		out = append(out, map[string]interface{}{
+			"rule_id":   strconv.FormatInt(hit.RuleID, 10),
+			"rule_name": hit.RuleName,
+			"category":  hit.Category,
+			"matched":   hit.Matched,
+			"score":     hit.Score,
+		})
+	}
+	return out
+}
+
+func imageHashBlockToMap(block *biz.CampusImageHashBlock) map[string]interface{} {
+	if block == nil {
+		return nil
+	}
+	out := map[string]interface{}{
+		"id":             strconv.FormatInt(block.ID, 10),
+		"phash":          "",
+		"dhash":          "",
+		"image_url":      block.ImageURL,
+		"source_post_id": "",
+		"reason":         block.Reason,
+		"created_by":     block.CreatedBy,
+		"created_at":     formatTime(block.CreatedAt),
+	}
+	if block.PHash != 0 {
+		out["phash"] = imagehash.Format(block.PHash)
+	}
+	if block.DHash != 0 {
+		out["dhash"] = imagehash.Format(block.DHash)
+	}
+	if block.SourcePostID > 0 {
+		out["source_post_id"] = strconv.FormatInt(block.SourcePostID, 10)
+	}
+	return out
+}
+
+func postRevisionToMap(revision *biz.CampusPostRevision) map[string]interface{} {
+	if revision == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":            strconv.FormatInt(revision.ID, 10),
+		"post_id":       strconv.FormatInt(revision.PostID, 10),
+		"revision":      revision.Revision,
+		"editor_id":     revision.EditorID,
+		"editor_role":   revision.EditorRole,
+		"title":         revision.Title,
+		"content":       revision.Content,
+		"images":        revision.Images,
+		"media_type":    revision.MediaType,
+		"extra":         revision.Extra,
+		"cover_url":     revision.CoverURL,
+		"status_before": revision.StatusBefore,
+		"created_at":    formatTime(revision.CreatedAt),
+	}
+}
+
+func postScheduleToMap(schedule *biz.CampusPostSchedule) map[string]interface{} {
+	if schedule == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"post_id":      strconv.FormatInt(schedule.PostID, 10),
+		"campus_code":  schedule.CampusCode,
+		"state":        schedule.State,
+		"publish_at":   formatOptionalTime(schedule.PublishAt),
+		"is_pinned":    schedule.IsPinned,
+		"is_featured":  schedule.IsFeatured,
+		"sort_weight":  schedule.SortWeight,
+		"created_by":   schedule.CreatedBy,
+		"updated_by":   schedule.UpdatedBy,
+		"last_error":   schedule.LastError,
+		"published_at": formatOptionalTime(schedule.PublishedAt),
+		"created_at":   formatTime(schedule.CreatedAt),
+		"updated_at":   formatTime(schedule.UpdatedAt),
+		"post":         postToMap(schedule.Post),
+	}
+}
+
+func searchHighlightToMap(highlight *biz.CampusSearchHighlight) map[string]interface{} {
+	if highlight == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"title":   highlight.Title,
+		"snippet": highlight.Snippet,
+	}
+}
+
+func searchReindexStatusToMap(status *biz.CampusSearchReindexStatus) map[string]interface{} {
+	if status == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"backend":     status.Backend,
+		"running":     status.Running,
+		"started_at":  formatOptionalTime(status.StartedAt),
+		"finished_at": formatOptionalTime(status.FinishedAt),
+		"posts":       status.Posts,
+		"comments":    status.Comments,
+		"pruned":      status.Pruned,
+		"documents":   status.Documents,
+		"error":       status.Error,
+	}
 }
 
 func postPublishState(post *biz.CampusForumPost) string {
@@ -3284,6 +5423,10 @@ func postPublishState(post *biz.CampusForumPost) string {
 		return "syncing"
 	case biz.CampusAuditStatusRejected:
 		return "needs_attention"
+	case biz.CampusAuditStatusDraft:
+		return "draft"
+	case biz.CampusAuditStatusScheduled:
+		return "scheduled"
 	default:
 		return "hidden"
 	}
@@ -3297,6 +5440,10 @@ func postClientStatusLabel(post *biz.CampusForumPost) string {
 		return "同步中"
 	case "needs_attention":
 		return "需修改"
+	case "draft":
+		return "草稿"
+	case "scheduled":
+		return "待定时发布"
 	default:
 		return "已隐藏"
 	}
@@ -3310,11 +5457,26 @@ func postClientStatusDetail(post *biz.CampusForumPost) string {
 		return "已发布，正在同步到首页"
 	case "needs_attention":
 		return "这条内容暂未同步，请修改后再发布"
+	case "draft":
+		return "草稿仅运营可见"
+	case "scheduled":
+		return "到点后自动发布"
 	default:
 		return "这条内容暂不可见"
 	}
 }
 
+func anonymousSettingsToMap(settings *biz.CampusAnonymousSettings) map[string]interface{} {
+	if settings == nil {
+		return map[string]interface{}{"category_codes": []string{}}
+	}
+	return map[string]interface{}{
+		"category_codes": settings.CategoryCodes,
+		"updated_by":     settings.UpdatedBy,
+		"updated_at":     formatTime(settings.UpdatedAt),
+	}
+}
+
 func auditSettingsToMap(settings *biz.CampusOpsAuditSettings) map[string]interface{} {
 	if settings == nil {
 		return map[string]interface{}{
@@ -3351,7 +5513,23 @@ func publicUserProfileToMap(user *biz.CampusPublicUserProfile) map[string]interf
 			"post_count":      stats.PostCount,
 			"like_count":      stats.LikeCount,
 			"collected_count": stats.CollectedCount,
+			"follow_count":    user.FollowCount,
+			"follower_count":  user.FollowerCount,
 		},
+		"is_following": user.IsFollowing,
+	}
+}
+
+func followUserToMap(user *biz.CampusFollowUser) map[string]interface{} {
+	return map[string]interface{}{
+		"user_id":        user.UserID,
+		"name":           user.Name,
+		"nickname":       user.Nickname,
+		"avatar":         user.Avatar,
+		"signature":      user.Signature,
+		"follow_count":   user.FollowCount,
+		"follower_count": user.FollowerCount,
+		"is_following":   user.IsFollowing,
 	}
 }
 
@@ -3360,17 +5538,91 @@ func reportToMap(report *biz.CampusForumReport) map[string]interface{} {
 		return nil
 	}
 	return map[string]interface{}{
-		"id":          strconv.FormatInt(report.ID, 10),
-		"target_type": report.TargetType,
-		"target_id":   strconv.FormatInt(report.TargetID, 10),
-		"target":      postToMap(report.Target),
-		"comment":     commentToMap(report.Comment),
-		"reporter":    authorToMap(report.Reporter),
-		"reason":      report.Reason,
-		"detail":      report.Detail,
-		"status":      report.Status,
-		"created_at":  formatTime(report.CreatedAt),
-		"updated_at":  formatTime(report.UpdatedAt),
+		"id":              strconv.FormatInt(report.ID, 10),
+		"target_type":     report.TargetType,
+		"target_id":       strconv.FormatInt(report.TargetID, 10),
+		"target":          postToMap(report.Target),
+		"comment":         commentToMap(report.Comment),
+		"reporter":        authorToMap(report.Reporter),
+		"reporter_weight": report.ReporterWeight,
+		"claim":           moderationClaimToMap(report.Claim),
+		"reason":          report.Reason,
+		"detail":          report.Detail,
+		"status":          report.Status,
+		"created_at":      formatTime(report.CreatedAt),
+		"updated_at":      formatTime(report.UpdatedAt),
+	}
+}
+
+func reportGroupToMap(group *biz.CampusReportGroup) map[string]interface{} {
+	reports := make([]map[string]interface{}, 0, len(group.Reports))
+	for _, report := range group.Reports {
+		item := reportToMap(report)
+		delete(item, "target")
+		delete(item, "comment")
+		reports = append(reports, item)
+	}
+	return map[string]interface{}{
+		"target_type":       group.TargetType,
+		"target_id":         strconv.FormatInt(group.TargetID, 10),
+		"target":            postToMap(group.Target),
+		"comment":           commentToMap(group.Comment),
+		"report_count":      group.ReportCount,
+		"pending_count":     group.PendingCount,
+		"reasons":           group.Reasons,
+		"reports":           reports,
+		"weighted_score":    group.WeightedScore,
+		"auto_hidden":       group.AutoHidden,
+		"claim":             moderationClaimToMap(group.Claim),
+		"first_reported_at": formatTime(group.FirstReportedAt),
+		"last_reported_at":  formatTime(group.LastReportedAt),
+	}
+}
+
+func moderationClaimToMap(claim *biz.CampusModerationClaim) map[string]interface{} {
+	if claim == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"target_type": claim.TargetType,
+		"target_id":   strconv.FormatInt(claim.TargetID, 10),
+		"operator_id": claim.OperatorID,
+		"operator":    authorToMap(claim.Operator),
+		"assigned_by": claim.AssignedBy,
+		"claimed_at":  formatTime(claim.ClaimedAt),
+		"expires_at":  formatTime(claim.ExpiresAt),
+	}
+}
+
+func appealsToMaps(appeals []*biz.CampusModerationAppeal) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(appeals))
+	for _, appeal := range appeals {
+		out = append(out, appealToMap(appeal))
+	}
+	return out
+}
+
+func appealToMap(appeal *biz.CampusModerationAppeal) map[string]interface{} {
+	if appeal == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":              strconv.FormatInt(appeal.ID, 10),
+		"target_type":     appeal.TargetType,
+		"target_id":       strconv.FormatInt(appeal.TargetID, 10),
+		"post_id":         strconv.FormatInt(appeal.PostID, 10),
+		"user_id":         appeal.UserID,
+		"user":            authorToMap(appeal.User),
+		"campus_code":     appeal.CampusCode,
+		"reason":          appeal.Reason,
+		"status":          appeal.Status,
+		"original_status": appeal.OriginalStatus,
+		"original_reason": appeal.OriginalReason,
+		"target_excerpt":  appeal.TargetExcerpt,
+		"reviewer_id":     appeal.ReviewerID,
+		"review_note":     appeal.ReviewNote,
+		"reviewed_at":     formatOptionalTime(appeal.ReviewedAt),
+		"created_at":      formatTime(appeal.CreatedAt),
 	}
 }
 
@@ -3600,6 +5852,7 @@ func aiReplyTaskToMap(task *biz.CampusAIReplyTask) map[string]interface{} {
 		"trigger_comment_id": strconv.FormatInt(task.TriggerCommentID, 10),
 		"asker_id":           task.AskerID,
 		"bot_user_id":        task.BotUserID,
+		"source":             task.Source,
 		"prompt":             task.Prompt,
 		"status":             task.Status,
 		"retry_count":        task.RetryCount,
@@ -4105,6 +6358,57 @@ func adminUserToMap(user *biz.CampusAdminUser) map[string]interface{} {
 		"last_active_ip":     user.LastActiveIP,
 		"last_active_path":   user.LastActivePath,
 		"last_active_status": user.LastActiveStatus,
+		"sanctions":          sanctionsToMaps(user.Sanctions),
+		"trust":              trustToMap(user.Trust),
+	}
+}
+
+func trustToMap(trust *biz.CampusUserTrustScore) map[string]interface{} {
+	if trust == nil {
+		return nil
+	}
+	breakdown := make([]map[string]interface{}, 0, len(trust.Breakdown))
+	for _, factor := range trust.Breakdown {
+		breakdown = append(breakdown, map[string]interface{}{
+			"code":   factor.Code,
+			"label":  factor.Label,
+			"value":  factor.Value,
+			"points": factor.Points,
+		})
+	}
+	return map[string]interface{}{
+		"score":     trust.Score,
+		"level":     trust.Level,
+		"breakdown": breakdown,
+	}
+}
+
+func sanctionsToMaps(sanctions []*biz.CampusUserSanction) []map[string]interface{} {
+	out := make([]map[string]interface{}, 0, len(sanctions))
+	for _, sanction := range sanctions {
+		out = append(out, sanctionToMap(sanction))
+	}
+	return out
+}
+
+func sanctionToMap(sanction *biz.CampusUserSanction) map[string]interface{} {
+	if sanction == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"id":            strconv.FormatInt(sanction.ID, 10),
+		"user_id":       sanction.UserID,
+		"type":          sanction.Type,
+		"reason":        sanction.Reason,
+		"appeal_note":   sanction.AppealNote,
+		"status":        sanction.Status,
+		"starts_at":     formatTime(sanction.StartsAt),
+		"expires_at":    formatOptionalTime(sanction.ExpiresAt),
+		"created_by":    sanction.CreatedBy,
+		"revoked_by":    sanction.RevokedBy,
+		"revoked_at":    formatOptionalTime(sanction.RevokedAt),
+		"revoke_reason": sanction.RevokeReason,
+		"created_at":    formatTime(sanction.CreatedAt),
 	}
 }
 
@@ -4132,6 +6436,7 @@ func commentToMap(comment *biz.CampusForumComment) map[string]interface{} {
 		"like_count":          comment.LikeCount,
 		"reply_count":         comment.ReplyCount,
 		"is_liked":            comment.IsLiked,
+		"is_accepted":         comment.IsAccepted,
 		"preview_replies":     previewReplies,
 		"created_at":          formatTime(comment.CreatedAt),
 		"updated_at":          formatTime(comment.UpdatedAt),
@@ -4143,12 +6448,13 @@ func authorToMap(author *biz.CampusForumAuthor) map[string]interface{} {
 		return nil
 	}
 	return map[string]interface{}{
-		"user_id":     author.UserID,
-		"name":        author.Name,
-		"nickname":    author.Nickname,
-		"avatar":      author.Avatar,
-		"school_name": author.SchoolName,
-		"auth_status": author.AuthStatus,
+		"user_id":      author.UserID,
+		"name":         author.Name,
+		"nickname":     author.Nickname,
+		"avatar":       author.Avatar,
+		"school_name":  author.SchoolName,
+		"auth_status":  author.AuthStatus,
+		"is_anonymous": author.IsAnonymous,
 	}
 }
 
@@ -171,3 +171,19 @@ func TestAIReplyOverviewToMapIncludesAutoReplyState(t *testing.T) {
 		t.Fatalf("bot fields = %#v", got)
 	}
 }
+
+func TestPollRequestDefaultsToAnonymous(t *testing.T) {
+	var omitted, public pollRequest
+	if err := json.Unmarshal([]byte(`{"options":["a","b"]}`), &omitted); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"options":["a","b"],"anonymous":false}`), &public); err != nil {
+		t.Fatal(err)
+	}
+	if !omitted.toInput().Anonymous {
+		t.Fatal("poll without anonymous field should default to anonymous")
+	}
+	if public.toInput().Anonymous {
+		t.Fatal("explicit anonymous=false should be kept")
+	}
+}
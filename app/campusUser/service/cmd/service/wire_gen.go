@@ -33,8 +33,9 @@ func wireApp(confServer *conf.Server, idgen *conf.Idgen, registry *conf.Registry
 		return nil, nil, err
 	}
 	userRepo := data.NewUserRepo(dataData, logger)
+	followRepo := data.NewFollowRepo(dataData, logger)
 	generator := data.NewIdGenerator(idgen)
-	userUsecase := biz.NewUserUsecase(userRepo, generator, logger)
+	userUsecase := biz.NewUserUsecase(userRepo, followRepo, generator, logger)
 	userServiceService := service.NewUserServiceService(userUsecase)
 	grpcServer := server.NewGRPCServer(confServer, userServiceService, logger)
 	httpServer := server.NewHTTPServer(confServer, logger)
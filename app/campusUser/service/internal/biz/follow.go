@@ -0,0 +1,153 @@
+package biz
+
+import (
+	"context"
+
+	"lehu-video/pkg/apperror"
+)
+
+type FollowUserCommand struct {
+	UserId       int64
+	TargetUserId int64
+}
+
+type FollowUserResult struct {
+	Changed bool
+}
+
+type ListFollowQuery struct {
+	UserId      int64
+	Page        int
+	PageSize    int
+	AfterUserId *int64 // 非空时按用户 ID 升序游标翻页，不统计总数
+	IdsOnly     bool
+}
+
+type ListFollowResult struct {
+	UserIds []int64
+	Users   []*User
+	Total   int64
+}
+
+type CheckFollowingQuery struct {
+	UserId        int64
+	TargetUserIds []int64
+}
+
+type CheckFollowingResult struct {
+	FollowingUserIds []int64
+}
+
+// FollowRepo 关注关系仓库，关注数和粉丝数与关系在同一事务内维护。
+type FollowRepo interface {
+	CreateFollow(ctx context.Context, followerId, followeeId int64) (bool, error)
+	DeleteFollow(ctx context.Context, followerId, followeeId int64) (bool, error)
+	ListFollowUserIds(ctx context.Context, userId int64, followers bool, afterUserId *int64, offset, limit int) ([]int64, int64, error)
+	ListFollowingIn(ctx context.Context, userId int64, targetIds []int64) ([]int64, error)
+}
+
+func (uc *UserUsecase) FollowUser(ctx context.Context, cmd *FollowUserCommand) (*FollowUserResult, error) {
+	if err := validateFollowCommand(cmd); err != nil {
+		return nil, err
+	}
+	exist, _, err := uc.repo.GetUserById(ctx, cmd.TargetUserId)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, apperror.NotFound("用户不存在")
+	}
+	changed, err := uc.follow.CreateFollow(ctx, cmd.UserId, cmd.TargetUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &FollowUserResult{Changed: changed}, nil
+}
+
+func (uc *UserUsecase) UnfollowUser(ctx context.Context, cmd *FollowUserCommand) (*FollowUserResult, error) {
+	if err := validateFollowCommand(cmd); err != nil {
+		return nil, err
+	}
+	changed, err := uc.follow.DeleteFollow(ctx, cmd.UserId, cmd.TargetUserId)
+	if err != nil {
+		return nil, err
+	}
+	return &FollowUserResult{Changed: changed}, nil
+}
+
+func (uc *UserUsecase) ListFollowers(ctx context.Context, query *ListFollowQuery) (*ListFollowResult, error) {
+	return uc.listFollow(ctx, query, true)
+}
+
+func (uc *UserUsecase) ListFollowing(ctx context.Context, query *ListFollowQuery) (*ListFollowResult, error) {
+	return uc.listFollow(ctx, query, false)
+}
+
+func (uc *UserUsecase) listFollow(ctx context.Context, query *ListFollowQuery, followers bool) (*ListFollowResult, error) {
+	if query.UserId <= 0 {
+		return nil, apperror.InvalidArgument("用户ID无效")
+	}
+	maxPageSize := 100
+	if query.IdsOnly {
+		maxPageSize = 1000
+	}
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize <= 0 {
+		query.PageSize = 20
+	}
+	if query.PageSize > maxPageSize {
+		query.PageSize = maxPageSize
+	}
+	offset := (query.Page - 1) * query.PageSize
+	if query.AfterUserId != nil {
+		offset = 0
+	}
+	ids, total, err := uc.follow.ListFollowUserIds(ctx, query.UserId, followers, query.AfterUserId, offset, query.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	result := &ListFollowResult{UserIds: ids, Users: []*User{}, Total: total}
+	if query.IdsOnly || len(ids) == 0 {
+		return result, nil
+	}
+	users, err := uc.repo.GetUserByIdList(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	userMap := make(map[int64]*User, len(users))
+	for _, user := range users {
+		userMap[user.Id] = user
+	}
+	for _, id := range ids {
+		if user, ok := userMap[id]; ok {
+			result.Users = append(result.Users, user)
+		}
+	}
+	return result, nil
+}
+
+func (uc *UserUsecase) CheckFollowing(ctx context.Context, query *CheckFollowingQuery) (*CheckFollowingResult, error) {
+	if query.UserId <= 0 || len(query.TargetUserIds) == 0 {
+		return &CheckFollowingResult{FollowingUserIds: []int64{}}, nil
+	}
+	if len(query.TargetUserIds) > 1000 {
+		return nil, apperror.InvalidArgument("一次最多查询 1000 个用户")
+	}
+	ids, err := uc.follow.ListFollowingIn(ctx, query.UserId, query.TargetUserIds)
+	if err != nil {
+		return nil, err
+	}
+	return &CheckFollowingResult{FollowingUserIds: ids}, nil
+}
+
+func validateFollowCommand(cmd *FollowUserCommand) error {
+	if cmd.UserId <= 0 || cmd.TargetUserId <= 0 {
+		return apperror.InvalidArgument("用户ID无效")
+	}
+	if cmd.UserId == cmd.TargetUserId {
+		return apperror.InvalidArgument("不能关注自己")
+	}
+	return nil
+}
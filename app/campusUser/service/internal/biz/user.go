@@ -119,13 +119,14 @@ type UserRepo interface {
 }
 
 type UserUsecase struct {
-	repo  UserRepo
-	idGen idgen.Generator
-	log   *log.Helper
+	repo   UserRepo
+	follow FollowRepo
+	idGen  idgen.Generator
+	log    *log.Helper
 }
 
-func NewUserUsecase(repo UserRepo, idGen idgen.Generator, logger log.Logger) *UserUsecase {
-	return &UserUsecase{repo: repo, idGen: idGen, log: log.NewHelper(logger)}
+func NewUserUsecase(repo UserRepo, follow FollowRepo, idGen idgen.Generator, logger log.Logger) *UserUsecase {
+	return &UserUsecase{repo: repo, follow: follow, idGen: idGen, log: log.NewHelper(logger)}
 }
 
 func (uc *UserUsecase) CreateUser(ctx context.Context, cmd *CreateUserCommand) (*CreateUserResult, error) {
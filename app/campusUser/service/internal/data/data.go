@@ -19,6 +19,7 @@ var ProviderSet = wire.NewSet(
 	NewData,
 	NewDB,
 	NewUserRepo,
+	NewFollowRepo,
 	NewIdGenerator,
 )
 
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/log"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"lehu-video/app/campusUser/service/internal/biz"
+	"lehu-video/app/campusUser/service/internal/data/model"
+)
+
+type followRepo struct {
+	data *Data
+	log  *log.Helper
+}
+
+func NewFollowRepo(data *Data, logger log.Logger) biz.FollowRepo {
+	return &followRepo{
+		data: data,
+		log:  log.NewHelper(logger),
+	}
+}
+
+func (r *followRepo) CreateFollow(ctx context.Context, followerId, followeeId int64) (bool, error) {
+	changed := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&model.UserFollow{
+			FollowerId: followerId,
+			FolloweeId: followeeId,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		changed = true
+		return r.adjustFollowCounts(tx, followerId, followeeId, 1)
+	})
+	if err != nil {
+		return false, fmt.Errorf("关注用户失败: %w", err)
+	}
+	return changed, nil
+}
+
+func (r *followRepo) DeleteFollow(ctx context.Context, followerId, followeeId int64) (bool, error) {
+	changed := false
+	err := r.data.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("follower_id = ? AND followee_id = ?", followerId, followeeId).Delete(&model.UserFollow{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		changed = true
+		return r.adjustFollowCounts(tx, followerId, followeeId, -1)
+	})
+	if err != nil {
+		return false, fmt.Errorf("取消关注失败: %w", err)
+	}
+	return changed, nil
+}
+
+func (r *followRepo) adjustFollowCounts(tx *gorm.DB, followerId, followeeId int64, delta int) error {
+	if err := tx.Table(model.User{}.TableName()).Where("id = ?", followerId).
+		Update("follow_count", gorm.Expr("GREATEST(follow_count + ?, 0)", delta)).Error; err != nil {
+		return err
+	}
+	return tx.Table(model.User{}.TableName()).Where("id = ?", followeeId).
+		Update("follower_count", gorm.Expr("GREATEST(follower_count + ?, 0)", delta)).Error
+}
+
+func (r *followRepo) ListFollowUserIds(ctx context.Context, userId int64, followers bool, afterUserId *int64, offset, limit int) ([]int64, int64, error) {
+	ownerColumn, otherColumn := "follower_id", "followee_id"
+	if followers {
+		ownerColumn, otherColumn = "followee_id", "follower_id"
+	}
+	db := r.data.db.WithContext(ctx).Table(model.UserFollow{}.TableName()).Where(ownerColumn+" = ?", userId)
+
+	var total int64
+	order := "created_at DESC, " + otherColumn + " DESC"
+	if afterUserId != nil {
+		db = db.Where(otherColumn+" > ?", *afterUserId)
+		order = otherColumn + " ASC"
+	} else if err := db.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("统计关注关系失败: %w", err)
+	}
+
+	ids := make([]int64, 0, limit)
+	if err := db.Order(order).Offset(offset).Limit(limit).Pluck(otherColumn, &ids).Error; err != nil {
+		return nil, 0, fmt.Errorf("查询关注关系失败: %w", err)
+	}
+	return ids, total, nil
+}
+
+func (r *followRepo) ListFollowingIn(ctx context.Context, userId int64, targetIds []int64) ([]int64, error) {
+	ids := make([]int64, 0)
+	if len(targetIds) == 0 {
+		return ids, nil
+	}
+	err := r.data.db.WithContext(ctx).Table(model.UserFollow{}.TableName()).
+		Where("follower_id = ? AND followee_id IN (?)", userId, targetIds).
+		Pluck("followee_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("查询关注关系失败: %w", err)
+	}
+	return ids, nil
+}
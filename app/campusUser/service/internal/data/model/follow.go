@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type UserFollow struct {
+	FollowerId int64     `json:"follower_id" gorm:"column:follower_id;primary_key"`
+	FolloweeId int64     `json:"followee_id" gorm:"column:followee_id;primary_key;index"`
+	CreatedAt  time.Time `json:"created_at" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (m UserFollow) TableName() string {
+	return "user_follow"
+}
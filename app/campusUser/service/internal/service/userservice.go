@@ -243,3 +243,121 @@ func (s *UserServiceService) UpdateUserLastOnlineTime(ctx context.Context, req *
 		Meta: utils.GetSuccessMeta(),
 	}, nil
 }
+
+func (s *UserServiceService) FollowUser(ctx context.Context, req *pb.FollowUserReq) (*pb.FollowUserResp, error) {
+	result, err := s.uc.FollowUser(ctx, &biz.FollowUserCommand{
+		UserId:       cast.ToInt64(req.UserId),
+		TargetUserId: cast.ToInt64(req.TargetUserId),
+	})
+	if err != nil {
+		return &pb.FollowUserResp{
+			Meta: utils.GetMetaWithError(err),
+		}, nil
+	}
+
+	return &pb.FollowUserResp{
+		Meta:    utils.GetSuccessMeta(),
+		Changed: result.Changed,
+	}, nil
+}
+
+func (s *UserServiceService) UnfollowUser(ctx context.Context, req *pb.UnfollowUserReq) (*pb.UnfollowUserResp, error) {
+	result, err := s.uc.UnfollowUser(ctx, &biz.FollowUserCommand{
+		UserId:       cast.ToInt64(req.UserId),
+		TargetUserId: cast.ToInt64(req.TargetUserId),
+	})
+	if err != nil {
+		return &pb.UnfollowUserResp{
+			Meta: utils.GetMetaWithError(err),
+		}, nil
+	}
+
+	return &pb.UnfollowUserResp{
+		Meta:    utils.GetSuccessMeta(),
+		Changed: result.Changed,
+	}, nil
+}
+
+func (s *UserServiceService) ListFollowers(ctx context.Context, req *pb.ListFollowReq) (*pb.ListFollowResp, error) {
+	result, err := s.uc.ListFollowers(ctx, toListFollowQuery(req))
+	if err != nil {
+		return &pb.ListFollowResp{
+			Meta: utils.GetMetaWithError(err),
+		}, nil
+	}
+	return toListFollowResp(result), nil
+}
+
+func (s *UserServiceService) ListFollowing(ctx context.Context, req *pb.ListFollowReq) (*pb.ListFollowResp, error) {
+	result, err := s.uc.ListFollowing(ctx, toListFollowQuery(req))
+	if err != nil {
+		return &pb.ListFollowResp{
+			Meta: utils.GetMetaWithError(err),
+		}, nil
+	}
+	return toListFollowResp(result), nil
+}
+
+func (s *UserServiceService) CheckFollowing(ctx context.Context, req *pb.CheckFollowingReq) (*pb.CheckFollowingResp, error) {
+	result, err := s.uc.CheckFollowing(ctx, &biz.CheckFollowingQuery{
+		UserId:        cast.ToInt64(req.UserId),
+		TargetUserIds: cast.ToInt64Slice(req.TargetUserIds),
+	})
+	if err != nil {
+		return &pb.CheckFollowingResp{
+			Meta: utils.GetMetaWithError(err),
+		}, nil
+	}
+
+	return &pb.CheckFollowingResp{
+		Meta:             utils.GetSuccessMeta(),
+		FollowingUserIds: utils.Slice2Slice(result.FollowingUserIds, formatUserId),
+	}, nil
+}
+
+func toListFollowQuery(req *pb.ListFollowReq) *biz.ListFollowQuery {
+	query := &biz.ListFollowQuery{
+		UserId:   cast.ToInt64(req.UserId),
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+		IdsOnly:  req.IdsOnly,
+	}
+	if req.AfterUserId != "" {
+		afterUserId := cast.ToInt64(req.AfterUserId)
+		query.AfterUserId = &afterUserId
+	}
+	return query
+}
+
+func toListFollowResp(result *biz.ListFollowResult) *pb.ListFollowResp {
+	users := make([]*pb.UserBaseInfo, 0, len(result.Users))
+	for _, user := range result.Users {
+		users = append(users, &pb.UserBaseInfo{
+			Id:              strconv.FormatInt(user.Id, 10),
+			Name:            user.Name,
+			Nickname:        user.Nickname,
+			Avatar:          user.Avatar,
+			BackgroundImage: user.BackgroundImage,
+			Signature:       user.Signature,
+			Gender:          user.Gender,
+			FollowCount:     user.FollowCount,
+			FollowerCount:   user.FollowerCount,
+			BeLikedCount:    user.BeLikedCount,
+			WorkCount:       user.WorkCount,
+			CollectionCount: user.CollectionCount,
+			CreatedAt:       user.CreatedAt.Format("2006-01-02 15:04:05"),
+			UpdatedAt:       user.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
+	}
+
+	return &pb.ListFollowResp{
+		Meta:    utils.GetSuccessMeta(),
+		Users:   users,
+		UserIds: utils.Slice2Slice(result.UserIds, formatUserId),
+		Total:   result.Total,
+	}
+}
+
+func formatUserId(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
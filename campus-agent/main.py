@@ -46,6 +46,9 @@ class ModerationAuditRequest(BaseModel):
     model_allowed: bool = True
     high_risk_words: List[str] = Field(default_factory=list)
     review_words: List[str] = Field(default_factory=list)
+    rule_risk_level: str = ""
+    rule_reason: str = ""
+    rule_evidence: List[str] = Field(default_factory=list)
 
 
 class ModelUsage(BaseModel):
@@ -539,6 +542,8 @@ def normalize_word_list(values: List[str], fallback: List[str]) -> List[str]:
 
 
 def rule_moderation(req: ModerationAuditRequest) -> ModerationAuditResult:
+    if req.rule_risk_level in ("low", "medium", "high"):
+        return engine_rule_result(req)
     text = f"{req.title}\n{req.content}".lower()
     high_words = normalize_word_list(req.high_risk_words, DEFAULT_HIGH_RISK_WORDS)
     medium_words = normalize_word_list(req.review_words, DEFAULT_REVIEW_WORDS)
@@ -582,6 +587,29 @@ def rule_moderation(req: ModerationAuditRequest) -> ModerationAuditResult:
     )
 
 
+def engine_rule_result(req: ModerationAuditRequest) -> ModerationAuditResult:
+    # campus-api 的规则引擎已经给出结论时直接沿用，避免两边词表不一致
+    evidence = list(req.rule_evidence or [])
+    if req.rule_risk_level == "low":
+        return ModerationAuditResult(
+            decision="pass",
+            confidence=0.96,
+            risk_level="low",
+            rule_risk_level="low",
+            reason=req.rule_reason or "规则未发现明显风险",
+            evidence=evidence or ["rule_low_risk"],
+            model_skipped_reason="rule_low_risk",
+        )
+    return ModerationAuditResult(
+        decision="review",
+        confidence=0.72 if req.rule_risk_level == "high" else 0.68,
+        risk_level=req.rule_risk_level,
+        rule_risk_level=req.rule_risk_level,
+        reason=req.rule_reason or "规则命中，需要人工确认",
+        evidence=evidence,
+    )
+
+
 heuristic_moderation = rule_moderation
 
 
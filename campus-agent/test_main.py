@@ -63,6 +63,12 @@ class CampusAgentTest(unittest.TestCase):
         self.assertEqual(result.risk_level, "high")
         self.assertIn("keyword:暗号甲", result.evidence)
 
+    def test_heuristic_moderation_uses_engine_result(self):
+        result = heuristic_moderation(ModerationAuditRequest(title="校园墙", content="这里有代考", rule_risk_level="medium", rule_reason="命中需人工确认规则：暗号乙", rule_evidence=["rule:复核:暗号乙"]))
+        self.assertEqual(result.decision, "review")
+        self.assertEqual(result.risk_level, "medium")
+        self.assertEqual(result.evidence, ["rule:复核:暗号乙"])
+
     def test_moderation_audit_calls_model_for_rule_low(self):
         calls = []
         original = agent_main.call_moderation_model
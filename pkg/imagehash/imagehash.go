@@ -0,0 +1,113 @@
+package imagehash
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"math/bits"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	phashSize    = 32
+	phashLowFreq = 8
+)
+
+// DHash 计算 64 位差值哈希：缩放到 9x8 灰度图，逐行比较相邻像素。
+func DHash(img image.Image) uint64 {
+	small := image.NewGray(image.Rect(0, 0, 9, 8))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), draw.Src, nil)
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			if small.GrayAt(x, y).Y > small.GrayAt(x+1, y).Y {
+				hash |= 1 << uint(y*8+x)
+			}
+		}
+	}
+	return hash
+}
+
+// PHash 计算 64 位感知哈希：32x32 灰度图做 DCT，取左上 8x8 低频系数与中位数比较。
+func PHash(img image.Image) uint64 {
+	small := image.NewGray(image.Rect(0, 0, phashSize, phashSize))
+	draw.ApproxBiLinear.Scale(small, small.Bounds(), img, img.Bounds(), draw.Src, nil)
+	pixels := make([][]float64, phashSize)
+	for y := 0; y < phashSize; y++ {
+		pixels[y] = make([]float64, phashSize)
+		for x := 0; x < phashSize; x++ {
+			pixels[y][x] = float64(small.GrayAt(x, y).Y)
+		}
+	}
+	coeffs := dct2D(pixels)
+	values := make([]float64, 0, phashLowFreq*phashLowFreq)
+	for y := 0; y < phashLowFreq; y++ {
+		for x := 0; x < phashLowFreq; x++ {
+			values = append(values, coeffs[y][x])
+		}
+	}
+	sorted := append([]float64(nil), values[1:]...)
+	sort.Float64s(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	var hash uint64
+	for i, value := range values {
+		if value > median {
+			hash |= 1 << uint(i)
+		}
+	}
+	return hash
+}
+
+func dct2D(pixels [][]float64) [][]float64 {
+	n := len(pixels)
+	table := make([][]float64, n)
+	for u := 0; u < n; u++ {
+		table[u] = make([]float64, n)
+		for x := 0; x < n; x++ {
+			table[u][x] = math.Cos(float64(2*x+1) * float64(u) * math.Pi / float64(2*n))
+		}
+	}
+	rows := make([][]float64, n)
+	for y := 0; y < n; y++ {
+		rows[y] = make([]float64, n)
+		for u := 0; u < n; u++ {
+			var sum float64
+			for x := 0; x < n; x++ {
+				sum += pixels[y][x] * table[u][x]
+			}
+			rows[y][u] = sum
+		}
+	}
+	out := make([][]float64, n)
+	for v := 0; v < n; v++ {
+		out[v] = make([]float64, n)
+		for u := 0; u < n; u++ {
+			var sum float64
+			for y := 0; y < n; y++ {
+				sum += rows[y][u] * table[v][y]
+			}
+			out[v][u] = sum
+		}
+	}
+	return out
+}
+
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+func Format(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+func Parse(value string) (uint64, error) {
+	value = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(value)), "0x")
+	if value == "" {
+		return 0, fmt.Errorf("empty image hash")
+	}
+	return strconv.ParseUint(value, 16, 64)
+}
@@ -0,0 +1,70 @@
+package imagehash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func testGradient(width, height int, shift uint8) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8((x*255)/width+y%7) + shift})
+		}
+	}
+	return img
+}
+
+func testBlocks(width, height int) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			value := uint8(40)
+			if (x*4/width+y*4/height)%2 == 0 {
+				value = 210
+			}
+			img.SetGray(x, y, color.Gray{Y: value})
+		}
+	}
+	return img
+}
+
+func flipped(src *image.Gray) *image.Gray {
+	bounds := src.Bounds()
+	out := image.NewGray(bounds)
+	for y := 0; y < bounds.Dy(); y++ {
+		for x := 0; x < bounds.Dx(); x++ {
+			out.SetGray(bounds.Dx()-1-x, y, src.GrayAt(x, y))
+		}
+	}
+	return out
+}
+
+func TestHashesTolerateResize(t *testing.T) {
+	original := DHash(testGradient(640, 480, 0))
+	if distance := Distance(original, DHash(testGradient(320, 240, 3))); distance > 6 {
+		t.Fatalf("dhash: resized copy should stay close, distance=%d", distance)
+	}
+	if distance := Distance(original, DHash(flipped(testGradient(640, 480, 0)))); distance < 20 {
+		t.Fatalf("dhash: different image should be far away, distance=%d", distance)
+	}
+	original = PHash(testBlocks(400, 400))
+	if distance := Distance(original, PHash(testBlocks(123, 123))); distance > 6 {
+		t.Fatalf("phash: resized copy should stay close, distance=%d", distance)
+	}
+	if distance := Distance(original, PHash(flipped(testBlocks(400, 400)))); distance < 20 {
+		t.Fatalf("phash: different image should be far away, distance=%d", distance)
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	hash := PHash(testBlocks(64, 64))
+	parsed, err := Parse("0x" + Format(hash))
+	if err != nil || parsed != hash {
+		t.Fatalf("round trip failed: %x %x %v", hash, parsed, err)
+	}
+	if _, err := Parse("not-a-hash"); err == nil {
+		t.Fatal("invalid hash should fail")
+	}
+}
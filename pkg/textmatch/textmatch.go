@@ -0,0 +1,183 @@
+package textmatch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-pinyin"
+)
+
+// 常见的变体字符，发帖时用来绕过关键词
+var variantRunes = map[rune]rune{
+	'薇': '微',
+	'嶶': '微',
+	'徴': '微',
+	'囗': '口',
+	'〇': '0',
+}
+
+// Normalize 统一全角/半角、大小写和常见变体字符，保留空白用于正则匹配
+func Normalize(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	lastSpace := false
+	for _, r := range text {
+		r = foldRune(r)
+		if r == 0 {
+			continue
+		}
+		if unicode.IsSpace(r) {
+			if !lastSpace {
+				b.WriteRune(' ')
+			}
+			lastSpace = true
+			continue
+		}
+		lastSpace = false
+		b.WriteRune(r)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Compact 在 Normalize 的基础上去掉空白、标点和符号，"赌 博"、"d-u-b-o" 会被压成连续文本
+func Compact(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+	for _, r := range text {
+		r = foldRune(r)
+		if r == 0 || unicode.IsSpace(r) || unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Pinyin 在 Compact 的基础上把汉字换成不带声调的拼音（多音字取常用读音），
+// "赌博"、"du博"、"D-U-B-O" 都会变成 "dubo"，同音替换字也会落到同一串拼音上
+func Pinyin(text string) string {
+	compact := Compact(text)
+	var b strings.Builder
+	b.Grow(len(compact) * 2)
+	for _, r := range compact {
+		if unicode.Is(unicode.Han, r) {
+			if syllables := pinyin.SinglePinyin(r, pinyinArgs); len(syllables) > 0 {
+				b.WriteString(syllables[0])
+				continue
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func foldRune(r rune) rune {
+	switch {
+	case r == '\u3000':
+		return ' '
+	case r >= '！' && r <= '～':
+		r -= 0xFEE0
+	case r >= '①' && r <= '⑨':
+		return '1' + (r - '①')
+	case r >= 'Ⓐ' && r <= 'Ⓩ':
+		return 'a' + (r - 'Ⓐ')
+	case r >= 'ⓐ' && r <= 'ⓩ':
+		return 'a' + (r - 'ⓐ')
+	case r == '\u200b' || r == '\u200c' || r == '\u200d' || r == '\ufeff':
+		return 0
+	}
+	if v, ok := variantRunes[r]; ok {
+		r = v
+	}
+	return unicode.ToLower(r)
+}
+
+type node struct {
+	next    map[rune]int
+	fail    int
+	outputs []int
+}
+
+// Matcher 是多关键词的 Aho-Corasick 自动机，构建后只读，可并发使用
+type Matcher struct {
+	nodes    []node
+	patterns []string
+}
+
+func NewMatcher(patterns []string) *Matcher {
+	m := &Matcher{nodes: []node{{next: map[rune]int{}}}, patterns: patterns}
+	for index, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+		current := 0
+		for _, r := range pattern {
+			child, ok := m.nodes[current].next[r]
+			if !ok {
+				child = len(m.nodes)
+				m.nodes = append(m.nodes, node{next: map[rune]int{}})
+				m.nodes[current].next[r] = child
+			}
+			current = child
+		}
+		m.nodes[current].outputs = append(m.nodes[current].outputs, index)
+	}
+	queue := make([]int, 0, len(m.nodes))
+	for _, child := range m.nodes[0].next {
+		queue = append(queue, child)
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for r, child := range m.nodes[current].next {
+			fail := m.nodes[current].fail
+			for fail > 0 {
+				if _, ok := m.nodes[fail].next[r]; ok {
+					break
+				}
+				fail = m.nodes[fail].fail
+			}
+			if target, ok := m.nodes[fail].next[r]; ok && target != child {
+				m.nodes[child].fail = target
+			}
+			m.nodes[child].outputs = append(m.nodes[child].outputs, m.nodes[m.nodes[child].fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+	return m
+}
+
+// Match 返回命中的关键词下标，每个关键词只返回一次，按首次命中顺序排列
+func (m *Matcher) Match(text string) []int {
+	if m == nil || len(m.nodes) <= 1 {
+		return nil
+	}
+	seen := map[int]bool{}
+	var hits []int
+	current := 0
+	for _, r := range text {
+		for current > 0 {
+			if _, ok := m.nodes[current].next[r]; ok {
+				break
+			}
+			current = m.nodes[current].fail
+		}
+		current = m.nodes[current].next[r]
+		for _, index := range m.nodes[current].outputs {
+			if !seen[index] {
+				seen[index] = true
+				hits = append(hits, index)
+			}
+		}
+	}
+	return hits
+}
+
+func (m *Matcher) Pattern(index int) string {
+	if m == nil || index < 0 || index >= len(m.patterns) {
+		return ""
+	}
+	return m.patterns[index]
+}
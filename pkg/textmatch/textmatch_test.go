@@ -0,0 +1,56 @@
+package textmatch
+
+import "testing"
+
+func TestCompactFoldsVariants(t *testing.T) {
+	cases := map[string]string{
+		"赌 博":      "赌博",
+		"Ｄ-Ｕ b.o":  "dubo",
+		"加薇信":      "加微信",
+		"扣\u200b扣": "扣扣",
+		"①③⑧":      "138",
+	}
+	for input, want := range cases {
+		if got := Compact(input); got != want {
+			t.Fatalf("Compact(%q) = %q, want %q", input, got, want)
+		}
+	}
+	if got := Normalize("  ＡＢ　 c  "); got != "ab c" {
+		t.Fatalf("Normalize = %q", got)
+	}
+}
+
+func TestPinyinFoldsHanAndLatin(t *testing.T) {
+	cases := map[string]string{
+		"赌博":       "dubo",
+		"du博":      "dubo",
+		"D-U 博":    "dubo",
+		"代 考，包过":   "daikaobaoguo",
+		"加薇信 abc1": "jiaweixinabc1",
+	}
+	for input, want := range cases {
+		if got := Pinyin(input); got != want {
+			t.Fatalf("Pinyin(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestMatcherFindsOverlappingPatterns(t *testing.T) {
+	m := NewMatcher([]string{"he", "she", "his", "hers", ""})
+	hits := m.Match("ushers")
+	want := []int{1, 0, 3}
+	if len(hits) != len(want) {
+		t.Fatalf("hits = %v, want %v", hits, want)
+	}
+	for i := range want {
+		if hits[i] != want[i] {
+			t.Fatalf("hits = %v, want %v", hits, want)
+		}
+	}
+	if hits := NewMatcher([]string{"代考", "考试"}).Match("期末代考试题"); len(hits) != 2 {
+		t.Fatalf("chinese hits = %v", hits)
+	}
+	if hits := NewMatcher(nil).Match("anything"); hits != nil {
+		t.Fatalf("empty matcher hits = %v", hits)
+	}
+}
@@ -0,0 +1,37 @@
+#!/usr/bin/env bash
+set -euo pipefail
+
+API_BASE="${API_BASE:-http://127.0.0.1:18080/v1}"
+ADMIN_TOKEN="${ADMIN_TOKEN:-}"
+WAIT="${WAIT:-1}"
+
+if [ -z "${ADMIN_TOKEN}" ]; then
+  echo "Usage: ADMIN_TOKEN=<admin jwt> [API_BASE=${API_BASE}] [WAIT=1] scripts/search-reindex.sh" >&2
+  exit 1
+fi
+
+auth=(-H "Authorization: Bearer ${ADMIN_TOKEN}")
+
+resp="$(curl -sS -X POST "${API_BASE}/campus/admin/search/reindex" "${auth[@]}" -H 'Content-Type: application/json' -d '{}')"
+if ! printf '%s' "${resp}" | grep -q '"code":0'; then
+  echo "Search reindex failed to start: ${resp}" >&2
+  exit 1
+fi
+echo "Search reindex started: ${resp}"
+
+if [ "${WAIT}" != "1" ]; then
+  exit 0
+fi
+
+while true; do
+  sleep 3
+  status="$(curl -sS "${API_BASE}/campus/admin/search/status" "${auth[@]}")"
+  if ! printf '%s' "${status}" | grep -q '"running":true'; then
+    echo "Search reindex finished: ${status}"
+    if printf '%s' "${status}" | grep -q '"error":"[^"]'; then
+      exit 1
+    fi
+    exit 0
+  fi
+  echo "Reindexing... ${status}"
+done
@@ -0,0 +1,21 @@
+-- 多校区支持：帖子、校园资料、版块增加 campus_code。
+-- 已有数据默认归属深汕校区；版块 campus_code 为空表示所有校区共享。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_profile`
+  ADD COLUMN `campus_code` VARCHAR(32) NOT NULL DEFAULT 'szpu_shenshan' COMMENT '所属校区' AFTER `union_id`,
+  ADD INDEX `idx_campus_profile_campus` (`campus_code`);
+
+ALTER TABLE `campus_forum_category`
+  ADD COLUMN `campus_code` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '空=所有校区共享' AFTER `id`,
+  ADD INDEX `idx_campus_forum_category_campus` (`campus_code`, `is_deleted`, `sort_order`),
+  DROP INDEX `uk_campus_forum_category_code`,
+  ADD UNIQUE KEY `uk_campus_forum_category_code` (`campus_code`, `code`);
+
+ALTER TABLE `campus_forum_post`
+  ADD COLUMN `campus_code` VARCHAR(32) NOT NULL DEFAULT 'szpu_shenshan' COMMENT '发帖校区' AFTER `id`,
+  ADD COLUMN `is_cross_campus` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '跨校区公开' AFTER `campus_code`,
+  ADD INDEX `idx_campus_post_campus_created` (`campus_code`, `status`, `is_deleted`, `created_at`, `id`),
+  ADD INDEX `idx_campus_post_cross_campus` (`is_cross_campus`, `status`, `is_deleted`, `created_at`, `id`);
@@ -0,0 +1,16 @@
+-- 课表 iCalendar 订阅：每个用户一条可撤销的订阅记录。
+-- 节次时间与开学日期按校区写入 campus_ops_setting（timetable_sections:<校区>、timetable_terms:<校区>）。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_timetable_subscription` (
+  `id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `nonce` VARCHAR(64) NOT NULL,
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '1=有效 0=已撤销',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_timetable_subscription_user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园课表日历订阅';
@@ -0,0 +1,27 @@
+-- 校历：考试、补考、放假与校园活动，按校区发布，e仔回答时间类问题时会引用。
+-- 教学周根据 campus_ops_setting 中的 timetable_terms:<校区> 开学日期计算。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_calendar_event` (
+  `id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '空=所有校区',
+  `term` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '如 2025-2026-1',
+  `event_type` VARCHAR(16) NOT NULL COMMENT 'exam/makeup_exam/holiday/event/teaching',
+  `title` VARCHAR(128) NOT NULL,
+  `description` VARCHAR(1024) NOT NULL DEFAULT '',
+  `location` VARCHAR(128) NOT NULL DEFAULT '',
+  `start_at` DATETIME(3) NOT NULL,
+  `end_at` DATETIME(3) NOT NULL,
+  `all_day` BOOLEAN NOT NULL DEFAULT FALSE,
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '1=发布 0=隐藏',
+  `is_deleted` BOOLEAN NOT NULL DEFAULT FALSE,
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_campus_calendar_event_range` (`campus_code`, `is_deleted`, `status`, `start_at`),
+  KEY `idx_campus_calendar_event_term` (`term`, `event_type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园校历（考试、放假、活动）';
@@ -0,0 +1,25 @@
+-- 帖子/评论全文检索：ngram FULLTEXT 索引表，只收录公开可见的帖子和评论。
+-- 建表后服务启动会在索引为空时自动全量回填，也可以执行 make search-reindex 手动重建。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_search_document` (
+  `doc_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `doc_id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `is_cross_campus` BOOLEAN NOT NULL DEFAULT FALSE,
+  `category_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `post_type` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL DEFAULT 0,
+  `title` VARCHAR(255) NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `indexed_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`doc_type`, `doc_id`),
+  KEY `idx_campus_search_document_post` (`post_id`),
+  KEY `idx_campus_search_document_indexed` (`indexed_at`),
+  FULLTEXT KEY `ft_campus_search_document_title` (`title`) WITH PARSER ngram,
+  FULLTEXT KEY `ft_campus_search_document_text` (`title`, `content`) WITH PARSER ngram
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园帖子/评论全文检索文档（仅含公开可见内容）';
@@ -0,0 +1,26 @@
+-- 作者编辑帖子：记录编辑次数，并保存每次编辑前的版本供审核回溯。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_forum_post`
+  ADD COLUMN `edit_count` INT NOT NULL DEFAULT 0 COMMENT '编辑次数' AFTER `collected_count`,
+  ADD COLUMN `edited_at` DATETIME(3) DEFAULT NULL COMMENT '最近编辑时间' AFTER `edit_count`;
+
+CREATE TABLE IF NOT EXISTS `campus_forum_post_revision` (
+  `id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL,
+  `revision` INT NOT NULL COMMENT '第几次编辑',
+  `editor_id` BIGINT NOT NULL,
+  `editor_role` VARCHAR(16) NOT NULL DEFAULT 'author' COMMENT 'author/admin',
+  `title` VARCHAR(120) NOT NULL,
+  `content` TEXT NOT NULL,
+  `images` JSON DEFAULT NULL,
+  `media_type` VARCHAR(16) NOT NULL DEFAULT 'text',
+  `extra` JSON DEFAULT NULL,
+  `cover_url` VARCHAR(1024) NOT NULL DEFAULT '',
+  `status_before` TINYINT NOT NULL DEFAULT 1 COMMENT '编辑前审核状态',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_post_revision_post` (`post_id`, `revision`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子编辑前版本';
@@ -0,0 +1,26 @@
+-- 运营草稿与定时发布：帖子状态新增 4=草稿 5=定时发布，置顶/精选/权重在发布时生效。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_forum_post`
+  MODIFY COLUMN `status` TINYINT NOT NULL DEFAULT 1 COMMENT '0=待审核 1=可见 2=拒绝 3=删除 4=草稿 5=定时发布';
+
+CREATE TABLE IF NOT EXISTS `campus_post_schedule` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `state` VARCHAR(16) NOT NULL DEFAULT 'draft' COMMENT 'draft/scheduled/published',
+  `publish_at` DATETIME(3) DEFAULT NULL COMMENT '定时发布时间',
+  `is_pinned` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '发布时置顶',
+  `is_featured` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '发布时精选',
+  `sort_weight` INT NOT NULL DEFAULT 0 COMMENT '发布时排序权重',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `last_error` VARCHAR(255) NOT NULL DEFAULT '',
+  `published_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_post_schedule_due` (`state`, `publish_at`),
+  INDEX `idx_campus_post_schedule_campus` (`campus_code`, `state`, `publish_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='运营草稿与定时发布';
@@ -0,0 +1,45 @@
+-- 投票帖：post_type=poll，选项、投票人和投票明细分表存储，投票人表保证每人只投一次。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_forum_post`
+  MODIFY COLUMN `post_type` VARCHAR(24) NOT NULL DEFAULT 'note' COMMENT 'note/lost/question/guide/club/poll';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll` (
+  `post_id` BIGINT NOT NULL,
+  `choice_mode` VARCHAR(16) NOT NULL DEFAULT 'single' COMMENT 'single/multi',
+  `max_choices` INT NOT NULL DEFAULT 1,
+  `closes_at` DATETIME(3) DEFAULT NULL COMMENT '投票截止时间',
+  `anonymous` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '1=不展示投票人',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'open' COMMENT 'open/closed',
+  `closed_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_post_poll_due` (`status`, `closes_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票帖设置';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_option` (
+  `post_id` BIGINT NOT NULL,
+  `option_id` INT NOT NULL COMMENT '从 1 开始的选项序号',
+  `text` VARCHAR(64) NOT NULL,
+  PRIMARY KEY (`post_id`, `option_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票选项';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_voter` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票人，每人每个投票只能投一次';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_vote` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `option_id` INT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`, `option_id`),
+  INDEX `idx_campus_post_poll_vote_option` (`post_id`, `option_id`),
+  INDEX `idx_campus_post_poll_vote_recent` (`post_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票选择明细';
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS `campus_lost_item` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL,
+  `kind` VARCHAR(16) NOT NULL COMMENT 'lost/found',
+  `category` VARCHAR(24) NOT NULL DEFAULT 'other' COMMENT 'card/electronics/keys/bag/clothing/book/other',
+  `place` VARCHAR(128) NOT NULL DEFAULT '',
+  `event_start` DATETIME(3) DEFAULT NULL COMMENT '丢失/捡到时间窗口起点',
+  `event_end` DATETIME(3) DEFAULT NULL COMMENT '丢失/捡到时间窗口终点',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'open' COMMENT 'open/returned',
+  `resolved_at` DATETIME(3) DEFAULT NULL,
+  `resolved_by` BIGINT NOT NULL DEFAULT 0,
+  `scanned_at` DATETIME(3) DEFAULT NULL COMMENT '自动匹配完成时间',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_lost_item_open` (`campus_code`, `kind`, `status`, `created_at`),
+  INDEX `idx_campus_lost_item_scan` (`scanned_at`, `status`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='失物招领结构化信息';
+
+CREATE TABLE IF NOT EXISTS `campus_lost_match` (
+  `id` BIGINT NOT NULL,
+  `lost_post_id` BIGINT NOT NULL,
+  `found_post_id` BIGINT NOT NULL,
+  `score` DECIMAL(6,3) NOT NULL DEFAULT 0,
+  `reasons` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'category,place,time,text',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_lost_match_pair` (`lost_post_id`, `found_post_id`),
+  INDEX `idx_campus_lost_match_found` (`found_post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='失物招领自动匹配结果';
@@ -0,0 +1,30 @@
+-- 问题自动回答没有触发评论，trigger_comment_id 记为 0，唯一键改为按帖子区分
+ALTER TABLE `campus_ai_reply_task`
+  ADD COLUMN `source` VARCHAR(16) NOT NULL DEFAULT 'mention' COMMENT 'mention=@e仔 question=问题长时间无人回答' AFTER `bot_user_id`,
+  MODIFY COLUMN `trigger_comment_id` BIGINT NOT NULL COMMENT '触发@e仔的评论ID，问题自动回答为 0',
+  DROP INDEX `uk_campus_ai_reply_trigger_comment`,
+  ADD UNIQUE KEY `uk_campus_ai_reply_trigger_comment` (`post_id`, `trigger_comment_id`);
+
+CREATE TABLE IF NOT EXISTS `campus_question` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL,
+  `accepted_comment_id` BIGINT NOT NULL DEFAULT 0 COMMENT '被采纳的一级评论 ID，0 表示未采纳',
+  `accepted_at` DATETIME(3) DEFAULT NULL,
+  `answered_at` DATETIME(3) DEFAULT NULL COMMENT '首个非提问者回答时间',
+  `ai_task_id` BIGINT NOT NULL DEFAULT 0 COMMENT '自动排队的 e仔回答任务 ID',
+  `ai_queued_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_question_state` (`campus_code`, `accepted_comment_id`, `answered_at`),
+  INDEX `idx_campus_question_ai` (`accepted_comment_id`, `answered_at`, `ai_task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='问答帖采纳与未解决状态';
+
+INSERT IGNORE INTO `campus_question` (`post_id`, `campus_code`, `author_id`, `answered_at`, `created_at`)
+SELECT p.`id`, p.`campus_code`, p.`author_id`,
+  (SELECT MIN(c.`created_at`) FROM `campus_forum_comment` c
+    WHERE c.`post_id` = p.`id` AND c.`author_id` <> p.`author_id` AND c.`status` = 1 AND c.`is_deleted` = FALSE),
+  p.`created_at`
+FROM `campus_forum_post` p
+WHERE p.`post_type` = 'question' AND p.`is_deleted` = FALSE;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS `campus_club_event` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `organizer_id` BIGINT NOT NULL COMMENT '发帖人即组织者',
+  `capacity` INT NOT NULL DEFAULT 0 COMMENT '报名上限，0 表示不限',
+  `starts_at` DATETIME(3) NOT NULL,
+  `signup_deadline` DATETIME(3) NOT NULL,
+  `place` VARCHAR(128) NOT NULL DEFAULT '',
+  `checkin_code` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '6 位签到码，只返回给组织者',
+  `reminder_sent_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_club_event_reminder` (`reminder_sent_at`, `starts_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='社团活动报名设置';
+
+CREATE TABLE IF NOT EXISTS `campus_club_registration` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'registered' COMMENT 'registered/waitlisted/cancelled',
+  `checked_in_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '报名时间，候补按此排序',
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`),
+  INDEX `idx_campus_club_registration_status` (`post_id`, `status`, `created_at`),
+  INDEX `idx_campus_club_registration_user` (`user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='社团活动报名与候补';
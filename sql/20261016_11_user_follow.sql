@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS `user_follow` (
+  `follower_id` BIGINT NOT NULL,
+  `followee_id` BIGINT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`follower_id`, `followee_id`),
+  INDEX `idx_user_follow_followee` (`followee_id`, `follower_id`),
+  INDEX `idx_user_follow_follower_created` (`follower_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户关注关系';
+
+CREATE TABLE IF NOT EXISTS `campus_post_fanout` (
+  `post_id` BIGINT NOT NULL,
+  `author_id` BIGINT NOT NULL,
+  `last_user_id` BIGINT NOT NULL DEFAULT 0 COMMENT '已推送到的粉丝 ID，按 ID 升序续推',
+  `notified_count` BIGINT NOT NULL DEFAULT 0,
+  `done_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='新帖推送粉丝进度';
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS `campus_user_block` (
+  `user_id` BIGINT NOT NULL,
+  `target_user_id` BIGINT NOT NULL,
+  `kind` VARCHAR(16) NOT NULL DEFAULT 'block' COMMENT 'block 拉黑：隐藏内容并禁止对方互动；mute 静音：只隐藏内容和通知',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`user_id`, `target_user_id`),
+  INDEX `idx_campus_user_block_kind` (`user_id`, `kind`, `updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户拉黑与静音';
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS `campus_tag` (
+  `id` BIGINT NOT NULL,
+  `name` VARCHAR(64) NOT NULL COMMENT '小写归一化后的话题名',
+  `display_name` VARCHAR(64) NOT NULL,
+  `status` TINYINT NOT NULL DEFAULT 0 COMMENT '0 正常 1 封禁 2 已合并',
+  `merged_into_id` BIGINT NOT NULL DEFAULT 0,
+  `is_featured` TINYINT(1) NOT NULL DEFAULT 0,
+  `post_count` BIGINT NOT NULL DEFAULT 0 COMMENT '可见帖子数，定时任务对账',
+  `trend_score` DOUBLE NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_tag_name` (`name`),
+  INDEX `idx_campus_tag_trending` (`status`, `is_featured`, `trend_score`),
+  INDEX `idx_campus_tag_merged` (`merged_into_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子话题';
+
+CREATE TABLE IF NOT EXISTS `campus_post_tag` (
+  `post_id` BIGINT NOT NULL,
+  `tag_id` BIGINT NOT NULL,
+  `source` VARCHAR(16) NOT NULL DEFAULT 'content' COMMENT 'explicit 发帖时选择；content 正文 #话题 提取',
+  `position` TINYINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `tag_id`),
+  INDEX `idx_campus_post_tag_tag` (`tag_id`, `post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子与话题关联';
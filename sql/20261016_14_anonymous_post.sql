@@ -0,0 +1,8 @@
+-- 敏感版块匿名发帖：帖子记录匿名标记，真实作者只在审核和举报后台可见。
+-- 允许匿名的版块由 campus_ops_setting.anonymous_categories 配置，逗号分隔版块 code。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_forum_post`
+  ADD COLUMN `is_anonymous` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '匿名发布，公开接口只展示帖内化名' AFTER `is_pinned`;
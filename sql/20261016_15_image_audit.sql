@@ -0,0 +1,29 @@
+-- 图片内容审核：上传确认和发帖后异步审核图片，违规或需复核的图片会把帖子转为待审核。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_image_audit_task` (
+  `id` BIGINT NOT NULL,
+  `target_type` VARCHAR(32) NOT NULL DEFAULT 'post' COMMENT 'post/upload',
+  `target_id` BIGINT NOT NULL COMMENT '帖子 ID 或上传文件 ID',
+  `image_url` VARCHAR(1024) NOT NULL,
+  `url_hash` CHAR(40) NOT NULL COMMENT 'image_url 的 sha1',
+  `status` VARCHAR(24) NOT NULL DEFAULT 'pending' COMMENT 'pending/processing/done/failed',
+  `provider` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'hash/wechat/local/noop',
+  `decision` VARCHAR(24) NOT NULL DEFAULT '' COMMENT 'pass/review/reject/skip',
+  `label` VARCHAR(64) NOT NULL DEFAULT '',
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `raw_result` TEXT,
+  `retry_count` INT NOT NULL DEFAULT 0,
+  `next_retry_at` DATETIME(3) DEFAULT NULL,
+  `locked_until` DATETIME(3) DEFAULT NULL,
+  `last_error` VARCHAR(600) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  `processed_at` DATETIME(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_image_audit_target` (`target_type`, `target_id`, `url_hash`),
+  INDEX `idx_campus_image_audit_status_next` (`status`, `next_retry_at`, `locked_until`, `id`),
+  INDEX `idx_campus_image_audit_url` (`url_hash`, `status`, `processed_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园图片审核任务';
@@ -0,0 +1,114 @@
+-- 图片感知哈希：base 文件服务在上传确认时为图片计算 pHash/dHash 并随文件记录保存，
+-- 校园服务按图片地址记录指纹，用于拦截与已驳回/下架帖子相似的图片，以及后台维护的违规图片库。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `file`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_hash_0`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_hash_1`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_hash_2`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_hash_3`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_hash_4`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_id_0`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_id_1`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_id_2`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_id_3`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_post_media_id_4`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_hash_0`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_hash_1`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_hash_2`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_hash_3`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_hash_4`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_id_0`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_id_1`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_id_2`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_id_3`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+ALTER TABLE `file_campus_public_id_4`
+  ADD COLUMN `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制' AFTER `uploaded`,
+  ADD COLUMN `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制' AFTER `phash`;
+
+CREATE TABLE IF NOT EXISTS `campus_image_fingerprint` (
+  `url_hash` CHAR(40) NOT NULL COMMENT 'image_url 的 sha1',
+  `image_url` VARCHAR(1024) NOT NULL,
+  `file_id` BIGINT NOT NULL DEFAULT 0 COMMENT '上传文件 ID，审核时补算为 0',
+  `phash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `dhash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `source` VARCHAR(16) NOT NULL DEFAULT 'upload' COMMENT 'upload/audit',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`url_hash`),
+  INDEX `idx_campus_image_fingerprint_file` (`file_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园图片感知哈希';
+
+CREATE TABLE IF NOT EXISTS `campus_image_hash_block` (
+  `id` BIGINT NOT NULL,
+  `phash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `dhash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `image_url` VARCHAR(1024) NOT NULL DEFAULT '',
+  `source_post_id` BIGINT NOT NULL DEFAULT 0,
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_image_hash_block_created` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园违规图片哈希库';
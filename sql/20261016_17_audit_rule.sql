@@ -0,0 +1,41 @@
+-- 审核规则引擎：关键词（Aho-Corasick）和正则规则存到 campus_audit_rule，
+-- 分类阈值与处理动作（pass/review/reject）以 JSON 存在 campus_ops_setting.audit_rule_categories。
+-- 未改动过的默认高风险词/复核词会迁移到规则表；运营自定义过的旧词表保留，继续作为附加关键词生效。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+ALTER TABLE `campus_ops_setting`
+  MODIFY COLUMN `setting_value` VARCHAR(4096) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS `campus_audit_rule` (
+  `id` BIGINT NOT NULL,
+  `name` VARCHAR(64) NOT NULL DEFAULT '',
+  `category` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '规则分类，阈值和处理动作见 campus_ops_setting.audit_rule_categories',
+  `rule_type` VARCHAR(16) NOT NULL DEFAULT 'keyword' COMMENT 'keyword/regex',
+  `patterns` TEXT NOT NULL COMMENT '关键词或正则，每行一个',
+  `score` INT NOT NULL DEFAULT 10,
+  `enabled` TINYINT(1) NOT NULL DEFAULT 1,
+  `hit_count` BIGINT NOT NULL DEFAULT 0,
+  `last_hit_at` DATETIME(3) DEFAULT NULL,
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_audit_rule_category` (`category`, `enabled`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园内容审核规则';
+
+INSERT INTO `campus_audit_rule` (`id`, `name`, `category`, `rule_type`, `patterns`, `score`) VALUES
+(9101, '高风险关键词', 'high_risk', 'keyword', REPLACE('赌博,裸聊,诈骗,代考,代课,身份证,银行卡,毒品,买卖账号,刷单,套现', ',', CHAR(10)), 10),
+(9102, '高风险拼音变体', 'high_risk', 'keyword', REPLACE('dubo,luoliao,zhapian,daikao,daike,shuadan,taoxian', ',', CHAR(10)), 10),
+(9103, '需复核关键词', 'review', 'keyword', REPLACE('加微信,兼职,引战,辱骂,曝光,挂人,联系方式,私聊,群号,二维码', ',', CHAR(10)), 10),
+(9104, '手机号', 'contact', 'regex', '(^|[^0-9])1[3-9][0-9]{9}([^0-9]|$)', 10),
+(9105, '微信号/QQ号', 'contact', 'regex', '(vx|wx|v信|微信|qq|扣扣) ?[:：]? ?[a-z0-9_-]{5,20}', 10)
+ON DUPLICATE KEY UPDATE `id` = `id`;
+
+UPDATE `campus_ops_setting` SET `setting_value` = ''
+WHERE `setting_key` = 'audit_high_risk_words' AND `setting_value` = '赌博,裸聊,诈骗,代考,代课,身份证,银行卡,毒品,买卖账号,刷单,套现';
+
+UPDATE `campus_ops_setting` SET `setting_value` = ''
+WHERE `setting_key` = 'audit_review_words' AND `setting_value` = '加微信,兼职,引战,辱骂,曝光,挂人,联系方式,私聊,群号,二维码';
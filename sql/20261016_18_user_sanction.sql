@@ -0,0 +1,25 @@
+-- 用户处罚：禁言（不能评论）、禁止发帖、封禁账号（除通知、资料、处罚说明和意见反馈外的登录接口全部拒绝）。
+-- expires_at 为空表示永久；到期由校园后台任务标记为 expired 并通知用户，操作记录写入 campus_audit_log。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_user_sanction` (
+  `id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `sanction_type` VARCHAR(16) NOT NULL COMMENT 'mute=禁言 post_ban=禁止发帖 suspend=封禁账号',
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `appeal_note` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '展示给用户的申诉说明',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'active' COMMENT 'active/expired/revoked',
+  `starts_at` DATETIME(3) NOT NULL,
+  `expires_at` DATETIME(3) DEFAULT NULL COMMENT 'NULL 表示永久',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `revoked_by` BIGINT NOT NULL DEFAULT 0,
+  `revoked_at` DATETIME(3) DEFAULT NULL,
+  `revoke_reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_user_sanction_user` (`user_id`, `status`, `created_at`),
+  INDEX `idx_campus_user_sanction_expire` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园用户处罚';
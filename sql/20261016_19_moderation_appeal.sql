@@ -0,0 +1,28 @@
+-- 内容申诉：作者对被驳回或被运营下架的帖子/评论申诉，同一内容只能申诉一次。
+-- 运营恢复或维持原处理后通知作者；超过 CAMPUS_OPS_SLA_APPEAL_OVERDUE 未处理的申诉进入 SLA 提醒。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_moderation_appeal` (
+  `id` BIGINT NOT NULL,
+  `target_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `target_id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL DEFAULT 0 COMMENT '评论申诉时为所属帖子',
+  `user_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/restored/confirmed',
+  `original_status` TINYINT NOT NULL DEFAULT 0 COMMENT '申诉时内容的审核状态',
+  `original_reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `target_excerpt` VARCHAR(255) NOT NULL DEFAULT '',
+  `reviewer_id` BIGINT NOT NULL DEFAULT 0,
+  `review_note` VARCHAR(255) NOT NULL DEFAULT '',
+  `reviewed_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_moderation_appeal_target` (`target_type`, `target_id`),
+  INDEX `idx_campus_moderation_appeal_status` (`campus_code`, `status`, `created_at`),
+  INDEX `idx_campus_moderation_appeal_user` (`user_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园内容申诉';
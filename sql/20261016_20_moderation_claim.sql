@@ -0,0 +1,19 @@
+-- 审核认领：运营认领帖子/评论后其他运营不能处理，CAMPUS_MODERATION_CLAIM_TTL 到期自动释放。
+-- 管理员指派的认领按 CAMPUS_MODERATION_ASSIGN_TTL 过期；审核完成后认领记录删除。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_moderation_claim` (
+  `target_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `target_id` BIGINT NOT NULL,
+  `operator_id` BIGINT NOT NULL,
+  `assigned_by` BIGINT NOT NULL DEFAULT 0 COMMENT '管理员指派时为指派人',
+  `claimed_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `expires_at` DATETIME(3) NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`target_type`, `target_id`),
+  INDEX `idx_campus_moderation_claim_operator` (`operator_id`, `expires_at`),
+  INDEX `idx_campus_moderation_claim_expires` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园审核认领';
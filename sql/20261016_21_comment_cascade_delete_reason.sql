@@ -0,0 +1,13 @@
+-- 作者删帖时一起删除的评论以前没有写 audit_reason，会被当成运营下架，评论作者可以发起申诉。
+-- 补上「帖子已删除」，申诉和违规统计都会排除这类评论。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+UPDATE `campus_forum_comment` c
+JOIN `campus_forum_post` p ON p.`id` = c.`post_id`
+SET c.`audit_reason` = '帖子已删除'
+WHERE c.`status` = 3
+  AND c.`is_deleted` = 1
+  AND c.`audit_reason` = ''
+  AND p.`audit_reason` = '用户删除';
@@ -0,0 +1,23 @@
+-- 热门话题和话题页的帖子数按学校统计，热度由定时任务下一轮写入。
+
+USE lehu_campus_db;
+SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS `campus_tag_stat` (
+  `campus_code` VARCHAR(32) NOT NULL,
+  `tag_id` BIGINT NOT NULL,
+  `post_count` BIGINT NOT NULL DEFAULT 0 COMMENT '本校可见帖子数，定时任务对账',
+  `trend_score` DOUBLE NOT NULL DEFAULT 0,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`campus_code`, `tag_id`),
+  INDEX `idx_campus_tag_stat_trending` (`campus_code`, `trend_score`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='话题分校帖子数和热度';
+
+INSERT INTO `campus_tag_stat` (`campus_code`, `tag_id`, `post_count`, `trend_score`, `updated_at`)
+SELECT p.`campus_code`, pt.`tag_id`, COUNT(*), 0, CURRENT_TIMESTAMP(3)
+FROM `campus_post_tag` pt
+JOIN `campus_forum_post` p ON p.`id` = pt.`post_id`
+JOIN `campus_tag` t ON t.`id` = pt.`tag_id`
+WHERE p.`status` = 1 AND p.`is_deleted` = 0 AND t.`status` <> 2
+GROUP BY p.`campus_code`, pt.`tag_id`
+ON DUPLICATE KEY UPDATE `post_count` = VALUES(`post_count`);
@@ -57,6 +57,15 @@ CREATE TABLE IF NOT EXISTS `user` (
   INDEX `idx_user_updated_at` (`updated_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
 
+CREATE TABLE IF NOT EXISTS `user_follow` (
+  `follower_id` BIGINT NOT NULL,
+  `followee_id` BIGINT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`follower_id`, `followee_id`),
+  INDEX `idx_user_follow_followee` (`followee_id`, `follower_id`),
+  INDEX `idx_user_follow_follower_created` (`follower_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户关注关系';
+
 
 CREATE TABLE IF NOT EXISTS `file` (
   `id` BIGINT NOT NULL,
@@ -66,6 +75,8 @@ CREATE TABLE IF NOT EXISTS `file` (
   `file_size` BIGINT NOT NULL DEFAULT 0,
   `file_type` VARCHAR(255) NOT NULL,
   `uploaded` BOOLEAN NOT NULL DEFAULT FALSE,
+  `phash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片感知哈希，十六进制',
+  `dhash` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '图片差值哈希，十六进制',
   `is_deleted` BOOLEAN NOT NULL DEFAULT FALSE,
   `created_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -118,6 +129,7 @@ CREATE TABLE IF NOT EXISTS `campus_profile` (
   `account_id` BIGINT NOT NULL,
   `open_id` VARCHAR(128) NOT NULL,
   `union_id` VARCHAR(128) DEFAULT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT 'szpu_shenshan' COMMENT '所属校区',
   `school_name` VARCHAR(100) NOT NULL DEFAULT '深圳职业技术大学深汕校区',
   `student_no` VARCHAR(64) DEFAULT NULL,
   `real_name` VARCHAR(64) DEFAULT NULL,
@@ -132,7 +144,8 @@ CREATE TABLE IF NOT EXISTS `campus_profile` (
   UNIQUE KEY `uk_campus_profile_user` (`user_id`),
   UNIQUE KEY `uk_campus_profile_openid` (`open_id`),
   INDEX `idx_campus_profile_student` (`student_no`),
-  INDEX `idx_campus_profile_auth` (`auth_status`)
+  INDEX `idx_campus_profile_auth` (`auth_status`),
+  INDEX `idx_campus_profile_campus` (`campus_code`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园身份资料';
 
 CREATE TABLE IF NOT EXISTS `campus_timetable_course` (
@@ -157,8 +170,62 @@ CREATE TABLE IF NOT EXISTS `campus_timetable_course` (
   INDEX `idx_campus_timetable_source` (`source`, `source_course_id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园个人课表课程';
 
+CREATE TABLE IF NOT EXISTS `campus_timetable_subscription` (
+  `id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `nonce` VARCHAR(64) NOT NULL,
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '1=有效 0=已撤销',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_timetable_subscription_user` (`user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园课表日历订阅';
+
+CREATE TABLE IF NOT EXISTS `campus_calendar_event` (
+  `id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '空=所有校区',
+  `term` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '如 2025-2026-1',
+  `event_type` VARCHAR(16) NOT NULL COMMENT 'exam/makeup_exam/holiday/event/teaching',
+  `title` VARCHAR(128) NOT NULL,
+  `description` VARCHAR(1024) NOT NULL DEFAULT '',
+  `location` VARCHAR(128) NOT NULL DEFAULT '',
+  `start_at` DATETIME(3) NOT NULL,
+  `end_at` DATETIME(3) NOT NULL,
+  `all_day` BOOLEAN NOT NULL DEFAULT FALSE,
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '1=发布 0=隐藏',
+  `is_deleted` BOOLEAN NOT NULL DEFAULT FALSE,
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  KEY `idx_campus_calendar_event_range` (`campus_code`, `is_deleted`, `status`, `start_at`),
+  KEY `idx_campus_calendar_event_term` (`term`, `event_type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园校历（考试、放假、活动）';
+
+CREATE TABLE IF NOT EXISTS `campus_search_document` (
+  `doc_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `doc_id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `is_cross_campus` BOOLEAN NOT NULL DEFAULT FALSE,
+  `category_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `post_type` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL DEFAULT 0,
+  `title` VARCHAR(255) NOT NULL DEFAULT '',
+  `content` TEXT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `indexed_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`doc_type`, `doc_id`),
+  KEY `idx_campus_search_document_post` (`post_id`),
+  KEY `idx_campus_search_document_indexed` (`indexed_at`),
+  FULLTEXT KEY `ft_campus_search_document_title` (`title`) WITH PARSER ngram,
+  FULLTEXT KEY `ft_campus_search_document_text` (`title`, `content`) WITH PARSER ngram
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园帖子/评论全文检索文档（仅含公开可见内容）';
+
 CREATE TABLE IF NOT EXISTS `campus_forum_category` (
   `id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '空=所有校区共享',
   `code` VARCHAR(32) NOT NULL,
   `name` VARCHAR(32) NOT NULL,
   `description` VARCHAR(255) NOT NULL DEFAULT '',
@@ -167,8 +234,9 @@ CREATE TABLE IF NOT EXISTS `campus_forum_category` (
   `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
   PRIMARY KEY (`id`),
-  UNIQUE KEY `uk_campus_forum_category_code` (`code`),
-  INDEX `idx_campus_forum_category_sort` (`is_deleted`, `sort_order`)
+  UNIQUE KEY `uk_campus_forum_category_code` (`campus_code`, `code`),
+  INDEX `idx_campus_forum_category_sort` (`is_deleted`, `sort_order`),
+  INDEX `idx_campus_forum_category_campus` (`campus_code`, `is_deleted`, `sort_order`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园论坛版块';
 
 INSERT INTO `campus_forum_category` (`id`, `code`, `name`, `description`, `sort_order`)
@@ -188,29 +256,36 @@ ON DUPLICATE KEY UPDATE
 
 CREATE TABLE IF NOT EXISTS `campus_forum_post` (
   `id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT 'szpu_shenshan' COMMENT '发帖校区',
+  `is_cross_campus` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '跨校区公开',
   `category_code` VARCHAR(32) NOT NULL,
   `author_id` BIGINT NOT NULL,
   `title` VARCHAR(120) NOT NULL,
   `content` TEXT NOT NULL,
   `images` JSON DEFAULT NULL,
   `media_type` VARCHAR(16) NOT NULL DEFAULT 'text' COMMENT 'text/image',
-  `post_type` VARCHAR(24) NOT NULL DEFAULT 'note' COMMENT 'note/lost/question/guide/club',
+  `post_type` VARCHAR(24) NOT NULL DEFAULT 'note' COMMENT 'note/lost/question/guide/club/poll',
   `extra` JSON DEFAULT NULL,
   `cover_url` VARCHAR(1024) NOT NULL DEFAULT '',
   `is_official` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '官方/运营内容',
   `is_featured` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '精选推荐',
   `is_pinned` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '首页置顶',
+  `is_anonymous` BOOLEAN NOT NULL DEFAULT FALSE COMMENT '匿名发布，公开接口只展示帖内化名',
   `sort_weight` INT NOT NULL DEFAULT 0 COMMENT '运营排序权重',
-  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '0=待审核 1=可见 2=拒绝 3=删除',
+  `status` TINYINT NOT NULL DEFAULT 1 COMMENT '0=待审核 1=可见 2=拒绝 3=删除 4=草稿 5=定时发布',
   `audit_reason` VARCHAR(255) NOT NULL DEFAULT '',
   `like_count` BIGINT NOT NULL DEFAULT 0,
   `comment_count` BIGINT NOT NULL DEFAULT 0,
   `collected_count` BIGINT NOT NULL DEFAULT 0,
+  `edit_count` INT NOT NULL DEFAULT 0 COMMENT '编辑次数',
+  `edited_at` DATETIME(3) DEFAULT NULL COMMENT '最近编辑时间',
   `is_deleted` BOOLEAN NOT NULL DEFAULT FALSE,
   `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
   PRIMARY KEY (`id`),
   INDEX `idx_campus_post_category_created` (`category_code`, `status`, `is_deleted`, `created_at`, `id`),
+  INDEX `idx_campus_post_campus_created` (`campus_code`, `status`, `is_deleted`, `created_at`, `id`),
+  INDEX `idx_campus_post_cross_campus` (`is_cross_campus`, `status`, `is_deleted`, `created_at`, `id`),
   INDEX `idx_campus_post_status_created` (`status`, `is_deleted`, `created_at`, `id`),
   INDEX `idx_campus_post_author` (`author_id`, `is_deleted`, `created_at`),
   INDEX `idx_campus_post_hot` (`status`, `is_deleted`, `like_count`, `comment_count`, `created_at`),
@@ -219,6 +294,194 @@ CREATE TABLE IF NOT EXISTS `campus_forum_post` (
   INDEX `idx_campus_post_ops_sort` (`status`, `is_deleted`, `is_pinned`, `is_featured`, `sort_weight`, `created_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园社区笔记';
 
+CREATE TABLE IF NOT EXISTS `campus_forum_post_revision` (
+  `id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL,
+  `revision` INT NOT NULL COMMENT '第几次编辑',
+  `editor_id` BIGINT NOT NULL,
+  `editor_role` VARCHAR(16) NOT NULL DEFAULT 'author' COMMENT 'author/admin',
+  `title` VARCHAR(120) NOT NULL,
+  `content` TEXT NOT NULL,
+  `images` JSON DEFAULT NULL,
+  `media_type` VARCHAR(16) NOT NULL DEFAULT 'text',
+  `extra` JSON DEFAULT NULL,
+  `cover_url` VARCHAR(1024) NOT NULL DEFAULT '',
+  `status_before` TINYINT NOT NULL DEFAULT 1 COMMENT '编辑前审核状态',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_post_revision_post` (`post_id`, `revision`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子编辑前版本';
+
+CREATE TABLE IF NOT EXISTS `campus_tag` (
+  `id` BIGINT NOT NULL,
+  `name` VARCHAR(64) NOT NULL COMMENT '小写归一化后的话题名',
+  `display_name` VARCHAR(64) NOT NULL,
+  `status` TINYINT NOT NULL DEFAULT 0 COMMENT '0 正常 1 封禁 2 已合并',
+  `merged_into_id` BIGINT NOT NULL DEFAULT 0,
+  `is_featured` TINYINT(1) NOT NULL DEFAULT 0,
+  `post_count` BIGINT NOT NULL DEFAULT 0 COMMENT '可见帖子数，定时任务对账',
+  `trend_score` DOUBLE NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_tag_name` (`name`),
+  INDEX `idx_campus_tag_trending` (`status`, `is_featured`, `trend_score`),
+  INDEX `idx_campus_tag_merged` (`merged_into_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子话题';
+
+CREATE TABLE IF NOT EXISTS `campus_post_tag` (
+  `post_id` BIGINT NOT NULL,
+  `tag_id` BIGINT NOT NULL,
+  `source` VARCHAR(16) NOT NULL DEFAULT 'content' COMMENT 'explicit 发帖时选择；content 正文 #话题 提取',
+  `position` TINYINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `tag_id`),
+  INDEX `idx_campus_post_tag_tag` (`tag_id`, `post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='帖子与话题关联';
+
+CREATE TABLE IF NOT EXISTS `campus_tag_stat` (
+  `campus_code` VARCHAR(32) NOT NULL,
+  `tag_id` BIGINT NOT NULL,
+  `post_count` BIGINT NOT NULL DEFAULT 0 COMMENT '本校可见帖子数，定时任务对账',
+  `trend_score` DOUBLE NOT NULL DEFAULT 0,
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`campus_code`, `tag_id`),
+  INDEX `idx_campus_tag_stat_trending` (`campus_code`, `trend_score`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='话题分校帖子数和热度';
+
+CREATE TABLE IF NOT EXISTS `campus_post_schedule` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `state` VARCHAR(16) NOT NULL DEFAULT 'draft' COMMENT 'draft/scheduled/published',
+  `publish_at` DATETIME(3) DEFAULT NULL COMMENT '定时发布时间',
+  `is_pinned` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '发布时置顶',
+  `is_featured` TINYINT(1) NOT NULL DEFAULT 0 COMMENT '发布时精选',
+  `sort_weight` INT NOT NULL DEFAULT 0 COMMENT '发布时排序权重',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `last_error` VARCHAR(255) NOT NULL DEFAULT '',
+  `published_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_post_schedule_due` (`state`, `publish_at`),
+  INDEX `idx_campus_post_schedule_campus` (`campus_code`, `state`, `publish_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='运营草稿与定时发布';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll` (
+  `post_id` BIGINT NOT NULL,
+  `choice_mode` VARCHAR(16) NOT NULL DEFAULT 'single' COMMENT 'single/multi',
+  `max_choices` INT NOT NULL DEFAULT 1,
+  `closes_at` DATETIME(3) DEFAULT NULL COMMENT '投票截止时间',
+  `anonymous` TINYINT(1) NOT NULL DEFAULT 1 COMMENT '1=不展示投票人',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'open' COMMENT 'open/closed',
+  `closed_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_post_poll_due` (`status`, `closes_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票帖设置';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_option` (
+  `post_id` BIGINT NOT NULL,
+  `option_id` INT NOT NULL COMMENT '从 1 开始的选项序号',
+  `text` VARCHAR(64) NOT NULL,
+  PRIMARY KEY (`post_id`, `option_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票选项';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_voter` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票人，每人每个投票只能投一次';
+
+CREATE TABLE IF NOT EXISTS `campus_post_poll_vote` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `option_id` INT NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`, `option_id`),
+  INDEX `idx_campus_post_poll_vote_option` (`post_id`, `option_id`),
+  INDEX `idx_campus_post_poll_vote_recent` (`post_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='投票选择明细';
+
+CREATE TABLE IF NOT EXISTS `campus_lost_item` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL,
+  `kind` VARCHAR(16) NOT NULL COMMENT 'lost/found',
+  `category` VARCHAR(24) NOT NULL DEFAULT 'other' COMMENT 'card/electronics/keys/bag/clothing/book/other',
+  `place` VARCHAR(128) NOT NULL DEFAULT '',
+  `event_start` DATETIME(3) DEFAULT NULL COMMENT '丢失/捡到时间窗口起点',
+  `event_end` DATETIME(3) DEFAULT NULL COMMENT '丢失/捡到时间窗口终点',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'open' COMMENT 'open/returned',
+  `resolved_at` DATETIME(3) DEFAULT NULL,
+  `resolved_by` BIGINT NOT NULL DEFAULT 0,
+  `scanned_at` DATETIME(3) DEFAULT NULL COMMENT '自动匹配完成时间',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_lost_item_open` (`campus_code`, `kind`, `status`, `created_at`),
+  INDEX `idx_campus_lost_item_scan` (`scanned_at`, `status`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='失物招领结构化信息';
+
+CREATE TABLE IF NOT EXISTS `campus_lost_match` (
+  `id` BIGINT NOT NULL,
+  `lost_post_id` BIGINT NOT NULL,
+  `found_post_id` BIGINT NOT NULL,
+  `score` DECIMAL(6,3) NOT NULL DEFAULT 0,
+  `reasons` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'category,place,time,text',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_lost_match_pair` (`lost_post_id`, `found_post_id`),
+  INDEX `idx_campus_lost_match_found` (`found_post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='失物招领自动匹配结果';
+
+CREATE TABLE IF NOT EXISTS `campus_question` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `author_id` BIGINT NOT NULL,
+  `accepted_comment_id` BIGINT NOT NULL DEFAULT 0 COMMENT '被采纳的一级评论 ID，0 表示未采纳',
+  `accepted_at` DATETIME(3) DEFAULT NULL,
+  `answered_at` DATETIME(3) DEFAULT NULL COMMENT '首个非提问者回答时间',
+  `ai_task_id` BIGINT NOT NULL DEFAULT 0 COMMENT '自动排队的 e仔回答任务 ID',
+  `ai_queued_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_question_state` (`campus_code`, `accepted_comment_id`, `answered_at`),
+  INDEX `idx_campus_question_ai` (`accepted_comment_id`, `answered_at`, `ai_task_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='问答帖采纳与未解决状态';
+
+CREATE TABLE IF NOT EXISTS `campus_club_event` (
+  `post_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `organizer_id` BIGINT NOT NULL COMMENT '发帖人即组织者',
+  `capacity` INT NOT NULL DEFAULT 0 COMMENT '报名上限，0 表示不限',
+  `starts_at` DATETIME(3) NOT NULL,
+  `signup_deadline` DATETIME(3) NOT NULL,
+  `place` VARCHAR(128) NOT NULL DEFAULT '',
+  `checkin_code` VARCHAR(16) NOT NULL DEFAULT '' COMMENT '6 位签到码，只返回给组织者',
+  `reminder_sent_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`),
+  INDEX `idx_campus_club_event_reminder` (`reminder_sent_at`, `starts_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='社团活动报名设置';
+
+CREATE TABLE IF NOT EXISTS `campus_club_registration` (
+  `post_id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `status` VARCHAR(16) NOT NULL DEFAULT 'registered' COMMENT 'registered/waitlisted/cancelled',
+  `checked_in_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT '报名时间，候补按此排序',
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`, `user_id`),
+  INDEX `idx_campus_club_registration_status` (`post_id`, `status`, `created_at`),
+  INDEX `idx_campus_club_registration_user` (`user_id`, `status`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='社团活动报名与候补';
+
 CREATE TABLE IF NOT EXISTS `campus_forum_comment` (
   `id` BIGINT NOT NULL,
   `post_id` BIGINT NOT NULL,
@@ -365,13 +628,35 @@ CREATE TABLE IF NOT EXISTS `campus_notification_outbox` (
   INDEX `idx_campus_notification_outbox_created` (`created_at`, `id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园通知可靠投递任务';
 
+CREATE TABLE IF NOT EXISTS `campus_post_fanout` (
+  `post_id` BIGINT NOT NULL,
+  `author_id` BIGINT NOT NULL,
+  `last_user_id` BIGINT NOT NULL DEFAULT 0 COMMENT '已推送到的粉丝 ID，按 ID 升序续推',
+  `notified_count` BIGINT NOT NULL DEFAULT 0,
+  `done_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`post_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='新帖推送粉丝进度';
+
+CREATE TABLE IF NOT EXISTS `campus_user_block` (
+  `user_id` BIGINT NOT NULL,
+  `target_user_id` BIGINT NOT NULL,
+  `kind` VARCHAR(16) NOT NULL DEFAULT 'block' COMMENT 'block 拉黑：隐藏内容并禁止对方互动；mute 静音：只隐藏内容和通知',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`user_id`, `target_user_id`),
+  INDEX `idx_campus_user_block_kind` (`user_id`, `kind`, `updated_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户拉黑与静音';
+
 CREATE TABLE IF NOT EXISTS `campus_ai_reply_task` (
   `id` BIGINT NOT NULL,
   `post_id` BIGINT NOT NULL COMMENT '帖子ID',
   `root_comment_id` BIGINT NOT NULL DEFAULT 0 COMMENT '一级评论ID',
-  `trigger_comment_id` BIGINT NOT NULL COMMENT '触发@e仔的评论ID',
+  `trigger_comment_id` BIGINT NOT NULL COMMENT '触发@e仔的评论ID，问题自动回答为 0',
   `asker_id` BIGINT NOT NULL COMMENT '提问用户ID',
   `bot_user_id` BIGINT NOT NULL COMMENT 'e仔官方账号用户ID',
+  `source` VARCHAR(16) NOT NULL DEFAULT 'mention' COMMENT 'mention=@e仔 question=问题长时间无人回答',
   `prompt` VARCHAR(600) NOT NULL DEFAULT '' COMMENT '去掉@后的问题文本',
   `status` VARCHAR(24) NOT NULL DEFAULT 'pending' COMMENT 'pending/processing/done/failed',
   `retry_count` INT NOT NULL DEFAULT 0,
@@ -383,7 +668,7 @@ CREATE TABLE IF NOT EXISTS `campus_ai_reply_task` (
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
   `processed_at` DATETIME(3) DEFAULT NULL,
   PRIMARY KEY (`id`),
-  UNIQUE KEY `uk_campus_ai_reply_trigger_comment` (`trigger_comment_id`),
+  UNIQUE KEY `uk_campus_ai_reply_trigger_comment` (`post_id`, `trigger_comment_id`),
   INDEX `idx_campus_ai_reply_status_next` (`status`, `next_retry_at`, `locked_until`, `id`),
   INDEX `idx_campus_ai_reply_bot_processed` (`bot_user_id`, `status`, `processed_at`),
   INDEX `idx_campus_ai_reply_post_created` (`post_id`, `created_at`)
@@ -392,7 +677,7 @@ CREATE TABLE IF NOT EXISTS `campus_ai_reply_task` (
 CREATE TABLE IF NOT EXISTS `campus_ops_setting` (
   `id` BIGINT NOT NULL AUTO_INCREMENT,
   `setting_key` VARCHAR(64) NOT NULL,
-  `setting_value` VARCHAR(512) NOT NULL DEFAULT '',
+  `setting_value` VARCHAR(4096) NOT NULL DEFAULT '',
   `updated_by` BIGINT NOT NULL DEFAULT 0,
   `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
   `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
@@ -417,8 +702,6 @@ INSERT INTO `campus_ops_setting` (`setting_key`, `setting_value`, `updated_by`)
 ('ai_monthly_budget_cny', '5', 0),
 ('ai_daily_budget_cny', '0.5', 0),
 ('ai_budget_warn_ratio', '0.7,0.9', 0),
-('audit_high_risk_words', '赌博,裸聊,诈骗,代考,代课,身份证,银行卡,毒品,买卖账号,刷单,套现', 0),
-('audit_review_words', '加微信,兼职,引战,辱骂,曝光,挂人,联系方式,私聊,群号,二维码', 0),
 ('ezai_auto_reply_enabled', 'true', 0)
 ON DUPLICATE KEY UPDATE `setting_key` = `setting_key`;
 
@@ -457,6 +740,56 @@ CREATE TABLE IF NOT EXISTS `campus_ai_audit_task` (
   INDEX `idx_campus_ai_audit_target_created` (`target_type`, `target_id`, `created_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园AI内容审核任务';
 
+CREATE TABLE IF NOT EXISTS `campus_image_audit_task` (
+  `id` BIGINT NOT NULL,
+  `target_type` VARCHAR(32) NOT NULL DEFAULT 'post' COMMENT 'post/upload',
+  `target_id` BIGINT NOT NULL COMMENT '帖子 ID 或上传文件 ID',
+  `image_url` VARCHAR(1024) NOT NULL,
+  `url_hash` CHAR(40) NOT NULL COMMENT 'image_url 的 sha1',
+  `status` VARCHAR(24) NOT NULL DEFAULT 'pending' COMMENT 'pending/processing/done/failed',
+  `provider` VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'hash/wechat/local/noop',
+  `decision` VARCHAR(24) NOT NULL DEFAULT '' COMMENT 'pass/review/reject/skip',
+  `label` VARCHAR(64) NOT NULL DEFAULT '',
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `raw_result` TEXT,
+  `retry_count` INT NOT NULL DEFAULT 0,
+  `next_retry_at` DATETIME(3) DEFAULT NULL,
+  `locked_until` DATETIME(3) DEFAULT NULL,
+  `last_error` VARCHAR(600) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  `processed_at` DATETIME(3) DEFAULT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_image_audit_target` (`target_type`, `target_id`, `url_hash`),
+  INDEX `idx_campus_image_audit_status_next` (`status`, `next_retry_at`, `locked_until`, `id`),
+  INDEX `idx_campus_image_audit_url` (`url_hash`, `status`, `processed_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园图片审核任务';
+
+CREATE TABLE IF NOT EXISTS `campus_image_fingerprint` (
+  `url_hash` CHAR(40) NOT NULL COMMENT 'image_url 的 sha1',
+  `image_url` VARCHAR(1024) NOT NULL,
+  `file_id` BIGINT NOT NULL DEFAULT 0 COMMENT '上传文件 ID，审核时补算为 0',
+  `phash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `dhash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `source` VARCHAR(16) NOT NULL DEFAULT 'upload' COMMENT 'upload/audit',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`url_hash`),
+  INDEX `idx_campus_image_fingerprint_file` (`file_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园图片感知哈希';
+
+CREATE TABLE IF NOT EXISTS `campus_image_hash_block` (
+  `id` BIGINT NOT NULL,
+  `phash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `dhash` BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  `image_url` VARCHAR(1024) NOT NULL DEFAULT '',
+  `source_post_id` BIGINT NOT NULL DEFAULT 0,
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_image_hash_block_created` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园违规图片哈希库';
+
 CREATE TABLE IF NOT EXISTS `campus_ops_alert` (
   `id` BIGINT NOT NULL,
   `alert_type` VARCHAR(48) NOT NULL DEFAULT '',
@@ -694,6 +1027,89 @@ CREATE TABLE IF NOT EXISTS `campus_audit_log` (
   INDEX `idx_campus_audit_user` (`user_id`, `created_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园内容审核记录';
 
+CREATE TABLE IF NOT EXISTS `campus_audit_rule` (
+  `id` BIGINT NOT NULL,
+  `name` VARCHAR(64) NOT NULL DEFAULT '',
+  `category` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '规则分类，阈值和处理动作见 campus_ops_setting.audit_rule_categories',
+  `rule_type` VARCHAR(16) NOT NULL DEFAULT 'keyword' COMMENT 'keyword/pinyin/regex',
+  `patterns` TEXT NOT NULL COMMENT '关键词或正则，每行一个',
+  `score` INT NOT NULL DEFAULT 10,
+  `enabled` TINYINT(1) NOT NULL DEFAULT 1,
+  `hit_count` BIGINT NOT NULL DEFAULT 0,
+  `last_hit_at` DATETIME(3) DEFAULT NULL,
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `updated_by` BIGINT NOT NULL DEFAULT 0,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_audit_rule_category` (`category`, `enabled`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园内容审核规则';
+
+INSERT INTO `campus_audit_rule` (`id`, `name`, `category`, `rule_type`, `patterns`, `score`) VALUES
+(9101, '高风险关键词', 'high_risk', 'keyword', REPLACE('赌博,裸聊,诈骗,代考,代课,身份证,银行卡,毒品,买卖账号,刷单,套现', ',', CHAR(10)), 10),
+(9102, '高风险拼音变体', 'high_risk', 'keyword', REPLACE('dubo,luoliao,zhapian,daikao,daike,shuadan,taoxian', ',', CHAR(10)), 10),
+(9103, '需复核关键词', 'review', 'keyword', REPLACE('加微信,兼职,引战,辱骂,曝光,挂人,联系方式,私聊,群号,二维码', ',', CHAR(10)), 10),
+(9104, '手机号', 'contact', 'regex', '(^|[^0-9])1[3-9][0-9]{9}([^0-9]|$)', 10),
+(9105, '微信号/QQ号', 'contact', 'regex', '(vx|wx|v信|微信|qq|扣扣) ?[:：]? ?[a-z0-9_-]{5,20}', 10)
+ON DUPLICATE KEY UPDATE `id` = `id`;
+
+CREATE TABLE IF NOT EXISTS `campus_user_sanction` (
+  `id` BIGINT NOT NULL,
+  `user_id` BIGINT NOT NULL,
+  `sanction_type` VARCHAR(16) NOT NULL COMMENT 'mute=禁言 post_ban=禁止发帖 suspend=封禁账号',
+  `reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `appeal_note` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '展示给用户的申诉说明',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'active' COMMENT 'active/expired/revoked',
+  `starts_at` DATETIME(3) NOT NULL,
+  `expires_at` DATETIME(3) DEFAULT NULL COMMENT 'NULL 表示永久',
+  `created_by` BIGINT NOT NULL DEFAULT 0,
+  `revoked_by` BIGINT NOT NULL DEFAULT 0,
+  `revoked_at` DATETIME(3) DEFAULT NULL,
+  `revoke_reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  INDEX `idx_campus_user_sanction_user` (`user_id`, `status`, `created_at`),
+  INDEX `idx_campus_user_sanction_expire` (`status`, `expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园用户处罚';
+
+CREATE TABLE IF NOT EXISTS `campus_moderation_appeal` (
+  `id` BIGINT NOT NULL,
+  `target_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `target_id` BIGINT NOT NULL,
+  `post_id` BIGINT NOT NULL DEFAULT 0 COMMENT '评论申诉时为所属帖子',
+  `user_id` BIGINT NOT NULL,
+  `campus_code` VARCHAR(32) NOT NULL DEFAULT '',
+  `reason` VARCHAR(500) NOT NULL DEFAULT '',
+  `status` VARCHAR(16) NOT NULL DEFAULT 'pending' COMMENT 'pending/restored/confirmed',
+  `original_status` TINYINT NOT NULL DEFAULT 0 COMMENT '申诉时内容的审核状态',
+  `original_reason` VARCHAR(255) NOT NULL DEFAULT '',
+  `target_excerpt` VARCHAR(255) NOT NULL DEFAULT '',
+  `reviewer_id` BIGINT NOT NULL DEFAULT 0,
+  `review_note` VARCHAR(255) NOT NULL DEFAULT '',
+  `reviewed_at` DATETIME(3) DEFAULT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_campus_moderation_appeal_target` (`target_type`, `target_id`),
+  INDEX `idx_campus_moderation_appeal_status` (`campus_code`, `status`, `created_at`),
+  INDEX `idx_campus_moderation_appeal_user` (`user_id`, `created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园内容申诉';
+
+CREATE TABLE IF NOT EXISTS `campus_moderation_claim` (
+  `target_type` VARCHAR(16) NOT NULL COMMENT 'post/comment',
+  `target_id` BIGINT NOT NULL,
+  `operator_id` BIGINT NOT NULL,
+  `assigned_by` BIGINT NOT NULL DEFAULT 0 COMMENT '管理员指派时为指派人',
+  `claimed_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `expires_at` DATETIME(3) NOT NULL,
+  `created_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+  `updated_at` DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3),
+  PRIMARY KEY (`target_type`, `target_id`),
+  INDEX `idx_campus_moderation_claim_operator` (`operator_id`, `expires_at`),
+  INDEX `idx_campus_moderation_claim_expires` (`expires_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='校园审核认领';
+
 CREATE TABLE IF NOT EXISTS `campus_operator` (
   `user_id` BIGINT NOT NULL,
   `role` VARCHAR(24) NOT NULL DEFAULT 'operator' COMMENT 'operator/admin',
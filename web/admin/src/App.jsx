@@ -6,11 +6,13 @@ import AdminPosts from './pages/Admin/AdminPosts.jsx';
 import AdminCompose from './pages/Admin/AdminCompose.jsx';
 import AdminUsers from './pages/Admin/AdminUsers.jsx';
 import AdminSecurity from './pages/Admin/AdminSecurity.jsx';
+import AdminImageBlocklist from './pages/Admin/AdminImageBlocklist.jsx';
 import AdminPermissions from './pages/Admin/AdminPermissions.jsx';
 import AdminNotifications from './pages/Admin/AdminNotifications.jsx';
 import AdminModeration from './pages/Admin/AdminModeration.jsx';
 import AdminAssistant from './pages/Admin/AdminAssistant.jsx';
 import AdminAuditSettings from './pages/Admin/AdminAuditSettings.jsx';
+import AdminAuditRules from './pages/Admin/AdminAuditRules.jsx';
 import AdminMoments from './pages/Admin/AdminMoments.jsx';
 import AdminCopilot from './pages/Admin/AdminCopilot.jsx';
 import './App.css';
@@ -35,6 +37,7 @@ function App() {
                     <Route path="moments" element={<AdminMoments />} />
                     <Route path="moderation" element={<AdminModeration />} />
                     <Route path="audit" element={<AdminAuditSettings />} />
+                    <Route path="audit-rules" element={<AdminAuditRules />} />
                     <Route path="assistant" element={<AdminAssistant />} />
                     <Route path="copilot" element={<AdminCopilot />} />
                     <Route path="notifications" element={<AdminNotifications />} />
@@ -44,6 +47,7 @@ function App() {
                     <Route path="reports" element={<AdminLegacyRedirect to="/admin/moderation" tab="reports" />} />
                     <Route path="feedback" element={<AdminLegacyRedirect to="/admin/moderation" tab="feedback" />} />
                     <Route path="security" element={<AdminSecurity />} />
+                    <Route path="image-blocklist" element={<AdminImageBlocklist />} />
                     <Route path="users" element={<AdminUsers />} />
                     <Route path="permissions" element={<AdminPermissions />} />
                 </Route>
@@ -120,6 +120,11 @@ export const campusAdminApi = {
     createPost: (data) => request.post('/campus/admin/posts', data),
     updatePost: (id, data) => request.put(`/campus/admin/posts/${id}`, data),
     deletePost: (id) => request.delete(`/campus/admin/posts/${id}`),
+    listPostRevisions: (id) => request.get(`/campus/admin/posts/${id}/revisions`),
+    listPostSchedules: (params) => request.get('/campus/admin/posts/scheduled', { params }),
+    listQuestions: (params) => request.get('/campus/admin/questions', { params }),
+    reschedulePost: (id, data) => request.put(`/campus/admin/posts/${id}/schedule`, data),
+    cancelPostSchedule: (id) => request.post(`/campus/admin/posts/${id}/schedule/cancel`),
     batchPosts: (data) => request.post('/campus/admin/posts/batch', data),
     listMomentsCandidates: (params) => request.get('/campus/admin/moments/candidates', { params }),
     createMomentsPackage: (data) => request.post('/campus/admin/moments/packages', data),
@@ -154,13 +159,33 @@ export const campusAdminApi = {
     },
     listReports: (params) => request.get('/campus/admin/reports', { params }),
     reviewReport: (id, data) => request.post(`/campus/admin/reports/${id}/review`, data),
+    listReportGroups: (params) => request.get('/campus/admin/report-groups', { params }),
+    reviewReportGroup: (targetType, targetID, data) => request.post(`/campus/admin/report-groups/${targetType}/${targetID}/review`, data),
+    claimModeration: (targetType, targetID) => request.post(`/campus/moderation/claims/${targetType}/${targetID}`),
+    releaseModeration: (targetType, targetID, data = {}) => request.post(`/campus/moderation/claims/${targetType}/${targetID}/release`, data),
+    assignModeration: (targetType, targetID, data) => request.post(`/campus/admin/moderation/claims/${targetType}/${targetID}/assign`, data),
+    moderationOperatorStats: (params) => request.get('/campus/admin/moderation/operator-stats', { params }),
+    listAppeals: (params) => request.get('/campus/admin/appeals', { params }),
+    reviewAppeal: (id, data) => request.post(`/campus/admin/appeals/${id}/review`, data),
     listFeedback: (params) => request.get('/campus/admin/feedback', { params }),
     reviewFeedback: (id, data) => request.post(`/campus/admin/feedback/${id}/review`, data),
     security: () => request.get('/campus/admin/security'),
     blockIP: (data) => request.post('/campus/admin/security/ip-blocks', data),
     unblockIP: (ip) => request.delete(`/campus/admin/security/ip-blocks/${encodeURIComponent(ip)}`),
+    listImageHashBlocks: () => request.get('/campus/admin/image-hash-blocks'),
+    createImageHashBlocks: (data) => request.post('/campus/admin/image-hash-blocks', data),
+    deleteImageHashBlock: (id) => request.delete(`/campus/admin/image-hash-blocks/${id}`),
+    getAuditRules: () => request.get('/campus/admin/audit-rules'),
+    createAuditRule: (data) => request.post('/campus/admin/audit-rules', data),
+    updateAuditRule: (id, data) => request.put(`/campus/admin/audit-rules/${id}`, data),
+    deleteAuditRule: (id) => request.delete(`/campus/admin/audit-rules/${id}`),
+    updateAuditRuleCategories: (data) => request.put('/campus/admin/audit-rule-categories', data),
+    dryRunAuditRules: (data) => request.post('/campus/admin/audit-rules/dry-run', data),
     listUsers: (params) => request.get('/campus/admin/users', { params }),
     updateUserRole: (id, role) => request.put(`/campus/admin/users/${id}/role`, { role }),
+    listUserSanctions: (id) => request.get(`/campus/admin/users/${id}/sanctions`),
+    createUserSanction: (id, data) => request.post(`/campus/admin/users/${id}/sanctions`, data),
+    revokeUserSanction: (id, reason = '') => request.post(`/campus/admin/sanctions/${id}/revoke`, { reason }),
     createNotification: (data) => request.post('/campus/admin/notifications', data),
     listCategories: () => request.get('/campus/forum/categories'),
 };
@@ -254,8 +254,8 @@ const AdminAIReplies = ({ mode = 'full', initialStatus = 'failed' }) => {
                                     <p>{excerpt(task.prompt, 110) || '无提问内容'}</p>
                                     <div className="admin-ai-quality-grid">
                                         <div>
-                                            <span>原评论</span>
-                                            <p>{excerpt(task.trigger_comment?.content, 180) || '未找到触发评论'}</p>
+                                            <span>{task.source === 'question' ? '原问题' : '原评论'}</span>
+                                            <p>{task.source === 'question' ? (excerpt(task.prompt, 180) || '无提问内容') : (excerpt(task.trigger_comment?.content, 180) || '未找到触发评论')}</p>
                                         </div>
                                         <div>
                                             <span>e仔回复</span>
@@ -299,7 +299,7 @@ const AdminAIReplies = ({ mode = 'full', initialStatus = 'failed' }) => {
                                         <Link to={`/admin/posts?keyword=${task.post_id}`}>
                                             帖子 {task.post_id} <FiExternalLink />
                                         </Link>
-                                        <span>触发评论 {task.trigger_comment_id}</span>
+                                        {task.source === 'question' ? <span>问题自动回答</span> : <span>触发评论 {task.trigger_comment_id}</span>}
                                         {task.answer_comment_id && task.answer_comment_id !== '0' && <span>回复评论 {task.answer_comment_id}</span>}
                                         {task.next_retry_at && <span>下次重试 {task.next_retry_at}</span>}
                                     </div>
@@ -0,0 +1,171 @@
+import { useEffect, useState } from 'react';
+import { useSearchParams } from 'react-router-dom';
+import { FiExternalLink, FiRotateCcw } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import './Admin.css';
+
+const appealStatusText = {
+    pending: '待处理',
+    restored: '已恢复',
+    confirmed: '维持原处理',
+};
+
+const originalStatusText = (status) => {
+    const map = { 2: '已驳回', 3: '已下架' };
+    return map[Number(status)] || '未知';
+};
+
+const AdminAppeals = () => {
+    const [searchParams] = useSearchParams();
+    const statusParam = searchParams.get('status') || 'pending';
+    const [appeals, setAppeals] = useState([]);
+    const [status, setStatus] = useState(statusParam);
+    const [page, setPage] = useState(1);
+    const [total, setTotal] = useState(0);
+    const [error, setError] = useState('');
+    const [message, setMessage] = useState('');
+    const [loading, setLoading] = useState(false);
+    const [actionLoading, setActionLoading] = useState(false);
+    const [reviewing, setReviewing] = useState(null);
+    const [note, setNote] = useState('');
+
+    const load = async (nextPage = page, nextStatus = status) => {
+        setLoading(true);
+        setError('');
+        try {
+            const data = await campusAdminApi.listAppeals({ page: nextPage, size: 20, status: nextStatus });
+            setAppeals(data.appeals || []);
+            setTotal(data.page_stats?.total || 0);
+            setPage(nextPage);
+        } catch (err) {
+            setError(err.message || '获取申诉失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    useEffect(() => {
+        setStatus(statusParam);
+        load(1, statusParam);
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [statusParam]);
+
+    const openTarget = (appeal) => {
+        if (appeal.target_type === 'comment') {
+            window.open(`/admin/moderation?tab=comments&post_id=${encodeURIComponent(appeal.post_id)}`, '_blank', 'noopener,noreferrer');
+            return;
+        }
+        window.open(`/admin/posts?keyword=${encodeURIComponent(appeal.target_id)}`, '_blank', 'noopener,noreferrer');
+    };
+
+    const review = async (action) => {
+        if (!reviewing) return;
+        setActionLoading(true);
+        setError('');
+        try {
+            await campusAdminApi.reviewAppeal(reviewing.id, { action, note: note.trim() });
+            setMessage(action === 'restore' ? '内容已恢复，已通知作者' : '已维持原处理，已通知作者');
+            window.setTimeout(() => setMessage(''), 2400);
+            setReviewing(null);
+            setNote('');
+            load(page);
+        } catch (err) {
+            setError(err.message || '处理申诉失败');
+        } finally {
+            setActionLoading(false);
+        }
+    };
+
+    return (
+        <>
+            {message && <div className="admin-toast success">{message}</div>}
+            <div className="admin-toolbar">
+                <select className="admin-select" value={status} onChange={(e) => setStatus(e.target.value)}>
+                    <option value="">全部申诉</option>
+                    <option value="pending">待处理</option>
+                    <option value="restored">已恢复</option>
+                    <option value="confirmed">维持原处理</option>
+                </select>
+                <button className="admin-button primary" onClick={() => load(1)}>查询</button>
+            </div>
+            {error && <div className="admin-error">{error}</div>}
+            {loading && <div className="admin-loading">申诉加载中...</div>}
+            {!loading && appeals.length === 0 && (
+                <div className="admin-empty">
+                    <FiRotateCcw />
+                    <span>暂无申诉</span>
+                </div>
+            )}
+            {!loading && appeals.length > 0 && <div className="admin-table-wrap">
+                <table className="admin-table">
+                    <thead>
+                        <tr>
+                            <th>申诉对象</th>
+                            <th>原处理</th>
+                            <th>申诉理由</th>
+                            <th>作者</th>
+                            <th>状态</th>
+                            <th>时间</th>
+                            <th>操作</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {appeals.map((appeal) => (
+                            <tr key={appeal.id}>
+                                <td className="admin-title-cell">
+                                    <strong>{appeal.target_excerpt || `#${appeal.target_id}`}</strong>
+                                    <div className="admin-muted">{`${appeal.target_type === 'post' ? '帖子' : '评论'} #${appeal.target_id}`}</div>
+                                </td>
+                                <td>
+                                    <div>{originalStatusText(appeal.original_status)}</div>
+                                    <div className="admin-muted">{appeal.original_reason}</div>
+                                </td>
+                                <td>
+                                    <div>{appeal.reason}</div>
+                                    {appeal.review_note && <div className="admin-muted">{`处理说明：${appeal.review_note}`}</div>}
+                                </td>
+                                <td>{appeal.user?.name || appeal.user?.nickname || '同学'}</td>
+                                <td>{appealStatusText[appeal.status] || appeal.status}</td>
+                                <td>{appeal.created_at}</td>
+                                <td>
+                                    <div className="admin-actions">
+                                        <button className="admin-button" onClick={() => openTarget(appeal)}><FiExternalLink /> 查看</button>
+                                        <button className="admin-button primary" disabled={actionLoading || appeal.status !== 'pending'} onClick={() => setReviewing(appeal)}>处理</button>
+                                    </div>
+                                </td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            </div>}
+            <div className="admin-pagination">
+                <span className="admin-muted">共 {total} 条</span>
+                <button className="admin-button" disabled={page <= 1} onClick={() => load(page - 1)}>上一页</button>
+                <button className="admin-button" disabled={page * 20 >= total} onClick={() => load(page + 1)}>下一页</button>
+            </div>
+            {reviewing && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon"><FiRotateCcw /></div>
+                        <h3>处理申诉</h3>
+                        <p>{`恢复后${reviewing.target_type === 'post' ? '帖子' : '评论'}重新展示；维持原处理则内容保持不变。两种结果都会通知作者。`}</p>
+                        <textarea
+                            className="admin-textarea compact"
+                            value={note}
+                            maxLength={200}
+                            onChange={(e) => setNote(e.target.value)}
+                            placeholder="处理说明，会展示给作者（可选）"
+                        />
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => { setReviewing(null); setNote(''); }}>取消</button>
+                            <button className="admin-button" disabled={actionLoading} onClick={() => review('confirm')}>维持原处理</button>
+                            <button className="admin-button primary" disabled={actionLoading} onClick={() => review('restore')}>恢复内容</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+        </>
+    );
+};
+
+export default AdminAppeals;
@@ -0,0 +1,299 @@
+import { useEffect, useState } from 'react';
+import { FiAlertTriangle, FiShield } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import './Admin.css';
+
+const emptyRule = { id: '', name: '', category: 'review', rule_type: 'keyword', patterns: '', score: 10, enabled: true };
+
+const actionText = { pass: '只统计', review: '待审核', reject: '直接拦截' };
+const riskText = { low: '低', medium: '中', high: '高' };
+const statusText = { 0: '待审核', 1: '已发布', 2: '已驳回', 3: '已下架', 4: '草稿', 5: '定时' };
+
+const AdminAuditRules = () => {
+    const [rules, setRules] = useState([]);
+    const [categories, setCategories] = useState([]);
+    const [form, setForm] = useState(emptyRule);
+    const [dryRun, setDryRun] = useState(null);
+    const [dryRunLimit, setDryRunLimit] = useState(100);
+    const [error, setError] = useState('');
+    const [message, setMessage] = useState('');
+    const [loading, setLoading] = useState(false);
+    const [running, setRunning] = useState(false);
+    const [confirmRule, setConfirmRule] = useState(null);
+
+    const load = async () => {
+        setLoading(true);
+        setError('');
+        try {
+            const data = await campusAdminApi.getAuditRules();
+            setRules(data.rules || []);
+            setCategories(data.categories || []);
+        } catch (err) {
+            setError(err.message || '获取审核规则失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    useEffect(() => {
+        load();
+    }, []);
+
+    const flash = (text) => {
+        setMessage(text);
+        window.setTimeout(() => setMessage(''), 2400);
+    };
+
+    const updateForm = (key, value) => setForm((prev) => ({ ...prev, [key]: value }));
+    const updateCategory = (index, key, value) => setCategories((prev) => prev.map((item, i) => (i === index ? { ...item, [key]: value } : item)));
+    const categoryName = (code) => categories.find((item) => item.code === code)?.name || code;
+
+    const rulePayload = () => ({
+        ...form,
+        score: Number(form.score) || 10,
+    });
+
+    const saveRule = async () => {
+        if (!form.name.trim() || !form.patterns.trim()) {
+            setError('请填写规则名称和关键词/正则');
+            return;
+        }
+        setError('');
+        try {
+            if (form.id) {
+                await campusAdminApi.updateAuditRule(form.id, rulePayload());
+            } else {
+                await campusAdminApi.createAuditRule(rulePayload());
+            }
+            flash('规则已保存');
+            setForm(emptyRule);
+            load();
+        } catch (err) {
+            setError(err.message || '保存审核规则失败');
+        }
+    };
+
+    const toggleRule = async (rule) => {
+        try {
+            await campusAdminApi.updateAuditRule(rule.id, { ...rule, patterns: (rule.patterns || []).join('\n'), enabled: !rule.enabled });
+            load();
+        } catch (err) {
+            setError(err.message || '更新规则失败');
+        }
+    };
+
+    const removeRule = async (id) => {
+        try {
+            await campusAdminApi.deleteAuditRule(id);
+            setConfirmRule(null);
+            flash('规则已删除');
+            load();
+        } catch (err) {
+            setError(err.message || '删除审核规则失败');
+        }
+    };
+
+    const saveCategories = async () => {
+        setError('');
+        try {
+            const data = await campusAdminApi.updateAuditRuleCategories({
+                categories: categories.map((item) => ({ ...item, threshold: Number(item.threshold) || 10 })),
+            });
+            setCategories(data.categories || []);
+            flash('分类策略已保存');
+        } catch (err) {
+            setError(err.message || '保存规则分类失败');
+        }
+    };
+
+    const runDryRun = async (withDraft) => {
+        setRunning(true);
+        setError('');
+        try {
+            const payload = { limit: Number(dryRunLimit) || 100 };
+            if (withDraft) payload.draft = rulePayload();
+            setDryRun(await campusAdminApi.dryRunAuditRules(payload));
+        } catch (err) {
+            setError(err.message || '试运行失败');
+        } finally {
+            setRunning(false);
+        }
+    };
+
+    if (loading && !rules.length) return <div className="admin-loading">审核规则加载中...</div>;
+
+    return (
+        <div className="admin-security-page">
+            {message && <div className="admin-toast success">{message}</div>}
+            {error && <div className="admin-error">{error}</div>}
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>分类策略</h2>
+                    <button className="admin-button primary" onClick={saveCategories}>保存策略</button>
+                </div>
+                <div className="admin-table-wrap">
+                    <table className="admin-table">
+                        <thead>
+                            <tr>
+                                <th>分类</th>
+                                <th>名称</th>
+                                <th>阈值</th>
+                                <th>达到阈值后</th>
+                                <th>风险</th>
+                            </tr>
+                        </thead>
+                        <tbody>
+                            {categories.map((item, index) => (
+                                <tr key={item.code}>
+                                    <td>{item.code}</td>
+                                    <td><input className="admin-input" value={item.name} onChange={(e) => updateCategory(index, 'name', e.target.value)} /></td>
+                                    <td><input className="admin-input" type="number" min="1" value={item.threshold} onChange={(e) => updateCategory(index, 'threshold', e.target.value)} /></td>
+                                    <td>
+                                        <select className="admin-select" value={item.action} onChange={(e) => updateCategory(index, 'action', e.target.value)}>
+                                            {Object.entries(actionText).map(([value, label]) => <option key={value} value={value}>{label}</option>)}
+                                        </select>
+                                    </td>
+                                    <td>
+                                        <select className="admin-select" value={item.risk_level} onChange={(e) => updateCategory(index, 'risk_level', e.target.value)}>
+                                            {Object.entries(riskText).map(([value, label]) => <option key={value} value={value}>{label}</option>)}
+                                        </select>
+                                    </td>
+                                </tr>
+                            ))}
+                        </tbody>
+                    </table>
+                </div>
+                <p className="admin-muted">同一分类内命中规则的分数累加，达到阈值后按动作处理。直接拦截会让发帖和评论提交失败，运营账号不受影响。</p>
+            </section>
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>{form.id ? '编辑规则' : '新增规则'}</h2>
+                    {form.id && <button className="admin-button" onClick={() => setForm(emptyRule)}>取消编辑</button>}
+                </div>
+                <div className="admin-toolbar security">
+                    <input className="admin-input" value={form.name} onChange={(e) => updateForm('name', e.target.value)} placeholder="规则名称，例如 代考代课" />
+                    <select className="admin-select" value={form.category} onChange={(e) => updateForm('category', e.target.value)}>
+                        {categories.map((item) => <option key={item.code} value={item.code}>{item.name}</option>)}
+                    </select>
+                    <select className="admin-select" value={form.rule_type} onChange={(e) => updateForm('rule_type', e.target.value)}>
+                        <option value="keyword">关键词</option>
+                        <option value="pinyin">拼音</option>
+                        <option value="regex">正则</option>
+                    </select>
+                    <input className="admin-input" type="number" min="1" max="100" value={form.score} onChange={(e) => updateForm('score', e.target.value)} placeholder="分数" />
+                </div>
+                <textarea
+                    className="admin-textarea compact"
+                    value={form.patterns}
+                    onChange={(e) => updateForm('patterns', e.target.value)}
+                    placeholder={form.rule_type === 'keyword' ? '代考,代课,daikao（逗号或换行分隔，拼音变体直接写拼音）' : form.rule_type === 'pinyin' ? '赌博,裸聊（按拼音匹配，能抓到 dubo、堵博 这类写法）' : '每行一个正则，例如 (vx|wx|微信) ?[:：]? ?[a-z0-9_-]{5,20}'}
+                />
+                <div className="admin-toolbar security">
+                    <label className="admin-muted">
+                        <input type="checkbox" checked={form.enabled} onChange={(e) => updateForm('enabled', e.target.checked)} /> 启用
+                    </label>
+                    <button className="admin-button" disabled={running} onClick={() => runDryRun(true)}>带草稿试运行</button>
+                    <button className="admin-button primary" onClick={saveRule}>保存规则</button>
+                </div>
+                <p className="admin-muted">匹配前会统一全角半角、大小写和常见变体字，并忽略字与字之间的空格和符号；拼音规则把关键词和正文都转成拼音再比，同音字也会命中，上线前先试运行；正则匹配保留空格。</p>
+            </section>
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>规则列表</h2>
+                    <span className="admin-muted">{rules.length} 条</span>
+                </div>
+                <div className="admin-security-list">
+                    {rules.map((rule) => (
+                        <div className="admin-security-row" key={rule.id}>
+                            <div>
+                                <strong>
+                                    {rule.name}
+                                    {' '}
+                                    <span className="admin-tag neutral">{categoryName(rule.category)}</span>
+                                    {' '}
+                                    <span className="admin-tag">{rule.rule_type === 'regex' ? '正则' : rule.rule_type === 'pinyin' ? '拼音' : '关键词'}</span>
+                                    {!rule.enabled && <span className="admin-tag warn">已停用</span>}
+                                </strong>
+                                <span>{(rule.patterns || []).join('、')}</span>
+                                <span>{`分数 ${rule.score} · 命中 ${rule.hit_count} 次${rule.last_hit_at ? ` · 最近 ${rule.last_hit_at}` : ''}`}</span>
+                            </div>
+                            <div className="admin-toolbar">
+                                <button className="admin-button" onClick={() => setForm({ ...rule, patterns: (rule.patterns || []).join('\n') })}>编辑</button>
+                                <button className="admin-button" onClick={() => toggleRule(rule)}>{rule.enabled ? '停用' : '启用'}</button>
+                                <button className="admin-button danger" onClick={() => setConfirmRule(rule)}>删除</button>
+                            </div>
+                        </div>
+                    ))}
+                    {!rules.length && <div className="admin-empty compact"><FiShield /> 暂无审核规则</div>}
+                </div>
+            </section>
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>试运行</h2>
+                    <div className="admin-toolbar">
+                        <input className="admin-input" type="number" min="1" max="500" value={dryRunLimit} onChange={(e) => setDryRunLimit(e.target.value)} />
+                        <button className="admin-button primary" disabled={running} onClick={() => runDryRun(false)}>{running ? '运行中...' : '用当前规则试运行'}</button>
+                    </div>
+                </div>
+                {!dryRun && <p className="admin-muted">对最近发布的帖子跑一遍规则，只展示结果，不改帖子状态，也不计入命中统计。</p>}
+                {dryRun && (
+                    <>
+                        <p className="admin-muted">{`扫描 ${dryRun.scanned} 篇，待审核 ${dryRun.flagged - dryRun.rejected} 篇，拦截 ${dryRun.rejected} 篇`}</p>
+                        <div className="admin-tag-row">
+                            {(dryRun.rule_hits || []).map((item) => (
+                                <span className="admin-tag" key={`${item.rule_id}-${item.rule_name}`}>{`${item.rule_name} × ${item.count}`}</span>
+                            ))}
+                        </div>
+                        <div className="admin-table-wrap">
+                            <table className="admin-table">
+                                <thead>
+                                    <tr>
+                                        <th>帖子</th>
+                                        <th>当前状态</th>
+                                        <th>规则结果</th>
+                                        <th>命中</th>
+                                    </tr>
+                                </thead>
+                                <tbody>
+                                    {(dryRun.items || []).map((item) => (
+                                        <tr key={item.post_id}>
+                                            <td>{`#${item.post_id} ${item.title}`}</td>
+                                            <td>{statusText[item.status] || item.status}</td>
+                                            <td>{`${actionText[item.decision] || item.decision} · ${item.reason}`}</td>
+                                            <td>{(item.hits || []).map((hit) => `${hit.rule_name}:${hit.matched}`).join('、')}</td>
+                                        </tr>
+                                    ))}
+                                    {!(dryRun.items || []).length && (
+                                        <tr>
+                                            <td colSpan="4">没有帖子命中规则</td>
+                                        </tr>
+                                    )}
+                                </tbody>
+                            </table>
+                        </div>
+                    </>
+                )}
+            </section>
+
+            {confirmRule && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon danger"><FiAlertTriangle /></div>
+                        <h3>确认删除规则？</h3>
+                        <p>删除「{confirmRule.name}」后命中统计一并清除；只想暂时关闭可以选择停用。</p>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setConfirmRule(null)}>取消</button>
+                            <button className="admin-button primary" onClick={() => removeRule(confirmRule.id)}>确认删除</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+        </div>
+    );
+};
+
+export default AdminAuditRules;
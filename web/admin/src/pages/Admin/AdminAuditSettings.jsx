@@ -282,8 +282,8 @@ const AdminAuditSettings = () => {
                 <div className="admin-agent-keyword-panel">
                     <div className="admin-agent-budget-head">
                         <div>
-                            <strong>审核关键词</strong>
-                            <span>高风险保留待审，需复核进入 Agent/人工判断。</span>
+                            <strong>附加关键词</strong>
+                            <span>主要规则在「审核规则」维护，这里的词分别并入高风险和需人工确认分类。</span>
                         </div>
                     </div>
                     <div className="admin-agent-keyword-grid">
@@ -293,7 +293,7 @@ const AdminAuditSettings = () => {
                                 className="admin-textarea compact"
                                 value={agentDraft.audit_high_risk_words}
                                 onChange={(e) => updateAgentDraft('audit_high_risk_words', e.target.value)}
-                                placeholder="赌博,裸聊,诈骗,代考"
+                                placeholder="留空则只用审核规则"
                             />
                         </label>
                         <label>
@@ -302,7 +302,7 @@ const AdminAuditSettings = () => {
                                 className="admin-textarea compact"
                                 value={agentDraft.audit_review_words}
                                 onChange={(e) => updateAgentDraft('audit_review_words', e.target.value)}
-                                placeholder="加微信,兼职,引战,二维码"
+                                placeholder="留空则只用审核规则"
                             />
                         </label>
                     </div>
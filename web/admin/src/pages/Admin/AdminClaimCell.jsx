@@ -0,0 +1,93 @@
+import { useState } from 'react';
+import { FiLock, FiUserCheck } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import './Admin.css';
+
+export const claimOperatorName = (claim) => claim?.operator?.name || claim?.operator?.nickname || `#${claim?.operator_id}`;
+
+const AdminClaimCell = ({ targetType, targetID, claim, onChanged, onError }) => {
+    const [loading, setLoading] = useState(false);
+    const [assigning, setAssigning] = useState(false);
+    const [operators, setOperators] = useState([]);
+    const [assignee, setAssignee] = useState('');
+    const [forceRelease, setForceRelease] = useState(false);
+
+    const run = async (action) => {
+        setLoading(true);
+        onError('');
+        try {
+            await action();
+            onChanged();
+        } catch (err) {
+            if (err.code === 403 && claim && !forceRelease) {
+                setForceRelease(true);
+            }
+            onError(err.message || '认领操作失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    const openAssign = async () => {
+        setAssigning(true);
+        if (operators.length > 0) return;
+        try {
+            const [ops, admins] = await Promise.all([
+                campusAdminApi.listUsers({ role: 'operator', page: 1, size: 50 }),
+                campusAdminApi.listUsers({ role: 'admin', page: 1, size: 50 }),
+            ]);
+            setOperators([...(ops.users || []), ...(admins.users || [])].map((item) => item.user).filter(Boolean));
+        } catch (err) {
+            onError(err.message || '获取运营列表失败');
+        }
+    };
+
+    const assign = () => run(async () => {
+        await campusAdminApi.assignModeration(targetType, targetID, { assignee_id: assignee });
+        setAssigning(false);
+        setAssignee('');
+    });
+
+    return (
+        <>
+            {claim ? (
+                <span className="admin-tag warn">
+                    <FiLock /> {`${claimOperatorName(claim)}${claim.assigned_by ? ' · 指派' : ''} · ${String(claim.expires_at).slice(11, 16)} 到期`}
+                </span>
+            ) : <span className="admin-muted">未认领</span>}
+            <div className="admin-actions">
+                <button className="admin-button" disabled={loading} onClick={() => run(() => campusAdminApi.claimModeration(targetType, targetID))}>
+                    {claim ? '续期' : '认领'}
+                </button>
+                {claim && (
+                    <button className="admin-button" disabled={loading} onClick={() => run(async () => {
+                        await campusAdminApi.releaseModeration(targetType, targetID, { force: forceRelease });
+                        setForceRelease(false);
+                    })}>
+                        {forceRelease ? '强制释放' : '释放'}
+                    </button>
+                )}
+                <button className="admin-button quiet" disabled={loading} onClick={openAssign}><FiUserCheck /> 指派</button>
+            </div>
+            {assigning && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon"><FiUserCheck /></div>
+                        <h3>指派审核</h3>
+                        <p>指派会覆盖当前认领，仅管理员可操作。</p>
+                        <select className="admin-select" value={assignee} onChange={(e) => setAssignee(e.target.value)}>
+                            <option value="">选择运营</option>
+                            {operators.map((user) => <option value={user.id} key={user.id}>{user.nickname || user.name || `#${user.id}`}</option>)}
+                        </select>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setAssigning(false)}>取消</button>
+                            <button className="admin-button primary" disabled={loading || !assignee} onClick={assign}>指派</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+        </>
+    );
+};
+
+export default AdminClaimCell;
@@ -16,6 +16,14 @@ const initialForm = {
     is_featured: true,
     is_pinned: true,
     sort_weight: 100,
+    publish_mode: 'now',
+    publish_at: '',
+};
+
+const publishModeMessages = {
+    now: '已发布到小程序首页',
+    draft: '已保存为草稿',
+    scheduled: '已设置定时发布',
 };
 
 const contentTemplates = [
@@ -351,8 +359,10 @@ const AdminCompose = () => {
                 is_featured: form.is_featured,
                 is_pinned: form.is_pinned,
                 sort_weight: Number(form.sort_weight || 0),
+                publish_mode: form.publish_mode,
+                publish_at: form.publish_mode === 'scheduled' ? form.publish_at : '',
             });
-            setMessage('已发布到小程序首页');
+            setMessage(publishModeMessages[form.publish_mode] || publishModeMessages.now);
             setForm(initialForm);
         } catch (err) {
             setError(err.message || '发布失败');
@@ -374,7 +384,7 @@ const AdminCompose = () => {
                         </div>
                         <button className="admin-button primary" type="button" disabled={loading || uploading} onClick={submit}>
                             <FiSend />
-                            {loading ? '发布中...' : '发布'}
+                            {loading ? '提交中...' : form.publish_mode === 'draft' ? '存草稿' : form.publish_mode === 'scheduled' ? '定时发布' : '发布'}
                         </button>
                     </div>
 
@@ -485,6 +495,15 @@ const AdminCompose = () => {
                             <button className={form.is_pinned ? 'admin-pill active' : 'admin-pill'} type="button" onClick={() => update('is_pinned', !form.is_pinned)}>置顶</button>
                         </div>
                         <p className="admin-muted">攻略可置顶，公告可关闭。</p>
+                        <div className="admin-simple-switch-row">
+                            <button className={form.publish_mode === 'now' ? 'admin-pill active' : 'admin-pill'} type="button" onClick={() => update('publish_mode', 'now')}>立即发布</button>
+                            <button className={form.publish_mode === 'draft' ? 'admin-pill active' : 'admin-pill'} type="button" onClick={() => update('publish_mode', 'draft')}>存草稿</button>
+                            <button className={form.publish_mode === 'scheduled' ? 'admin-pill active' : 'admin-pill'} type="button" onClick={() => update('publish_mode', 'scheduled')}>定时</button>
+                        </div>
+                        {form.publish_mode === 'scheduled' && (
+                            <input className="admin-input" type="datetime-local" value={form.publish_at} onChange={(e) => update('publish_at', e.target.value)} />
+                        )}
+                        {form.publish_mode !== 'now' && <p className="admin-muted">置顶、精选和权重在发布时生效。</p>}
                     </section>
 
                     <section className="admin-preview-panel">
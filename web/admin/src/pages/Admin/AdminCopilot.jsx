@@ -40,6 +40,9 @@ const alertTypeText = {
     ai_budget_warning: '预算',
     report_overdue: '举报超时',
     audit_overdue: '待审超时',
+    appeal_created: '申诉',
+    appeal_overdue: '申诉超时',
+    report_auto_hidden: '举报隐藏',
     feishu_delivery_degraded: '飞书异常',
 };
 
@@ -0,0 +1,134 @@
+import { useEffect, useState } from 'react';
+import { FiAlertTriangle, FiImage } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import './Admin.css';
+
+const AdminImageBlocklist = () => {
+    const [blocks, setBlocks] = useState([]);
+    const [form, setForm] = useState({ post_id: '', image_url: '', phash: '', reason: '' });
+    const [error, setError] = useState('');
+    const [message, setMessage] = useState('');
+    const [loading, setLoading] = useState(false);
+    const [confirmBlock, setConfirmBlock] = useState(null);
+
+    const load = async () => {
+        setLoading(true);
+        setError('');
+        try {
+            const data = await campusAdminApi.listImageHashBlocks();
+            setBlocks(data.blocks || []);
+        } catch (err) {
+            setError(err.message || '获取违规图片库失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    useEffect(() => {
+        load();
+    }, []);
+
+    const updateForm = (key, value) => setForm((prev) => ({ ...prev, [key]: value }));
+
+    const flash = (text) => {
+        setMessage(text);
+        window.setTimeout(() => setMessage(''), 2400);
+    };
+
+    const submit = async () => {
+        const payload = {
+            post_id: form.post_id.trim(),
+            image_url: form.image_url.trim(),
+            phash: form.phash.trim(),
+            reason: form.reason.trim() || '运营加入违规图片库',
+        };
+        if (!payload.post_id && !payload.image_url && !payload.phash) {
+            setError('请填写帖子 ID、图片地址或 pHash');
+            return;
+        }
+        setError('');
+        try {
+            const data = await campusAdminApi.createImageHashBlocks(payload);
+            flash(`已加入 ${(data.blocks || []).length} 张图片`);
+            setForm({ post_id: '', image_url: '', phash: '', reason: '' });
+            load();
+        } catch (err) {
+            setError(err.message || '加入违规图片库失败');
+        }
+    };
+
+    const remove = async (id) => {
+        try {
+            await campusAdminApi.deleteImageHashBlock(id);
+            setConfirmBlock(null);
+            flash('已移出违规图片库');
+            load();
+        } catch (err) {
+            setError(err.message || '移除失败');
+        }
+    };
+
+    if (loading && !blocks.length) return <div className="admin-loading">违规图片库加载中...</div>;
+
+    return (
+        <div className="admin-security-page">
+            {message && <div className="admin-toast success">{message}</div>}
+            {error && <div className="admin-error">{error}</div>}
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>加入违规图片</h2>
+                    <button className="admin-button" onClick={load}>刷新</button>
+                </div>
+                <div className="admin-toolbar security">
+                    <input className="admin-input" value={form.post_id} onChange={(e) => updateForm('post_id', e.target.value)} placeholder="帖子 ID（整帖图片入库）" />
+                    <input className="admin-input" value={form.image_url} onChange={(e) => updateForm('image_url', e.target.value)} placeholder="站内图片地址" />
+                    <input className="admin-input" value={form.phash} onChange={(e) => updateForm('phash', e.target.value)} placeholder="pHash，16 位十六进制" />
+                    <input className="admin-input" value={form.reason} onChange={(e) => updateForm('reason', e.target.value)} placeholder="原因，例如 二维码广告" />
+                    <button className="admin-button danger" onClick={submit}>加入</button>
+                </div>
+                <p className="admin-muted">新发帖图片与库中图片的感知哈希足够接近时会被拦截到待审核；与已驳回、已下架帖子图片相似的也会自动处理。</p>
+            </section>
+
+            <section className="admin-panel">
+                <div className="admin-panel-head">
+                    <h2>违规图片库</h2>
+                    <span className="admin-muted">{blocks.length} 条</span>
+                </div>
+                <div className="admin-security-list">
+                    {blocks.map((item) => (
+                        <div className="admin-security-row" key={item.id}>
+                            <div>
+                                <strong>{item.phash || item.dhash}</strong>
+                                <span>
+                                    {item.reason || '未填写原因'}
+                                    {item.source_post_id ? ` · 帖子 #${item.source_post_id}` : ''}
+                                    {` · ${item.created_at}`}
+                                </span>
+                                {item.image_url && <a href={item.image_url} target="_blank" rel="noreferrer">查看图片</a>}
+                            </div>
+                            <button className="admin-button" onClick={() => setConfirmBlock(item)}>移除</button>
+                        </div>
+                    ))}
+                    {!blocks.length && <div className="admin-empty compact"><FiImage /> 暂无违规图片</div>}
+                </div>
+            </section>
+
+            {confirmBlock && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon danger"><FiAlertTriangle /></div>
+                        <h3>确认移出违规图片库？</h3>
+                        <p>移除后，与 {confirmBlock.phash || confirmBlock.dhash} 相似的图片不再被自动拦截，已处理的帖子不会自动恢复。</p>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setConfirmBlock(null)}>取消</button>
+                            <button className="admin-button primary" onClick={() => remove(confirmBlock.id)}>确认移除</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+        </div>
+    );
+};
+
+export default AdminImageBlocklist;
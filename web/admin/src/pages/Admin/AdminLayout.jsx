@@ -1,6 +1,6 @@
 import { useState } from 'react';
 import { NavLink, Navigate, Outlet, useLocation, useNavigate } from 'react-router-dom';
-import { FiBarChart2, FiBell, FiChevronDown, FiCpu, FiEdit3, FiFileText, FiGrid, FiKey, FiShield, FiUsers, FiZap } from 'react-icons/fi';
+import { FiBarChart2, FiBell, FiChevronDown, FiCpu, FiEdit3, FiFileText, FiFilter, FiGrid, FiImage, FiKey, FiShield, FiUsers, FiZap } from 'react-icons/fi';
 import { clearUserData, getCurrentUser, isLoggedIn } from '../../api/user';
 import './Admin.css';
 
@@ -18,6 +18,8 @@ const navItems = [
 const advancedItems = [
     { to: '/admin/notifications', label: '系统通知', icon: <FiBell /> },
     { to: '/admin/security', label: '安全中心', icon: <FiShield /> },
+    { to: '/admin/audit-rules', label: '审核规则', icon: <FiFilter /> },
+    { to: '/admin/image-blocklist', label: '违规图片库', icon: <FiImage /> },
     { to: '/admin/users', label: '用户管理', icon: <FiUsers /> },
     { to: '/admin/permissions', label: '权限管理', icon: <FiKey /> },
 ];
@@ -43,6 +45,8 @@ const titleMap = {
     '/admin/reports': '举报处理',
     '/admin/feedback': '用户反馈',
     '/admin/security': '安全中心',
+    '/admin/audit-rules': '审核规则',
+    '/admin/image-blocklist': '违规图片库',
     '/admin/users': '用户管理',
     '/admin/permissions': '权限管理',
 };
@@ -52,7 +56,7 @@ const subtitleMap = {
     '/admin/posts': '置顶、精选、审核、下架。',
     '/admin/compose': '官方攻略、问答和公告。',
     '/admin/moments': '今日热帖九图素材。',
-    '/admin/moderation': '举报、反馈、评论。',
+    '/admin/moderation': '举报、申诉、反馈、评论。',
     '/admin/audit': '不审、人工审、AI 初审。',
     '/admin/assistant': '人设、知识库、回复任务。',
     '/admin/copilot': '日报、提醒、审核闭环。',
@@ -63,6 +67,8 @@ const subtitleMap = {
     '/admin/reports': '举报对象和处理状态。',
     '/admin/feedback': '用户问题和跟进状态。',
     '/admin/security': '请求、限流、异常 IP。',
+    '/admin/audit-rules': '关键词、正则、阈值和试运行。',
+    '/admin/image-blocklist': '二维码广告、重复违规图。',
     '/admin/users': '用户画像和风险记录。',
     '/admin/permissions': '后台角色和权限。',
 };
@@ -1,15 +1,19 @@
 import { useEffect, useMemo, useState } from 'react';
 import { useSearchParams } from 'react-router-dom';
-import { FiFlag, FiMessageCircle, FiSend } from 'react-icons/fi';
+import { FiBarChart2, FiFlag, FiMessageCircle, FiRotateCcw, FiSend } from 'react-icons/fi';
+import AdminAppeals from './AdminAppeals';
 import AdminComments from './AdminComments';
 import AdminFeedback from './AdminFeedback';
+import AdminModerationStats from './AdminModerationStats';
 import AdminReports from './AdminReports';
 import './Admin.css';
 
 const tabs = [
     { key: 'reports', label: '举报处理', icon: <FiFlag /> },
+    { key: 'appeals', label: '内容申诉', icon: <FiRotateCcw /> },
     { key: 'feedback', label: '用户反馈', icon: <FiSend /> },
     { key: 'comments', label: '评论管理', icon: <FiMessageCircle /> },
+    { key: 'operators', label: '处理统计', icon: <FiBarChart2 /> },
 ];
 
 const AdminModeration = () => {
@@ -49,8 +53,10 @@ const AdminModeration = () => {
 
             <div className="admin-tab-panel">
                 {activeTab === 'reports' && <AdminReports />}
+                {activeTab === 'appeals' && <AdminAppeals />}
                 {activeTab === 'feedback' && <AdminFeedback />}
                 {activeTab === 'comments' && <AdminComments />}
+                {activeTab === 'operators' && <AdminModerationStats />}
             </div>
         </div>
     );
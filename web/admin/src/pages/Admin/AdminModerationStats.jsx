@@ -0,0 +1,86 @@
+import { useEffect, useState } from 'react';
+import { FiBarChart2 } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import './Admin.css';
+
+const AdminModerationStats = () => {
+    const [days, setDays] = useState('7');
+    const [operators, setOperators] = useState([]);
+    const [since, setSince] = useState('');
+    const [error, setError] = useState('');
+    const [loading, setLoading] = useState(false);
+
+    const load = async (nextDays = days) => {
+        setLoading(true);
+        setError('');
+        try {
+            const data = await campusAdminApi.moderationOperatorStats({ days: nextDays });
+            setOperators(data.operators || []);
+            setSince(data.since || '');
+        } catch (err) {
+            setError(err.message || '获取处理统计失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    useEffect(() => {
+        load(days);
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [days]);
+
+    return (
+        <>
+            <div className="admin-toolbar">
+                <select className="admin-select" value={days} onChange={(e) => setDays(e.target.value)}>
+                    <option value="1">最近 1 天</option>
+                    <option value="7">最近 7 天</option>
+                    <option value="30">最近 30 天</option>
+                </select>
+                {since && <span className="admin-muted">{`自 ${since} 起`}</span>}
+            </div>
+            {error && <div className="admin-error">{error}</div>}
+            {loading && <div className="admin-loading">统计加载中...</div>}
+            {!loading && operators.length === 0 && (
+                <div className="admin-empty">
+                    <FiBarChart2 />
+                    <span>这段时间还没有审核记录</span>
+                </div>
+            )}
+            {!loading && operators.length > 0 && <div className="admin-table-wrap">
+                <table className="admin-table">
+                    <thead>
+                        <tr>
+                            <th>运营</th>
+                            <th>审核</th>
+                            <th>通过</th>
+                            <th>驳回</th>
+                            <th>下架</th>
+                            <th>举报</th>
+                            <th>申诉</th>
+                            <th>认领中</th>
+                            <th>最近处理</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {operators.map((item) => (
+                            <tr key={item.operator_id}>
+                                <td>{item.operator?.name || item.operator?.nickname || `#${item.operator_id}`}</td>
+                                <td>{item.reviewed}</td>
+                                <td>{item.approved}</td>
+                                <td>{item.rejected}</td>
+                                <td>{item.deleted}</td>
+                                <td>{item.reports_handled}</td>
+                                <td>{item.appeals_handled}</td>
+                                <td>{item.active_claims}</td>
+                                <td>{item.last_action_at || '-'}</td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            </div>}
+        </>
+    );
+};
+
+export default AdminModerationStats;
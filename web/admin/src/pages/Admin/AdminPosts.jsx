@@ -22,6 +22,7 @@ const postTypeOptions = [
     ['问答', 'question'],
     ['失物', 'lost'],
     ['社团', 'club'],
+    ['投票', 'poll'],
 ];
 
 const opsOptions = [
@@ -38,6 +39,8 @@ const statusOptions = [
     ['待审核', '0'],
     ['已拒绝', '2'],
     ['已下架', '3'],
+    ['草稿', '4'],
+    ['待定时发布', '5'],
 ];
 
 const batchActions = [
@@ -96,6 +99,7 @@ const AdminPosts = () => {
     const [error, setError] = useState('');
     const [loading, setLoading] = useState(false);
     const [actionLoading, setActionLoading] = useState(false);
+    const [revisionView, setRevisionView] = useState(null);
 
     const selectedSet = useMemo(() => new Set(selectedIds), [selectedIds]);
     const allCurrentSelected = posts.length > 0 && posts.every((post) => selectedSet.has(String(post.id)));
@@ -195,6 +199,17 @@ const AdminPosts = () => {
         setSelectedIds(posts.map((post) => String(post.id)));
     };
 
+    const openRevisions = async (post) => {
+        setRevisionView({ post, revisions: [], loading: true });
+        try {
+            const data = await campusAdminApi.listPostRevisions(post.id);
+            setRevisionView({ post, revisions: data.revisions || [], loading: false });
+        } catch (err) {
+            setRevisionView(null);
+            setError(err.message || '获取修订记录失败');
+        }
+    };
+
     const saveWeight = (post, value) => {
         const weight = Number(value || 0);
         updatePost(post, { sort_weight: weight }, '权重已保存');
@@ -317,6 +332,7 @@ const AdminPosts = () => {
                                 {post.is_official && <span className="admin-tag">官方</span>}
                                 <span className="admin-tag neutral">{postTypeText(post.post_type)}</span>
                                 <span className="admin-tag neutral">{post.category_name || post.category_code || '未分组'}</span>
+                                {post.edit_count > 0 && <span className="admin-tag warn">已编辑 {post.edit_count} 次</span>}
                             </div>
                             <div className="admin-post-meta">
                                 <span>{compactNumber(post.like_count)} 赞</span>
@@ -351,6 +367,7 @@ const AdminPosts = () => {
                                     </button>
                                 )}
                                 <button className={post.is_official ? 'admin-button active quiet' : 'admin-button quiet'} disabled={actionLoading} onClick={() => updatePost(post, { is_official: !post.is_official })}>{post.is_official ? '官方' : '设官方'}</button>
+                                {post.edit_count > 0 && <button className="admin-button quiet" type="button" onClick={() => openRevisions(post)}>修订历史</button>}
                             </div>
                             <div className="admin-weight-editor">
                                 <input
@@ -377,6 +394,35 @@ const AdminPosts = () => {
                 <button className="admin-button" disabled={loading || page * pageSize >= total} onClick={() => load(page + 1)}>下一页</button>
             </div>
 
+            {revisionView && (
+                <div className="admin-modal-backdrop" role="presentation" onClick={() => setRevisionView(null)}>
+                    <div className="admin-confirm-modal admin-revision-modal" onClick={(e) => e.stopPropagation()}>
+                        <h3>修订历史</h3>
+                        <p>「{revisionView.post.title}」当前为第 {revisionView.post.edit_count} 次编辑后的版本，以下是每次编辑前的内容。</p>
+                        {revisionView.loading && <div className="admin-loading">加载中...</div>}
+                        {!revisionView.loading && revisionView.revisions.length === 0 && <div className="admin-empty">暂无修订记录</div>}
+                        <div className="admin-revision-list">
+                            {revisionView.revisions.map((revision) => (
+                                <article className="admin-revision-item" key={revision.id}>
+                                    <div className="admin-post-meta">
+                                        <span>第 {revision.revision} 次编辑前</span>
+                                        <span>{revision.editor_role === 'admin' ? '运营修改' : '作者修改'}</span>
+                                        <span>原状态 {statusText(revision.status_before)}</span>
+                                        <span>{revision.created_at}</span>
+                                    </div>
+                                    <h4>{revision.title}</h4>
+                                    <p>{revision.content}</p>
+                                    {revision.images?.length > 0 && <span className="admin-muted">{revision.images.length} 张图片</span>}
+                                </article>
+                            ))}
+                        </div>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setRevisionView(null)}>关闭</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+
             {confirmAction && (
                 <div className="admin-modal-backdrop" role="presentation">
                     <div className="admin-confirm-modal">
@@ -0,0 +1,170 @@
+import { useEffect, useState } from 'react';
+import { FiEyeOff, FiFlag, FiLock } from 'react-icons/fi';
+import { campusAdminApi } from '../../api/admin';
+import AdminClaimCell from './AdminClaimCell';
+import './Admin.css';
+
+const reportStatusText = (status) => {
+    const map = { 0: '待处理', 1: '已成立', 2: '已驳回' };
+    return map[Number(status)] || '未知';
+};
+
+const AdminReportGroups = ({ status, claim }) => {
+    const [groups, setGroups] = useState([]);
+    const [threshold, setThreshold] = useState(0);
+    const [page, setPage] = useState(1);
+    const [total, setTotal] = useState(0);
+    const [error, setError] = useState('');
+    const [message, setMessage] = useState('');
+    const [loading, setLoading] = useState(false);
+    const [actionLoading, setActionLoading] = useState(false);
+    const [expanded, setExpanded] = useState('');
+    const [forceRetry, setForceRetry] = useState(null);
+
+    const load = async (nextPage = page) => {
+        setLoading(true);
+        setError('');
+        try {
+            const data = await campusAdminApi.listReportGroups({ page: nextPage, size: 20, status, claim });
+            setGroups(data.groups || []);
+            setThreshold(Number(data.auto_hide_threshold || 0));
+            setTotal(data.page_stats?.total || 0);
+            setPage(nextPage);
+        } catch (err) {
+            setError(err.message || '获取举报失败');
+        } finally {
+            setLoading(false);
+        }
+    };
+
+    useEffect(() => {
+        load(1);
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [status, claim]);
+
+    const review = async (group, action, force = false) => {
+        setActionLoading(true);
+        setError('');
+        try {
+            await campusAdminApi.reviewReportGroup(group.target_type, group.target_id, {
+                action,
+                reason: action === 'uphold' ? '举报成立下架' : '举报不成立',
+                force,
+            });
+            setMessage(action === 'uphold' ? '已下架内容，相关举报记为成立' : '已驳回举报，自动隐藏的内容已恢复');
+            window.setTimeout(() => setMessage(''), 2400);
+            setForceRetry(null);
+            load(page);
+        } catch (err) {
+            if (err.code === 409 && !force) {
+                setForceRetry({ group, action, message: err.message });
+                return;
+            }
+            setError(err.message || '处理举报失败');
+        } finally {
+            setActionLoading(false);
+        }
+    };
+
+    const key = (group) => `${group.target_type}:${group.target_id}`;
+
+    return (
+        <>
+            {message && <div className="admin-toast success">{message}</div>}
+            {threshold > 0 && <p className="admin-muted">{`待处理举报加权分达到 ${threshold} 会自动隐藏内容并转入待审，驳回举报即可恢复。`}</p>}
+            {error && <div className="admin-error">{error}</div>}
+            {loading && <div className="admin-loading">举报加载中...</div>}
+            {!loading && groups.length === 0 && (
+                <div className="admin-empty">
+                    <FiFlag />
+                    <span>暂无举报事项</span>
+                </div>
+            )}
+            {!loading && groups.length > 0 && <div className="admin-table-wrap">
+                <table className="admin-table">
+                    <thead>
+                        <tr>
+                            <th>举报对象</th>
+                            <th>举报</th>
+                            <th>理由</th>
+                            <th>加权分</th>
+                            <th>认领</th>
+                            <th>最近举报</th>
+                            <th>操作</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {groups.map((group) => (
+                            <tr key={key(group)}>
+                                <td className="admin-title-cell">
+                                    <strong>{group.target_type === 'post' ? group.target?.title : group.comment?.content}</strong>
+                                    <div className="admin-muted">{`${group.target_type === 'post' ? '帖子' : '评论'} #${group.target_id}`}</div>
+                                    {group.auto_hidden && <span className="admin-tag warn"><FiEyeOff /> 已自动隐藏</span>}
+                                </td>
+                                <td>
+                                    <div>{`${group.report_count} 条，待处理 ${group.pending_count}`}</div>
+                                    <button className="admin-button" onClick={() => setExpanded(expanded === key(group) ? '' : key(group))}>
+                                        {expanded === key(group) ? '收起' : '举报人'}
+                                    </button>
+                                    {expanded === key(group) && (
+                                        <div className="admin-revision-list">
+                                            {(group.reports || []).map((report) => (
+                                                <div className="admin-revision-item" key={report.id}>
+                                                    <strong>{`${report.reporter?.name || report.reporter?.nickname || '同学'} · 权重 ${report.reporter_weight}`}</strong>
+                                                    <p>{`${report.reason}${report.detail ? `：${report.detail}` : ''} · ${reportStatusText(report.status)}`}</p>
+                                                </div>
+                                            ))}
+                                        </div>
+                                    )}
+                                </td>
+                                <td>
+                                    <div className="admin-tag-row">
+                                        {(group.reasons || []).map((reason) => <span className="admin-tag neutral" key={reason}>{reason}</span>)}
+                                    </div>
+                                </td>
+                                <td>{group.weighted_score}</td>
+                                <td>
+                                    <AdminClaimCell
+                                        targetType={group.target_type}
+                                        targetID={group.target_id}
+                                        claim={group.claim}
+                                        onChanged={() => load(page)}
+                                        onError={setError}
+                                    />
+                                </td>
+                                <td>{group.last_reported_at}</td>
+                                <td>
+                                    <div className="admin-actions">
+                                        <button className="admin-button danger" disabled={actionLoading || Number(group.pending_count) === 0} onClick={() => review(group, 'uphold')}>成立并下架</button>
+                                        <button className="admin-button" disabled={actionLoading || Number(group.pending_count) === 0} onClick={() => review(group, 'dismiss')}>驳回全部</button>
+                                    </div>
+                                </td>
+                            </tr>
+                        ))}
+                    </tbody>
+                </table>
+            </div>}
+            <div className="admin-pagination">
+                <span className="admin-muted">共 {total} 个对象</span>
+                <button className="admin-button" disabled={page <= 1} onClick={() => load(page - 1)}>上一页</button>
+                <button className="admin-button" disabled={page * 20 >= total} onClick={() => load(page + 1)}>下一页</button>
+            </div>
+            {forceRetry && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon"><FiLock /></div>
+                        <h3>内容已被认领</h3>
+                        <p>{forceRetry.message}</p>
+                        <p className="admin-confirm-warning">只有管理员可以强制处理，操作会记入审核记录。</p>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setForceRetry(null)}>取消</button>
+                            <button className="admin-button danger" disabled={actionLoading} onClick={() => review(forceRetry.group, forceRetry.action, true)}>强制处理</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+        </>
+    );
+};
+
+export default AdminReportGroups;
@@ -1,7 +1,9 @@
 import { useEffect, useState } from 'react';
 import { useSearchParams } from 'react-router-dom';
-import { FiExternalLink, FiFlag, FiTrash2 } from 'react-icons/fi';
+import { FiExternalLink, FiFlag, FiLock, FiTrash2 } from 'react-icons/fi';
 import { campusAdminApi } from '../../api/admin';
+import AdminClaimCell from './AdminClaimCell';
+import AdminReportGroups from './AdminReportGroups';
 import './Admin.css';
 
 const reportStatusText = (status) => {
@@ -14,6 +16,8 @@ const AdminReports = () => {
     const statusParam = searchParams.get('status') || '-1';
     const [reports, setReports] = useState([]);
     const [status, setStatus] = useState(statusParam);
+    const [view, setView] = useState(searchParams.get('view') === 'groups' ? 'groups' : 'list');
+    const [claim, setClaim] = useState(searchParams.get('claim') || '');
     const [page, setPage] = useState(1);
     const [total, setTotal] = useState(0);
     const [error, setError] = useState('');
@@ -21,12 +25,13 @@ const AdminReports = () => {
     const [loading, setLoading] = useState(false);
     const [actionLoading, setActionLoading] = useState(false);
     const [confirmAction, setConfirmAction] = useState(null);
+    const [forceRetry, setForceRetry] = useState(null);
 
     const load = async (nextPage = page, nextStatus = status) => {
         setLoading(true);
         setError('');
         try {
-            const data = await campusAdminApi.listReports({ page: nextPage, size: 20, status: nextStatus });
+            const data = await campusAdminApi.listReports({ page: nextPage, size: 20, status: nextStatus, claim });
             setReports(data.reports || []);
             setTotal(data.page_stats?.total || 0);
             setPage(nextPage);
@@ -43,21 +48,35 @@ const AdminReports = () => {
         // eslint-disable-next-line react-hooks/exhaustive-deps
     }, [statusParam]);
 
-    const review = async (report, action) => {
+    // 对象被别人认领时接口返回 409，管理员可以确认后带 force 重试
+    const runReview = async (run, successText, failText, force = false) => {
         setActionLoading(true);
         setError('');
         try {
-            await campusAdminApi.reviewReport(report.id, { action, reason: action === 'resolve' ? '已处理' : '已驳回' });
-            setMessage(action === 'resolve' ? '举报已设为已处理' : '举报已驳回');
+            await run(force);
+            setMessage(successText);
             window.setTimeout(() => setMessage(''), 2400);
+            setConfirmAction(null);
+            setForceRetry(null);
             load(page);
         } catch (err) {
-            setError(err.message || '处理举报失败');
+            if (err.code === 409 && !force) {
+                setConfirmAction(null);
+                setForceRetry({ message: err.message, run, successText, failText });
+                return;
+            }
+            setError(err.message || failText);
         } finally {
             setActionLoading(false);
         }
     };
 
+    const review = (report, action) => runReview(
+        (force) => campusAdminApi.reviewReport(report.id, { action, reason: action === 'resolve' ? '已处理' : '已驳回', force }),
+        action === 'resolve' ? '举报已设为已处理' : '举报已驳回',
+        '处理举报失败',
+    );
+
     const targetPostID = (report) => {
         if (!report) return '';
         if (report.target_type === 'post') return report.target_id;
@@ -77,47 +96,14 @@ const AdminReports = () => {
         window.open(`/admin/posts?keyword=${encodeURIComponent(String(postID))}`, '_blank', 'noopener,noreferrer');
     };
 
-    const takedownTarget = async () => {
+    const takedownTarget = () => {
         if (!confirmAction?.report) return;
         const { report } = confirmAction;
-        setActionLoading(true);
-        setError('');
-        try {
-            if (report.target_type === 'post') {
-                const target = report.target;
-                if (target) {
-                    await campusAdminApi.updatePost(report.target_id, {
-                        category_code: target.category_code,
-                        title: target.title,
-                        content: target.content,
-                        images: target.images || [],
-                        media_type: target.media_type || 'text',
-                        post_type: target.post_type || 'note',
-                        extra: target.extra || {},
-                        cover_url: target.cover_url || '',
-                        status: 3,
-                        audit_reason: '举报处理下架',
-                        is_official: Boolean(target.is_official),
-                        is_featured: Boolean(target.is_featured),
-                        is_pinned: Boolean(target.is_pinned),
-                        sort_weight: Number(target.sort_weight || 0),
-                    });
-                } else {
-                    await campusAdminApi.deletePost(report.target_id);
-                }
-            } else {
-                await campusAdminApi.deleteComment(report.target_id);
-            }
-            await campusAdminApi.reviewReport(report.id, { action: 'resolve', reason: '已下架举报对象' });
-            setMessage('举报对象已下架，举报已设为已处理');
-            window.setTimeout(() => setMessage(''), 2400);
-            setConfirmAction(null);
-            load(page);
-        } catch (err) {
-            setError(err.message || '下架举报对象失败');
-        } finally {
-            setActionLoading(false);
-        }
+        runReview(
+            (force) => campusAdminApi.reviewReportGroup(report.target_type, report.target_id, { action: 'uphold', reason: '举报处理下架', force }),
+            '举报对象已下架，相关举报已设为已处理',
+            '下架举报对象失败',
+        );
     };
 
     return (
@@ -130,8 +116,19 @@ const AdminReports = () => {
                     <option value="1">已处理</option>
                     <option value="2">已驳回</option>
                 </select>
-                <button className="admin-button primary" onClick={() => load(1)}>查询</button>
+                <select className="admin-select" value={view} onChange={(e) => setView(e.target.value)}>
+                    <option value="list">逐条查看</option>
+                    <option value="groups">按对象聚合</option>
+                </select>
+                <select className="admin-select" value={claim} onChange={(e) => setClaim(e.target.value)}>
+                    <option value="">全部认领状态</option>
+                    <option value="available">可认领</option>
+                    <option value="mine">我认领的</option>
+                </select>
+                {view === 'list' && <button className="admin-button primary" onClick={() => load(1)}>查询</button>}
             </div>
+            {view === 'groups' && <AdminReportGroups status={status} claim={claim} />}
+            {view === 'list' && <>
             {error && <div className="admin-error">{error}</div>}
             {loading && <div className="admin-loading">举报加载中...</div>}
             {!loading && reports.length === 0 && (
@@ -148,6 +145,7 @@ const AdminReports = () => {
                             <th>原因</th>
                             <th>举报人</th>
                             <th>状态</th>
+                            <th>认领</th>
                             <th>时间</th>
                             <th>操作</th>
                         </tr>
@@ -163,8 +161,20 @@ const AdminReports = () => {
                                     <div>{report.reason}</div>
                                     <div className="admin-muted">{report.detail}</div>
                                 </td>
-                                <td>{report.reporter?.name || report.reporter?.nickname || '同学'}</td>
+                                <td>
+                                    <div>{report.reporter?.name || report.reporter?.nickname || '同学'}</div>
+                                    {report.reporter_weight !== undefined && <div className="admin-muted">{`权重 ${report.reporter_weight}`}</div>}
+                                </td>
                                 <td>{reportStatusText(report.status)}</td>
+                                <td>
+                                    <AdminClaimCell
+                                        targetType={report.target_type}
+                                        targetID={report.target_id}
+                                        claim={report.claim}
+                                        onChanged={() => load(page)}
+                                        onError={setError}
+                                    />
+                                </td>
                                 <td>{report.created_at}</td>
                                 <td>
                                     <div className="admin-actions">
@@ -189,7 +199,7 @@ const AdminReports = () => {
                     <div className="admin-confirm-modal">
                         <div className="admin-modal-icon"><FiTrash2 /></div>
                         <h3>下架举报对象</h3>
-                        <p>确认下架这条举报对应的{confirmAction.report?.target_type === 'post' ? '帖子' : '评论'}吗？下架后前台不再展示，举报会同时设为已处理。</p>
+                        <p>确认下架这条举报对应的{confirmAction.report?.target_type === 'post' ? '帖子' : '评论'}吗？下架后前台不再展示，该对象的待处理举报会同时设为已处理。</p>
                         <div className="admin-modal-actions">
                             <button className="admin-button" onClick={() => setConfirmAction(null)}>取消</button>
                             <button className="admin-button danger" disabled={actionLoading} onClick={takedownTarget}>确认下架</button>
@@ -197,6 +207,21 @@ const AdminReports = () => {
                     </div>
                 </div>
             )}
+            {forceRetry && (
+                <div className="admin-modal-backdrop" role="presentation">
+                    <div className="admin-confirm-modal">
+                        <div className="admin-modal-icon"><FiLock /></div>
+                        <h3>内容已被认领</h3>
+                        <p>{forceRetry.message}</p>
+                        <p className="admin-confirm-warning">只有管理员可以强制处理，操作会记入审核记录。</p>
+                        <div className="admin-modal-actions">
+                            <button className="admin-button" onClick={() => setForceRetry(null)}>取消</button>
+                            <button className="admin-button danger" disabled={actionLoading} onClick={() => runReview(forceRetry.run, forceRetry.successText, forceRetry.failText, true)}>强制处理</button>
+                        </div>
+                    </div>
+                </div>
+            )}
+            </>}
         </>
     );
 };
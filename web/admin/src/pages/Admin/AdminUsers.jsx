@@ -1,6 +1,6 @@
 import { useEffect, useMemo, useState } from 'react';
 import { Link } from 'react-router-dom';
-import { FiAlertCircle, FiAward, FiClock, FiMessageCircle, FiRefreshCw, FiSearch, FiShield, FiUserCheck, FiUsers } from 'react-icons/fi';
+import { FiAlertCircle, FiAward, FiClock, FiMessageCircle, FiRefreshCw, FiSearch, FiShield, FiSlash, FiUserCheck, FiUsers } from 'react-icons/fi';
 import { campusAdminApi } from '../../api/admin';
 import { compactNumber, roleText } from './adminUtils';
 import './Admin.css';
@@ -20,6 +20,28 @@ const authOptions = [
     ['已认证', '1'],
 ];
 
+const sanctionTypes = [
+    ['禁言', 'mute'],
+    ['禁止发帖', 'post_ban'],
+    ['封禁账号', 'suspend'],
+];
+
+const sanctionDurations = [
+    ['1 天', 24],
+    ['3 天', 72],
+    ['7 天', 168],
+    ['30 天', 720],
+    ['永久（仅管理员）', 0],
+];
+
+const sanctionTypeText = Object.fromEntries(sanctionTypes.map(([label, value]) => [value, label]));
+const sanctionStatusText = { active: '生效中', expired: '已到期', revoked: '已解除' };
+
+const trustLevelText = { trusted: '高信用', normal: '普通', low: '低信用' };
+const trustLevelTag = { trusted: 'neutral', normal: '', low: 'warn' };
+
+const emptySanction = { type: 'mute', duration_hours: 24, reason: '', appeal_note: '' };
+
 const AdminUsers = () => {
     const [users, setUsers] = useState([]);
     const [filters, setFilters] = useState({ keyword: '', role: '', authStatus: '-1' });
@@ -27,6 +49,7 @@ const AdminUsers = () => {
     const [total, setTotal] = useState(0);
     const [error, setError] = useState('');
     const [loading, setLoading] = useState(false);
+    const [sanctionUser, setSanctionUser] = useState(null);
 
     const load = async (nextPage = page, nextFilters = filters) => {
         setLoading(true);
@@ -142,7 +165,7 @@ const AdminUsers = () => {
             {!loading && users.length > 0 && (
                 <div className="admin-user-list">
                     {users.map((item) => (
-                        <UserCard item={item} key={item.user.id} />
+                        <UserCard item={item} key={item.user.id} onSanction={setSanctionUser} />
                     ))}
                 </div>
             )}
@@ -151,11 +174,18 @@ const AdminUsers = () => {
                 <button className="admin-button" disabled={page <= 1} onClick={() => load(page - 1)}>上一页</button>
                 <button className="admin-button" disabled={page * pageSize >= total} onClick={() => load(page + 1)}>下一页</button>
             </div>
+            {sanctionUser && (
+                <SanctionModal
+                    item={sanctionUser}
+                    onClose={() => setSanctionUser(null)}
+                    onChanged={() => load(page)}
+                />
+            )}
         </>
     );
 };
 
-const UserCard = ({ item }) => {
+const UserCard = ({ item, onSanction }) => {
     const user = item.user || {};
     const profile = item.profile || {};
     const name = user.nickname || user.name || profile.real_name || '深汕同学';
@@ -203,6 +233,20 @@ const UserCard = ({ item }) => {
                     <span>{activeLabel}</span>
                     {item.last_active_path && <em>{item.last_active_path} · HTTP {item.last_active_status || 0}</em>}
                 </div>
+                {item.trust && (
+                    <div className="admin-user-activity">
+                        <strong>
+                            信用分 {item.trust.score}
+                            {' '}
+                            <span className={`admin-tag ${trustLevelTag[item.trust.level] || ''}`}>{trustLevelText[item.trust.level] || item.trust.level}</span>
+                        </strong>
+                        <span>
+                            {(item.trust.breakdown || []).length
+                                ? item.trust.breakdown.map((factor) => `${factor.label} ${factor.points > 0 ? '+' : ''}${factor.points}`).join(' · ')
+                                : '基础分，暂无加减项'}
+                        </span>
+                    </div>
+                )}
                 <div className="admin-user-risk">
                     <span><FiMessageCircle /> 反馈 {compactNumber(item.feedback_count || 0)}</span>
                     <span><FiAlertCircle /> 举报 {compactNumber(item.report_count || 0)}</span>
@@ -212,11 +256,123 @@ const UserCard = ({ item }) => {
                     <FiShield />
                     <span>角色：{roleText(role)}。权限在独立模块调整。</span>
                 </div>
+                {(item.sanctions || []).length > 0 && (
+                    <div className="admin-tag-row">
+                        {item.sanctions.map((sanction) => (
+                            <span className="admin-tag warn" key={sanction.id}>
+                                {`${sanctionTypeText[sanction.type] || sanction.type} 至 ${sanction.expires_at || '永久'}`}
+                            </span>
+                        ))}
+                    </div>
+                )}
+                {role === 'user' && (
+                    <button className="admin-button" onClick={() => onSanction(item)}>
+                        <FiSlash /> 处罚
+                    </button>
+                )}
             </div>
         </article>
     );
 };
 
+const SanctionModal = ({ item, onClose, onChanged }) => {
+    const userId = item.user?.id;
+    const name = item.user?.nickname || item.user?.name || `#${userId}`;
+    const [form, setForm] = useState(emptySanction);
+    const [history, setHistory] = useState([]);
+    const [error, setError] = useState('');
+    const [saving, setSaving] = useState(false);
+
+    const loadHistory = async () => {
+        try {
+            const data = await campusAdminApi.listUserSanctions(userId);
+            setHistory(data.sanctions || []);
+        } catch (err) {
+            setError(err.message || '获取处罚记录失败');
+        }
+    };
+
+    useEffect(() => {
+        loadHistory();
+        // eslint-disable-next-line react-hooks/exhaustive-deps
+    }, [userId]);
+
+    const updateForm = (key, value) => setForm((prev) => ({ ...prev, [key]: value }));
+
+    const submit = async () => {
+        if (!form.reason.trim()) {
+            setError('请填写处罚原因');
+            return;
+        }
+        setSaving(true);
+        setError('');
+        try {
+            await campusAdminApi.createUserSanction(userId, { ...form, duration_hours: Number(form.duration_hours) });
+            setForm(emptySanction);
+            loadHistory();
+            onChanged();
+        } catch (err) {
+            setError(err.message || '处罚失败');
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    const revoke = async (sanction) => {
+        setError('');
+        try {
+            await campusAdminApi.revokeUserSanction(sanction.id, '后台提前解除');
+            loadHistory();
+            onChanged();
+        } catch (err) {
+            setError(err.message || '解除处罚失败');
+        }
+    };
+
+    return (
+        <div className="admin-modal-backdrop" role="presentation">
+            <div className="admin-confirm-modal admin-revision-modal">
+                <h3>{`处罚 ${name}`}</h3>
+                {error && <div className="admin-error">{error}</div>}
+                <div className="admin-toolbar security">
+                    <select className="admin-select" value={form.type} onChange={(e) => updateForm('type', e.target.value)}>
+                        {sanctionTypes.map(([label, value]) => <option value={value} key={value}>{label}</option>)}
+                    </select>
+                    <select className="admin-select" value={form.duration_hours} onChange={(e) => updateForm('duration_hours', e.target.value)}>
+                        {sanctionDurations.map(([label, value]) => <option value={value} key={value}>{label}</option>)}
+                    </select>
+                    <input className="admin-input" value={form.reason} maxLength={120} onChange={(e) => updateForm('reason', e.target.value)} placeholder="处罚原因，会展示给用户" />
+                </div>
+                <textarea
+                    className="admin-textarea compact"
+                    value={form.appeal_note}
+                    maxLength={200}
+                    onChange={(e) => updateForm('appeal_note', e.target.value)}
+                    placeholder="申诉说明，留空使用默认：如有异议，可在「意见反馈」中说明情况申诉。"
+                />
+                <p className="admin-muted">禁言不能评论，禁止发帖不能发新帖，封禁账号只保留通知、资料和意见反馈。同类型处罚会覆盖旧的一条。</p>
+                <div className="admin-revision-list">
+                    {history.map((sanction) => (
+                        <div className="admin-revision-item" key={sanction.id}>
+                            <strong>{`${sanctionTypeText[sanction.type] || sanction.type} · ${sanctionStatusText[sanction.status] || sanction.status}`}</strong>
+                            <p>{`${sanction.starts_at} 至 ${sanction.expires_at || '永久'} · ${sanction.reason}`}</p>
+                            {sanction.revoke_reason && <p className="admin-muted">{`解除说明：${sanction.revoke_reason}`}</p>}
+                            {sanction.status === 'active' && (
+                                <button className="admin-button" onClick={() => revoke(sanction)}>提前解除</button>
+                            )}
+                        </div>
+                    ))}
+                    {!history.length && <div className="admin-empty compact"><FiShield /> 暂无处罚记录</div>}
+                </div>
+                <div className="admin-modal-actions">
+                    <button className="admin-button" onClick={onClose}>关闭</button>
+                    <button className="admin-button primary" disabled={saving} onClick={submit}>{saving ? '提交中...' : '确认处罚'}</button>
+                </div>
+            </div>
+        </div>
+    );
+};
+
 const Metric = ({ label, value }) => (
     <div>
         <strong>{compactNumber(value || 0)}</strong>
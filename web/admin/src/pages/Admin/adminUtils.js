@@ -4,6 +4,8 @@ export const statusText = (status) => {
         1: '正常展示',
         2: '已拒绝',
         3: '已下架',
+        4: '草稿',
+        5: '待定时发布',
     };
     return map[Number(status)] || '未知';
 };
@@ -24,6 +26,7 @@ export const postTypeText = (type) => {
         question: '问答',
         guide: '攻略',
         club: '社团',
+        poll: '投票',
     };
     return map[type || 'note'] || '笔记';
 };